@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -15,7 +16,9 @@ import (
 	"github.com/VieiraVitor/transaction-flow/internal/application/usecase"
 	"github.com/VieiraVitor/transaction-flow/internal/infra/database"
 	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/metrics"
 	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/tracing"
 	_ "github.com/lib/pq"
 )
 
@@ -30,6 +33,18 @@ func main() {
 
 	cfg := config.LoadConfig()
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(cfg, os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	shutdownTracing, err := tracing.InitTracing(context.Background(), cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	db, err := database.ConnectDB(cfg)
 	if err != nil {
 		log.Fatal(err)
@@ -37,23 +52,73 @@ func main() {
 
 	defer db.Close()
 
+	if cfg.AutoMigrate {
+		if err := migrateUp(db, cfg.MigrationLockTimeout); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	schemaVersion, err := database.LatestSchemaVersion()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	metrics.RegisterDB(db, cfg.DBName)
+
 	accountRepo := repository.NewAccountRepository(db)
 	transactionRepo := repository.NewTransactionRepository(db)
+	idempotencyRepo := repository.NewIdempotencyRepository(db)
+	operationTypeRepo := repository.NewOperationTypeRepository(db)
+	invoiceRepo := repository.NewInvoiceRepository(db)
+	ledgerRepo := repository.NewLedgerRepository(db)
+	authorizationRepo := repository.NewAuthorizationRepository(db)
+	disputeRepo := repository.NewDisputeRepository(db)
+	healthRepo := repository.NewHealthRepository(db)
+
+	operationTypeCatalog := usecase.NewOperationTypeCatalog(operationTypeRepo, cfg.OperationTypeCatalogMaxAge)
 
 	accountUseCase := usecase.NewAccountUseCase(accountRepo)
-	transactionUseCase := usecase.NewTransactionUseCase(transactionRepo)
+	transactionUseCase := usecase.NewTransactionUseCase(transactionRepo, accountRepo, operationTypeCatalog)
+	idempotencyUseCase := usecase.NewIdempotencyUseCase(idempotencyRepo, cfg.IdempotencyKeyTTL, cfg.IdempotencyKeyLease)
+	operationTypeUseCase := usecase.NewOperationTypeUseCase(operationTypeRepo, operationTypeCatalog)
+	invoiceUseCase := usecase.NewInvoiceUseCase(invoiceRepo, accountRepo)
+	ledgerUseCase := usecase.NewLedgerUseCase(ledgerRepo)
+	chargeUseCase := usecase.NewChargeUseCase(invoiceRepo, transactionRepo, cfg.InterestMonthlyRate, cfg.LateFee)
+	authorizationUseCase := usecase.NewAuthorizationUseCase(authorizationRepo, accountRepo, cfg.AuthorizationTTL)
+	disputeUseCase := usecase.NewDisputeUseCase(disputeRepo, transactionRepo, accountRepo)
+	healthUseCase := usecase.NewHealthUseCase(healthRepo, schemaVersion, cfg.HealthCheckTimeout)
 
 	handlers := api.NewHandlers(
 		accountUseCase,
 		transactionUseCase,
+		idempotencyUseCase,
+		operationTypeUseCase,
+		invoiceUseCase,
+		ledgerUseCase,
+		authorizationUseCase,
+		disputeUseCase,
+		healthUseCase,
+		cfg.DBQueryTimeout,
 	)
 	routes := handlers.NewRoutes()
 
+	// Requests still running when the shutdown timeout expires have their queries cancelled.
+	requestsCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+
 	server := &http.Server{
-		Addr:    cfg.AppPort,
-		Handler: routes,
+		Addr:        cfg.AppPort,
+		Handler:     routes,
+		BaseContext: func(net.Listener) context.Context { return requestsCtx },
 	}
 
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go purgeExpiredIdempotencyKeys(jobsCtx, idempotencyUseCase)
+	go closeInvoices(jobsCtx, invoiceUseCase, cfg.InvoiceClosingInterval)
+	go accrueCharges(jobsCtx, chargeUseCase, cfg.ChargeAccrualInterval)
+	go expireAuthorizations(jobsCtx, authorizationUseCase, cfg.AuthorizationExpiryInterval)
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 
@@ -67,12 +132,105 @@ func main() {
 	<-stop
 	logger.Logger.Info("Signal received. Stopping...")
 
+	// Readiness fails from now on; keep serving while load balancers take the instance out.
+	healthUseCase.BeginShutdown()
+	time.Sleep(cfg.ShutdownDrainDelay)
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	if err := server.Shutdown(ctx); err != nil {
+		cancelRequests()
 		logger.Logger.ErrorContext(ctx, "Failed to stop server", "error", err.Error())
 	} else {
 		logger.Logger.Info("Server finished successfully")
 	}
+
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Logger.ErrorContext(ctx, "Failed to flush traces", "error", err.Error())
+	}
+}
+
+// purgeExpiredIdempotencyKeys periodically deletes idempotency keys past their TTL. Expired
+// keys are already ignored when reused, this only keeps the table from growing.
+func purgeExpiredIdempotencyKeys(ctx context.Context, idempotencyUseCase usecase.IdempotencyUseCase) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := idempotencyUseCase.PurgeExpiredKeys(ctx)
+			if err != nil {
+				logger.Logger.ErrorContext(ctx, "Failed to purge expired idempotency keys", "error", err.Error())
+				continue
+			}
+			logger.Logger.Info("Expired idempotency keys purged", "deleted", deleted)
+		}
+	}
+}
+
+// closeInvoices periodically closes the invoices whose closing date has passed, so they are
+// closed at most one interval after midnight of the closing day.
+func closeInvoices(ctx context.Context, invoiceUseCase usecase.InvoiceUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			closed, err := invoiceUseCase.CloseInvoices(ctx, time.Now())
+			if err != nil {
+				logger.Logger.ErrorContext(ctx, "Failed to close invoices", "error", err.Error())
+				continue
+			}
+			logger.Logger.Info("Invoices closed", "closed", closed)
+		}
+	}
+}
+
+// accrueCharges periodically posts the late fees and revolving interest of overdue invoices.
+// Each day's charges are posted at most one interval after midnight and never twice.
+func accrueCharges(ctx context.Context, chargeUseCase usecase.ChargeUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			posted, err := chargeUseCase.AccrueCharges(ctx, time.Now())
+			if err != nil {
+				logger.Logger.ErrorContext(ctx, "Failed to accrue charges", "error", err.Error())
+				continue
+			}
+			logger.Logger.Info("Charges accrued", "posted", posted)
+		}
+	}
+}
+
+// expireAuthorizations periodically releases the holds of authorizations that were neither
+// captured nor voided before their expiry.
+func expireAuthorizations(ctx context.Context, authorizationUseCase usecase.AuthorizationUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := authorizationUseCase.ExpireAuthorizations(ctx, time.Now())
+			if err != nil {
+				logger.Logger.ErrorContext(ctx, "Failed to expire authorizations", "error", err.Error())
+				continue
+			}
+			logger.Logger.Info("Authorizations expired", "expired", expired)
+		}
+	}
 }
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/config"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/database"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+)
+
+const migrateUsage = "usage: transaction-flow migrate up|down [steps]|status|version"
+
+// runMigrate runs the migrate subcommand with the migrations embedded in the binary. down
+// reverts a single migration unless told how many.
+func runMigrate(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	db, err := database.ConnectDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := database.NewMigrator(context.Background(), db, cfg.MigrationLockTimeout)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	switch args[0] {
+	case "up":
+		if err := migrator.Up(); err != nil {
+			return err
+		}
+		return printVersion(migrator)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil {
+				return fmt.Errorf("steps must be a number: %s", migrateUsage)
+			}
+		}
+		if err := migrator.Down(steps); err != nil {
+			return err
+		}
+		return printVersion(migrator)
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			applied := "pending"
+			if status.Applied {
+				applied = "applied"
+			}
+			fmt.Printf("%06d %-8s %s\n", status.Version, applied, status.Name)
+		}
+		return printVersion(migrator)
+	case "version":
+		return printVersion(migrator)
+	default:
+		return fmt.Errorf("unknown migrate command %q: %s", args[0], migrateUsage)
+	}
+}
+
+func printVersion(migrator *database.Migrator) error {
+	version, dirty, err := migrator.Version()
+	if err != nil {
+		return err
+	}
+	if dirty {
+		fmt.Printf("version %d (dirty)\n", version)
+		return nil
+	}
+	fmt.Printf("version %d\n", version)
+	return nil
+}
+
+// migrateUp applies the pending migrations before the application starts serving.
+func migrateUp(db *sql.DB, lockTimeout time.Duration) error {
+	migrator, err := database.NewMigrator(context.Background(), db, lockTimeout)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	if err := migrator.Up(); err != nil {
+		return err
+	}
+
+	version, _, err := migrator.Version()
+	if err != nil {
+		return err
+	}
+	logger.Logger.Info("Database migrated", "version", version)
+	return nil
+}
@@ -3,6 +3,9 @@ package config
 import (
 	"os"
 	"strconv"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
 )
 
 type Config struct {
@@ -12,6 +15,37 @@ type Config struct {
 	DBPassword string
 	DBName     string
 	AppPort    string
+
+	// AutoMigrate applies the pending migrations on start, waiting up to MigrationLockTimeout
+	// for other instances applying them.
+	AutoMigrate          bool
+	MigrationLockTimeout time.Duration
+
+	// DBQueryTimeout bounds the database work done while serving a single API request.
+	DBQueryTimeout time.Duration
+
+	HealthCheckTimeout time.Duration
+	// ShutdownDrainDelay is how long the instance keeps serving, reported not ready, after
+	// SIGTERM before it stops accepting connections.
+	ShutdownDrainDelay time.Duration
+
+	// TracingExporter is where spans are sent: "none", "stdout" or "otlp".
+	TracingExporter string
+	OTLPEndpoint    string
+
+	IdempotencyKeyTTL time.Duration
+	// IdempotencyKeyLease is how long a key stays reserved by a request that has not
+	// answered yet; after that a retry takes the key over.
+	IdempotencyKeyLease        time.Duration
+	OperationTypeCatalogMaxAge time.Duration
+	InvoiceClosingInterval     time.Duration
+
+	InterestMonthlyRate   domain.InterestRate
+	LateFee               domain.Money
+	ChargeAccrualInterval time.Duration
+
+	AuthorizationTTL            time.Duration
+	AuthorizationExpiryInterval time.Duration
 }
 
 func LoadConfig() *Config {
@@ -22,6 +56,29 @@ func LoadConfig() *Config {
 		DBPassword: getEnv("DB_PASSWORD", "postgres"),
 		DBName:     getEnv("DB_NAME", "transactions"),
 		AppPort:    getEnv("APP_PORT", ":8080"),
+
+		AutoMigrate:          getEnvAsBool("AUTO_MIGRATE", false),
+		MigrationLockTimeout: getEnvAsDuration("MIGRATION_LOCK_TIMEOUT", time.Minute),
+
+		DBQueryTimeout: getEnvAsDuration("DB_QUERY_TIMEOUT", 5*time.Second),
+
+		HealthCheckTimeout: getEnvAsDuration("HEALTH_CHECK_TIMEOUT", 2*time.Second),
+		ShutdownDrainDelay: getEnvAsDuration("SHUTDOWN_DRAIN_DELAY", 5*time.Second),
+
+		TracingExporter: getEnv("TRACING_EXPORTER", "none"),
+		OTLPEndpoint:    getEnv("OTLP_ENDPOINT", "http://localhost:4318"),
+
+		IdempotencyKeyTTL:          getEnvAsDuration("IDEMPOTENCY_KEY_TTL", 24*time.Hour),
+		IdempotencyKeyLease:        getEnvAsDuration("IDEMPOTENCY_KEY_LEASE", time.Minute),
+		OperationTypeCatalogMaxAge: getEnvAsDuration("OPERATION_TYPE_CATALOG_MAX_AGE", time.Minute),
+		InvoiceClosingInterval:     getEnvAsDuration("INVOICE_CLOSING_INTERVAL", time.Hour),
+
+		InterestMonthlyRate:   getEnvAsInterestRate("INTEREST_MONTHLY_RATE", domain.InterestRate(1200)),
+		LateFee:               getEnvAsMoney("LATE_FEE", domain.MustParseMoney("10.00")),
+		ChargeAccrualInterval: getEnvAsDuration("CHARGE_ACCRUAL_INTERVAL", time.Hour),
+
+		AuthorizationTTL:            getEnvAsDuration("AUTHORIZATION_TTL", 7*24*time.Hour),
+		AuthorizationExpiryInterval: getEnvAsDuration("AUTHORIZATION_EXPIRY_INTERVAL", 5*time.Minute),
 	}
 }
 
@@ -41,3 +98,44 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value, exists := os.LookupEnv(key); exists {
+		boolValue, err := strconv.ParseBool(value)
+		if err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		duration, err := time.ParseDuration(value)
+		if err == nil {
+			return duration
+		}
+	}
+	return defaultValue
+}
+
+func getEnvAsMoney(key string, defaultValue domain.Money) domain.Money {
+	if value, exists := os.LookupEnv(key); exists {
+		money, err := domain.ParseMoney(value)
+		if err == nil {
+			return money
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsInterestRate reads a percentage with up to two decimals, "12.5" being 12.50%.
+func getEnvAsInterestRate(key string, defaultValue domain.InterestRate) domain.InterestRate {
+	if value, exists := os.LookupEnv(key); exists {
+		rate, err := domain.ParseInterestRate(value)
+		if err == nil {
+			return rate
+		}
+	}
+	return defaultValue
+}
@@ -17,7 +17,7 @@ const docTemplate = `{
     "paths": {
         "/accounts": {
             "post": {
-                "description": "Creates a new account with a document number",
+                "description": "Creates a new account with a CPF or CNPJ document number and an available credit limit",
                 "consumes": [
                     "application/json"
                 ],
@@ -37,6 +37,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.CreateAccountRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the recorded response when the request is retried with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -53,13 +59,13 @@ const docTemplate = `{
                         }
                     },
                     "409": {
-                        "description": "Account Already Exists",
+                        "description": "Account Already Exists or Request In Progress",
                         "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
+                            "$ref": "#/definitions/dto.AccountAlreadyExistsResponse"
                         }
                     },
                     "422": {
-                        "description": "Validation Error",
+                        "description": "Validation Error or Idempotency Key Reused",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -117,9 +123,62 @@ const docTemplate = `{
                 }
             }
         },
-        "/transactions": {
-            "post": {
-                "description": "Registers a new financial transaction",
+        "/accounts/{id}/balance": {
+            "get": {
+                "description": "Sums the account's transactions into its signed balance, total debits and total credits",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Accounts"
+                ],
+                "summary": "Retrieve the balance of an account",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only transactions with event_date at or before this RFC3339 timestamp",
+                        "name": "as_of",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Account Balance",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccountBalanceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Account Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/accounts/{id}/billing-cycle": {
+            "patch": {
+                "description": "Sets the days of the month on which the invoices of an account close and fall due. Invoices already closed are not affected; installments not billed yet move to the new cycle",
                 "consumes": [
                     "application/json"
                 ],
@@ -127,26 +186,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Accounts"
                 ],
-                "summary": "Create a transaction",
+                "summary": "Update the billing cycle",
                 "parameters": [
                     {
-                        "description": "Transaction Request",
-                        "name": "transaction",
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Billing cycle update request",
+                        "name": "billingCycle",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateTransactionRequest"
+                            "$ref": "#/definitions/dto.UpdateBillingCycleRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Transaction Created",
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateTransactionResponse"
-                        }
+                    "204": {
+                        "description": "Billing Cycle Updated"
                     },
                     "400": {
                         "description": "Invalid Request",
@@ -154,8 +217,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Account Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "422": {
-                        "description": "Validation Failed",
+                        "description": "Validation Error",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -168,63 +237,2251 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.CreateAccountRequest": {
-            "type": "object",
-            "properties": {
-                "document_number": {
-                    "type": "string",
-                    "example": "1234567890"
-                }
-            }
         },
-        "dto.CreateAccountResponse": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "integer",
-                    "example": 1
+        "/accounts/{id}/credit-limit": {
+            "patch": {
+                "description": "Sets the total credit limit of an account. The available limit moves by the same difference, keeping what open debits and pending authorizations use.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Accounts"
+                ],
+                "summary": "Update the credit limit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credit limit update request",
+                        "name": "creditLimit",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateCreditLimitRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Credit Limit Updated"
+                    },
+                    "400": {
+                        "description": "Invalid Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Account Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "dto.CreateTransactionRequest": {
-            "type": "object",
-            "properties": {
-                "account_id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "amount": {
-                    "type": "number",
-                    "example": 100
-                },
-                "operation_type_id": {
-                    "type": "integer",
-                    "example": 4
+        "/accounts/{id}/invoices": {
+            "get": {
+                "description": "Lists the closed invoices of an account, newest first, without their line items",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invoices"
+                ],
+                "summary": "List the invoices of an account",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invoices",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ListInvoicesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Account Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "dto.CreateTransactionResponse": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "integer",
-                    "example": 1
+        "/accounts/{id}/status": {
+            "patch": {
+                "description": "Changes the status of an account, recording the reason and who changed it. Blocked accounts only accept credits, such as payments, and closed accounts accept no transactions. Closing an account is final",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Accounts"
+                ],
+                "summary": "Block, unblock or close an account",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status change request",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateAccountStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Status Updated"
+                    },
+                    "400": {
+                        "description": "Invalid Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Account Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Status Changed Concurrently",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation Error or Invalid Status Transition",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "dto.GetAccountResponse": {
-            "type": "object",
-            "properties": {
+        "/accounts/{id}/status-history": {
+            "get": {
+                "description": "Lists every status change of an account, oldest first, with its reason and actor",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Accounts"
+                ],
+                "summary": "List the status history of an account",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Status History",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ListAccountStatusChangesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Account Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/accounts/{id}/transactions": {
+            "get": {
+                "description": "Lists an account's transactions newest first using cursor-based pagination",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "List the transactions of an account",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (1-100, default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned as next_cursor by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Operation type filter",
+                        "name": "operation_type_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only transactions with event_date at or after this RFC3339 timestamp",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only transactions with event_date before this RFC3339 timestamp",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transactions Page",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ListTransactionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/authorizations": {
+            "post": {
+                "description": "Holds an amount against the available credit limit of an account without posting a transaction. The hold is released when the authorization is captured, voided or expires",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authorizations"
+                ],
+                "summary": "Authorize a purchase",
+                "parameters": [
+                    {
+                        "description": "Authorization Request",
+                        "name": "authorization",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateAuthorizationRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the recorded response when the request is retried with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Authorization Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthorizationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Request In Progress",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation Failed, Account Not Found, Account Blocked or Closed, Insufficient Credit Limit or Idempotency Key Reused",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/authorizations/{id}": {
+            "get": {
+                "description": "Fetches an authorization by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authorizations"
+                ],
+                "summary": "Retrieve an authorization",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Authorization Details",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthorizationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Authorization Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/authorizations/{id}/capture": {
+            "post": {
+                "description": "Posts a CompraAVista for the captured amount and releases the hold. Without an amount the whole authorization is captured; a smaller amount releases the rest. An authorization is captured only once",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authorizations"
+                ],
+                "summary": "Capture an authorization",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Amount to capture",
+                        "name": "capture",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CaptureAuthorizationRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the recorded response when the request is retried with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Authorization Captured",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthorizationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Authorization Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Authorization Not Pending or Request In Progress",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation Failed, Authorization Expired, Capture Exceeds Authorization, Account Blocked or Closed, Insufficient Credit Limit or Idempotency Key Reused",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/authorizations/{id}/void": {
+            "post": {
+                "description": "Cancels a pending authorization and gives the held amount back to the available credit limit",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authorizations"
+                ],
+                "summary": "Void an authorization",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Authorization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Authorization Voided",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthorizationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Authorization Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Authorization Not Pending",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Authorization Expired",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/disputes/{id}": {
+            "get": {
+                "description": "Fetches a dispute by ID with its history",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Disputes"
+                ],
+                "summary": "Retrieve a dispute",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Dispute ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dispute Details",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DisputeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Dispute Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/disputes/{id}/resolve": {
+            "post": {
+                "description": "Closes a dispute as WON, keeping the provisional credit, or as LOST, posting an ESTORNO DE CREDITO PROVISORIO that takes it back. A dispute is resolved only once",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Disputes"
+                ],
+                "summary": "Resolve a dispute",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Dispute ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Outcome of the dispute",
+                        "name": "resolution",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResolveDisputeRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the recorded response when the request is retried with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dispute Resolved",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DisputeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Dispute Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Dispute Already Resolved or Request In Progress",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation Failed or Idempotency Key Reused",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Answers as long as the process can serve HTTP, without checking its dependencies",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Check that the process is alive",
+                "responses": {
+                    "200": {
+                        "description": "Alive",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LivenessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/invoices/{id}": {
+            "get": {
+                "description": "Fetches a closed invoice with its totals, minimum payment and line items",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invoices"
+                ],
+                "summary": "Retrieve an invoice",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Invoice ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invoice Details",
+                        "schema": {
+                            "$ref": "#/definitions/dto.InvoiceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invoice Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ledger/trial-balance": {
+            "get": {
+                "description": "Totals the debits and credits posted to each account of the general ledger. Every transaction is posted as a balanced journal entry, so the totals match",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ledger"
+                ],
+                "summary": "Retrieve the trial balance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only journal entries dated at or before this RFC3339 timestamp",
+                        "name": "as_of",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Trial Balance",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TrialBalanceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/operation-types": {
+            "get": {
+                "description": "Lists every operation type of the catalog, including the inactive ones",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Operation Types"
+                ],
+                "summary": "List the operation types",
+                "responses": {
+                    "200": {
+                        "description": "Operation Types",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ListOperationTypesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds an active operation type to the catalog. The direction decides the sign of its transactions and cannot be changed later",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Operation Types"
+                ],
+                "summary": "Create an operation type",
+                "parameters": [
+                    {
+                        "description": "Operation type creation request",
+                        "name": "operationType",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateOperationTypeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Operation Type Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateOperationTypeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Operation Type Already Exists",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/operation-types/{id}": {
+            "patch": {
+                "description": "Renames, activates or deactivates an operation type. Inactive types reject new transactions. System types (5 to 12) cannot be changed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Operation Types"
+                ],
+                "summary": "Update an operation type",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Operation Type ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Operation type update request",
+                        "name": "operationType",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateOperationTypeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Operation Type Updated",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OperationTypeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Operation Type Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Operation Type Already Exists",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation Error or System Operation Type",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Checks that the database answers, that its schema is at the expected migration version and that the instance is not shutting down",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Check that the instance can serve traffic",
+                "responses": {
+                    "200": {
+                        "description": "Ready",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReadinessResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Not Ready",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReadinessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions": {
+            "post": {
+                "description": "Registers a new financial transaction. Installment purchases (operation type 2) accept the number of installments they are paid in",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Create a transaction",
+                "parameters": [
+                    {
+                        "description": "Transaction Request",
+                        "name": "transaction",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateTransactionRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the recorded response when the request is retried with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Transaction Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateTransactionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Request In Progress",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation Failed, Account Not Found, Account Blocked or Closed, Invalid Operation Type, Insufficient Credit Limit or Idempotency Key Reused",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}": {
+            "get": {
+                "description": "Fetches transaction details by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Retrieve a transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transaction Details",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetTransactionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/disputes": {
+            "get": {
+                "description": "Lists every dispute opened for a transaction, oldest first, with the history of each one and the transactions it posted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Disputes"
+                ],
+                "summary": "List the disputes of a transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Disputes",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ListDisputesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Opens a dispute for a purchase or withdrawal and credits the disputed amount provisionally (CREDITO PROVISORIO) until the dispute is resolved. Without an amount the whole transaction is disputed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Disputes"
+                ],
+                "summary": "Dispute a transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dispute Request",
+                        "name": "dispute",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.OpenDisputeRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the recorded response when the request is retried with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Dispute Opened",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DisputeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Transaction Already Disputed or Request In Progress",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation Failed, Transaction Not Disputable, Dispute Exceeds Amount, Account Closed or Idempotency Key Reused",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/installments": {
+            "get": {
+                "description": "Lists the installment schedule of an installment purchase, empty for other transactions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "List the installments of a transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Installments",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ListInstallmentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/reversal": {
+            "post": {
+                "description": "Creates a compensating transaction (estorno) with the opposite sign of the original one. Without an amount the whole transaction is reversed. Reversals, transfers, installment purchases and provisional credits cannot be reversed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Reverse a transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Amount to reverse",
+                        "name": "reversal",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReverseTransactionRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the recorded response when the request is retried with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Reversal Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateTransactionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Transaction Already Reversed, Transaction Disputed or Request In Progress",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation Failed, Reversal Not Allowed, Reversal Exceeds Amount, Account Blocked or Closed, Insufficient Credit Limit or Idempotency Key Reused",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers": {
+            "post": {
+                "description": "Debits one account and credits another in a single operation: either both legs are recorded or neither is",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Transfer between accounts",
+                "parameters": [
+                    {
+                        "description": "Transfer Request",
+                        "name": "transfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateTransferRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the recorded response when the request is retried with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Transfer Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Request In Progress",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation Failed, Account Not Found, Account Blocked or Closed, Insufficient Credit Limit or Idempotency Key Reused",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AccountAlreadyExistsResponse": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "description": {
+                    "type": "string",
+                    "example": "an account with document number 12345678909 already exists"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "account already exists"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 409
+                }
+            }
+        },
+        "dto.AccountBalanceResponse": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "as_of": {
+                    "type": "string",
+                    "example": "2025-01-31T23:59:59Z"
+                },
+                "balance": {
+                    "type": "number",
+                    "example": -70
+                },
+                "last_transaction_at": {
+                    "type": "string",
+                    "example": "2025-01-31T12:00:00Z"
+                },
+                "total_credits": {
+                    "type": "number",
+                    "example": 50
+                },
+                "total_debits": {
+                    "type": "number",
+                    "example": 120
+                }
+            }
+        },
+        "dto.AccountStatusChangeResponse": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string",
+                    "example": "analyst@bank.com"
+                },
+                "changed_at": {
+                    "type": "string",
+                    "example": "2025-01-31T12:00:00Z"
+                },
+                "from_status": {
+                    "type": "string",
+                    "example": "ACTIVE"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "fraud suspicion"
+                },
+                "to_status": {
+                    "type": "string",
+                    "example": "BLOCKED"
+                }
+            }
+        },
+        "dto.AuthorizationResponse": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "amount": {
+                    "type": "number",
+                    "example": 150
+                },
+                "captured_amount": {
+                    "type": "number",
+                    "example": 120.5
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-31T12:00:00Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-02-07T12:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "type": "string",
+                    "example": "CAPTURED"
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.CaptureAuthorizationRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 120.5
+                }
+            }
+        },
+        "dto.CreateAccountRequest": {
+            "type": "object",
+            "properties": {
+                "available_credit_limit": {
+                    "type": "number",
+                    "example": 1000
+                },
+                "document_number": {
+                    "type": "string",
+                    "example": "123.456.789-09"
+                }
+            }
+        },
+        "dto.CreateAccountResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.CreateAuthorizationRequest": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "amount": {
+                    "type": "number",
+                    "example": 150
+                }
+            }
+        },
+        "dto.CreateOperationTypeRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "TARIFA"
+                },
+                "direction": {
+                    "type": "string",
+                    "enum": [
+                        "DEBIT",
+                        "CREDIT"
+                    ],
+                    "example": "DEBIT"
+                }
+            }
+        },
+        "dto.CreateOperationTypeResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "dto.CreateTransactionRequest": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "amount": {
+                    "type": "number",
+                    "example": 100
+                },
+                "installments": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "operation_type_id": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "dto.CreateTransactionResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.CreateTransferRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 25.5
+                },
+                "from_account_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "to_account_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "dto.DisputeEventResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-31T12:00:00Z"
+                },
+                "from_status": {
+                    "type": "string",
+                    "example": "OPENED"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "note": {
+                    "type": "string",
+                    "example": "purchase not recognized"
+                },
+                "to_status": {
+                    "type": "string",
+                    "example": "PROVISIONAL_CREDIT"
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 11
+                }
+            }
+        },
+        "dto.DisputeResponse": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "amount": {
+                    "type": "number",
+                    "example": 40
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DisputeEventResponse"
+                    }
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "opened_at": {
+                    "type": "string",
+                    "example": "2025-01-31T12:00:00Z"
+                },
+                "provisional_credit_transaction_id": {
+                    "type": "integer",
+                    "example": 11
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "purchase not recognized"
+                },
+                "resolved_at": {
+                    "type": "string",
+                    "example": "2025-02-15T12:00:00Z"
+                },
+                "reversal_transaction_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "status": {
+                    "type": "string",
+                    "example": "PROVISIONAL_CREDIT"
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.GetAccountResponse": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "available_credit_limit": {
+                    "type": "number",
+                    "example": 850
+                },
+                "closing_day": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "credit_balance": {
+                    "type": "number",
+                    "example": 0
+                },
+                "credit_limit": {
+                    "type": "number",
+                    "example": 1000
+                },
+                "document_number": {
+                    "type": "string",
+                    "example": "12345678909"
+                },
+                "document_type": {
+                    "type": "string",
+                    "example": "CPF"
+                },
+                "due_day": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ACTIVE"
+                }
+            }
+        },
+        "dto.GetTransactionResponse": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "amount": {
+                    "type": "number",
+                    "example": -50
+                },
+                "balance": {
+                    "type": "number",
+                    "example": -20
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-31T12:00:01Z"
+                },
+                "event_date": {
+                    "type": "string",
+                    "example": "2025-01-31T12:00:00Z"
+                },
+                "explanation": {
+                    "type": "string",
+                    "example": "late fee for invoice 2 due on 2025-02-10 with 100.00 unpaid"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "invoice_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "operation_type_description": {
+                    "type": "string",
+                    "example": "COMPRA A VISTA"
+                },
+                "operation_type_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reverses_transaction_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "transfer_id": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "dto.HealthCheckResponse": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "type": "string",
+                    "example": "schema is at version 19, expected at least 20"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "DOWN"
+                }
+            }
+        },
+        "dto.InstallmentResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 33.34
+                },
+                "due_date": {
+                    "type": "string",
+                    "example": "2025-02-10"
+                },
+                "number": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.InvoiceItemResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": -33.34
+                },
+                "event_date": {
+                    "type": "string",
+                    "example": "2025-01-20T10:00:00Z"
+                },
+                "installment_number": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "operation_type_description": {
+                    "type": "string",
+                    "example": "COMPRA PARCELADA"
+                },
+                "operation_type_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.InvoiceResponse": {
+            "type": "object",
+            "properties": {
                 "account_id": {
                     "type": "integer",
                     "example": 1
                 },
-                "document_number": {
+                "balance": {
+                    "type": "number",
+                    "example": -83.34
+                },
+                "closed_at": {
+                    "type": "string",
+                    "example": "2025-02-03T00:10:00Z"
+                },
+                "closing_date": {
+                    "type": "string",
+                    "example": "2025-02-03"
+                },
+                "due_date": {
+                    "type": "string",
+                    "example": "2025-02-10"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.InvoiceItemResponse"
+                    }
+                },
+                "minimum_payment": {
+                    "type": "number",
+                    "example": 12.51
+                },
+                "period_start": {
+                    "type": "string",
+                    "example": "2025-01-03T00:00:00Z"
+                },
+                "previous_balance": {
+                    "type": "number",
+                    "example": 0
+                },
+                "total_credits": {
+                    "type": "number",
+                    "example": 50
+                },
+                "total_debits": {
+                    "type": "number",
+                    "example": 133.34
+                },
+                "total_due": {
+                    "type": "number",
+                    "example": 83.34
+                }
+            }
+        },
+        "dto.ListAccountStatusChangesResponse": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AccountStatusChangeResponse"
+                    }
+                }
+            }
+        },
+        "dto.ListDisputesResponse": {
+            "type": "object",
+            "properties": {
+                "disputes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DisputeResponse"
+                    }
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.ListInstallmentsResponse": {
+            "type": "object",
+            "properties": {
+                "installments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.InstallmentResponse"
+                    }
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.ListInvoicesResponse": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "invoices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.InvoiceResponse"
+                    }
+                }
+            }
+        },
+        "dto.ListOperationTypesResponse": {
+            "type": "object",
+            "properties": {
+                "operation_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OperationTypeResponse"
+                    }
+                }
+            }
+        },
+        "dto.ListTransactionsResponse": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "type": "string",
+                    "example": "MjAyNS0wMS0zMVQxMjowMDowMFp8MQ"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TransactionResponse"
+                    }
+                }
+            }
+        },
+        "dto.LivenessResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "UP"
+                }
+            }
+        },
+        "dto.OpenDisputeRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 40
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "purchase not recognized"
+                }
+            }
+        },
+        "dto.OperationTypeResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-31T12:00:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "TARIFA"
+                },
+                "direction": {
+                    "type": "string",
+                    "example": "DEBIT"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "dto.ReadinessResponse": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/dto.HealthCheckResponse"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "DOWN"
+                }
+            }
+        },
+        "dto.ResolveDisputeRequest": {
+            "type": "object",
+            "properties": {
+                "outcome": {
+                    "type": "string",
+                    "example": "LOST"
+                }
+            }
+        },
+        "dto.ReverseTransactionRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 20
+                }
+            }
+        },
+        "dto.TransactionResponse": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "amount": {
+                    "type": "number",
+                    "example": -50
+                },
+                "balance": {
+                    "type": "number",
+                    "example": -20
+                },
+                "event_date": {
+                    "type": "string",
+                    "example": "2025-01-31T12:00:00Z"
+                },
+                "explanation": {
+                    "type": "string",
+                    "example": "late fee for invoice 2 due on 2025-02-10 with 100.00 unpaid"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "invoice_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "operation_type_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reverses_transaction_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "transfer_id": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "dto.TransferResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 25.5
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-31T12:00:00Z"
+                },
+                "credit_transaction_id": {
+                    "type": "integer",
+                    "example": 11
+                },
+                "debit_transaction_id": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "from_account_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "to_account_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "dto.TrialBalanceLineResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number",
+                    "example": 70
+                },
+                "ledger_account": {
+                    "type": "string",
+                    "example": "RECEIVABLES"
+                },
+                "total_credits": {
+                    "type": "number",
+                    "example": 50
+                },
+                "total_debits": {
+                    "type": "number",
+                    "example": 120
+                }
+            }
+        },
+        "dto.TrialBalanceResponse": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TrialBalanceLineResponse"
+                    }
+                },
+                "as_of": {
+                    "type": "string",
+                    "example": "2025-01-31T23:59:59Z"
+                },
+                "balanced": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "total_credits": {
+                    "type": "number",
+                    "example": 170
+                },
+                "total_debits": {
+                    "type": "number",
+                    "example": 170
+                }
+            }
+        },
+        "dto.UpdateAccountStatusRequest": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string",
+                    "example": "analyst@bank.com"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "fraud suspicion"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "ACTIVE",
+                        "BLOCKED",
+                        "CLOSED"
+                    ],
+                    "example": "BLOCKED"
+                }
+            }
+        },
+        "dto.UpdateBillingCycleRequest": {
+            "type": "object",
+            "properties": {
+                "closing_day": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "due_day": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.UpdateCreditLimitRequest": {
+            "type": "object",
+            "properties": {
+                "credit_limit": {
+                    "type": "number",
+                    "example": 1500
+                }
+            }
+        },
+        "dto.UpdateOperationTypeRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "description": {
                     "type": "string",
-                    "example": "1234567890"
+                    "example": "TARIFA MENSAL"
                 }
             }
         },
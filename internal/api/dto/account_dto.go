@@ -2,19 +2,68 @@ package dto
 
 import (
 	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
 )
 
 type CreateAccountRequest struct {
-	DocumentNumber string `json:"document_number" example:"1234567890"`
+	DocumentNumber       string       `json:"document_number" example:"123.456.789-09"`
+	AvailableCreditLimit domain.Money `json:"available_credit_limit" swaggertype:"number" example:"1000.00"`
 }
 
 type CreateAccountResponse struct {
 	ID int64 `json:"id" example:"1"`
 }
 
+type AccountAlreadyExistsResponse struct {
+	StatusCode  int    `json:"status_code" example:"409"`
+	Error       string `json:"error" example:"account already exists"`
+	Description string `json:"description" example:"an account with document number 12345678909 already exists"`
+	AccountID   int64  `json:"account_id" example:"1"`
+}
+
 type GetAccountResponse struct {
-	AccountID      int64  `json:"account_id" example:"1"`
-	DocumentNumber string `json:"document_number" example:"1234567890"`
+	AccountID            int64        `json:"account_id" example:"1"`
+	DocumentNumber       string       `json:"document_number" example:"12345678909"`
+	DocumentType         string       `json:"document_type,omitempty" example:"CPF"`
+	CreditLimit          domain.Money `json:"credit_limit" swaggertype:"number" example:"1000.00"`
+	AvailableCreditLimit domain.Money `json:"available_credit_limit" swaggertype:"number" example:"850.00"`
+	CreditBalance        domain.Money `json:"credit_balance" swaggertype:"number" example:"0.00"`
+	ClosingDay           int          `json:"closing_day" example:"3"`
+	DueDay               int          `json:"due_day" example:"10"`
+	Status               string       `json:"status" example:"ACTIVE"`
+}
+
+type UpdateCreditLimitRequest struct {
+	CreditLimit *domain.Money `json:"credit_limit" swaggertype:"number" example:"1500.00"`
+}
+
+type UpdateBillingCycleRequest struct {
+	ClosingDay *int `json:"closing_day" example:"3"`
+	DueDay     *int `json:"due_day" example:"10"`
+}
+
+type UpdateAccountStatusRequest struct {
+	Status string `json:"status" example:"BLOCKED" enums:"ACTIVE,BLOCKED,CLOSED"`
+	Reason string `json:"reason" example:"fraud suspicion"`
+	Actor  string `json:"actor" example:"analyst@bank.com"`
+}
+
+type AccountStatusChangeResponse struct {
+	ID         int64     `json:"id" example:"1"`
+	FromStatus string    `json:"from_status" example:"ACTIVE"`
+	ToStatus   string    `json:"to_status" example:"BLOCKED"`
+	Reason     string    `json:"reason" example:"fraud suspicion"`
+	Actor      string    `json:"actor" example:"analyst@bank.com"`
+	ChangedAt  time.Time `json:"changed_at" example:"2025-01-31T12:00:00Z"`
+}
+
+type ListAccountStatusChangesResponse struct {
+	AccountID int64                         `json:"account_id" example:"1"`
+	Changes   []AccountStatusChangeResponse `json:"changes"`
 }
 
 func (c *CreateAccountRequest) Validate() error {
@@ -22,5 +71,77 @@ func (c *CreateAccountRequest) Validate() error {
 		return errors.New("document_number is mandatory")
 	}
 
+	if c.AvailableCreditLimit.IsNegative() {
+		return errors.New("available_credit_limit must not be negative")
+	}
+
+	return nil
+}
+
+func (u *UpdateCreditLimitRequest) Validate() error {
+	if u.CreditLimit == nil {
+		return errors.New("credit_limit is mandatory")
+	}
+
+	if u.CreditLimit.IsNegative() {
+		return errors.New("credit_limit must not be negative")
+	}
+
+	return nil
+}
+
+func (u *UpdateBillingCycleRequest) Validate() error {
+	if u.ClosingDay == nil {
+		return errors.New("closing_day is mandatory")
+	}
+
+	if u.DueDay == nil {
+		return errors.New("due_day is mandatory")
+	}
+
 	return nil
 }
+
+func (u *UpdateAccountStatusRequest) Validate() error {
+	if u.Status == "" {
+		return errors.New("status is mandatory")
+	}
+
+	if !domain.AccountStatus(u.Status).IsValid() {
+		return fmt.Errorf("status must be one of %s, %s or %s", domain.AccountStatusActive, domain.AccountStatusBlocked, domain.AccountStatusClosed)
+	}
+
+	if u.Reason == "" {
+		return errors.New("reason is mandatory")
+	}
+
+	if u.Actor == "" {
+		return errors.New("actor is mandatory")
+	}
+
+	return nil
+}
+
+func NewListAccountStatusChangesResponse(accountID int64, changes []domain.AccountStatusChange) ListAccountStatusChangesResponse {
+	resp := ListAccountStatusChangesResponse{AccountID: accountID, Changes: make([]AccountStatusChangeResponse, 0, len(changes))}
+	for _, change := range changes {
+		resp.Changes = append(resp.Changes, AccountStatusChangeResponse{
+			ID:         change.ID,
+			FromStatus: string(change.FromStatus),
+			ToStatus:   string(change.ToStatus),
+			Reason:     change.Reason,
+			Actor:      change.Actor,
+			ChangedAt:  change.ChangedAt,
+		})
+	}
+	return resp
+}
+
+func NewAccountAlreadyExistsResponse(err *domain.AccountAlreadyExistsError) AccountAlreadyExistsResponse {
+	return AccountAlreadyExistsResponse{
+		StatusCode:  http.StatusConflict,
+		Error:       "account already exists",
+		Description: err.Error(),
+		AccountID:   err.AccountID,
+	}
+}
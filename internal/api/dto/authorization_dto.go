@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+)
+
+type CreateAuthorizationRequest struct {
+	AccountID int64        `json:"account_id" example:"1"`
+	Amount    domain.Money `json:"amount" swaggertype:"number" example:"150.00"`
+}
+
+type CaptureAuthorizationRequest struct {
+	Amount *domain.Money `json:"amount,omitempty" swaggertype:"number" example:"120.50"`
+}
+
+type AuthorizationResponse struct {
+	ID             int64        `json:"id" example:"1"`
+	AccountID      int64        `json:"account_id" example:"1"`
+	Amount         domain.Money `json:"amount" swaggertype:"number" example:"150.00"`
+	CapturedAmount domain.Money `json:"captured_amount" swaggertype:"number" example:"120.50"`
+	Status         string       `json:"status" example:"CAPTURED"`
+	TransactionID  *int64       `json:"transaction_id,omitempty" example:"10"`
+	ExpiresAt      time.Time    `json:"expires_at" example:"2025-02-07T12:00:00Z"`
+	CreatedAt      time.Time    `json:"created_at" example:"2025-01-31T12:00:00Z"`
+}
+
+func (c *CreateAuthorizationRequest) Validate() error {
+	if c.AccountID == 0 {
+		return errors.New("accountID is mandatory")
+	}
+
+	if !c.Amount.IsPositive() {
+		return errors.New("amount must be positive")
+	}
+
+	return nil
+}
+
+func (c *CaptureAuthorizationRequest) Validate() error {
+	if c.Amount != nil && !c.Amount.IsPositive() {
+		return errors.New("amount must be positive")
+	}
+
+	return nil
+}
+
+func NewAuthorizationResponse(authorization *domain.Authorization) AuthorizationResponse {
+	return AuthorizationResponse{
+		ID:             authorization.ID,
+		AccountID:      authorization.AccountID,
+		Amount:         authorization.Amount,
+		CapturedAmount: authorization.CapturedAmount,
+		Status:         string(authorization.Status),
+		TransactionID:  authorization.TransactionID,
+		ExpiresAt:      authorization.ExpiresAt,
+		CreatedAt:      authorization.CreatedAt,
+	}
+}
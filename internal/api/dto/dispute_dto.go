@@ -0,0 +1,104 @@
+package dto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+)
+
+type OpenDisputeRequest struct {
+	Amount *domain.Money `json:"amount,omitempty" swaggertype:"number" example:"40.00"`
+	Reason string        `json:"reason" example:"purchase not recognized"`
+}
+
+type ResolveDisputeRequest struct {
+	Outcome string `json:"outcome" example:"LOST"`
+}
+
+type DisputeEventResponse struct {
+	ID            int64     `json:"id" example:"2"`
+	FromStatus    string    `json:"from_status,omitempty" example:"OPENED"`
+	ToStatus      string    `json:"to_status" example:"PROVISIONAL_CREDIT"`
+	TransactionID *int64    `json:"transaction_id,omitempty" example:"11"`
+	Note          string    `json:"note,omitempty" example:"purchase not recognized"`
+	CreatedAt     time.Time `json:"created_at" example:"2025-01-31T12:00:00Z"`
+}
+
+type DisputeResponse struct {
+	ID                             int64                  `json:"id" example:"1"`
+	TransactionID                  int64                  `json:"transaction_id" example:"10"`
+	AccountID                      int64                  `json:"account_id" example:"1"`
+	Amount                         domain.Money           `json:"amount" swaggertype:"number" example:"40.00"`
+	Reason                         string                 `json:"reason" example:"purchase not recognized"`
+	Status                         string                 `json:"status" example:"PROVISIONAL_CREDIT"`
+	ProvisionalCreditTransactionID *int64                 `json:"provisional_credit_transaction_id,omitempty" example:"11"`
+	ReversalTransactionID          *int64                 `json:"reversal_transaction_id,omitempty" example:"12"`
+	OpenedAt                       time.Time              `json:"opened_at" example:"2025-01-31T12:00:00Z"`
+	ResolvedAt                     *time.Time             `json:"resolved_at,omitempty" example:"2025-02-15T12:00:00Z"`
+	Events                         []DisputeEventResponse `json:"events"`
+}
+
+type ListDisputesResponse struct {
+	TransactionID int64             `json:"transaction_id" example:"10"`
+	Disputes      []DisputeResponse `json:"disputes"`
+}
+
+func (o *OpenDisputeRequest) Validate() error {
+	if o.Reason == "" {
+		return errors.New("reason is mandatory")
+	}
+
+	if o.Amount != nil && !o.Amount.IsPositive() {
+		return errors.New("amount must be positive")
+	}
+
+	return nil
+}
+
+func (r *ResolveDisputeRequest) Validate() error {
+	if r.Outcome == "" {
+		return errors.New("outcome is mandatory")
+	}
+
+	if !domain.DisputeStatus(r.Outcome).IsOutcome() {
+		return errors.New("outcome must be one of WON or LOST")
+	}
+
+	return nil
+}
+
+func NewDisputeResponse(dispute *domain.Dispute) DisputeResponse {
+	resp := DisputeResponse{
+		ID:                             dispute.ID,
+		TransactionID:                  dispute.TransactionID,
+		AccountID:                      dispute.AccountID,
+		Amount:                         dispute.Amount,
+		Reason:                         dispute.Reason,
+		Status:                         string(dispute.Status),
+		ProvisionalCreditTransactionID: dispute.ProvisionalCreditTransactionID,
+		ReversalTransactionID:          dispute.ReversalTransactionID,
+		OpenedAt:                       dispute.OpenedAt,
+		ResolvedAt:                     dispute.ResolvedAt,
+		Events:                         make([]DisputeEventResponse, 0, len(dispute.Events)),
+	}
+	for _, event := range dispute.Events {
+		resp.Events = append(resp.Events, DisputeEventResponse{
+			ID:            event.ID,
+			FromStatus:    string(event.FromStatus),
+			ToStatus:      string(event.ToStatus),
+			TransactionID: event.TransactionID,
+			Note:          event.Note,
+			CreatedAt:     event.CreatedAt,
+		})
+	}
+	return resp
+}
+
+func NewListDisputesResponse(transactionID int64, disputes []domain.Dispute) ListDisputesResponse {
+	resp := ListDisputesResponse{TransactionID: transactionID, Disputes: make([]DisputeResponse, 0, len(disputes))}
+	for i := range disputes {
+		resp.Disputes = append(resp.Disputes, NewDisputeResponse(&disputes[i]))
+	}
+	return resp
+}
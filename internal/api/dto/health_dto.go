@@ -0,0 +1,25 @@
+package dto
+
+import "github.com/VieiraVitor/transaction-flow/internal/domain"
+
+type LivenessResponse struct {
+	Status string `json:"status" example:"UP"`
+}
+
+type HealthCheckResponse struct {
+	Status string `json:"status" example:"DOWN"`
+	Detail string `json:"detail,omitempty" example:"schema is at version 19, expected at least 20"`
+}
+
+type ReadinessResponse struct {
+	Status string                         `json:"status" example:"DOWN"`
+	Checks map[string]HealthCheckResponse `json:"checks"`
+}
+
+func NewReadinessResponse(readiness domain.Readiness) ReadinessResponse {
+	resp := ReadinessResponse{Status: string(readiness.Status), Checks: make(map[string]HealthCheckResponse, len(readiness.Checks))}
+	for _, check := range readiness.Checks {
+		resp.Checks[check.Name] = HealthCheckResponse{Status: string(check.Status), Detail: check.Detail}
+	}
+	return resp
+}
@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+)
+
+type InvoiceItemResponse struct {
+	TransactionID            int64        `json:"transaction_id" example:"1"`
+	OperationTypeID          int          `json:"operation_type_id" example:"2"`
+	OperationTypeDescription string       `json:"operation_type_description" example:"COMPRA PARCELADA"`
+	InstallmentNumber        *int         `json:"installment_number,omitempty" example:"1"`
+	Amount                   domain.Money `json:"amount" swaggertype:"number" example:"-33.34"`
+	EventDate                time.Time    `json:"event_date" example:"2025-01-20T10:00:00Z"`
+}
+
+type InvoiceResponse struct {
+	ID              int64                 `json:"id" example:"1"`
+	AccountID       int64                 `json:"account_id" example:"1"`
+	PeriodStart     time.Time             `json:"period_start" example:"2025-01-03T00:00:00Z"`
+	ClosingDate     string                `json:"closing_date" example:"2025-02-03"`
+	DueDate         string                `json:"due_date" example:"2025-02-10"`
+	PreviousBalance domain.Money          `json:"previous_balance" swaggertype:"number" example:"0.00"`
+	TotalDebits     domain.Money          `json:"total_debits" swaggertype:"number" example:"133.34"`
+	TotalCredits    domain.Money          `json:"total_credits" swaggertype:"number" example:"50.00"`
+	Balance         domain.Money          `json:"balance" swaggertype:"number" example:"-83.34"`
+	TotalDue        domain.Money          `json:"total_due" swaggertype:"number" example:"83.34"`
+	MinimumPayment  domain.Money          `json:"minimum_payment" swaggertype:"number" example:"12.51"`
+	ClosedAt        time.Time             `json:"closed_at" example:"2025-02-03T00:10:00Z"`
+	Items           []InvoiceItemResponse `json:"items,omitempty"`
+}
+
+type ListInvoicesResponse struct {
+	AccountID int64             `json:"account_id" example:"1"`
+	Invoices  []InvoiceResponse `json:"invoices"`
+}
+
+func NewInvoiceResponse(invoice domain.Invoice) InvoiceResponse {
+	resp := InvoiceResponse{
+		ID:              invoice.ID,
+		AccountID:       invoice.AccountID,
+		PeriodStart:     invoice.PeriodStart,
+		ClosingDate:     invoice.ClosingDate.Format(time.DateOnly),
+		DueDate:         invoice.DueDate.Format(time.DateOnly),
+		PreviousBalance: invoice.PreviousBalance,
+		TotalDebits:     invoice.TotalDebits,
+		TotalCredits:    invoice.TotalCredits,
+		Balance:         invoice.Balance,
+		TotalDue:        invoice.TotalDue(),
+		MinimumPayment:  invoice.MinimumPayment,
+		ClosedAt:        invoice.ClosedAt,
+	}
+
+	for _, item := range invoice.Items {
+		resp.Items = append(resp.Items, InvoiceItemResponse{
+			TransactionID:            item.TransactionID,
+			OperationTypeID:          int(item.OperationTypeID),
+			OperationTypeDescription: item.OperationTypeDescription,
+			InstallmentNumber:        item.InstallmentNumber,
+			Amount:                   item.Amount,
+			EventDate:                item.EventDate,
+		})
+	}
+
+	return resp
+}
+
+func NewListInvoicesResponse(accountID int64, invoices []domain.Invoice) ListInvoicesResponse {
+	resp := ListInvoicesResponse{AccountID: accountID, Invoices: make([]InvoiceResponse, 0, len(invoices))}
+	for _, invoice := range invoices {
+		resp.Invoices = append(resp.Invoices, NewInvoiceResponse(invoice))
+	}
+	return resp
+}
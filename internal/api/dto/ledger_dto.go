@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+)
+
+type TrialBalanceLineResponse struct {
+	LedgerAccount string       `json:"ledger_account" example:"RECEIVABLES"`
+	TotalDebits   domain.Money `json:"total_debits" swaggertype:"number" example:"120.00"`
+	TotalCredits  domain.Money `json:"total_credits" swaggertype:"number" example:"50.00"`
+	Balance       domain.Money `json:"balance" swaggertype:"number" example:"70.00"`
+}
+
+type TrialBalanceResponse struct {
+	Accounts     []TrialBalanceLineResponse `json:"accounts"`
+	TotalDebits  domain.Money               `json:"total_debits" swaggertype:"number" example:"170.00"`
+	TotalCredits domain.Money               `json:"total_credits" swaggertype:"number" example:"170.00"`
+	Balanced     bool                       `json:"balanced" example:"true"`
+	AsOf         *time.Time                 `json:"as_of,omitempty" example:"2025-01-31T23:59:59Z"`
+}
+
+func NewTrialBalanceResponse(trialBalance *domain.TrialBalance) TrialBalanceResponse {
+	accounts := make([]TrialBalanceLineResponse, 0, len(trialBalance.Lines))
+	for _, line := range trialBalance.Lines {
+		accounts = append(accounts, TrialBalanceLineResponse{
+			LedgerAccount: string(line.LedgerAccount),
+			TotalDebits:   line.Debits,
+			TotalCredits:  line.Credits,
+			Balance:       line.Balance,
+		})
+	}
+
+	return TrialBalanceResponse{
+		Accounts:     accounts,
+		TotalDebits:  trialBalance.TotalDebits,
+		TotalCredits: trialBalance.TotalCredits,
+		Balanced:     trialBalance.Balanced(),
+		AsOf:         trialBalance.AsOf,
+	}
+}
@@ -0,0 +1,83 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+)
+
+const maxOperationTypeDescriptionLength = 50
+
+type CreateOperationTypeRequest struct {
+	Description string                    `json:"description" example:"TARIFA"`
+	Direction   domain.OperationDirection `json:"direction" swaggertype:"string" enums:"DEBIT,CREDIT" example:"DEBIT"`
+}
+
+type CreateOperationTypeResponse struct {
+	ID int `json:"id" example:"5"`
+}
+
+type UpdateOperationTypeRequest struct {
+	Description *string `json:"description,omitempty" example:"TARIFA MENSAL"`
+	Active      *bool   `json:"active,omitempty" example:"false"`
+}
+
+type OperationTypeResponse struct {
+	ID          int       `json:"id" example:"5"`
+	Description string    `json:"description" example:"TARIFA"`
+	Direction   string    `json:"direction" example:"DEBIT"`
+	Active      bool      `json:"active" example:"true"`
+	CreatedAt   time.Time `json:"created_at" example:"2025-01-31T12:00:00Z"`
+}
+
+type ListOperationTypesResponse struct {
+	OperationTypes []OperationTypeResponse `json:"operation_types"`
+}
+
+func (c *CreateOperationTypeRequest) Validate() error {
+	if err := validateOperationTypeDescription(c.Description); err != nil {
+		return err
+	}
+
+	if !c.Direction.IsValid() {
+		return errors.New("direction must be DEBIT or CREDIT")
+	}
+
+	return nil
+}
+
+func (u *UpdateOperationTypeRequest) Validate() error {
+	if u.Description == nil && u.Active == nil {
+		return errors.New("description or active is mandatory")
+	}
+
+	if u.Description != nil {
+		return validateOperationTypeDescription(*u.Description)
+	}
+
+	return nil
+}
+
+func NewOperationTypeResponse(operationType *domain.OperationTypeDefinition) OperationTypeResponse {
+	return OperationTypeResponse{
+		ID:          int(operationType.ID()),
+		Description: operationType.Description(),
+		Direction:   string(operationType.Direction()),
+		Active:      operationType.Active(),
+		CreatedAt:   operationType.CreatedAt(),
+	}
+}
+
+func validateOperationTypeDescription(description string) error {
+	if strings.TrimSpace(description) == "" {
+		return errors.New("description is mandatory")
+	}
+
+	if len(description) > maxOperationTypeDescriptionLength {
+		return errors.New("description must have at most 50 characters")
+	}
+
+	return nil
+}
@@ -1,17 +1,84 @@
 package dto
 
-import "errors"
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+)
 
 type CreateTransactionRequest struct {
-	AccountID       int64   `json:"account_id" example:"1"`
-	OperationTypeID int     `json:"operation_type_id" example:"4"`
-	Amount          float64 `json:"amount" example:"100"`
+	AccountID       int64        `json:"account_id" example:"1"`
+	OperationTypeID int          `json:"operation_type_id" example:"4"`
+	Amount          domain.Money `json:"amount" swaggertype:"number" example:"100.00"`
+	Installments    int          `json:"installments,omitempty" example:"3"`
 }
 
 type CreateTransactionResponse struct {
 	ID int64 `json:"id" example:"1"`
 }
 
+type TransactionResponse struct {
+	ID                    int64        `json:"id" example:"1"`
+	AccountID             int64        `json:"account_id" example:"1"`
+	OperationTypeID       int          `json:"operation_type_id" example:"1"`
+	Amount                domain.Money `json:"amount" swaggertype:"number" example:"-50.00"`
+	Balance               domain.Money `json:"balance" swaggertype:"number" example:"-20.00"`
+	ReversesTransactionID *int64       `json:"reverses_transaction_id,omitempty" example:"3"`
+	InvoiceID             *int64       `json:"invoice_id,omitempty" example:"2"`
+	TransferID            *int64       `json:"transfer_id,omitempty" example:"4"`
+	Explanation           string       `json:"explanation,omitempty" example:"late fee for invoice 2 due on 2025-02-10 with 100.00 unpaid"`
+	EventDate             time.Time    `json:"event_date" example:"2025-01-31T12:00:00Z"`
+}
+
+type GetTransactionResponse struct {
+	ID                       int64        `json:"id" example:"1"`
+	AccountID                int64        `json:"account_id" example:"1"`
+	OperationTypeID          int          `json:"operation_type_id" example:"1"`
+	OperationTypeDescription string       `json:"operation_type_description" example:"COMPRA A VISTA"`
+	Amount                   domain.Money `json:"amount" swaggertype:"number" example:"-50.00"`
+	Balance                  domain.Money `json:"balance" swaggertype:"number" example:"-20.00"`
+	ReversesTransactionID    *int64       `json:"reverses_transaction_id,omitempty" example:"3"`
+	InvoiceID                *int64       `json:"invoice_id,omitempty" example:"2"`
+	TransferID               *int64       `json:"transfer_id,omitempty" example:"4"`
+	Explanation              string       `json:"explanation,omitempty" example:"late fee for invoice 2 due on 2025-02-10 with 100.00 unpaid"`
+	EventDate                time.Time    `json:"event_date" example:"2025-01-31T12:00:00Z"`
+	CreatedAt                time.Time    `json:"created_at" example:"2025-01-31T12:00:01Z"`
+}
+
+type ReverseTransactionRequest struct {
+	Amount *domain.Money `json:"amount,omitempty" swaggertype:"number" example:"20.00"`
+}
+
+type InstallmentResponse struct {
+	Number  int          `json:"number" example:"1"`
+	Amount  domain.Money `json:"amount" swaggertype:"number" example:"33.34"`
+	DueDate string       `json:"due_date" example:"2025-02-10"`
+}
+
+type ListInstallmentsResponse struct {
+	TransactionID int64                 `json:"transaction_id" example:"1"`
+	Installments  []InstallmentResponse `json:"installments"`
+}
+
+type ListTransactionsResponse struct {
+	Transactions []TransactionResponse `json:"transactions"`
+	NextCursor   string                `json:"next_cursor,omitempty" example:"MjAyNS0wMS0zMVQxMjowMDowMFp8MQ"`
+}
+
+type AccountBalanceResponse struct {
+	AccountID         int64        `json:"account_id" example:"1"`
+	Balance           domain.Money `json:"balance" swaggertype:"number" example:"-70.00"`
+	TotalDebits       domain.Money `json:"total_debits" swaggertype:"number" example:"120.00"`
+	TotalCredits      domain.Money `json:"total_credits" swaggertype:"number" example:"50.00"`
+	LastTransactionAt *time.Time   `json:"last_transaction_at,omitempty" example:"2025-01-31T12:00:00Z"`
+	AsOf              *time.Time   `json:"as_of,omitempty" example:"2025-01-31T23:59:59Z"`
+}
+
 func (c *CreateTransactionRequest) Validate() error {
 	if c.AccountID == 0 {
 		return errors.New("accountID is mandatory")
@@ -21,9 +88,124 @@ func (c *CreateTransactionRequest) Validate() error {
 		return errors.New("operationTypeID is mandatory")
 	}
 
-	if c.Amount == 0 {
+	if c.Amount.IsZero() {
 		return errors.New("amount is mandatory")
 	}
 
+	if c.Installments != 0 {
+		if c.OperationTypeID != int(domain.CompraParcelada) {
+			return fmt.Errorf("installments are only accepted for operation type %d", domain.CompraParcelada)
+		}
+		if c.Installments < 1 || c.Installments > domain.MaxInstallments {
+			return fmt.Errorf("installments must be between 1 and %d", domain.MaxInstallments)
+		}
+	}
+
 	return nil
 }
+
+func (r *ReverseTransactionRequest) Validate() error {
+	if r.Amount != nil && !r.Amount.IsPositive() {
+		return errors.New("amount must be positive")
+	}
+
+	return nil
+}
+
+func NewTransactionResponse(transaction domain.Transaction) TransactionResponse {
+	return TransactionResponse{
+		ID:                    transaction.ID(),
+		AccountID:             transaction.AccountID(),
+		OperationTypeID:       int(transaction.OperationTypeID()),
+		Amount:                transaction.Amount(),
+		Balance:               transaction.Balance(),
+		ReversesTransactionID: transaction.ReversesTransactionID(),
+		InvoiceID:             transaction.InvoiceID(),
+		TransferID:            transaction.TransferID(),
+		Explanation:           transaction.Explanation(),
+		EventDate:             transaction.EventDate(),
+	}
+}
+
+func NewGetTransactionResponse(transaction *domain.Transaction) GetTransactionResponse {
+	return GetTransactionResponse{
+		ID:                       transaction.ID(),
+		AccountID:                transaction.AccountID(),
+		OperationTypeID:          int(transaction.OperationTypeID()),
+		OperationTypeDescription: transaction.OperationTypeDescription(),
+		Amount:                   transaction.Amount(),
+		Balance:                  transaction.Balance(),
+		ReversesTransactionID:    transaction.ReversesTransactionID(),
+		InvoiceID:                transaction.InvoiceID(),
+		TransferID:               transaction.TransferID(),
+		Explanation:              transaction.Explanation(),
+		EventDate:                transaction.EventDate(),
+		CreatedAt:                transaction.CreatedAt(),
+	}
+}
+
+func NewListInstallmentsResponse(transactionID int64, installments []domain.Installment) ListInstallmentsResponse {
+	resp := ListInstallmentsResponse{TransactionID: transactionID, Installments: make([]InstallmentResponse, 0, len(installments))}
+	for _, installment := range installments {
+		resp.Installments = append(resp.Installments, InstallmentResponse{
+			Number:  installment.Number,
+			Amount:  installment.Amount,
+			DueDate: installment.DueDate.Format(time.DateOnly),
+		})
+	}
+	return resp
+}
+
+func NewListTransactionsResponse(transactions []domain.Transaction, next *domain.TransactionCursor) ListTransactionsResponse {
+	resp := ListTransactionsResponse{Transactions: make([]TransactionResponse, 0, len(transactions))}
+	for _, transaction := range transactions {
+		resp.Transactions = append(resp.Transactions, NewTransactionResponse(transaction))
+	}
+
+	if next != nil {
+		resp.NextCursor = EncodeTransactionCursor(*next)
+	}
+
+	return resp
+}
+
+// EncodeTransactionCursor turns a cursor into the opaque token returned to clients.
+func EncodeTransactionCursor(cursor domain.TransactionCursor) string {
+	raw := fmt.Sprintf("%s|%d", cursor.EventDate.UTC().Format(time.RFC3339Nano), cursor.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeTransactionCursor(token string) (domain.TransactionCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return domain.TransactionCursor{}, errors.New("invalid cursor")
+	}
+
+	eventDate, id, found := strings.Cut(string(raw), "|")
+	if !found {
+		return domain.TransactionCursor{}, errors.New("invalid cursor")
+	}
+
+	parsedEventDate, err := time.Parse(time.RFC3339Nano, eventDate)
+	if err != nil {
+		return domain.TransactionCursor{}, errors.New("invalid cursor")
+	}
+
+	parsedID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return domain.TransactionCursor{}, errors.New("invalid cursor")
+	}
+
+	return domain.TransactionCursor{EventDate: parsedEventDate, ID: parsedID}, nil
+}
+
+func NewAccountBalanceResponse(balance *domain.AccountBalance) AccountBalanceResponse {
+	return AccountBalanceResponse{
+		AccountID:         balance.AccountID,
+		Balance:           balance.Balance,
+		TotalDebits:       balance.Debits,
+		TotalCredits:      balance.Credits,
+		LastTransactionAt: balance.LastTransactionAt,
+		AsOf:              balance.AsOf,
+	}
+}
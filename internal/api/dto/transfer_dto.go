@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+)
+
+type CreateTransferRequest struct {
+	FromAccountID int64        `json:"from_account_id" example:"1"`
+	ToAccountID   int64        `json:"to_account_id" example:"2"`
+	Amount        domain.Money `json:"amount" swaggertype:"number" example:"25.50"`
+}
+
+type TransferResponse struct {
+	ID                  int64        `json:"id" example:"1"`
+	FromAccountID       int64        `json:"from_account_id" example:"1"`
+	ToAccountID         int64        `json:"to_account_id" example:"2"`
+	Amount              domain.Money `json:"amount" swaggertype:"number" example:"25.50"`
+	DebitTransactionID  int64        `json:"debit_transaction_id" example:"10"`
+	CreditTransactionID int64        `json:"credit_transaction_id" example:"11"`
+	CreatedAt           time.Time    `json:"created_at" example:"2025-01-31T12:00:00Z"`
+}
+
+func (c *CreateTransferRequest) Validate() error {
+	if c.FromAccountID == 0 {
+		return errors.New("fromAccountID is mandatory")
+	}
+
+	if c.ToAccountID == 0 {
+		return errors.New("toAccountID is mandatory")
+	}
+
+	if c.FromAccountID == c.ToAccountID {
+		return errors.New("fromAccountID and toAccountID must be different")
+	}
+
+	if !c.Amount.IsPositive() {
+		return errors.New("amount must be positive")
+	}
+
+	return nil
+}
+
+func NewTransferResponse(transfer *domain.Transfer) TransferResponse {
+	return TransferResponse{
+		ID:                  transfer.ID,
+		FromAccountID:       transfer.FromAccountID,
+		ToAccountID:         transfer.ToAccountID,
+		Amount:              transfer.Amount,
+		DebitTransactionID:  transfer.DebitTransactionID,
+		CreditTransactionID: transfer.CreditTransactionID,
+		CreatedAt:           transfer.CreatedAt,
+	}
+}
@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,6 +10,7 @@ import (
 	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
 	"github.com/VieiraVitor/transaction-flow/internal/api/response"
 	"github.com/VieiraVitor/transaction-flow/internal/application/usecase"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
 	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
 	"github.com/go-chi/chi/v5"
 )
@@ -25,19 +25,20 @@ func NewAccountHandler(useCase usecase.AccountUseCase) *AccountHandler {
 
 // CreateAccount godoc
 // @Summary Create an account
-// @Description Creates a new account with a document number
+// @Description Creates a new account with a CPF or CNPJ document number and an available credit limit
 // @Tags Accounts
 // @Accept  json
 // @Produce  json
 // @Param account body dto.CreateAccountRequest true "Account creation request"
+// @Param Idempotency-Key header string false "Replays the recorded response when the request is retried with the same key"
 // @Success 201 {object} dto.CreateAccountResponse "Account Created"
 // @Failure 400 {object} response.ErrorResponse "Invalid Request"
-// @Failure 422 {object} response.ErrorResponse "Validation Error"
-// @Failure 409 {object} response.ErrorResponse "Account Already Exists"
+// @Failure 422 {object} response.ErrorResponse "Validation Error or Idempotency Key Reused"
+// @Failure 409 {object} dto.AccountAlreadyExistsResponse "Account Already Exists or Request In Progress"
 // @Failure 500 {object} response.ErrorResponse "Internal Server Error"
 // @Router /accounts [post]
 func (h *AccountHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
-	ctx := context.Background()
+	ctx := r.Context()
 	var req dto.CreateAccountRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		response.SendErrorResponse(w, http.StatusBadRequest, "invalid request", fmt.Sprintf("malformed request :%v", err))
@@ -49,8 +50,20 @@ func (h *AccountHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	id, err := h.useCase.CreateAccount(ctx, req.DocumentNumber)
+	id, err := h.useCase.CreateAccount(ctx, req.DocumentNumber, req.AvailableCreditLimit)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidDocument) {
+			response.SendErrorResponse(w, http.StatusUnprocessableEntity, "validation failed", err.Error())
+			return
+		}
+		var alreadyExists *domain.AccountAlreadyExistsError
+		if errors.As(err, &alreadyExists) {
+			response.SendJSONResponse(ctx, w, http.StatusConflict, dto.NewAccountAlreadyExistsResponse(alreadyExists))
+			return
+		}
+		if sendConstraintError(w, err) {
+			return
+		}
 		response.SendErrorResponse(w, http.StatusInternalServerError, "could not create account", err.Error())
 		return
 	}
@@ -71,7 +84,7 @@ func (h *AccountHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
 // @Failure 500 {object} response.ErrorResponse "Internal Server Error"
 // @Router /accounts/{id} [get]
 func (h *AccountHandler) GetAccount(w http.ResponseWriter, r *http.Request) {
-	ctx := context.Background()
+	ctx := r.Context()
 	idParam := chi.URLParam(r, "id")
 	accountID, err := strconv.ParseInt(idParam, 10, 64)
 	if err != nil {
@@ -90,8 +103,205 @@ func (h *AccountHandler) GetAccount(w http.ResponseWriter, r *http.Request) {
 	}
 
 	accountResponse := &dto.GetAccountResponse{
-		AccountID:      account.ID(),
-		DocumentNumber: account.DocumentNumber(),
+		AccountID:            account.ID(),
+		DocumentNumber:       account.DocumentNumber(),
+		DocumentType:         string(account.DocumentType()),
+		CreditLimit:          account.CreditLimit(),
+		AvailableCreditLimit: account.AvailableCreditLimit(),
+		CreditBalance:        account.CreditBalance(),
+		ClosingDay:           account.BillingCycle().ClosingDay,
+		DueDay:               account.BillingCycle().DueDay,
+		Status:               string(account.Status()),
 	}
 	response.SendJSONResponse(ctx, w, http.StatusOK, accountResponse)
 }
+
+// UpdateCreditLimit godoc
+// @Summary Update the credit limit
+// @Description Sets the total credit limit of an account. The available limit moves by the same difference, keeping what open debits and pending authorizations use.
+// @Tags Accounts
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Account ID"
+// @Param creditLimit body dto.UpdateCreditLimitRequest true "Credit limit update request"
+// @Success 204 "Credit Limit Updated"
+// @Failure 400 {object} response.ErrorResponse "Invalid Request"
+// @Failure 404 {object} response.ErrorResponse "Account Not Found"
+// @Failure 422 {object} response.ErrorResponse "Validation Error"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /accounts/{id}/credit-limit [patch]
+func (h *AccountHandler) UpdateCreditLimit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idParam := chi.URLParam(r, "id")
+	accountID, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "could not parse id", err.Error())
+		return
+	}
+
+	var req dto.UpdateCreditLimitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "invalid request", fmt.Sprintf("malformed request :%v", err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.SendErrorResponse(w, http.StatusUnprocessableEntity, "validation failed", err.Error())
+		return
+	}
+
+	if err := h.useCase.UpdateCreditLimit(ctx, accountID, *req.CreditLimit); err != nil {
+		if errors.Is(err, repository.ErrAccountNotFound) {
+			response.SendErrorResponse(w, http.StatusNotFound, "account not found", err.Error())
+			return
+		}
+		if sendConstraintError(w, err) {
+			return
+		}
+		response.SendErrorResponse(w, http.StatusInternalServerError, "could not update credit limit", err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UpdateBillingCycle godoc
+// @Summary Update the billing cycle
+// @Description Sets the days of the month on which the invoices of an account close and fall due. Invoices already closed are not affected; installments not billed yet move to the new cycle
+// @Tags Accounts
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Account ID"
+// @Param billingCycle body dto.UpdateBillingCycleRequest true "Billing cycle update request"
+// @Success 204 "Billing Cycle Updated"
+// @Failure 400 {object} response.ErrorResponse "Invalid Request"
+// @Failure 404 {object} response.ErrorResponse "Account Not Found"
+// @Failure 422 {object} response.ErrorResponse "Validation Error"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /accounts/{id}/billing-cycle [patch]
+func (h *AccountHandler) UpdateBillingCycle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idParam := chi.URLParam(r, "id")
+	accountID, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "could not parse id", err.Error())
+		return
+	}
+
+	var req dto.UpdateBillingCycleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "invalid request", fmt.Sprintf("malformed request :%v", err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.SendErrorResponse(w, http.StatusUnprocessableEntity, "validation failed", err.Error())
+		return
+	}
+
+	if err := h.useCase.UpdateBillingCycle(ctx, accountID, *req.ClosingDay, *req.DueDay); err != nil {
+		if errors.Is(err, domain.ErrInvalidBillingCycle) {
+			response.SendErrorResponse(w, http.StatusUnprocessableEntity, "validation failed", err.Error())
+			return
+		}
+		if errors.Is(err, repository.ErrAccountNotFound) {
+			response.SendErrorResponse(w, http.StatusNotFound, "account not found", err.Error())
+			return
+		}
+		if sendConstraintError(w, err) {
+			return
+		}
+		response.SendErrorResponse(w, http.StatusInternalServerError, "could not update billing cycle", err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UpdateStatus godoc
+// @Summary Block, unblock or close an account
+// @Description Changes the status of an account, recording the reason and who changed it. Blocked accounts only accept credits, such as payments, and closed accounts accept no transactions. Closing an account is final
+// @Tags Accounts
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Account ID"
+// @Param status body dto.UpdateAccountStatusRequest true "Status change request"
+// @Success 204 "Status Updated"
+// @Failure 400 {object} response.ErrorResponse "Invalid Request"
+// @Failure 404 {object} response.ErrorResponse "Account Not Found"
+// @Failure 409 {object} response.ErrorResponse "Status Changed Concurrently"
+// @Failure 422 {object} response.ErrorResponse "Validation Error or Invalid Status Transition"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /accounts/{id}/status [patch]
+func (h *AccountHandler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idParam := chi.URLParam(r, "id")
+	accountID, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "could not parse id", err.Error())
+		return
+	}
+
+	var req dto.UpdateAccountStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "invalid request", fmt.Sprintf("malformed request :%v", err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.SendErrorResponse(w, http.StatusUnprocessableEntity, "validation failed", err.Error())
+		return
+	}
+
+	if err := h.useCase.UpdateStatus(ctx, accountID, domain.AccountStatus(req.Status), req.Reason, req.Actor); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrAccountNotFound):
+			response.SendErrorResponse(w, http.StatusNotFound, "account not found", err.Error())
+		case errors.Is(err, domain.ErrInvalidAccountStatusTransition), errors.Is(err, domain.ErrInvalidAccountStatus):
+			response.SendErrorResponse(w, http.StatusUnprocessableEntity, "invalid status transition", err.Error())
+		case errors.Is(err, repository.ErrAccountStatusChanged):
+			response.SendErrorResponse(w, http.StatusConflict, "account status changed", err.Error())
+		default:
+			if sendConstraintError(w, err) {
+				return
+			}
+			response.SendErrorResponse(w, http.StatusInternalServerError, "could not update account status", err.Error())
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListStatusChanges godoc
+// @Summary List the status history of an account
+// @Description Lists every status change of an account, oldest first, with its reason and actor
+// @Tags Accounts
+// @Produce  json
+// @Param id path int true "Account ID"
+// @Success 200 {object} dto.ListAccountStatusChangesResponse "Status History"
+// @Failure 400 {object} response.ErrorResponse "Invalid Request"
+// @Failure 404 {object} response.ErrorResponse "Account Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /accounts/{id}/status-history [get]
+func (h *AccountHandler) ListStatusChanges(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idParam := chi.URLParam(r, "id")
+	accountID, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "could not parse id", err.Error())
+		return
+	}
+
+	changes, err := h.useCase.ListStatusChanges(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, repository.ErrAccountNotFound) {
+			response.SendErrorResponse(w, http.StatusNotFound, "account not found", err.Error())
+			return
+		}
+		response.SendErrorResponse(w, http.StatusInternalServerError, "could not list account status changes", err.Error())
+		return
+	}
+
+	response.SendJSONResponse(ctx, w, http.StatusOK, dto.NewListAccountStatusChangesResponse(accountID, changes))
+}
@@ -2,12 +2,13 @@ package handler
 
 import (
 	"bytes"
-	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
 	"github.com/VieiraVitor/transaction-flow/internal/api/response"
@@ -28,18 +29,19 @@ func TestAccountHandler_CreateAccount_WhenAccounCreatedSuccessfully_ShouldReturn
 	hdlr := NewAccountHandler(mockUseCase)
 
 	documentNumber := "12345678900"
+	availableCreditLimit := domain.MustParseMoney("1000")
 	expectedID := int64(1)
 
 	router := chi.NewRouter()
 	router.Post("/accounts", hdlr.CreateAccount)
 
-	reqBody, _ := json.Marshal(dto.CreateAccountRequest{DocumentNumber: documentNumber})
+	reqBody, _ := json.Marshal(dto.CreateAccountRequest{DocumentNumber: documentNumber, AvailableCreditLimit: availableCreditLimit})
 	req := httptest.NewRequest(http.MethodPost, "/accounts", bytes.NewReader(reqBody))
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
 	mockUseCase.EXPECT().
-		CreateAccount(gomock.Any(), documentNumber).
+		CreateAccount(gomock.Any(), documentNumber, availableCreditLimit).
 		Return(expectedID, nil)
 
 	// Act
@@ -73,7 +75,7 @@ func TestAccountHandler_CreateAccount_WhenFailedToCreateAccount_ShouldReturn500(
 	errorExpected := errors.New("could not create account")
 
 	mockUseCase.EXPECT().
-		CreateAccount(context.Background(), "12345678900").
+		CreateAccount(gomock.Any(), "12345678900", domain.Money{}).
 		Return(int64(0), errorExpected)
 
 	// Act
@@ -89,6 +91,101 @@ func TestAccountHandler_CreateAccount_WhenFailedToCreateAccount_ShouldReturn500(
 	assert.Equal(t, "could not create account", errorResponse.Description)
 }
 
+func TestAccountHandler_CreateAccount_WhenDocumentNumberAlreadyExists_ShouldReturn409(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockAccountUseCase(ctrl)
+	hdlr := NewAccountHandler(mockUseCase)
+
+	router := chi.NewRouter()
+	router.Post("/accounts", hdlr.CreateAccount)
+
+	reqBody, _ := json.Marshal(dto.CreateAccountRequest{DocumentNumber: "12345678900"})
+	req := httptest.NewRequest(http.MethodPost, "/accounts", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	mockUseCase.EXPECT().
+		CreateAccount(gomock.Any(), "12345678900", domain.Money{}).
+		Return(int64(0), fmt.Errorf("wrapped: %w", &domain.AccountAlreadyExistsError{AccountID: 42, DocumentNumber: "12345678900"}))
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var conflictResponse dto.AccountAlreadyExistsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &conflictResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, conflictResponse.StatusCode)
+	assert.Equal(t, "account already exists", conflictResponse.Error)
+	assert.Equal(t, int64(42), conflictResponse.AccountID)
+}
+
+func TestAccountHandler_CreateAccount_WhenDocumentNumberIsInvalid_ShouldReturn422(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockAccountUseCase(ctrl)
+	hdlr := NewAccountHandler(mockUseCase)
+
+	router := chi.NewRouter()
+	router.Post("/accounts", hdlr.CreateAccount)
+
+	reqBody, _ := json.Marshal(dto.CreateAccountRequest{DocumentNumber: "123"})
+	req := httptest.NewRequest(http.MethodPost, "/accounts", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	mockUseCase.EXPECT().
+		CreateAccount(gomock.Any(), "123", domain.Money{}).
+		Return(int64(0), fmt.Errorf("%w: must have 11 digits (CPF) or 14 digits (CNPJ), got 3", domain.ErrInvalidDocument))
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "validation failed", errorResponse.Error)
+	assert.Equal(t, "invalid document number: must have 11 digits (CPF) or 14 digits (CNPJ), got 3", errorResponse.Description)
+}
+
+func TestAccountHandler_UpdateCreditLimit_WhenConstraintViolated_ShouldReturn422(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockAccountUseCase(ctrl)
+	hdlr := NewAccountHandler(mockUseCase)
+
+	router := chi.NewRouter()
+	router.Patch("/accounts/{id}/credit-limit", hdlr.UpdateCreditLimit)
+
+	req := httptest.NewRequest(http.MethodPatch, "/accounts/1/credit-limit", bytes.NewReader([]byte(`{"credit_limit": 10}`)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	mockUseCase.EXPECT().
+		UpdateCreditLimit(gomock.Any(), int64(1), domain.MustParseMoney("10")).
+		Return(fmt.Errorf("failed to update available credit limit: %w", domain.ErrConstraintViolation))
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "constraint violation", errorResponse.Error)
+}
+
 func TestAccountHandler_CreateAccount_WhenDocumentNumberIsNull_ShouldReturn422(t *testing.T) {
 	// Arrange
 	ctrl := gomock.NewController(t)
@@ -158,11 +255,11 @@ func TestAccountHandler_GetAccount_WhenAccountExists_ShouldReturn200(t *testing.
 	mockUseCase := mocks.NewMockAccountUseCase(ctrl)
 	hdlr := NewAccountHandler(mockUseCase)
 
-	account := domain.NewAccount("12345678900")
+	account := domain.NewAccount("12345678900", domain.MustParseMoney("1000"))
 	account.SetID(1)
 
 	mockUseCase.EXPECT().
-		GetAccount(context.Background(), int64(1)).
+		GetAccount(gomock.Any(), int64(1)).
 		Return(account, nil)
 
 	router := chi.NewRouter()
@@ -181,6 +278,9 @@ func TestAccountHandler_GetAccount_WhenAccountExists_ShouldReturn200(t *testing.
 	assert.NoError(t, err)
 	assert.Equal(t, account.ID(), response.AccountID)
 	assert.Equal(t, account.DocumentNumber(), response.DocumentNumber)
+	assert.Equal(t, account.CreditLimit(), response.CreditLimit)
+	assert.Equal(t, account.AvailableCreditLimit(), response.AvailableCreditLimit)
+	assert.Equal(t, "ACTIVE", response.Status)
 }
 
 func TestAccountHandler_GetAccount_WhenNotFoundAccount_ShouldReturn404(t *testing.T) {
@@ -192,7 +292,7 @@ func TestAccountHandler_GetAccount_WhenNotFoundAccount_ShouldReturn404(t *testin
 	hdlr := NewAccountHandler(mockUseCase)
 
 	mockUseCase.EXPECT().
-		GetAccount(context.Background(), int64(1)).
+		GetAccount(gomock.Any(), int64(1)).
 		Return(nil, repository.ErrAccountNotFound)
 
 	router := chi.NewRouter()
@@ -225,7 +325,7 @@ func TestAccountHandler_GetAccount_WhenFailedToGetAccount_ShouldReturn500(t *tes
 	errorExpected := errors.New("could not get account")
 
 	mockUseCase.EXPECT().
-		GetAccount(context.Background(), int64(1)).
+		GetAccount(gomock.Any(), int64(1)).
 		Return(nil, errorExpected)
 
 	router := chi.NewRouter()
@@ -274,3 +374,301 @@ func TestAccountHandler_GetAccount_WhenInvalidID_ShouldReturn400(t *testing.T) {
 	assert.Equal(t, "strconv.ParseInt: parsing \"abc\": invalid syntax", errorResponse.Description)
 
 }
+
+func TestAccountHandler_CreateAccount_WhenCreditLimitIsNegative_ShouldReturn422(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockAccountUseCase(ctrl)
+	hdlr := NewAccountHandler(mockUseCase)
+
+	router := chi.NewRouter()
+	router.Post("/accounts", hdlr.CreateAccount)
+
+	reqBody, _ := json.Marshal(dto.CreateAccountRequest{DocumentNumber: "12345678900", AvailableCreditLimit: domain.MustParseMoney("-1")})
+	req := httptest.NewRequest(http.MethodPost, "/accounts", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "validation failed", errorResponse.Error)
+	assert.Equal(t, "available_credit_limit must not be negative", errorResponse.Description)
+}
+
+func TestAccountHandler_UpdateCreditLimit_WhenUpdatedSuccessfully_ShouldReturn204(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockAccountUseCase(ctrl)
+	hdlr := NewAccountHandler(mockUseCase)
+
+	router := chi.NewRouter()
+	router.Patch("/accounts/{id}/credit-limit", hdlr.UpdateCreditLimit)
+
+	req := httptest.NewRequest(http.MethodPatch, "/accounts/1/credit-limit", bytes.NewReader([]byte(`{"credit_limit": 1500}`)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	mockUseCase.EXPECT().
+		UpdateCreditLimit(gomock.Any(), int64(1), domain.MustParseMoney("1500")).
+		Return(nil)
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestAccountHandler_UpdateCreditLimit_WhenCreditLimitIsMissing_ShouldReturn422(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockAccountUseCase(ctrl)
+	hdlr := NewAccountHandler(mockUseCase)
+
+	router := chi.NewRouter()
+	router.Patch("/accounts/{id}/credit-limit", hdlr.UpdateCreditLimit)
+
+	req := httptest.NewRequest(http.MethodPatch, "/accounts/1/credit-limit", bytes.NewReader([]byte(`{}`)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "credit_limit is mandatory", errorResponse.Description)
+}
+
+func TestAccountHandler_UpdateCreditLimit_WhenAccountNotFound_ShouldReturn404(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockAccountUseCase(ctrl)
+	hdlr := NewAccountHandler(mockUseCase)
+
+	router := chi.NewRouter()
+	router.Patch("/accounts/{id}/credit-limit", hdlr.UpdateCreditLimit)
+
+	req := httptest.NewRequest(http.MethodPatch, "/accounts/1/credit-limit", bytes.NewReader([]byte(`{"credit_limit": 1500}`)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	mockUseCase.EXPECT().
+		UpdateCreditLimit(gomock.Any(), int64(1), domain.MustParseMoney("1500")).
+		Return(repository.ErrAccountNotFound)
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "account not found", errorResponse.Error)
+}
+
+func TestAccountHandler_UpdateBillingCycle_WhenUpdatedSuccessfully_ShouldReturn204(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockAccountUseCase(ctrl)
+	hdlr := NewAccountHandler(mockUseCase)
+
+	router := chi.NewRouter()
+	router.Patch("/accounts/{id}/billing-cycle", hdlr.UpdateBillingCycle)
+
+	req := httptest.NewRequest(http.MethodPatch, "/accounts/1/billing-cycle", bytes.NewReader([]byte(`{"closing_day": 20, "due_day": 5}`)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	mockUseCase.EXPECT().
+		UpdateBillingCycle(gomock.Any(), int64(1), 20, 5).
+		Return(nil)
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestAccountHandler_UpdateBillingCycle_WhenInvalid_ShouldReturn422(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		useCaseErr  error
+		description string
+	}{
+		{"missing closing day", `{"due_day": 5}`, nil, "closing_day is mandatory"},
+		{"missing due day", `{"closing_day": 20}`, nil, "due_day is mandatory"},
+		{"day out of range", `{"closing_day": 30, "due_day": 5}`, fmt.Errorf("%w: closing day must be between 1 and 28", domain.ErrInvalidBillingCycle), "invalid billing cycle: closing day must be between 1 and 28"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockAccountUseCase(ctrl)
+			hdlr := NewAccountHandler(mockUseCase)
+
+			router := chi.NewRouter()
+			router.Patch("/accounts/{id}/billing-cycle", hdlr.UpdateBillingCycle)
+
+			req := httptest.NewRequest(http.MethodPatch, "/accounts/1/billing-cycle", bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			if tt.useCaseErr != nil {
+				mockUseCase.EXPECT().
+					UpdateBillingCycle(gomock.Any(), int64(1), gomock.Any(), gomock.Any()).
+					Return(tt.useCaseErr)
+			}
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+			var errorResponse response.ErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+			assert.NoError(t, err)
+			assert.Equal(t, "validation failed", errorResponse.Error)
+			assert.Equal(t, tt.description, errorResponse.Description)
+		})
+	}
+}
+
+func TestAccountHandler_UpdateStatus_WhenUpdatedSuccessfully_ShouldReturn204(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockAccountUseCase(ctrl)
+	hdlr := NewAccountHandler(mockUseCase)
+
+	router := chi.NewRouter()
+	router.Patch("/accounts/{id}/status", hdlr.UpdateStatus)
+
+	req := httptest.NewRequest(http.MethodPatch, "/accounts/1/status", bytes.NewReader([]byte(`{"status": "BLOCKED", "reason": "fraud suspicion", "actor": "analyst@bank.com"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	mockUseCase.EXPECT().
+		UpdateStatus(gomock.Any(), int64(1), domain.AccountStatusBlocked, "fraud suspicion", "analyst@bank.com").
+		Return(nil)
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestAccountHandler_UpdateStatus_WhenFails_ShouldReturnError(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		useCaseErr     error
+		expectedStatus int
+		expectedError  string
+	}{
+		{"missing status", `{"reason": "r", "actor": "a"}`, nil, http.StatusUnprocessableEntity, "validation failed"},
+		{"unknown status", `{"status": "SUSPENDED", "reason": "r", "actor": "a"}`, nil, http.StatusUnprocessableEntity, "validation failed"},
+		{"missing reason", `{"status": "BLOCKED", "actor": "a"}`, nil, http.StatusUnprocessableEntity, "validation failed"},
+		{"missing actor", `{"status": "BLOCKED", "reason": "r"}`, nil, http.StatusUnprocessableEntity, "validation failed"},
+		{"account not found", `{"status": "BLOCKED", "reason": "r", "actor": "a"}`, repository.ErrAccountNotFound, http.StatusNotFound, "account not found"},
+		{"closed account", `{"status": "ACTIVE", "reason": "r", "actor": "a"}`, fmt.Errorf("%w: from CLOSED to ACTIVE", domain.ErrInvalidAccountStatusTransition), http.StatusUnprocessableEntity, "invalid status transition"},
+		{"concurrent change", `{"status": "BLOCKED", "reason": "r", "actor": "a"}`, repository.ErrAccountStatusChanged, http.StatusConflict, "account status changed"},
+		{"unexpected error", `{"status": "BLOCKED", "reason": "r", "actor": "a"}`, errors.New("connection refused"), http.StatusInternalServerError, "could not update account status"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockAccountUseCase(ctrl)
+			hdlr := NewAccountHandler(mockUseCase)
+
+			router := chi.NewRouter()
+			router.Patch("/accounts/{id}/status", hdlr.UpdateStatus)
+
+			req := httptest.NewRequest(http.MethodPatch, "/accounts/1/status", bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			if tt.useCaseErr != nil {
+				mockUseCase.EXPECT().
+					UpdateStatus(gomock.Any(), int64(1), gomock.Any(), "r", "a").
+					Return(tt.useCaseErr)
+			}
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var errorResponse response.ErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedError, errorResponse.Error)
+		})
+	}
+}
+
+func TestAccountHandler_ListStatusChanges_WhenAccountExists_ShouldReturn200(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockAccountUseCase(ctrl)
+	hdlr := NewAccountHandler(mockUseCase)
+
+	changedAt := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	mockUseCase.EXPECT().
+		ListStatusChanges(gomock.Any(), int64(1)).
+		Return([]domain.AccountStatusChange{
+			{ID: 3, AccountID: 1, FromStatus: domain.AccountStatusActive, ToStatus: domain.AccountStatusBlocked, Reason: "fraud suspicion", Actor: "analyst@bank.com", ChangedAt: changedAt},
+		}, nil)
+
+	router := chi.NewRouter()
+	router.Get("/accounts/{id}/status-history", hdlr.ListStatusChanges)
+	req := httptest.NewRequest(http.MethodGet, "/accounts/1/status-history", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dto.ListAccountStatusChangesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), response.AccountID)
+	assert.Equal(t, []dto.AccountStatusChangeResponse{
+		{ID: 3, FromStatus: "ACTIVE", ToStatus: "BLOCKED", Reason: "fraud suspicion", Actor: "analyst@bank.com", ChangedAt: changedAt},
+	}, response.Changes)
+}
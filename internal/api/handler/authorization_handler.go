@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/api/response"
+	"github.com/VieiraVitor/transaction-flow/internal/application/usecase"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/go-chi/chi/v5"
+)
+
+type AuthorizationHandler struct {
+	useCase usecase.AuthorizationUseCase
+}
+
+func NewAuthorizationHandler(useCase usecase.AuthorizationUseCase) *AuthorizationHandler {
+	return &AuthorizationHandler{useCase: useCase}
+}
+
+// CreateAuthorization godoc
+// @Summary Authorize a purchase
+// @Description Holds an amount against the available credit limit of an account without posting a transaction. The hold is released when the authorization is captured, voided or expires
+// @Tags Authorizations
+// @Accept  json
+// @Produce  json
+// @Param authorization body dto.CreateAuthorizationRequest true "Authorization Request"
+// @Param Idempotency-Key header string false "Replays the recorded response when the request is retried with the same key"
+// @Success 201 {object} dto.AuthorizationResponse "Authorization Created"
+// @Failure 400 {object} response.ErrorResponse "Invalid Request"
+// @Failure 409 {object} response.ErrorResponse "Request In Progress"
+// @Failure 422 {object} response.ErrorResponse "Validation Failed, Account Not Found, Account Blocked or Closed, Insufficient Credit Limit or Idempotency Key Reused"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /authorizations [post]
+func (h *AuthorizationHandler) CreateAuthorization(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateAuthorizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "invalid request", fmt.Sprintf("malformed request :%v", err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.SendErrorResponse(w, http.StatusUnprocessableEntity, "validation failed", err.Error())
+		return
+	}
+
+	authorization, err := h.useCase.CreateAuthorization(r.Context(), req.AccountID, req.Amount)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrTransactionAccountNotFound) || errors.Is(err, repository.ErrAccountNotFound):
+			response.SendErrorResponse(w, http.StatusUnprocessableEntity, "account not found", err.Error())
+		default:
+			sendAuthorizationError(w, err, "could not create authorization")
+		}
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusCreated, dto.NewAuthorizationResponse(authorization))
+}
+
+// GetAuthorization godoc
+// @Summary Retrieve an authorization
+// @Description Fetches an authorization by ID
+// @Tags Authorizations
+// @Produce  json
+// @Param id path int true "Authorization ID"
+// @Success 200 {object} dto.AuthorizationResponse "Authorization Details"
+// @Failure 400 {object} response.ErrorResponse "Invalid Request"
+// @Failure 404 {object} response.ErrorResponse "Authorization Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /authorizations/{id} [get]
+func (h *AuthorizationHandler) GetAuthorization(w http.ResponseWriter, r *http.Request) {
+	authorizationID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "could not parse id", err.Error())
+		return
+	}
+
+	authorization, err := h.useCase.GetAuthorization(r.Context(), authorizationID)
+	if err != nil {
+		sendAuthorizationError(w, err, "could not get authorization")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, dto.NewAuthorizationResponse(authorization))
+}
+
+// CaptureAuthorization godoc
+// @Summary Capture an authorization
+// @Description Posts a CompraAVista for the captured amount and releases the hold. Without an amount the whole authorization is captured; a smaller amount releases the rest. An authorization is captured only once
+// @Tags Authorizations
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Authorization ID"
+// @Param capture body dto.CaptureAuthorizationRequest false "Amount to capture"
+// @Param Idempotency-Key header string false "Replays the recorded response when the request is retried with the same key"
+// @Success 200 {object} dto.AuthorizationResponse "Authorization Captured"
+// @Failure 400 {object} response.ErrorResponse "Invalid Request"
+// @Failure 404 {object} response.ErrorResponse "Authorization Not Found"
+// @Failure 409 {object} response.ErrorResponse "Authorization Not Pending or Request In Progress"
+// @Failure 422 {object} response.ErrorResponse "Validation Failed, Authorization Expired, Capture Exceeds Authorization, Account Blocked or Closed, Insufficient Credit Limit or Idempotency Key Reused"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /authorizations/{id}/capture [post]
+func (h *AuthorizationHandler) CaptureAuthorization(w http.ResponseWriter, r *http.Request) {
+	authorizationID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "could not parse id", err.Error())
+		return
+	}
+
+	var req dto.CaptureAuthorizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.SendErrorResponse(w, http.StatusBadRequest, "invalid request", fmt.Sprintf("malformed request :%v", err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.SendErrorResponse(w, http.StatusUnprocessableEntity, "validation failed", err.Error())
+		return
+	}
+
+	authorization, err := h.useCase.CaptureAuthorization(r.Context(), authorizationID, req.Amount)
+	if err != nil {
+		sendAuthorizationError(w, err, "could not capture authorization")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, dto.NewAuthorizationResponse(authorization))
+}
+
+// VoidAuthorization godoc
+// @Summary Void an authorization
+// @Description Cancels a pending authorization and gives the held amount back to the available credit limit
+// @Tags Authorizations
+// @Produce  json
+// @Param id path int true "Authorization ID"
+// @Success 200 {object} dto.AuthorizationResponse "Authorization Voided"
+// @Failure 400 {object} response.ErrorResponse "Invalid Request"
+// @Failure 404 {object} response.ErrorResponse "Authorization Not Found"
+// @Failure 409 {object} response.ErrorResponse "Authorization Not Pending"
+// @Failure 422 {object} response.ErrorResponse "Authorization Expired"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /authorizations/{id}/void [post]
+func (h *AuthorizationHandler) VoidAuthorization(w http.ResponseWriter, r *http.Request) {
+	authorizationID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "could not parse id", err.Error())
+		return
+	}
+
+	authorization, err := h.useCase.VoidAuthorization(r.Context(), authorizationID)
+	if err != nil {
+		sendAuthorizationError(w, err, "could not void authorization")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, dto.NewAuthorizationResponse(authorization))
+}
+
+// sendAuthorizationError answers the errors shared by the authorization endpoints, falling
+// back to a 500 with the given message.
+func sendAuthorizationError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, repository.ErrAuthorizationNotFound):
+		response.SendErrorResponse(w, http.StatusNotFound, "authorization not found", err.Error())
+	case errors.Is(err, domain.ErrAuthorizationNotPending):
+		response.SendErrorResponse(w, http.StatusConflict, "authorization not pending", err.Error())
+	case errors.Is(err, domain.ErrAuthorizationExpired):
+		response.SendErrorResponse(w, http.StatusUnprocessableEntity, "authorization expired", err.Error())
+	case errors.Is(err, domain.ErrCaptureExceedsAuthorization):
+		response.SendErrorResponse(w, http.StatusUnprocessableEntity, "capture exceeds authorization", err.Error())
+	case errors.Is(err, domain.ErrInvalidAuthorization):
+		response.SendErrorResponse(w, http.StatusUnprocessableEntity, "validation failed", err.Error())
+	case errors.Is(err, repository.ErrInsufficientCreditLimit):
+		response.SendErrorResponse(w, http.StatusUnprocessableEntity, "insufficient credit limit", err.Error())
+	default:
+		if sendAccountStatusError(w, err) || sendConstraintError(w, err) {
+			return
+		}
+		response.SendErrorResponse(w, http.StatusInternalServerError, message, err.Error())
+	}
+}
@@ -0,0 +1,262 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/api/response"
+	"github.com/VieiraVitor/transaction-flow/internal/application/usecase"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/VieiraVitor/transaction-flow/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorizationHandler_CreateAuthorization_WhenValidInput_ShouldReturn201(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockAuthorizationUseCase(ctrl)
+	hdlr := NewAuthorizationHandler(mockUseCase)
+
+	amount := domain.MustParseMoney("150")
+	createdAt := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	authorization, _ := domain.NewAuthorization(1, amount, createdAt, 7*24*time.Hour)
+	authorization.ID = 3
+	mockUseCase.EXPECT().
+		CreateAuthorization(gomock.Any(), int64(1), amount).
+		Return(&authorization, nil)
+
+	router := chi.NewRouter()
+	router.Post("/authorizations", hdlr.CreateAuthorization)
+
+	reqBody, _ := json.Marshal(dto.CreateAuthorizationRequest{AccountID: 1, Amount: amount})
+	req := httptest.NewRequest(http.MethodPost, "/authorizations", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp dto.AuthorizationResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), resp.ID)
+	assert.Equal(t, amount, resp.Amount)
+	assert.Equal(t, "PENDING", resp.Status)
+	assert.Nil(t, resp.TransactionID)
+	assert.Equal(t, createdAt.Add(7*24*time.Hour), resp.ExpiresAt)
+}
+
+func TestAuthorizationHandler_CreateAuthorization_WhenFails_ShouldMapError(t *testing.T) {
+	tests := []struct {
+		name           string
+		request        dto.CreateAuthorizationRequest
+		err            error
+		expectedStatus int
+		expectedError  string
+	}{
+		{name: "MissingAccount", request: dto.CreateAuthorizationRequest{Amount: domain.MustParseMoney("10")}, expectedStatus: http.StatusUnprocessableEntity, expectedError: "validation failed"},
+		{name: "NegativeAmount", request: dto.CreateAuthorizationRequest{AccountID: 1, Amount: domain.MustParseMoney("-10")}, expectedStatus: http.StatusUnprocessableEntity, expectedError: "validation failed"},
+		{name: "AccountNotFound", request: dto.CreateAuthorizationRequest{AccountID: 1, Amount: domain.MustParseMoney("10")}, err: usecase.ErrTransactionAccountNotFound, expectedStatus: http.StatusUnprocessableEntity, expectedError: "account not found"},
+		{name: "AccountBlocked", request: dto.CreateAuthorizationRequest{AccountID: 1, Amount: domain.MustParseMoney("10")}, err: domain.ErrAccountBlocked, expectedStatus: http.StatusUnprocessableEntity, expectedError: "account blocked"},
+		{name: "InsufficientCreditLimit", request: dto.CreateAuthorizationRequest{AccountID: 1, Amount: domain.MustParseMoney("10")}, err: repository.ErrInsufficientCreditLimit, expectedStatus: http.StatusUnprocessableEntity, expectedError: "insufficient credit limit"},
+		{name: "UnexpectedError", request: dto.CreateAuthorizationRequest{AccountID: 1, Amount: domain.MustParseMoney("10")}, err: assert.AnError, expectedStatus: http.StatusInternalServerError, expectedError: "could not create authorization"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockAuthorizationUseCase(ctrl)
+			hdlr := NewAuthorizationHandler(mockUseCase)
+			router := chi.NewRouter()
+			router.Post("/authorizations", hdlr.CreateAuthorization)
+
+			if tt.err != nil {
+				mockUseCase.EXPECT().
+					CreateAuthorization(gomock.Any(), tt.request.AccountID, tt.request.Amount).
+					Return(nil, tt.err)
+			}
+
+			reqBody, _ := json.Marshal(tt.request)
+			req := httptest.NewRequest(http.MethodPost, "/authorizations", bytes.NewReader(reqBody))
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var errorResponse response.ErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedError, errorResponse.Error)
+		})
+	}
+}
+
+func TestAuthorizationHandler_CaptureAuthorization_WhenAmountInformed_ShouldCaptureIt(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockAuthorizationUseCase(ctrl)
+	hdlr := NewAuthorizationHandler(mockUseCase)
+
+	amount := domain.MustParseMoney("120.50")
+	transactionID := int64(9)
+	mockUseCase.EXPECT().
+		CaptureAuthorization(gomock.Any(), int64(3), &amount).
+		Return(&domain.Authorization{ID: 3, AccountID: 1, Amount: domain.MustParseMoney("150"), CapturedAmount: amount, Status: domain.AuthorizationCaptured, TransactionID: &transactionID}, nil)
+
+	router := chi.NewRouter()
+	router.Post("/authorizations/{id}/capture", hdlr.CaptureAuthorization)
+
+	req := httptest.NewRequest(http.MethodPost, "/authorizations/3/capture", bytes.NewReader([]byte(`{"amount": 120.50}`)))
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp dto.AuthorizationResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "CAPTURED", resp.Status)
+	assert.Equal(t, amount, resp.CapturedAmount)
+	assert.Equal(t, int64(9), *resp.TransactionID)
+}
+
+func TestAuthorizationHandler_CaptureAuthorization_WhenBodyIsEmpty_ShouldCaptureWholeAuthorization(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockAuthorizationUseCase(ctrl)
+	hdlr := NewAuthorizationHandler(mockUseCase)
+
+	mockUseCase.EXPECT().
+		CaptureAuthorization(gomock.Any(), int64(3), nil).
+		Return(&domain.Authorization{ID: 3, Status: domain.AuthorizationCaptured}, nil)
+
+	router := chi.NewRouter()
+	router.Post("/authorizations/{id}/capture", hdlr.CaptureAuthorization)
+
+	req := httptest.NewRequest(http.MethodPost, "/authorizations/3/capture", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAuthorizationHandler_CaptureAuthorization_WhenFails_ShouldMapError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedError  string
+	}{
+		{name: "NotFound", err: repository.ErrAuthorizationNotFound, expectedStatus: http.StatusNotFound, expectedError: "authorization not found"},
+		{name: "NotPending", err: fmt.Errorf("%w: authorization 3 is VOIDED", domain.ErrAuthorizationNotPending), expectedStatus: http.StatusConflict, expectedError: "authorization not pending"},
+		{name: "Expired", err: domain.ErrAuthorizationExpired, expectedStatus: http.StatusUnprocessableEntity, expectedError: "authorization expired"},
+		{name: "ExceedsAmount", err: domain.ErrCaptureExceedsAuthorization, expectedStatus: http.StatusUnprocessableEntity, expectedError: "capture exceeds authorization"},
+		{name: "AccountClosed", err: domain.ErrAccountClosed, expectedStatus: http.StatusUnprocessableEntity, expectedError: "account closed"},
+		{name: "UnexpectedError", err: assert.AnError, expectedStatus: http.StatusInternalServerError, expectedError: "could not capture authorization"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockAuthorizationUseCase(ctrl)
+			hdlr := NewAuthorizationHandler(mockUseCase)
+			router := chi.NewRouter()
+			router.Post("/authorizations/{id}/capture", hdlr.CaptureAuthorization)
+
+			mockUseCase.EXPECT().
+				CaptureAuthorization(gomock.Any(), int64(3), nil).
+				Return(nil, tt.err)
+
+			req := httptest.NewRequest(http.MethodPost, "/authorizations/3/capture", nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var errorResponse response.ErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedError, errorResponse.Error)
+		})
+	}
+}
+
+func TestAuthorizationHandler_VoidAuthorization_WhenPending_ShouldReturn200(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockAuthorizationUseCase(ctrl)
+	hdlr := NewAuthorizationHandler(mockUseCase)
+
+	mockUseCase.EXPECT().
+		VoidAuthorization(gomock.Any(), int64(3)).
+		Return(&domain.Authorization{ID: 3, AccountID: 1, Amount: domain.MustParseMoney("150"), Status: domain.AuthorizationVoided}, nil)
+
+	router := chi.NewRouter()
+	router.Post("/authorizations/{id}/void", hdlr.VoidAuthorization)
+
+	req := httptest.NewRequest(http.MethodPost, "/authorizations/3/void", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp dto.AuthorizationResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "VOIDED", resp.Status)
+}
+
+func TestAuthorizationHandler_GetAuthorization_WhenInvalidID_ShouldReturn400(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hdlr := NewAuthorizationHandler(mocks.NewMockAuthorizationUseCase(ctrl))
+	router := chi.NewRouter()
+	router.Get("/authorizations/{id}", hdlr.GetAuthorization)
+
+	req := httptest.NewRequest(http.MethodGet, "/authorizations/abc", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/api/response"
+	"github.com/VieiraVitor/transaction-flow/internal/application/usecase"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/go-chi/chi/v5"
+)
+
+type DisputeHandler struct {
+	useCase usecase.DisputeUseCase
+}
+
+func NewDisputeHandler(useCase usecase.DisputeUseCase) *DisputeHandler {
+	return &DisputeHandler{useCase: useCase}
+}
+
+// OpenDispute godoc
+// @Summary Dispute a transaction
+// @Description Opens a dispute for a purchase or withdrawal and credits the disputed amount provisionally (CREDITO PROVISORIO) until the dispute is resolved. Without an amount the whole transaction is disputed
+// @Tags Disputes
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Transaction ID"
+// @Param dispute body dto.OpenDisputeRequest true "Dispute Request"
+// @Param Idempotency-Key header string false "Replays the recorded response when the request is retried with the same key"
+// @Success 201 {object} dto.DisputeResponse "Dispute Opened"
+// @Failure 400 {object} response.ErrorResponse "Invalid Request"
+// @Failure 404 {object} response.ErrorResponse "Transaction Not Found"
+// @Failure 409 {object} response.ErrorResponse "Transaction Already Disputed or Request In Progress"
+// @Failure 422 {object} response.ErrorResponse "Validation Failed, Transaction Not Disputable, Dispute Exceeds Amount, Account Closed or Idempotency Key Reused"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /transactions/{id}/disputes [post]
+func (h *DisputeHandler) OpenDispute(w http.ResponseWriter, r *http.Request) {
+	transactionID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "could not parse id", err.Error())
+		return
+	}
+
+	var req dto.OpenDisputeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "invalid request", fmt.Sprintf("malformed request :%v", err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.SendErrorResponse(w, http.StatusUnprocessableEntity, "validation failed", err.Error())
+		return
+	}
+
+	dispute, err := h.useCase.OpenDispute(r.Context(), transactionID, req.Amount, req.Reason)
+	if err != nil {
+		sendDisputeError(w, err, "could not open dispute")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusCreated, dto.NewDisputeResponse(dispute))
+}
+
+// ListDisputes godoc
+// @Summary List the disputes of a transaction
+// @Description Lists every dispute opened for a transaction, oldest first, with the history of each one and the transactions it posted
+// @Tags Disputes
+// @Produce  json
+// @Param id path int true "Transaction ID"
+// @Success 200 {object} dto.ListDisputesResponse "Disputes"
+// @Failure 400 {object} response.ErrorResponse "Invalid Request"
+// @Failure 404 {object} response.ErrorResponse "Transaction Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /transactions/{id}/disputes [get]
+func (h *DisputeHandler) ListDisputes(w http.ResponseWriter, r *http.Request) {
+	transactionID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "could not parse id", err.Error())
+		return
+	}
+
+	disputes, err := h.useCase.ListDisputes(r.Context(), transactionID)
+	if err != nil {
+		sendDisputeError(w, err, "could not list disputes")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, dto.NewListDisputesResponse(transactionID, disputes))
+}
+
+// GetDispute godoc
+// @Summary Retrieve a dispute
+// @Description Fetches a dispute by ID with its history
+// @Tags Disputes
+// @Produce  json
+// @Param id path int true "Dispute ID"
+// @Success 200 {object} dto.DisputeResponse "Dispute Details"
+// @Failure 400 {object} response.ErrorResponse "Invalid Request"
+// @Failure 404 {object} response.ErrorResponse "Dispute Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /disputes/{id} [get]
+func (h *DisputeHandler) GetDispute(w http.ResponseWriter, r *http.Request) {
+	disputeID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "could not parse id", err.Error())
+		return
+	}
+
+	dispute, err := h.useCase.GetDispute(r.Context(), disputeID)
+	if err != nil {
+		sendDisputeError(w, err, "could not get dispute")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, dto.NewDisputeResponse(dispute))
+}
+
+// ResolveDispute godoc
+// @Summary Resolve a dispute
+// @Description Closes a dispute as WON, keeping the provisional credit, or as LOST, posting an ESTORNO DE CREDITO PROVISORIO that takes it back. A dispute is resolved only once
+// @Tags Disputes
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Dispute ID"
+// @Param resolution body dto.ResolveDisputeRequest true "Outcome of the dispute"
+// @Param Idempotency-Key header string false "Replays the recorded response when the request is retried with the same key"
+// @Success 200 {object} dto.DisputeResponse "Dispute Resolved"
+// @Failure 400 {object} response.ErrorResponse "Invalid Request"
+// @Failure 404 {object} response.ErrorResponse "Dispute Not Found"
+// @Failure 409 {object} response.ErrorResponse "Dispute Already Resolved or Request In Progress"
+// @Failure 422 {object} response.ErrorResponse "Validation Failed or Idempotency Key Reused"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /disputes/{id}/resolve [post]
+func (h *DisputeHandler) ResolveDispute(w http.ResponseWriter, r *http.Request) {
+	disputeID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "could not parse id", err.Error())
+		return
+	}
+
+	var req dto.ResolveDisputeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "invalid request", fmt.Sprintf("malformed request :%v", err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.SendErrorResponse(w, http.StatusUnprocessableEntity, "validation failed", err.Error())
+		return
+	}
+
+	dispute, err := h.useCase.ResolveDispute(r.Context(), disputeID, domain.DisputeStatus(req.Outcome))
+	if err != nil {
+		sendDisputeError(w, err, "could not resolve dispute")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, dto.NewDisputeResponse(dispute))
+}
+
+// sendDisputeError answers the errors shared by the dispute endpoints, falling back to a
+// 500 with the given message.
+func sendDisputeError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, repository.ErrTransactionNotFound):
+		response.SendErrorResponse(w, http.StatusNotFound, "transaction not found", err.Error())
+	case errors.Is(err, repository.ErrDisputeNotFound):
+		response.SendErrorResponse(w, http.StatusNotFound, "dispute not found", err.Error())
+	case errors.Is(err, repository.ErrDisputeAlreadyOpen):
+		response.SendErrorResponse(w, http.StatusConflict, "transaction already disputed", err.Error())
+	case errors.Is(err, domain.ErrDisputeNotResolvable):
+		response.SendErrorResponse(w, http.StatusConflict, "dispute already resolved", err.Error())
+	case errors.Is(err, repository.ErrDisputeExceedsAmount):
+		response.SendErrorResponse(w, http.StatusUnprocessableEntity, "dispute exceeds amount", err.Error())
+	case errors.Is(err, domain.ErrTransactionNotDisputable):
+		response.SendErrorResponse(w, http.StatusUnprocessableEntity, "transaction not disputable", err.Error())
+	case errors.Is(err, domain.ErrInvalidDispute) || errors.Is(err, domain.ErrInvalidDisputeOutcome):
+		response.SendErrorResponse(w, http.StatusUnprocessableEntity, "validation failed", err.Error())
+	default:
+		if sendAccountStatusError(w, err) || sendConstraintError(w, err) {
+			return
+		}
+		response.SendErrorResponse(w, http.StatusInternalServerError, message, err.Error())
+	}
+}
@@ -0,0 +1,269 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/api/response"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/VieiraVitor/transaction-flow/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisputeHandler_OpenDispute_WhenValidInput_ShouldReturn201(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockDisputeUseCase(ctrl)
+	hdlr := NewDisputeHandler(mockUseCase)
+
+	amount := domain.MustParseMoney("40")
+	creditID := int64(11)
+	openedAt := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	mockUseCase.EXPECT().
+		OpenDispute(gomock.Any(), int64(10), &amount, "purchase not recognized").
+		Return(&domain.Dispute{
+			ID:                             1,
+			TransactionID:                  10,
+			AccountID:                      1,
+			Amount:                         amount,
+			Reason:                         "purchase not recognized",
+			Status:                         domain.DisputeProvisionalCredit,
+			ProvisionalCreditTransactionID: &creditID,
+			OpenedAt:                       openedAt,
+			Events: []domain.DisputeEvent{
+				{ID: 1, DisputeID: 1, ToStatus: domain.DisputeOpened, Note: "purchase not recognized", CreatedAt: openedAt},
+				{ID: 2, DisputeID: 1, FromStatus: domain.DisputeOpened, ToStatus: domain.DisputeProvisionalCredit, TransactionID: &creditID, CreatedAt: openedAt},
+			},
+		}, nil)
+
+	router := chi.NewRouter()
+	router.Post("/transactions/{id}/disputes", hdlr.OpenDispute)
+
+	reqBody, _ := json.Marshal(dto.OpenDisputeRequest{Amount: &amount, Reason: "purchase not recognized"})
+	req := httptest.NewRequest(http.MethodPost, "/transactions/10/disputes", bytes.NewReader(reqBody))
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp dto.DisputeResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), resp.ID)
+	assert.Equal(t, "PROVISIONAL_CREDIT", resp.Status)
+	assert.Equal(t, int64(11), *resp.ProvisionalCreditTransactionID)
+	assert.Nil(t, resp.ResolvedAt)
+	assert.Len(t, resp.Events, 2)
+	assert.Equal(t, "", resp.Events[0].FromStatus)
+	assert.Equal(t, "PROVISIONAL_CREDIT", resp.Events[1].ToStatus)
+}
+
+func TestDisputeHandler_OpenDispute_WhenFails_ShouldMapError(t *testing.T) {
+	tests := []struct {
+		name           string
+		request        dto.OpenDisputeRequest
+		err            error
+		expectedStatus int
+		expectedError  string
+	}{
+		{name: "MissingReason", request: dto.OpenDisputeRequest{}, expectedStatus: http.StatusUnprocessableEntity, expectedError: "validation failed"},
+		{name: "TransactionNotFound", request: dto.OpenDisputeRequest{Reason: "reason"}, err: repository.ErrTransactionNotFound, expectedStatus: http.StatusNotFound, expectedError: "transaction not found"},
+		{name: "AlreadyDisputed", request: dto.OpenDisputeRequest{Reason: "reason"}, err: fmt.Errorf("%w: transaction 10", repository.ErrDisputeAlreadyOpen), expectedStatus: http.StatusConflict, expectedError: "transaction already disputed"},
+		{name: "NotDisputable", request: dto.OpenDisputeRequest{Reason: "reason"}, err: domain.ErrTransactionNotDisputable, expectedStatus: http.StatusUnprocessableEntity, expectedError: "transaction not disputable"},
+		{name: "ExceedsAmount", request: dto.OpenDisputeRequest{Reason: "reason"}, err: domain.ErrInvalidDispute, expectedStatus: http.StatusUnprocessableEntity, expectedError: "validation failed"},
+		{name: "ExceedsAmountNotReversed", request: dto.OpenDisputeRequest{Reason: "reason"}, err: fmt.Errorf("%w: 100.00 disputed, 60.00 left", repository.ErrDisputeExceedsAmount), expectedStatus: http.StatusUnprocessableEntity, expectedError: "dispute exceeds amount"},
+		{name: "AccountClosed", request: dto.OpenDisputeRequest{Reason: "reason"}, err: domain.ErrAccountClosed, expectedStatus: http.StatusUnprocessableEntity, expectedError: "account closed"},
+		{name: "UnexpectedError", request: dto.OpenDisputeRequest{Reason: "reason"}, err: assert.AnError, expectedStatus: http.StatusInternalServerError, expectedError: "could not open dispute"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockDisputeUseCase(ctrl)
+			hdlr := NewDisputeHandler(mockUseCase)
+			router := chi.NewRouter()
+			router.Post("/transactions/{id}/disputes", hdlr.OpenDispute)
+
+			if tt.err != nil {
+				mockUseCase.EXPECT().
+					OpenDispute(gomock.Any(), int64(10), nil, tt.request.Reason).
+					Return(nil, tt.err)
+			}
+
+			reqBody, _ := json.Marshal(tt.request)
+			req := httptest.NewRequest(http.MethodPost, "/transactions/10/disputes", bytes.NewReader(reqBody))
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var errorResponse response.ErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedError, errorResponse.Error)
+		})
+	}
+}
+
+func TestDisputeHandler_ResolveDispute_WhenLost_ShouldReturnReversal(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockDisputeUseCase(ctrl)
+	hdlr := NewDisputeHandler(mockUseCase)
+
+	creditID, reversalID := int64(11), int64(12)
+	resolvedAt := time.Date(2025, 2, 15, 12, 0, 0, 0, time.UTC)
+	mockUseCase.EXPECT().
+		ResolveDispute(gomock.Any(), int64(1), domain.DisputeLost).
+		Return(&domain.Dispute{ID: 1, Status: domain.DisputeLost, ProvisionalCreditTransactionID: &creditID, ReversalTransactionID: &reversalID, ResolvedAt: &resolvedAt}, nil)
+
+	router := chi.NewRouter()
+	router.Post("/disputes/{id}/resolve", hdlr.ResolveDispute)
+
+	req := httptest.NewRequest(http.MethodPost, "/disputes/1/resolve", bytes.NewReader([]byte(`{"outcome": "LOST"}`)))
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp dto.DisputeResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "LOST", resp.Status)
+	assert.Equal(t, int64(12), *resp.ReversalTransactionID)
+	assert.Equal(t, resolvedAt, *resp.ResolvedAt)
+}
+
+func TestDisputeHandler_ResolveDispute_WhenFails_ShouldMapError(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+		expectedError  string
+	}{
+		{name: "MalformedBody", body: `{"outcome":`, expectedStatus: http.StatusBadRequest, expectedError: "invalid request"},
+		{name: "MissingOutcome", body: `{}`, expectedStatus: http.StatusUnprocessableEntity, expectedError: "validation failed"},
+		{name: "InvalidOutcome", body: `{"outcome": "OPENED"}`, expectedStatus: http.StatusUnprocessableEntity, expectedError: "validation failed"},
+		{name: "NotFound", body: `{"outcome": "WON"}`, err: repository.ErrDisputeNotFound, expectedStatus: http.StatusNotFound, expectedError: "dispute not found"},
+		{name: "AlreadyResolved", body: `{"outcome": "WON"}`, err: fmt.Errorf("%w: dispute 1 is LOST", domain.ErrDisputeNotResolvable), expectedStatus: http.StatusConflict, expectedError: "dispute already resolved"},
+		{name: "UnexpectedError", body: `{"outcome": "WON"}`, err: assert.AnError, expectedStatus: http.StatusInternalServerError, expectedError: "could not resolve dispute"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockDisputeUseCase(ctrl)
+			hdlr := NewDisputeHandler(mockUseCase)
+			router := chi.NewRouter()
+			router.Post("/disputes/{id}/resolve", hdlr.ResolveDispute)
+
+			if tt.err != nil {
+				mockUseCase.EXPECT().
+					ResolveDispute(gomock.Any(), int64(1), domain.DisputeWon).
+					Return(nil, tt.err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/disputes/1/resolve", bytes.NewReader([]byte(tt.body)))
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var errorResponse response.ErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedError, errorResponse.Error)
+		})
+	}
+}
+
+func TestDisputeHandler_ListDisputes_ShouldReturnDisputesOfTransaction(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockDisputeUseCase(ctrl)
+	hdlr := NewDisputeHandler(mockUseCase)
+
+	mockUseCase.EXPECT().
+		ListDisputes(gomock.Any(), int64(10)).
+		Return([]domain.Dispute{
+			{ID: 1, TransactionID: 10, Status: domain.DisputeLost},
+			{ID: 2, TransactionID: 10, Status: domain.DisputeProvisionalCredit},
+		}, nil)
+
+	router := chi.NewRouter()
+	router.Get("/transactions/{id}/disputes", hdlr.ListDisputes)
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions/10/disputes", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp dto.ListDisputesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), resp.TransactionID)
+	assert.Len(t, resp.Disputes, 2)
+	assert.Equal(t, "LOST", resp.Disputes[0].Status)
+}
+
+func TestDisputeHandler_GetDispute_WhenNotFound_ShouldReturn404(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockDisputeUseCase(ctrl)
+	hdlr := NewDisputeHandler(mockUseCase)
+
+	mockUseCase.EXPECT().
+		GetDispute(gomock.Any(), int64(1)).
+		Return(nil, repository.ErrDisputeNotFound)
+
+	router := chi.NewRouter()
+	router.Get("/disputes/{id}", hdlr.GetDispute)
+
+	req := httptest.NewRequest(http.MethodGet, "/disputes/1", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "dispute not found", errorResponse.Error)
+}
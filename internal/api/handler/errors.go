@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/response"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+)
+
+// sendConstraintError answers the data constraint errors that any repository may return and
+// reports whether err was one of them, so every handler maps them to the same status.
+func sendConstraintError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrAlreadyExists):
+		response.SendErrorResponse(w, http.StatusConflict, "resource already exists", err.Error())
+	case errors.Is(err, domain.ErrReferenceNotFound):
+		response.SendErrorResponse(w, http.StatusUnprocessableEntity, "referenced resource not found", err.Error())
+	case errors.Is(err, domain.ErrConstraintViolation):
+		response.SendErrorResponse(w, http.StatusUnprocessableEntity, "constraint violation", err.Error())
+	default:
+		return false
+	}
+	return true
+}
+
+// sendAccountStatusError answers the errors of transactions rejected because of the status
+// of the account and reports whether err was one of them.
+func sendAccountStatusError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrAccountBlocked):
+		response.SendErrorResponse(w, http.StatusUnprocessableEntity, "account blocked", err.Error())
+	case errors.Is(err, domain.ErrAccountClosed):
+		response.SendErrorResponse(w, http.StatusUnprocessableEntity, "account closed", err.Error())
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/api/response"
+	"github.com/VieiraVitor/transaction-flow/internal/application/usecase"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+)
+
+type HealthHandler struct {
+	useCase usecase.HealthUseCase
+}
+
+func NewHealthHandler(useCase usecase.HealthUseCase) *HealthHandler {
+	return &HealthHandler{useCase: useCase}
+}
+
+// Liveness godoc
+// @Summary Check that the process is alive
+// @Description Answers as long as the process can serve HTTP, without checking its dependencies
+// @Tags Health
+// @Produce  json
+// @Success 200 {object} dto.LivenessResponse "Alive"
+// @Router /healthz [get]
+func (h *HealthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, dto.LivenessResponse{Status: string(domain.HealthUp)})
+}
+
+// Readiness godoc
+// @Summary Check that the instance can serve traffic
+// @Description Checks that the database answers, that its schema is at the expected migration version and that the instance is not shutting down
+// @Tags Health
+// @Produce  json
+// @Success 200 {object} dto.ReadinessResponse "Ready"
+// @Failure 503 {object} dto.ReadinessResponse "Not Ready"
+// @Router /readyz [get]
+func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
+	readiness := h.useCase.CheckReadiness(r.Context())
+
+	statusCode := http.StatusOK
+	if !readiness.IsReady() {
+		statusCode = http.StatusServiceUnavailable
+	}
+	response.SendJSONResponse(r.Context(), w, statusCode, dto.NewReadinessResponse(readiness))
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthHandler_Liveness_ShouldReturn200(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hdlr := NewHealthHandler(mocks.NewMockHealthUseCase(ctrl))
+	router := chi.NewRouter()
+	router.Get("/healthz", hdlr.Liveness)
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"UP"}`, w.Body.String())
+}
+
+func TestHealthHandler_Readiness(t *testing.T) {
+	tests := []struct {
+		name           string
+		readiness      domain.Readiness
+		expectedStatus int
+		expectedBody   dto.ReadinessResponse
+	}{
+		{
+			name: "WhenReady_ShouldReturn200",
+			readiness: domain.NewReadiness([]domain.HealthCheck{
+				{Name: domain.HealthCheckDatabase, Status: domain.HealthUp},
+				{Name: domain.HealthCheckShutdown, Status: domain.HealthUp},
+			}),
+			expectedStatus: http.StatusOK,
+			expectedBody: dto.ReadinessResponse{Status: "UP", Checks: map[string]dto.HealthCheckResponse{
+				"database": {Status: "UP"},
+				"shutdown": {Status: "UP"},
+			}},
+		},
+		{
+			name: "WhenShuttingDown_ShouldReturn503",
+			readiness: domain.NewReadiness([]domain.HealthCheck{
+				{Name: domain.HealthCheckDatabase, Status: domain.HealthUp},
+				{Name: domain.HealthCheckShutdown, Status: domain.HealthDown, Detail: "shutting down"},
+			}),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody: dto.ReadinessResponse{Status: "DOWN", Checks: map[string]dto.HealthCheckResponse{
+				"database": {Status: "UP"},
+				"shutdown": {Status: "DOWN", Detail: "shutting down"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockHealthUseCase(ctrl)
+			mockUseCase.EXPECT().CheckReadiness(gomock.Any()).Return(tt.readiness)
+
+			hdlr := NewHealthHandler(mockUseCase)
+			router := chi.NewRouter()
+			router.Get("/readyz", hdlr.Readiness)
+			req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var resp dto.ReadinessResponse
+			err := json.Unmarshal(w.Body.Bytes(), &resp)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedBody, resp)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/api/response"
+	"github.com/VieiraVitor/transaction-flow/internal/application/usecase"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/go-chi/chi/v5"
+)
+
+type InvoiceHandler struct {
+	useCase usecase.InvoiceUseCase
+}
+
+func NewInvoiceHandler(useCase usecase.InvoiceUseCase) *InvoiceHandler {
+	return &InvoiceHandler{useCase: useCase}
+}
+
+// GetInvoice godoc
+// @Summary Retrieve an invoice
+// @Description Fetches a closed invoice with its totals, minimum payment and line items
+// @Tags Invoices
+// @Produce  json
+// @Param id path int true "Invoice ID"
+// @Success 200 {object} dto.InvoiceResponse "Invoice Details"
+// @Failure 400 {object} response.ErrorResponse "Invalid Request"
+// @Failure 404 {object} response.ErrorResponse "Invoice Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /invoices/{id} [get]
+func (h *InvoiceHandler) GetInvoice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idParam := chi.URLParam(r, "id")
+	invoiceID, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "could not parse id", err.Error())
+		return
+	}
+
+	invoice, err := h.useCase.GetInvoice(ctx, invoiceID)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvoiceNotFound) {
+			response.SendErrorResponse(w, http.StatusNotFound, "invoice not found", err.Error())
+			return
+		}
+		response.SendErrorResponse(w, http.StatusInternalServerError, "could not get invoice", err.Error())
+		return
+	}
+
+	response.SendJSONResponse(ctx, w, http.StatusOK, dto.NewInvoiceResponse(*invoice))
+}
+
+// ListInvoices godoc
+// @Summary List the invoices of an account
+// @Description Lists the closed invoices of an account, newest first, without their line items
+// @Tags Invoices
+// @Produce  json
+// @Param id path int true "Account ID"
+// @Success 200 {object} dto.ListInvoicesResponse "Invoices"
+// @Failure 400 {object} response.ErrorResponse "Invalid Request"
+// @Failure 404 {object} response.ErrorResponse "Account Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /accounts/{id}/invoices [get]
+func (h *InvoiceHandler) ListInvoices(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idParam := chi.URLParam(r, "id")
+	accountID, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "could not parse id", err.Error())
+		return
+	}
+
+	invoices, err := h.useCase.ListInvoices(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, repository.ErrAccountNotFound) {
+			response.SendErrorResponse(w, http.StatusNotFound, "account not found", err.Error())
+			return
+		}
+		response.SendErrorResponse(w, http.StatusInternalServerError, "could not list invoices", err.Error())
+		return
+	}
+
+	response.SendJSONResponse(ctx, w, http.StatusOK, dto.NewListInvoicesResponse(accountID, invoices))
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/api/response"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/VieiraVitor/transaction-flow/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvoiceHandler_GetInvoice_WhenInvoiceExists_ShouldReturn200(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockInvoiceUseCase(ctrl)
+	hdlr := NewInvoiceHandler(mockUseCase)
+
+	installmentNumber := 1
+	cycle := domain.BillingCycle{ClosingDay: 3, DueDay: 10}
+	invoice := domain.NewInvoice(
+		1,
+		time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC),
+		cycle,
+		domain.MustParseMoney("0"),
+		[]domain.InvoiceItem{
+			{TransactionID: 4, OperationTypeID: domain.CompraParcelada, OperationTypeDescription: "COMPRA PARCELADA", InstallmentNumber: &installmentNumber, Amount: domain.MustParseMoney("-33.34")},
+			{TransactionID: 5, OperationTypeID: domain.Pagamento, OperationTypeDescription: "PAGAMENTO", Amount: domain.MustParseMoney("10")},
+		},
+	)
+	invoice.ID = 7
+
+	mockUseCase.EXPECT().
+		GetInvoice(gomock.Any(), int64(7)).
+		Return(&invoice, nil)
+
+	router := chi.NewRouter()
+	router.Get("/invoices/{id}", hdlr.GetInvoice)
+	req := httptest.NewRequest(http.MethodGet, "/invoices/7", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.InvoiceResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), resp.ID)
+	assert.Equal(t, "2025-02-03", resp.ClosingDate)
+	assert.Equal(t, "2025-02-10", resp.DueDate)
+	assert.Equal(t, domain.MustParseMoney("33.34"), resp.TotalDebits)
+	assert.Equal(t, domain.MustParseMoney("10"), resp.TotalCredits)
+	assert.Equal(t, domain.MustParseMoney("23.34"), resp.TotalDue)
+	assert.Equal(t, domain.MustParseMoney("10"), resp.MinimumPayment)
+	assert.Len(t, resp.Items, 2)
+	assert.Equal(t, &installmentNumber, resp.Items[0].InstallmentNumber)
+	assert.Nil(t, resp.Items[1].InstallmentNumber)
+}
+
+func TestInvoiceHandler_GetInvoice_WhenInvoiceNotFound_ShouldReturn404(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockInvoiceUseCase(ctrl)
+	hdlr := NewInvoiceHandler(mockUseCase)
+
+	mockUseCase.EXPECT().
+		GetInvoice(gomock.Any(), int64(7)).
+		Return(nil, repository.ErrInvoiceNotFound)
+
+	router := chi.NewRouter()
+	router.Get("/invoices/{id}", hdlr.GetInvoice)
+	req := httptest.NewRequest(http.MethodGet, "/invoices/7", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "invoice not found", errorResponse.Error)
+}
+
+func TestInvoiceHandler_ListInvoices_WhenAccountNotFound_ShouldReturn404(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockInvoiceUseCase(ctrl)
+	hdlr := NewInvoiceHandler(mockUseCase)
+
+	mockUseCase.EXPECT().
+		ListInvoices(gomock.Any(), int64(9)).
+		Return(nil, repository.ErrAccountNotFound)
+
+	router := chi.NewRouter()
+	router.Get("/accounts/{id}/invoices", hdlr.ListInvoices)
+	req := httptest.NewRequest(http.MethodGet, "/accounts/9/invoices", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "account not found", errorResponse.Error)
+}
+
+func TestInvoiceHandler_ListInvoices_WhenFailedToListInvoices_ShouldReturn500(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockInvoiceUseCase(ctrl)
+	hdlr := NewInvoiceHandler(mockUseCase)
+
+	mockUseCase.EXPECT().
+		ListInvoices(gomock.Any(), int64(1)).
+		Return(nil, errors.New("failed to list invoices"))
+
+	router := chi.NewRouter()
+	router.Get("/accounts/{id}/invoices", hdlr.ListInvoices)
+	req := httptest.NewRequest(http.MethodGet, "/accounts/1/invoices", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "could not list invoices", errorResponse.Error)
+}
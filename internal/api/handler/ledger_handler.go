@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/api/response"
+	"github.com/VieiraVitor/transaction-flow/internal/application/usecase"
+)
+
+type LedgerHandler struct {
+	useCase usecase.LedgerUseCase
+}
+
+func NewLedgerHandler(useCase usecase.LedgerUseCase) *LedgerHandler {
+	return &LedgerHandler{useCase: useCase}
+}
+
+// GetTrialBalance godoc
+// @Summary Retrieve the trial balance
+// @Description Totals the debits and credits posted to each account of the general ledger. Every transaction is posted as a balanced journal entry, so the totals match
+// @Tags Ledger
+// @Produce  json
+// @Param as_of query string false "Only journal entries dated at or before this RFC3339 timestamp"
+// @Success 200 {object} dto.TrialBalanceResponse "Trial Balance"
+// @Failure 400 {object} response.ErrorResponse "Invalid Request"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /ledger/trial-balance [get]
+func (h *LedgerHandler) GetTrialBalance(w http.ResponseWriter, r *http.Request) {
+	var asOf *time.Time
+	if asOfParam := r.URL.Query().Get("as_of"); asOfParam != "" {
+		parsedAsOf, err := time.Parse(time.RFC3339, asOfParam)
+		if err != nil {
+			response.SendErrorResponse(w, http.StatusBadRequest, "invalid query parameters", "as_of must be an RFC3339 timestamp")
+			return
+		}
+		asOf = &parsedAsOf
+	}
+
+	trialBalance, err := h.useCase.GetTrialBalance(r.Context(), asOf)
+	if err != nil {
+		response.SendErrorResponse(w, http.StatusInternalServerError, "could not get trial balance", err.Error())
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, dto.NewTrialBalanceResponse(trialBalance))
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/api/response"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedgerHandler_GetTrialBalance_WhenAsOfInformed_ShouldReturn200(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockLedgerUseCase(ctrl)
+	hdlr := NewLedgerHandler(mockUseCase)
+
+	asOf := time.Date(2025, 1, 31, 23, 59, 59, 0, time.UTC)
+	trialBalance := domain.NewTrialBalance([]domain.TrialBalanceLine{
+		{LedgerAccount: domain.LedgerCash, Debits: domain.MustParseMoney("30"), Credits: domain.MustParseMoney("50"), Balance: domain.MustParseMoney("-20")},
+		{LedgerAccount: domain.LedgerReceivables, Debits: domain.MustParseMoney("50"), Credits: domain.MustParseMoney("30"), Balance: domain.MustParseMoney("20")},
+	}, &asOf)
+	mockUseCase.EXPECT().
+		GetTrialBalance(gomock.Any(), &asOf).
+		Return(&trialBalance, nil)
+
+	router := chi.NewRouter()
+	router.Get("/ledger/trial-balance", hdlr.GetTrialBalance)
+	req := httptest.NewRequest(http.MethodGet, "/ledger/trial-balance?as_of=2025-01-31T23:59:59Z", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp dto.TrialBalanceResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Accounts, 2)
+	assert.Equal(t, "CASH", resp.Accounts[0].LedgerAccount)
+	assert.Equal(t, domain.MustParseMoney("80"), resp.TotalDebits)
+	assert.Equal(t, domain.MustParseMoney("80"), resp.TotalCredits)
+	assert.True(t, resp.Balanced)
+}
+
+func TestLedgerHandler_GetTrialBalance_WhenAsOfIsInvalid_ShouldReturn400(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hdlr := NewLedgerHandler(mocks.NewMockLedgerUseCase(ctrl))
+	router := chi.NewRouter()
+	router.Get("/ledger/trial-balance", hdlr.GetTrialBalance)
+	req := httptest.NewRequest(http.MethodGet, "/ledger/trial-balance?as_of=yesterday", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestLedgerHandler_GetTrialBalance_WhenFailedToGetTrialBalance_ShouldReturn500(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockLedgerUseCase(ctrl)
+	hdlr := NewLedgerHandler(mockUseCase)
+	mockUseCase.EXPECT().
+		GetTrialBalance(gomock.Any(), gomock.Nil()).
+		Return(nil, errors.New("db error"))
+
+	router := chi.NewRouter()
+	router.Get("/ledger/trial-balance", hdlr.GetTrialBalance)
+	req := httptest.NewRequest(http.MethodGet, "/ledger/trial-balance", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "could not get trial balance", errorResponse.Error)
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/api/response"
+	"github.com/VieiraVitor/transaction-flow/internal/application/usecase"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/go-chi/chi/v5"
+)
+
+type OperationTypeHandler struct {
+	useCase usecase.OperationTypeUseCase
+}
+
+func NewOperationTypeHandler(useCase usecase.OperationTypeUseCase) *OperationTypeHandler {
+	return &OperationTypeHandler{useCase: useCase}
+}
+
+// ListOperationTypes godoc
+// @Summary List the operation types
+// @Description Lists every operation type of the catalog, including the inactive ones
+// @Tags Operation Types
+// @Produce  json
+// @Success 200 {object} dto.ListOperationTypesResponse "Operation Types"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /operation-types [get]
+func (h *OperationTypeHandler) ListOperationTypes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	operationTypes, err := h.useCase.ListOperationTypes(ctx)
+	if err != nil {
+		response.SendErrorResponse(w, http.StatusInternalServerError, "could not list operation types", err.Error())
+		return
+	}
+
+	listResponse := dto.ListOperationTypesResponse{OperationTypes: make([]dto.OperationTypeResponse, 0, len(operationTypes))}
+	for i := range operationTypes {
+		listResponse.OperationTypes = append(listResponse.OperationTypes, dto.NewOperationTypeResponse(&operationTypes[i]))
+	}
+	response.SendJSONResponse(ctx, w, http.StatusOK, listResponse)
+}
+
+// CreateOperationType godoc
+// @Summary Create an operation type
+// @Description Adds an active operation type to the catalog. The direction decides the sign of its transactions and cannot be changed later
+// @Tags Operation Types
+// @Accept  json
+// @Produce  json
+// @Param operationType body dto.CreateOperationTypeRequest true "Operation type creation request"
+// @Success 201 {object} dto.CreateOperationTypeResponse "Operation Type Created"
+// @Failure 400 {object} response.ErrorResponse "Invalid Request"
+// @Failure 409 {object} response.ErrorResponse "Operation Type Already Exists"
+// @Failure 422 {object} response.ErrorResponse "Validation Error"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /operation-types [post]
+func (h *OperationTypeHandler) CreateOperationType(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.CreateOperationTypeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "invalid request", fmt.Sprintf("malformed request :%v", err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.SendErrorResponse(w, http.StatusUnprocessableEntity, "validation failed", err.Error())
+		return
+	}
+
+	id, err := h.useCase.CreateOperationType(ctx, req.Description, req.Direction)
+	if err != nil {
+		if sendConstraintError(w, err) {
+			return
+		}
+		response.SendErrorResponse(w, http.StatusInternalServerError, "could not create operation type", err.Error())
+		return
+	}
+
+	response.SendJSONResponse(ctx, w, http.StatusCreated, dto.CreateOperationTypeResponse{ID: int(id)})
+}
+
+// UpdateOperationType godoc
+// @Summary Update an operation type
+// @Description Renames, activates or deactivates an operation type. Inactive types reject new transactions. System types (5 to 12) cannot be changed
+// @Tags Operation Types
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Operation Type ID"
+// @Param operationType body dto.UpdateOperationTypeRequest true "Operation type update request"
+// @Success 200 {object} dto.OperationTypeResponse "Operation Type Updated"
+// @Failure 400 {object} response.ErrorResponse "Invalid Request"
+// @Failure 404 {object} response.ErrorResponse "Operation Type Not Found"
+// @Failure 409 {object} response.ErrorResponse "Operation Type Already Exists"
+// @Failure 422 {object} response.ErrorResponse "Validation Error or System Operation Type"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /operation-types/{id} [patch]
+func (h *OperationTypeHandler) UpdateOperationType(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idParam := chi.URLParam(r, "id")
+	operationTypeID, err := strconv.Atoi(idParam)
+	if err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "could not parse id", err.Error())
+		return
+	}
+
+	var req dto.UpdateOperationTypeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "invalid request", fmt.Sprintf("malformed request :%v", err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.SendErrorResponse(w, http.StatusUnprocessableEntity, "validation failed", err.Error())
+		return
+	}
+
+	operationType, err := h.useCase.UpdateOperationType(ctx, domain.OperationType(operationTypeID), req.Description, req.Active)
+	if err != nil {
+		if errors.Is(err, repository.ErrOperationTypeNotFound) {
+			response.SendErrorResponse(w, http.StatusNotFound, "operation type not found", err.Error())
+			return
+		}
+		if errors.Is(err, usecase.ErrSystemOperationType) {
+			response.SendErrorResponse(w, http.StatusUnprocessableEntity, "system operation type", err.Error())
+			return
+		}
+		if sendConstraintError(w, err) {
+			return
+		}
+		response.SendErrorResponse(w, http.StatusInternalServerError, "could not update operation type", err.Error())
+		return
+	}
+
+	response.SendJSONResponse(ctx, w, http.StatusOK, dto.NewOperationTypeResponse(operationType))
+}
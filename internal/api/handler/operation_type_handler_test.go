@@ -0,0 +1,292 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/api/response"
+	"github.com/VieiraVitor/transaction-flow/internal/application/usecase"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/VieiraVitor/transaction-flow/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationTypeHandler_ListOperationTypes_WhenTypesExist_ShouldReturn200(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockOperationTypeUseCase(ctrl)
+	hdlr := NewOperationTypeHandler(mockUseCase)
+
+	router := chi.NewRouter()
+	router.Get("/operation-types", hdlr.ListOperationTypes)
+
+	payment := domain.NewOperationTypeDefinition("PAGAMENTO", domain.DirectionCredit)
+	payment.SetID(domain.Pagamento)
+
+	req := httptest.NewRequest(http.MethodGet, "/operation-types", nil)
+	w := httptest.NewRecorder()
+
+	mockUseCase.EXPECT().
+		ListOperationTypes(gomock.Any()).
+		Return([]domain.OperationTypeDefinition{*payment}, nil)
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var listResponse dto.ListOperationTypesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &listResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.OperationTypeResponse{{ID: 4, Description: "PAGAMENTO", Direction: "CREDIT", Active: true}}, listResponse.OperationTypes)
+}
+
+func TestOperationTypeHandler_CreateOperationType_WhenCreatedSuccessfully_ShouldReturn201(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockOperationTypeUseCase(ctrl)
+	hdlr := NewOperationTypeHandler(mockUseCase)
+
+	router := chi.NewRouter()
+	router.Post("/operation-types", hdlr.CreateOperationType)
+
+	reqBody, _ := json.Marshal(dto.CreateOperationTypeRequest{Description: "TARIFA", Direction: domain.DirectionDebit})
+	req := httptest.NewRequest(http.MethodPost, "/operation-types", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	mockUseCase.EXPECT().
+		CreateOperationType(gomock.Any(), "TARIFA", domain.DirectionDebit).
+		Return(domain.OperationType(5), nil)
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var createResponse dto.CreateOperationTypeResponse
+	err := json.Unmarshal(w.Body.Bytes(), &createResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, createResponse.ID)
+}
+
+func TestOperationTypeHandler_CreateOperationType_InvalidInputs_ShouldReturn422(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        dto.CreateOperationTypeRequest
+		description string
+	}{
+		{"EmptyDescription", dto.CreateOperationTypeRequest{Direction: domain.DirectionDebit}, "description is mandatory"},
+		{"InvalidDirection", dto.CreateOperationTypeRequest{Description: "TARIFA", Direction: "BOTH"}, "direction must be DEBIT or CREDIT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockOperationTypeUseCase(ctrl)
+			hdlr := NewOperationTypeHandler(mockUseCase)
+
+			router := chi.NewRouter()
+			router.Post("/operation-types", hdlr.CreateOperationType)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/operation-types", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+			var errorResponse response.ErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+			assert.NoError(t, err)
+			assert.Equal(t, "validation failed", errorResponse.Error)
+			assert.Equal(t, tt.description, errorResponse.Description)
+		})
+	}
+}
+
+func TestOperationTypeHandler_CreateOperationType_WhenDescriptionExists_ShouldReturn409(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockOperationTypeUseCase(ctrl)
+	hdlr := NewOperationTypeHandler(mockUseCase)
+
+	router := chi.NewRouter()
+	router.Post("/operation-types", hdlr.CreateOperationType)
+
+	reqBody, _ := json.Marshal(dto.CreateOperationTypeRequest{Description: "SAQUE", Direction: domain.DirectionDebit})
+	req := httptest.NewRequest(http.MethodPost, "/operation-types", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	mockUseCase.EXPECT().
+		CreateOperationType(gomock.Any(), "SAQUE", domain.DirectionDebit).
+		Return(domain.OperationType(0), fmt.Errorf("failed to create operation type: %w", domain.ErrAlreadyExists))
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestOperationTypeHandler_UpdateOperationType_WhenUpdatedSuccessfully_ShouldReturn200(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockOperationTypeUseCase(ctrl)
+	hdlr := NewOperationTypeHandler(mockUseCase)
+
+	router := chi.NewRouter()
+	router.Patch("/operation-types/{id}", hdlr.UpdateOperationType)
+
+	active := false
+	updated := domain.NewOperationTypeDefinition("SAQUE", domain.DirectionDebit)
+	updated.SetID(domain.Saque)
+	updated.SetActive(false)
+
+	reqBody, _ := json.Marshal(dto.UpdateOperationTypeRequest{Active: &active})
+	req := httptest.NewRequest(http.MethodPatch, "/operation-types/3", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	mockUseCase.EXPECT().
+		UpdateOperationType(gomock.Any(), domain.Saque, nil, &active).
+		Return(updated, nil)
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var operationTypeResponse dto.OperationTypeResponse
+	err := json.Unmarshal(w.Body.Bytes(), &operationTypeResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, operationTypeResponse.ID)
+	assert.False(t, operationTypeResponse.Active)
+}
+
+func TestOperationTypeHandler_UpdateOperationType_WhenNoFieldInformed_ShouldReturn422(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockOperationTypeUseCase(ctrl)
+	hdlr := NewOperationTypeHandler(mockUseCase)
+
+	router := chi.NewRouter()
+	router.Patch("/operation-types/{id}", hdlr.UpdateOperationType)
+
+	req := httptest.NewRequest(http.MethodPatch, "/operation-types/3", bytes.NewReader([]byte(`{}`)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestOperationTypeHandler_UpdateOperationType_WhenNotFound_ShouldReturn404(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockOperationTypeUseCase(ctrl)
+	hdlr := NewOperationTypeHandler(mockUseCase)
+
+	router := chi.NewRouter()
+	router.Patch("/operation-types/{id}", hdlr.UpdateOperationType)
+
+	description := "TARIFA"
+	reqBody, _ := json.Marshal(dto.UpdateOperationTypeRequest{Description: &description})
+	req := httptest.NewRequest(http.MethodPatch, "/operation-types/9", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	mockUseCase.EXPECT().
+		UpdateOperationType(gomock.Any(), domain.OperationType(9), &description, nil).
+		Return(nil, repository.ErrOperationTypeNotFound)
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestOperationTypeHandler_UpdateOperationType_WhenSystemType_ShouldReturn422(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockOperationTypeUseCase(ctrl)
+	hdlr := NewOperationTypeHandler(mockUseCase)
+
+	router := chi.NewRouter()
+	router.Patch("/operation-types/{id}", hdlr.UpdateOperationType)
+
+	active := false
+	reqBody, _ := json.Marshal(dto.UpdateOperationTypeRequest{Active: &active})
+	req := httptest.NewRequest(http.MethodPatch, "/operation-types/5", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	mockUseCase.EXPECT().
+		UpdateOperationType(gomock.Any(), domain.Estorno, nil, &active).
+		Return(nil, usecase.ErrSystemOperationType)
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestOperationTypeHandler_ListOperationTypes_WhenFailedToList_ShouldReturn500(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockOperationTypeUseCase(ctrl)
+	hdlr := NewOperationTypeHandler(mockUseCase)
+
+	router := chi.NewRouter()
+	router.Get("/operation-types", hdlr.ListOperationTypes)
+
+	req := httptest.NewRequest(http.MethodGet, "/operation-types", nil)
+	w := httptest.NewRecorder()
+
+	mockUseCase.EXPECT().
+		ListOperationTypes(gomock.Any()).
+		Return(nil, errors.New("connection reset"))
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
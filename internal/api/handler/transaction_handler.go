@@ -1,14 +1,20 @@
 package handler
 
 import (
-	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
 	"github.com/VieiraVitor/transaction-flow/internal/api/response"
 	"github.com/VieiraVitor/transaction-flow/internal/application/usecase"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/go-chi/chi/v5"
 )
 
 type TransactionHandler struct {
@@ -23,14 +29,16 @@ func NewTransactionHandler(useCase usecase.TransactionUseCase) *TransactionHandl
 
 // CreateTransaction godoc
 // @Summary Create a transaction
-// @Description Registers a new financial transaction
+// @Description Registers a new financial transaction. Installment purchases (operation type 2) accept the number of installments they are paid in
 // @Tags Transactions
 // @Accept  json
 // @Produce  json
 // @Param transaction body dto.CreateTransactionRequest true "Transaction Request"
+// @Param Idempotency-Key header string false "Replays the recorded response when the request is retried with the same key"
 // @Success 201 {object} dto.CreateTransactionResponse "Transaction Created"
 // @Failure 400 {object} response.ErrorResponse "Invalid Request"
-// @Failure 422 {object} response.ErrorResponse "Validation Failed"
+// @Failure 409 {object} response.ErrorResponse "Request In Progress"
+// @Failure 422 {object} response.ErrorResponse "Validation Failed, Account Not Found, Account Blocked or Closed, Invalid Operation Type, Insufficient Credit Limit or Idempotency Key Reused"
 // @Failure 500 {object} response.ErrorResponse "Internal Server Error"
 // @Router /transactions [post]
 func (h *TransactionHandler) CreateTransaction(w http.ResponseWriter, r *http.Request) {
@@ -45,12 +53,302 @@ func (h *TransactionHandler) CreateTransaction(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	transactionID, err := h.useCase.CreateTransaction(context.Background(), req.AccountID, req.OperationTypeID, req.Amount)
+	var transactionID int64
+	var err error
+	if req.Installments > 0 {
+		transactionID, err = h.useCase.CreateInstallmentPurchase(r.Context(), req.AccountID, req.Amount, req.Installments)
+	} else {
+		transactionID, err = h.useCase.CreateTransaction(r.Context(), req.AccountID, req.OperationTypeID, req.Amount)
+	}
 	if err != nil {
+		if errors.Is(err, usecase.ErrTransactionAccountNotFound) || errors.Is(err, repository.ErrAccountNotFound) {
+			response.SendErrorResponse(w, http.StatusUnprocessableEntity, "account not found", err.Error())
+			return
+		}
+		if sendAccountStatusError(w, err) {
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidOperationType) {
+			response.SendErrorResponse(w, http.StatusUnprocessableEntity, "invalid operation type", err.Error())
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidInstallments) {
+			response.SendErrorResponse(w, http.StatusUnprocessableEntity, "validation failed", err.Error())
+			return
+		}
+		if errors.Is(err, repository.ErrInsufficientCreditLimit) {
+			response.SendErrorResponse(w, http.StatusUnprocessableEntity, "insufficient credit limit", err.Error())
+			return
+		}
+		if sendConstraintError(w, err) {
+			return
+		}
 		response.SendErrorResponse(w, http.StatusInternalServerError, "could not create transaction", err.Error())
 		return
 	}
 
 	transactionResponse := dto.CreateTransactionResponse{ID: transactionID}
-	response.SendJSONResponse(context.Background(), w, http.StatusCreated, transactionResponse)
+	response.SendJSONResponse(r.Context(), w, http.StatusCreated, transactionResponse)
+}
+
+// ReverseTransaction godoc
+// @Summary Reverse a transaction
+// @Description Creates a compensating transaction (estorno) with the opposite sign of the original one. Without an amount the whole transaction is reversed. Reversals, transfers, installment purchases and provisional credits cannot be reversed
+// @Tags Transactions
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Transaction ID"
+// @Param reversal body dto.ReverseTransactionRequest false "Amount to reverse"
+// @Param Idempotency-Key header string false "Replays the recorded response when the request is retried with the same key"
+// @Success 201 {object} dto.CreateTransactionResponse "Reversal Created"
+// @Failure 400 {object} response.ErrorResponse "Invalid Request"
+// @Failure 404 {object} response.ErrorResponse "Transaction Not Found"
+// @Failure 409 {object} response.ErrorResponse "Transaction Already Reversed, Transaction Disputed or Request In Progress"
+// @Failure 422 {object} response.ErrorResponse "Validation Failed, Reversal Not Allowed, Reversal Exceeds Amount, Account Blocked or Closed, Insufficient Credit Limit or Idempotency Key Reused"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /transactions/{id}/reversal [post]
+func (h *TransactionHandler) ReverseTransaction(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	transactionID, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "could not parse id", err.Error())
+		return
+	}
+
+	var req dto.ReverseTransactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.SendErrorResponse(w, http.StatusBadRequest, "invalid request", fmt.Sprintf("malformed request :%v", err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.SendErrorResponse(w, http.StatusUnprocessableEntity, "validation failed", err.Error())
+		return
+	}
+
+	reversalID, err := h.useCase.ReverseTransaction(r.Context(), transactionID, req.Amount)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrTransactionNotFound):
+			response.SendErrorResponse(w, http.StatusNotFound, "transaction not found", err.Error())
+		case errors.Is(err, repository.ErrTransactionAlreadyReversed):
+			response.SendErrorResponse(w, http.StatusConflict, "transaction already reversed", err.Error())
+		case errors.Is(err, repository.ErrTransactionDisputed):
+			response.SendErrorResponse(w, http.StatusConflict, "transaction disputed", err.Error())
+		case errors.Is(err, usecase.ErrReversalOfReversal) || errors.Is(err, usecase.ErrInvalidOperationType):
+			response.SendErrorResponse(w, http.StatusUnprocessableEntity, "reversal not allowed", err.Error())
+		case errors.Is(err, repository.ErrReversalExceedsAmount):
+			response.SendErrorResponse(w, http.StatusUnprocessableEntity, "reversal exceeds amount", err.Error())
+		case errors.Is(err, repository.ErrInsufficientCreditLimit):
+			response.SendErrorResponse(w, http.StatusUnprocessableEntity, "insufficient credit limit", err.Error())
+		default:
+			if sendAccountStatusError(w, err) || sendConstraintError(w, err) {
+				return
+			}
+			response.SendErrorResponse(w, http.StatusInternalServerError, "could not reverse transaction", err.Error())
+		}
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusCreated, dto.CreateTransactionResponse{ID: reversalID})
+}
+
+// GetTransaction godoc
+// @Summary Retrieve a transaction
+// @Description Fetches transaction details by ID
+// @Tags Transactions
+// @Produce  json
+// @Param id path int true "Transaction ID"
+// @Success 200 {object} dto.GetTransactionResponse "Transaction Details"
+// @Failure 400 {object} response.ErrorResponse "Invalid Request"
+// @Failure 404 {object} response.ErrorResponse "Transaction Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /transactions/{id} [get]
+func (h *TransactionHandler) GetTransaction(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	transactionID, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "could not parse id", err.Error())
+		return
+	}
+
+	transaction, err := h.useCase.GetTransaction(r.Context(), transactionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrTransactionNotFound) {
+			response.SendErrorResponse(w, http.StatusNotFound, "transaction not found", err.Error())
+			return
+		}
+		response.SendErrorResponse(w, http.StatusInternalServerError, "could not get transaction", err.Error())
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, dto.NewGetTransactionResponse(transaction))
+}
+
+// ListInstallments godoc
+// @Summary List the installments of a transaction
+// @Description Lists the installment schedule of an installment purchase, empty for other transactions
+// @Tags Transactions
+// @Produce  json
+// @Param id path int true "Transaction ID"
+// @Success 200 {object} dto.ListInstallmentsResponse "Installments"
+// @Failure 400 {object} response.ErrorResponse "Invalid Request"
+// @Failure 404 {object} response.ErrorResponse "Transaction Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /transactions/{id}/installments [get]
+func (h *TransactionHandler) ListInstallments(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	transactionID, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "could not parse id", err.Error())
+		return
+	}
+
+	installments, err := h.useCase.ListInstallments(r.Context(), transactionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrTransactionNotFound) {
+			response.SendErrorResponse(w, http.StatusNotFound, "transaction not found", err.Error())
+			return
+		}
+		response.SendErrorResponse(w, http.StatusInternalServerError, "could not list installments", err.Error())
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, dto.NewListInstallmentsResponse(transactionID, installments))
+}
+
+// ListTransactions godoc
+// @Summary List the transactions of an account
+// @Description Lists an account's transactions newest first using cursor-based pagination
+// @Tags Transactions
+// @Produce  json
+// @Param id path int true "Account ID"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param operation_type_id query int false "Operation type filter"
+// @Param from query string false "Only transactions with event_date at or after this RFC3339 timestamp"
+// @Param to query string false "Only transactions with event_date before this RFC3339 timestamp"
+// @Success 200 {object} dto.ListTransactionsResponse "Transactions Page"
+// @Failure 400 {object} response.ErrorResponse "Invalid Request"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /accounts/{id}/transactions [get]
+func (h *TransactionHandler) ListTransactions(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	accountID, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "could not parse id", err.Error())
+		return
+	}
+
+	filter, err := parseTransactionFilter(r, accountID)
+	if err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "invalid query parameters", err.Error())
+		return
+	}
+
+	transactions, next, err := h.useCase.ListTransactions(r.Context(), filter)
+	if err != nil {
+		response.SendErrorResponse(w, http.StatusInternalServerError, "could not list transactions", err.Error())
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, dto.NewListTransactionsResponse(transactions, next))
+}
+
+// GetAccountBalance godoc
+// @Summary Retrieve the balance of an account
+// @Description Sums the account's transactions into its signed balance, total debits and total credits
+// @Tags Accounts
+// @Produce  json
+// @Param id path int true "Account ID"
+// @Param as_of query string false "Only transactions with event_date at or before this RFC3339 timestamp"
+// @Success 200 {object} dto.AccountBalanceResponse "Account Balance"
+// @Failure 400 {object} response.ErrorResponse "Invalid Request"
+// @Failure 404 {object} response.ErrorResponse "Account Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /accounts/{id}/balance [get]
+func (h *TransactionHandler) GetAccountBalance(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	accountID, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "could not parse id", err.Error())
+		return
+	}
+
+	var asOf *time.Time
+	if asOfParam := r.URL.Query().Get("as_of"); asOfParam != "" {
+		parsedAsOf, err := time.Parse(time.RFC3339, asOfParam)
+		if err != nil {
+			response.SendErrorResponse(w, http.StatusBadRequest, "invalid query parameters", "as_of must be an RFC3339 timestamp")
+			return
+		}
+		asOf = &parsedAsOf
+	}
+
+	balance, err := h.useCase.GetAccountBalance(r.Context(), accountID, asOf)
+	if err != nil {
+		if errors.Is(err, repository.ErrAccountNotFound) {
+			response.SendErrorResponse(w, http.StatusNotFound, "account not found", err.Error())
+			return
+		}
+		response.SendErrorResponse(w, http.StatusInternalServerError, "could not get account balance", err.Error())
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, dto.NewAccountBalanceResponse(balance))
+}
+
+func parseTransactionFilter(r *http.Request, accountID int64) (domain.TransactionFilter, error) {
+	query := r.URL.Query()
+	filter := domain.TransactionFilter{
+		AccountID: accountID,
+		Limit:     domain.DefaultTransactionPageSize,
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		parsedLimit, err := strconv.Atoi(limit)
+		if err != nil || parsedLimit < 1 || parsedLimit > domain.MaxTransactionPageSize {
+			return filter, fmt.Errorf("limit must be between 1 and %d", domain.MaxTransactionPageSize)
+		}
+		filter.Limit = parsedLimit
+	}
+
+	if cursor := query.Get("cursor"); cursor != "" {
+		after, err := dto.DecodeTransactionCursor(cursor)
+		if err != nil {
+			return filter, err
+		}
+		filter.After = &after
+	}
+
+	if operationTypeID := query.Get("operation_type_id"); operationTypeID != "" {
+		parsedOperationTypeID, err := strconv.Atoi(operationTypeID)
+		if err != nil {
+			return filter, errors.New("operation_type_id must be an integer")
+		}
+		operationType := domain.OperationType(parsedOperationTypeID)
+		filter.OperationTypeID = &operationType
+	}
+
+	if from := query.Get("from"); from != "" {
+		parsedFrom, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return filter, errors.New("from must be an RFC3339 timestamp")
+		}
+		filter.From = &parsedFrom
+	}
+
+	if to := query.Get("to"); to != "" {
+		parsedTo, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return filter, errors.New("to must be an RFC3339 timestamp")
+		}
+		filter.To = &parsedTo
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return filter, errors.New("from must be before to")
+	}
+
+	return filter, nil
 }
@@ -4,11 +4,17 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/api/response"
+	"github.com/VieiraVitor/transaction-flow/internal/application/usecase"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
 	"github.com/VieiraVitor/transaction-flow/internal/mocks"
 	"github.com/go-chi/chi/v5"
 	"github.com/golang/mock/gomock"
@@ -25,7 +31,7 @@ func TestTransactionHandler_CreateTransaction_WhenTransactionCreatedSuccessfully
 
 	accountID := int64(123)
 	operationTypeID := 1
-	amount := 100.0
+	amount := domain.MustParseMoney("100")
 	expectedID := int64(1)
 
 	router := chi.NewRouter()
@@ -83,6 +89,35 @@ func TestTransactionHandler_CreateTransaction_WhenInvalidRequest_ShouldReturn400
 	assert.Equal(t, "invalid request", responseData["error"])
 }
 
+func TestTransactionHandler_CreateTransaction_WhenAmountHasMoreThanTwoDecimals_ShouldReturn400(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTransactionUseCase(ctrl)
+	hdlr := NewTransactionHandler(mockUseCase)
+
+	router := chi.NewRouter()
+	router.Post("/transactions", hdlr.CreateTransaction)
+
+	body := []byte(`{"account_id": 1, "operation_type_id": 1, "amount": 10.005}`)
+
+	req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var responseData map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &responseData)
+	assert.NoError(t, err)
+	assert.Equal(t, "invalid request", responseData["error"])
+}
+
 func TestTransactionHandler_CreateTransaction_WhenFailedToCreateTransaction_ShouldReturn500(t *testing.T) {
 	// Arrange
 	ctrl := gomock.NewController(t)
@@ -93,7 +128,7 @@ func TestTransactionHandler_CreateTransaction_WhenFailedToCreateTransaction_Shou
 
 	accountID := int64(123)
 	operationTypeID := 1
-	amount := 100.0
+	amount := domain.MustParseMoney("100")
 
 	router := chi.NewRouter()
 	router.Post("/transactions", hdlr.CreateTransaction)
@@ -116,6 +151,150 @@ func TestTransactionHandler_CreateTransaction_WhenFailedToCreateTransaction_Shou
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
 
+func TestTransactionHandler_CreateTransaction_WhenInsufficientCreditLimit_ShouldReturn422(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTransactionUseCase(ctrl)
+	hdlr := NewTransactionHandler(mockUseCase)
+
+	accountID := int64(123)
+	operationTypeID := 1
+	amount := domain.MustParseMoney("100")
+
+	router := chi.NewRouter()
+	router.Post("/transactions", hdlr.CreateTransaction)
+
+	reqBody, _ := json.Marshal(dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: operationTypeID, Amount: amount})
+	req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	mockUseCase.EXPECT().
+		CreateTransaction(gomock.Any(), accountID, operationTypeID, amount).
+		Return(int64(0), repository.ErrInsufficientCreditLimit)
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, errorResponse.StatusCode)
+	assert.Equal(t, "insufficient credit limit", errorResponse.Error)
+}
+
+func TestTransactionHandler_CreateTransaction_WhenAccountNotFound_ShouldReturn422(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTransactionUseCase(ctrl)
+	hdlr := NewTransactionHandler(mockUseCase)
+
+	accountID := int64(123)
+	operationTypeID := 1
+	amount := domain.MustParseMoney("100")
+
+	router := chi.NewRouter()
+	router.Post("/transactions", hdlr.CreateTransaction)
+
+	reqBody, _ := json.Marshal(dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: operationTypeID, Amount: amount})
+	req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	mockUseCase.EXPECT().
+		CreateTransaction(gomock.Any(), accountID, operationTypeID, amount).
+		Return(int64(0), fmt.Errorf("%w: %d", usecase.ErrTransactionAccountNotFound, accountID))
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, errorResponse.StatusCode)
+	assert.Equal(t, "account not found", errorResponse.Error)
+}
+
+func TestTransactionHandler_CreateTransaction_WhenAccountIsBlocked_ShouldReturn422(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTransactionUseCase(ctrl)
+	hdlr := NewTransactionHandler(mockUseCase)
+
+	accountID := int64(123)
+	operationTypeID := 1
+	amount := domain.MustParseMoney("100")
+
+	router := chi.NewRouter()
+	router.Post("/transactions", hdlr.CreateTransaction)
+
+	reqBody, _ := json.Marshal(dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: operationTypeID, Amount: amount})
+	req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	mockUseCase.EXPECT().
+		CreateTransaction(gomock.Any(), accountID, operationTypeID, amount).
+		Return(int64(0), fmt.Errorf("%w: account %d only accepts credits", domain.ErrAccountBlocked, accountID))
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "account blocked", errorResponse.Error)
+	assert.Equal(t, "account is blocked: account 123 only accepts credits", errorResponse.Description)
+}
+
+func TestTransactionHandler_CreateTransaction_WhenInvalidOperationType_ShouldReturn422(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTransactionUseCase(ctrl)
+	hdlr := NewTransactionHandler(mockUseCase)
+
+	accountID := int64(123)
+	operationTypeID := 10
+	amount := domain.MustParseMoney("100")
+
+	router := chi.NewRouter()
+	router.Post("/transactions", hdlr.CreateTransaction)
+
+	reqBody, _ := json.Marshal(dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: operationTypeID, Amount: amount})
+	req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	mockUseCase.EXPECT().
+		CreateTransaction(gomock.Any(), accountID, operationTypeID, amount).
+		Return(int64(0), fmt.Errorf("%w: %d", usecase.ErrInvalidOperationType, operationTypeID))
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "invalid operation type", errorResponse.Error)
+	assert.Equal(t, "invalid operation type: 10", errorResponse.Description)
+}
+
 func TestTransactionHandler_CreateTransaction_InvalidInputs_ShouldReturn422(t *testing.T) {
 	// Arrange
 	ctrl := gomock.NewController(t)
@@ -134,17 +313,17 @@ func TestTransactionHandler_CreateTransaction_InvalidInputs_ShouldReturn422(t *t
 	}{
 		{
 			name:           "When AccountID is 0",
-			requestBody:    dto.CreateTransactionRequest{AccountID: 0, OperationTypeID: 1, Amount: 100},
+			requestBody:    dto.CreateTransactionRequest{AccountID: 0, OperationTypeID: 1, Amount: domain.MustParseMoney("100")},
 			expectedStatus: http.StatusUnprocessableEntity,
 		},
 		{
 			name:           "When OperationTypeID is 0",
-			requestBody:    dto.CreateTransactionRequest{AccountID: 1, OperationTypeID: 0, Amount: 100},
+			requestBody:    dto.CreateTransactionRequest{AccountID: 1, OperationTypeID: 0, Amount: domain.MustParseMoney("100")},
 			expectedStatus: http.StatusUnprocessableEntity,
 		},
 		{
 			name:           "When Amount is 0",
-			requestBody:    dto.CreateTransactionRequest{AccountID: 1, OperationTypeID: 1, Amount: 0},
+			requestBody:    dto.CreateTransactionRequest{AccountID: 1, OperationTypeID: 1, Amount: domain.MustParseMoney("0")},
 			expectedStatus: http.StatusUnprocessableEntity,
 		},
 	}
@@ -164,3 +343,568 @@ func TestTransactionHandler_CreateTransaction_InvalidInputs_ShouldReturn422(t *t
 		})
 	}
 }
+
+func TestTransactionHandler_ReverseTransaction_WhenBodyIsEmpty_ShouldReverseWholeTransaction(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTransactionUseCase(ctrl)
+	hdlr := NewTransactionHandler(mockUseCase)
+
+	router := chi.NewRouter()
+	router.Post("/transactions/{id}/reversal", hdlr.ReverseTransaction)
+
+	req := httptest.NewRequest(http.MethodPost, "/transactions/7/reversal", nil)
+	w := httptest.NewRecorder()
+
+	mockUseCase.EXPECT().
+		ReverseTransaction(gomock.Any(), int64(7), nil).
+		Return(int64(8), nil)
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var reversalResponse dto.CreateTransactionResponse
+	err := json.Unmarshal(w.Body.Bytes(), &reversalResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8), reversalResponse.ID)
+}
+
+func TestTransactionHandler_ReverseTransaction_WhenAmountIsNotPositive_ShouldReturn422(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTransactionUseCase(ctrl)
+	hdlr := NewTransactionHandler(mockUseCase)
+
+	router := chi.NewRouter()
+	router.Post("/transactions/{id}/reversal", hdlr.ReverseTransaction)
+
+	req := httptest.NewRequest(http.MethodPost, "/transactions/7/reversal", bytes.NewReader([]byte(`{"amount": -10}`)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "amount must be positive", errorResponse.Description)
+}
+
+func TestTransactionHandler_ReverseTransaction_WhenUseCaseFails_ShouldMapError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		statusCode int
+		message    string
+	}{
+		{"NotFound", repository.ErrTransactionNotFound, http.StatusNotFound, "transaction not found"},
+		{"AlreadyReversed", repository.ErrTransactionAlreadyReversed, http.StatusConflict, "transaction already reversed"},
+		{"Disputed", fmt.Errorf("%w: 30.00 of transaction 7 is disputed", repository.ErrTransactionDisputed), http.StatusConflict, "transaction disputed"},
+		{"ReversalOfReversal", usecase.ErrReversalOfReversal, http.StatusUnprocessableEntity, "reversal not allowed"},
+		{"ExceedsAmount", fmt.Errorf("%w: 30.00 requested, 20.00 left", repository.ErrReversalExceedsAmount), http.StatusUnprocessableEntity, "reversal exceeds amount"},
+		{"InsufficientCreditLimit", repository.ErrInsufficientCreditLimit, http.StatusUnprocessableEntity, "insufficient credit limit"},
+		{"Unexpected", errors.New("connection reset"), http.StatusInternalServerError, "could not reverse transaction"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockTransactionUseCase(ctrl)
+			hdlr := NewTransactionHandler(mockUseCase)
+
+			router := chi.NewRouter()
+			router.Post("/transactions/{id}/reversal", hdlr.ReverseTransaction)
+
+			amount := domain.MustParseMoney("30")
+			reqBody, _ := json.Marshal(dto.ReverseTransactionRequest{Amount: &amount})
+			req := httptest.NewRequest(http.MethodPost, "/transactions/7/reversal", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			mockUseCase.EXPECT().
+				ReverseTransaction(gomock.Any(), int64(7), &amount).
+				Return(int64(0), tt.err)
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.statusCode, w.Code)
+			var errorResponse response.ErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.message, errorResponse.Error)
+		})
+	}
+}
+
+func TestTransactionHandler_ListTransactions_WhenTransactionsExist_ShouldReturn200(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTransactionUseCase(ctrl)
+	hdlr := NewTransactionHandler(mockUseCase)
+
+	router := chi.NewRouter()
+	router.Get("/accounts/{id}/transactions", hdlr.ListTransactions)
+
+	eventDate := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	transaction := domain.NewTransaction(1, domain.CompraAVista, domain.MustParseMoney("-50"), eventDate)
+	transaction.SetID(7)
+	next := &domain.TransactionCursor{EventDate: eventDate, ID: 7}
+	operationType := domain.CompraAVista
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mockUseCase.EXPECT().
+		ListTransactions(gomock.Any(), domain.TransactionFilter{AccountID: 1, OperationTypeID: &operationType, From: &from, Limit: 1}).
+		Return([]domain.Transaction{transaction}, next, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/1/transactions?limit=1&operation_type_id=1&from=2025-01-01T00:00:00Z", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.ListTransactionsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Transactions, 1)
+	assert.Equal(t, int64(7), resp.Transactions[0].ID)
+	assert.Equal(t, domain.MustParseMoney("-50"), resp.Transactions[0].Amount)
+	assert.Equal(t, dto.EncodeTransactionCursor(*next), resp.NextCursor)
+
+	cursor, err := dto.DecodeTransactionCursor(resp.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, *next, cursor)
+}
+
+func TestTransactionHandler_ListTransactions_WhenInvalidQuery_ShouldReturn400(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTransactionUseCase(ctrl)
+	hdlr := NewTransactionHandler(mockUseCase)
+
+	router := chi.NewRouter()
+	router.Get("/accounts/{id}/transactions", hdlr.ListTransactions)
+
+	testCases := []struct {
+		name                string
+		url                 string
+		expectedDescription string
+	}{
+		{name: "When limit is too large", url: "/accounts/1/transactions?limit=101", expectedDescription: "limit must be between 1 and 100"},
+		{name: "When cursor is invalid", url: "/accounts/1/transactions?cursor=not-a-cursor", expectedDescription: "invalid cursor"},
+		{name: "When from is invalid", url: "/accounts/1/transactions?from=yesterday", expectedDescription: "from must be an RFC3339 timestamp"},
+		{name: "When range is empty", url: "/accounts/1/transactions?from=2025-02-01T00:00:00Z&to=2025-01-01T00:00:00Z", expectedDescription: "from must be before to"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			var errorResponse response.ErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+			assert.NoError(t, err)
+			assert.Equal(t, "invalid query parameters", errorResponse.Error)
+			assert.Equal(t, tc.expectedDescription, errorResponse.Description)
+		})
+	}
+}
+
+func TestTransactionHandler_ListTransactions_WhenFailedToListTransactions_ShouldReturn500(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTransactionUseCase(ctrl)
+	hdlr := NewTransactionHandler(mockUseCase)
+
+	router := chi.NewRouter()
+	router.Get("/accounts/{id}/transactions", hdlr.ListTransactions)
+
+	mockUseCase.EXPECT().
+		ListTransactions(gomock.Any(), gomock.Any()).
+		Return(nil, nil, errors.New("failed to list transactions"))
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/1/transactions", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestTransactionHandler_CreateTransaction_WhenInstallmentsInformed_ShouldCreateInstallmentPurchase(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTransactionUseCase(ctrl)
+	hdlr := NewTransactionHandler(mockUseCase)
+
+	amount := domain.MustParseMoney("300")
+
+	router := chi.NewRouter()
+	router.Post("/transactions", hdlr.CreateTransaction)
+
+	reqBody, _ := json.Marshal(dto.CreateTransactionRequest{AccountID: 1, OperationTypeID: int(domain.CompraParcelada), Amount: amount, Installments: 3})
+	req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	mockUseCase.EXPECT().
+		CreateInstallmentPurchase(gomock.Any(), int64(1), amount, 3).
+		Return(int64(5), nil)
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response dto.CreateTransactionResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), response.ID)
+}
+
+func TestTransactionHandler_CreateTransaction_WhenInvalidInstallments_ShouldReturn422(t *testing.T) {
+	tests := []struct {
+		name            string
+		operationTypeID int
+		installments    int
+		description     string
+	}{
+		{"not an installment purchase", int(domain.CompraAVista), 3, "installments are only accepted for operation type 2"},
+		{"too many installments", int(domain.CompraParcelada), domain.MaxInstallments + 1, "installments must be between 1 and 24"},
+		{"negative installments", int(domain.CompraParcelada), -1, "installments must be between 1 and 24"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockTransactionUseCase(ctrl)
+			hdlr := NewTransactionHandler(mockUseCase)
+
+			router := chi.NewRouter()
+			router.Post("/transactions", hdlr.CreateTransaction)
+
+			reqBody, _ := json.Marshal(dto.CreateTransactionRequest{AccountID: 1, OperationTypeID: tt.operationTypeID, Amount: domain.MustParseMoney("100"), Installments: tt.installments})
+			req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+			var errorResponse response.ErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.description, errorResponse.Description)
+		})
+	}
+}
+
+func TestTransactionHandler_ListInstallments_WhenTransactionExists_ShouldReturn200(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTransactionUseCase(ctrl)
+	hdlr := NewTransactionHandler(mockUseCase)
+
+	installments := []domain.Installment{
+		{ID: 1, TransactionID: 7, Number: 1, Amount: domain.MustParseMoney("50.01"), DueDate: time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, TransactionID: 7, Number: 2, Amount: domain.MustParseMoney("50"), DueDate: time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)},
+	}
+	mockUseCase.EXPECT().
+		ListInstallments(gomock.Any(), int64(7)).
+		Return(installments, nil)
+
+	router := chi.NewRouter()
+	router.Get("/transactions/{id}/installments", hdlr.ListInstallments)
+	req := httptest.NewRequest(http.MethodGet, "/transactions/7/installments", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.ListInstallmentsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), resp.TransactionID)
+	assert.Len(t, resp.Installments, 2)
+	assert.Equal(t, domain.MustParseMoney("50.01"), resp.Installments[0].Amount)
+	assert.Equal(t, "2025-03-10", resp.Installments[1].DueDate)
+}
+
+func TestTransactionHandler_ListInstallments_WhenTransactionNotFound_ShouldReturn404(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTransactionUseCase(ctrl)
+	hdlr := NewTransactionHandler(mockUseCase)
+
+	mockUseCase.EXPECT().
+		ListInstallments(gomock.Any(), int64(7)).
+		Return(nil, repository.ErrTransactionNotFound)
+
+	router := chi.NewRouter()
+	router.Get("/transactions/{id}/installments", hdlr.ListInstallments)
+	req := httptest.NewRequest(http.MethodGet, "/transactions/7/installments", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "transaction not found", errorResponse.Error)
+}
+
+func TestTransactionHandler_GetTransaction_WhenTransactionExists_ShouldReturn200(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTransactionUseCase(ctrl)
+	hdlr := NewTransactionHandler(mockUseCase)
+
+	eventDate := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	transaction := domain.NewTransaction(1, domain.CompraAVista, domain.MustParseMoney("-50"), eventDate)
+	transaction.SetID(7)
+	transaction.SetOperationTypeDescription("COMPRA A VISTA")
+	transaction.SetCreatedAt(eventDate.Add(time.Second))
+
+	mockUseCase.EXPECT().
+		GetTransaction(gomock.Any(), int64(7)).
+		Return(&transaction, nil)
+
+	router := chi.NewRouter()
+	router.Get("/transactions/{id}", hdlr.GetTransaction)
+	req := httptest.NewRequest(http.MethodGet, "/transactions/7", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.GetTransactionResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), resp.ID)
+	assert.Equal(t, int64(1), resp.AccountID)
+	assert.Equal(t, 1, resp.OperationTypeID)
+	assert.Equal(t, "COMPRA A VISTA", resp.OperationTypeDescription)
+	assert.Equal(t, domain.MustParseMoney("-50"), resp.Amount)
+	assert.Equal(t, eventDate, resp.EventDate)
+	assert.Equal(t, eventDate.Add(time.Second), resp.CreatedAt)
+}
+
+func TestTransactionHandler_GetTransaction_WhenNotFoundTransaction_ShouldReturn404(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTransactionUseCase(ctrl)
+	hdlr := NewTransactionHandler(mockUseCase)
+
+	mockUseCase.EXPECT().
+		GetTransaction(gomock.Any(), int64(7)).
+		Return(nil, repository.ErrTransactionNotFound)
+
+	router := chi.NewRouter()
+	router.Get("/transactions/{id}", hdlr.GetTransaction)
+	req := httptest.NewRequest(http.MethodGet, "/transactions/7", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, errorResponse.StatusCode)
+	assert.Equal(t, "transaction not found", errorResponse.Error)
+	assert.Equal(t, "transaction not found", errorResponse.Description)
+}
+
+func TestTransactionHandler_GetTransaction_WhenFailedToGetTransaction_ShouldReturn500(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTransactionUseCase(ctrl)
+	hdlr := NewTransactionHandler(mockUseCase)
+
+	mockUseCase.EXPECT().
+		GetTransaction(gomock.Any(), int64(7)).
+		Return(nil, errors.New("could not get transaction"))
+
+	router := chi.NewRouter()
+	router.Get("/transactions/{id}", hdlr.GetTransaction)
+	req := httptest.NewRequest(http.MethodGet, "/transactions/7", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestTransactionHandler_GetTransaction_WhenInvalidID_ShouldReturn400(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTransactionUseCase(ctrl)
+	hdlr := NewTransactionHandler(mockUseCase)
+
+	router := chi.NewRouter()
+	router.Get("/transactions/{id}", hdlr.GetTransaction)
+	req := httptest.NewRequest(http.MethodGet, "/transactions/abc", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "could not parse id", errorResponse.Error)
+}
+
+func TestTransactionHandler_GetAccountBalance_WhenAccountExists_ShouldReturn200(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTransactionUseCase(ctrl)
+	hdlr := NewTransactionHandler(mockUseCase)
+
+	asOf := time.Date(2025, 1, 31, 23, 59, 59, 0, time.UTC)
+	lastTransactionAt := time.Date(2025, 1, 30, 10, 0, 0, 0, time.UTC)
+
+	mockUseCase.EXPECT().
+		GetAccountBalance(gomock.Any(), int64(1), &asOf).
+		Return(&domain.AccountBalance{
+			AccountID:         1,
+			Balance:           domain.MustParseMoney("-70"),
+			Debits:            domain.MustParseMoney("120"),
+			Credits:           domain.MustParseMoney("50"),
+			LastTransactionAt: &lastTransactionAt,
+			AsOf:              &asOf,
+		}, nil)
+
+	router := chi.NewRouter()
+	router.Get("/accounts/{id}/balance", hdlr.GetAccountBalance)
+	req := httptest.NewRequest(http.MethodGet, "/accounts/1/balance?as_of=2025-01-31T23:59:59Z", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{
+		"account_id": 1,
+		"balance": -70.00,
+		"total_debits": 120.00,
+		"total_credits": 50.00,
+		"last_transaction_at": "2025-01-30T10:00:00Z",
+		"as_of": "2025-01-31T23:59:59Z"
+	}`, w.Body.String())
+}
+
+func TestTransactionHandler_GetAccountBalance_WhenInvalidAsOf_ShouldReturn400(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTransactionUseCase(ctrl)
+	hdlr := NewTransactionHandler(mockUseCase)
+
+	router := chi.NewRouter()
+	router.Get("/accounts/{id}/balance", hdlr.GetAccountBalance)
+	req := httptest.NewRequest(http.MethodGet, "/accounts/1/balance?as_of=yesterday", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "as_of must be an RFC3339 timestamp", errorResponse.Description)
+}
+
+func TestTransactionHandler_GetAccountBalance_WhenAccountNotFound_ShouldReturn404(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTransactionUseCase(ctrl)
+	hdlr := NewTransactionHandler(mockUseCase)
+
+	mockUseCase.EXPECT().
+		GetAccountBalance(gomock.Any(), int64(1), nil).
+		Return(nil, repository.ErrAccountNotFound)
+
+	router := chi.NewRouter()
+	router.Get("/accounts/{id}/balance", hdlr.GetAccountBalance)
+	req := httptest.NewRequest(http.MethodGet, "/accounts/1/balance", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/api/response"
+	"github.com/VieiraVitor/transaction-flow/internal/application/usecase"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+)
+
+type TransferHandler struct {
+	useCase usecase.TransactionUseCase
+}
+
+func NewTransferHandler(useCase usecase.TransactionUseCase) *TransferHandler {
+	return &TransferHandler{useCase: useCase}
+}
+
+// CreateTransfer godoc
+// @Summary Transfer between accounts
+// @Description Debits one account and credits another in a single operation: either both legs are recorded or neither is
+// @Tags Transfers
+// @Accept  json
+// @Produce  json
+// @Param transfer body dto.CreateTransferRequest true "Transfer Request"
+// @Param Idempotency-Key header string false "Replays the recorded response when the request is retried with the same key"
+// @Success 201 {object} dto.TransferResponse "Transfer Created"
+// @Failure 400 {object} response.ErrorResponse "Invalid Request"
+// @Failure 409 {object} response.ErrorResponse "Request In Progress"
+// @Failure 422 {object} response.ErrorResponse "Validation Failed, Account Not Found, Account Blocked or Closed, Insufficient Credit Limit or Idempotency Key Reused"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /transfers [post]
+func (h *TransferHandler) CreateTransfer(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateTransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendErrorResponse(w, http.StatusBadRequest, "invalid request", fmt.Sprintf("malformed request :%v", err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.SendErrorResponse(w, http.StatusUnprocessableEntity, "validation failed", err.Error())
+		return
+	}
+
+	transfer, err := h.useCase.CreateTransfer(r.Context(), req.FromAccountID, req.ToAccountID, req.Amount)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrTransactionAccountNotFound) || errors.Is(err, repository.ErrAccountNotFound):
+			response.SendErrorResponse(w, http.StatusUnprocessableEntity, "account not found", err.Error())
+		case errors.Is(err, domain.ErrInvalidTransfer):
+			response.SendErrorResponse(w, http.StatusUnprocessableEntity, "validation failed", err.Error())
+		case errors.Is(err, repository.ErrInsufficientCreditLimit):
+			response.SendErrorResponse(w, http.StatusUnprocessableEntity, "insufficient credit limit", err.Error())
+		default:
+			if sendAccountStatusError(w, err) || sendConstraintError(w, err) {
+				return
+			}
+			response.SendErrorResponse(w, http.StatusInternalServerError, "could not create transfer", err.Error())
+		}
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusCreated, dto.NewTransferResponse(transfer))
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/api/response"
+	"github.com/VieiraVitor/transaction-flow/internal/application/usecase"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/VieiraVitor/transaction-flow/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferHandler_CreateTransfer_WhenValidInput_ShouldReturn201(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTransactionUseCase(ctrl)
+	hdlr := NewTransferHandler(mockUseCase)
+
+	amount := domain.MustParseMoney("25.50")
+	createdAt := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	mockUseCase.EXPECT().
+		CreateTransfer(gomock.Any(), int64(1), int64(2), amount).
+		Return(&domain.Transfer{ID: 4, FromAccountID: 1, ToAccountID: 2, Amount: amount, DebitTransactionID: 10, CreditTransactionID: 11, CreatedAt: createdAt}, nil)
+
+	router := chi.NewRouter()
+	router.Post("/transfers", hdlr.CreateTransfer)
+
+	reqBody, _ := json.Marshal(dto.CreateTransferRequest{FromAccountID: 1, ToAccountID: 2, Amount: amount})
+	req := httptest.NewRequest(http.MethodPost, "/transfers", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp dto.TransferResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), resp.ID)
+	assert.Equal(t, amount, resp.Amount)
+	assert.Equal(t, int64(10), resp.DebitTransactionID)
+	assert.Equal(t, int64(11), resp.CreditTransactionID)
+}
+
+func TestTransferHandler_CreateTransfer_WhenInvalidInput_ShouldReturn422(t *testing.T) {
+	tests := []struct {
+		name    string
+		request dto.CreateTransferRequest
+		message string
+	}{
+		{name: "MissingFromAccount", request: dto.CreateTransferRequest{ToAccountID: 2, Amount: domain.MustParseMoney("10")}, message: "fromAccountID is mandatory"},
+		{name: "SameAccount", request: dto.CreateTransferRequest{FromAccountID: 1, ToAccountID: 1, Amount: domain.MustParseMoney("10")}, message: "fromAccountID and toAccountID must be different"},
+		{name: "NegativeAmount", request: dto.CreateTransferRequest{FromAccountID: 1, ToAccountID: 2, Amount: domain.MustParseMoney("-10")}, message: "amount must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			hdlr := NewTransferHandler(mocks.NewMockTransactionUseCase(ctrl))
+			router := chi.NewRouter()
+			router.Post("/transfers", hdlr.CreateTransfer)
+
+			reqBody, _ := json.Marshal(tt.request)
+			req := httptest.NewRequest(http.MethodPost, "/transfers", bytes.NewReader(reqBody))
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+			var errorResponse response.ErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+			assert.NoError(t, err)
+			assert.Equal(t, "validation failed", errorResponse.Error)
+			assert.Equal(t, tt.message, errorResponse.Description)
+		})
+	}
+}
+
+func TestTransferHandler_CreateTransfer_WhenUseCaseFails_ShouldMapError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedError  string
+	}{
+		{name: "AccountNotFound", err: usecase.ErrTransactionAccountNotFound, expectedStatus: http.StatusUnprocessableEntity, expectedError: "account not found"},
+		{name: "SenderBlocked", err: fmt.Errorf("%w: account 1 only accepts credits", domain.ErrAccountBlocked), expectedStatus: http.StatusUnprocessableEntity, expectedError: "account blocked"},
+		{name: "ReceiverClosed", err: fmt.Errorf("%w: account 2", domain.ErrAccountClosed), expectedStatus: http.StatusUnprocessableEntity, expectedError: "account closed"},
+		{name: "InsufficientCreditLimit", err: repository.ErrInsufficientCreditLimit, expectedStatus: http.StatusUnprocessableEntity, expectedError: "insufficient credit limit"},
+		{name: "UnexpectedError", err: assert.AnError, expectedStatus: http.StatusInternalServerError, expectedError: "could not create transfer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockTransactionUseCase(ctrl)
+			hdlr := NewTransferHandler(mockUseCase)
+			mockUseCase.EXPECT().
+				CreateTransfer(gomock.Any(), int64(1), int64(2), domain.MustParseMoney("10")).
+				Return(nil, tt.err)
+
+			router := chi.NewRouter()
+			router.Post("/transfers", hdlr.CreateTransfer)
+
+			reqBody, _ := json.Marshal(dto.CreateTransferRequest{FromAccountID: 1, ToAccountID: 2, Amount: domain.MustParseMoney("10")})
+			req := httptest.NewRequest(http.MethodPost, "/transfers", bytes.NewReader(reqBody))
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var errorResponse response.ErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedError, errorResponse.Error)
+		})
+	}
+}
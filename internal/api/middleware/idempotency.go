@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/response"
+	"github.com/VieiraVitor/transaction-flow/internal/application/usecase"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+// Custom ResponseWriter to capture the response recorded for an Idempotency-Key
+type idempotentResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (iw *idempotentResponseWriter) WriteHeader(code int) {
+	iw.statusCode = code
+	iw.ResponseWriter.WriteHeader(code)
+}
+
+func (iw *idempotentResponseWriter) Write(p []byte) (int, error) {
+	if iw.statusCode == 0 {
+		iw.statusCode = http.StatusOK
+	}
+	iw.body.Write(p)
+	return iw.ResponseWriter.Write(p)
+}
+
+// NewIdempotencyMiddleware makes the wrapped handler honor the Idempotency-Key header: the
+// first response for a key is recorded, retries with the same payload replay it and a reused
+// key with a different payload is rejected. Requests without the header are not affected.
+func NewIdempotencyMiddleware(useCase usecase.IdempotencyUseCase) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > maxIdempotencyKeyLength {
+				response.SendErrorResponse(w, http.StatusBadRequest, "invalid idempotency key", "Idempotency-Key must have at most 255 characters")
+				return
+			}
+
+			var requestBody []byte
+			if r.Body != nil {
+				var err error
+				requestBody, err = io.ReadAll(r.Body)
+				if err != nil {
+					response.SendErrorResponse(w, http.StatusBadRequest, "invalid request", err.Error())
+					return
+				}
+				r.Body = io.NopCloser(bytes.NewReader(requestBody))
+			}
+
+			// The outcome must be recorded even if the client goes away mid-request.
+			ctx := context.WithoutCancel(r.Context())
+			scope := r.Method + " " + r.URL.Path
+
+			recorded, claimToken, err := useCase.BeginRequest(ctx, scope, key, requestBody)
+			if err != nil {
+				switch {
+				case errors.Is(err, repository.ErrIdempotencyKeyReused):
+					response.SendErrorResponse(w, http.StatusUnprocessableEntity, "idempotency key reused", err.Error())
+				case errors.Is(err, repository.ErrIdempotencyKeyInProgress):
+					response.SendErrorResponse(w, http.StatusConflict, "request in progress", err.Error())
+				default:
+					response.SendErrorResponse(w, http.StatusInternalServerError, "could not process idempotency key", err.Error())
+				}
+				return
+			}
+
+			if recorded != nil {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set(IdempotentReplayedHeader, "true")
+				w.WriteHeader(recorded.StatusCode)
+				_, _ = w.Write(recorded.Body)
+				return
+			}
+
+			// A panicking handler leaves no response to replay, so the key is released for a retry.
+			served := false
+			defer func() {
+				if served {
+					return
+				}
+				if err := useCase.AbortRequest(ctx, scope, key, claimToken); err != nil {
+					logger.Logger.ErrorContext(ctx, "failed to release idempotency key", slog.String("key", key), slog.String("error", err.Error()))
+				}
+			}()
+
+			iw := &idempotentResponseWriter{ResponseWriter: w}
+			next.ServeHTTP(iw, r)
+			served = true
+
+			if iw.statusCode == 0 {
+				iw.statusCode = http.StatusOK
+			}
+
+			err = useCase.CompleteRequest(ctx, scope, key, claimToken, domain.IdempotentResponse{StatusCode: iw.statusCode, Body: iw.body.Bytes()})
+			if err != nil {
+				logger.Logger.ErrorContext(ctx, "failed to record idempotent response", slog.String("key", key), slog.String("error", err.Error()))
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/VieiraVitor/transaction-flow/internal/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newIdempotentRequest(key string, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	return req
+}
+
+func TestIdempotencyMiddleware_WhenNoKey_ShouldCallHandler(t *testing.T) {
+	// Arrange
+	logger.InitLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockIdempotencyUseCase(ctrl)
+	calls := 0
+	handler := NewIdempotencyMiddleware(mockUseCase)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(w, newIdempotentRequest("", `{"amount": 10}`))
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotencyMiddleware_WhenFirstRequest_ShouldRecordResponse(t *testing.T) {
+	// Arrange
+	logger.InitLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockIdempotencyUseCase(ctrl)
+	body := `{"amount": 10}`
+
+	mockUseCase.EXPECT().
+		BeginRequest(gomock.Any(), "POST /transactions", "key-1", []byte(body)).
+		Return(nil, "claim-1", nil)
+	mockUseCase.EXPECT().
+		CompleteRequest(gomock.Any(), "POST /transactions", "key-1", "claim-1", domain.IdempotentResponse{StatusCode: http.StatusCreated, Body: []byte(`{"id":1}`)}).
+		Return(nil)
+
+	handler := NewIdempotencyMiddleware(mockUseCase)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(w, newIdempotentRequest("key-1", body))
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, `{"id":1}`, w.Body.String())
+	assert.Empty(t, w.Header().Get(IdempotentReplayedHeader))
+}
+
+func TestIdempotencyMiddleware_WhenReplayed_ShouldReturnRecordedResponse(t *testing.T) {
+	// Arrange
+	logger.InitLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockIdempotencyUseCase(ctrl)
+
+	mockUseCase.EXPECT().
+		BeginRequest(gomock.Any(), gomock.Any(), "key-1", gomock.Any()).
+		Return(&domain.IdempotentResponse{StatusCode: http.StatusCreated, Body: []byte(`{"id":1}`)}, "", nil)
+
+	handler := NewIdempotencyMiddleware(mockUseCase)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler must not be called for a replay")
+	}))
+
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(w, newIdempotentRequest("key-1", `{"amount": 10}`))
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, `{"id":1}`, w.Body.String())
+	assert.Equal(t, "true", w.Header().Get(IdempotentReplayedHeader))
+}
+
+func TestIdempotencyMiddleware_WhenKeyErrors_ShouldReturnErrorStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"reused with a different payload", repository.ErrIdempotencyKeyReused, http.StatusUnprocessableEntity},
+		{"still in progress", repository.ErrIdempotencyKeyInProgress, http.StatusConflict},
+		{"unexpected failure", assert.AnError, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			logger.InitLogger()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockIdempotencyUseCase(ctrl)
+			mockUseCase.EXPECT().
+				BeginRequest(gomock.Any(), gomock.Any(), "key-1", gomock.Any()).
+				Return(nil, "", tt.err)
+
+			handler := NewIdempotencyMiddleware(mockUseCase)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Fatal("handler must not be called")
+			}))
+
+			w := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(w, newIdempotentRequest("key-1", `{"amount": 10}`))
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestIdempotencyMiddleware_WhenHandlerPanics_ShouldReleaseKey(t *testing.T) {
+	// Arrange
+	logger.InitLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockIdempotencyUseCase(ctrl)
+	mockUseCase.EXPECT().
+		BeginRequest(gomock.Any(), gomock.Any(), "key-1", gomock.Any()).
+		Return(nil, "claim-1", nil)
+	mockUseCase.EXPECT().
+		AbortRequest(gomock.Any(), "POST /transactions", "key-1", "claim-1").
+		Return(nil)
+
+	handler := RecoverMiddleware(NewIdempotencyMiddleware(mockUseCase)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("test panic")
+	})))
+
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(w, newIdempotentRequest("key-1", `{"amount": 10}`))
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Custom ResponseWriter to capture status code and response body
@@ -31,7 +32,13 @@ func (rl *responseLogger) Write(p []byte) (int, error) {
 func LoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		startTime := time.Now()
-		traceID := uuid.NewString()
+		// Requests are traced by TracingMiddleware; the random traceID only covers requests
+		// served without it.
+		ctx := r.Context()
+		if !trace.SpanContextFromContext(ctx).IsValid() {
+			ctx = logger.WithTraceID(ctx, uuid.NewString())
+			r = r.WithContext(ctx)
+		}
 
 		var reqBody bytes.Buffer
 		if r.Body != nil && r.Method != http.MethodGet {
@@ -42,8 +49,7 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 
 		respLogger := &responseLogger{ResponseWriter: w}
 
-		logger.Logger.Info("Request received",
-			slog.String("traceID", traceID),
+		logger.Logger.InfoContext(ctx, "Request received",
 			slog.String("method", r.Method),
 			slog.String("path", r.URL.Path),
 			slog.String("requestBody", reqBody.String()),
@@ -51,8 +57,7 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 
 		next.ServeHTTP(respLogger, r)
 
-		logger.Logger.Info("Request finished",
-			slog.String("traceID", traceID),
+		logger.Logger.InfoContext(ctx, "Request finished",
 			slog.String("method", r.Method),
 			slog.String("path", r.URL.Path),
 			slog.String("status", http.StatusText(respLogger.statusCode)),
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoggingMiddleware_ShouldStoreTraceIDInRequestContext(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	logger.Logger = slog.New(logger.NewContextHandler(slog.NewJSONHandler(&output, nil)))
+
+	var traceID string
+	handler := LoggingMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceID = logger.TraceID(r.Context())
+		logger.Logger.ErrorContext(r.Context(), "failed inside handler")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(w, req)
+
+	// Assert
+	assert.NotEmpty(t, traceID)
+	assert.Equal(t, 3, bytes.Count(output.Bytes(), []byte(`"traceID":"`+traceID+`"`)))
+}
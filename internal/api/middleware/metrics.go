@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/infra/metrics"
+	"github.com/go-chi/chi/v5"
+)
+
+// Custom ResponseWriter to capture the status code reported in the metrics
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (sr *statusRecorder) WriteHeader(code int) {
+	sr.statusCode = code
+	sr.ResponseWriter.WriteHeader(code)
+}
+
+// MetricsMiddleware counts the requests and observes their latency by route pattern, so
+// /accounts/1 and /accounts/2 share a series. Unmatched paths are reported as "not_found".
+func MetricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+
+		next.ServeHTTP(recorder, r)
+
+		route := "not_found"
+		if routeContext := chi.RouteContext(r.Context()); routeContext != nil && routeContext.RoutePattern() != "" {
+			route = routeContext.RoutePattern()
+		}
+		status := strconv.Itoa(recorder.statusCode)
+
+		metrics.HTTPRequestsTotal.WithLabelValues(r.Method, route, status).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(r.Method, route, status).Observe(time.Since(startTime).Seconds())
+	})
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/VieiraVitor/transaction-flow/internal/infra/metrics"
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsMiddleware_ShouldCountRequestsByRoutePatternAndStatus(t *testing.T) {
+	// Arrange
+	router := chi.NewRouter()
+	router.Use(MetricsMiddleware)
+	router.Get("/accounts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	counter := metrics.HTTPRequestsTotal.WithLabelValues(http.MethodGet, "/accounts/{id}", "404")
+	before := testutil.ToFloat64(counter)
+
+	// Act
+	for _, path := range []string{"/accounts/1", "/accounts/2"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	// Assert
+	assert.Equal(t, before+2, testutil.ToFloat64(counter))
+}
+
+func TestMetricsMiddleware_WhenRouteDoesNotExist_ShouldReportNotFound(t *testing.T) {
+	// Arrange
+	router := chi.NewRouter()
+	router.Use(MetricsMiddleware)
+	router.Get("/accounts/{id}", func(w http.ResponseWriter, r *http.Request) {})
+	counter := metrics.HTTPRequestsTotal.WithLabelValues(http.MethodGet, "not_found", "404")
+	before := testutil.ToFloat64(counter)
+
+	// Act
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+	// Assert
+	assert.Equal(t, before+1, testutil.ToFloat64(counter))
+}
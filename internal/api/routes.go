@@ -1,42 +1,117 @@
 package api
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/VieiraVitor/transaction-flow/internal/api/handler"
 	"github.com/VieiraVitor/transaction-flow/internal/api/middleware"
 	"github.com/VieiraVitor/transaction-flow/internal/application/usecase"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/metrics"
 	"github.com/go-chi/chi/v5"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
 type Handlers struct {
-	accountHandler     *handler.AccountHandler
-	transactionHandler *handler.TransactionHandler
+	accountHandler       *handler.AccountHandler
+	transactionHandler   *handler.TransactionHandler
+	operationTypeHandler *handler.OperationTypeHandler
+	invoiceHandler       *handler.InvoiceHandler
+	transferHandler      *handler.TransferHandler
+	ledgerHandler        *handler.LedgerHandler
+	authorizationHandler *handler.AuthorizationHandler
+	disputeHandler       *handler.DisputeHandler
+	healthHandler        *handler.HealthHandler
+	idempotency          func(http.Handler) http.Handler
+	timeout              func(http.Handler) http.Handler
 }
 
 func NewHandlers(
 	accountUseCase usecase.AccountUseCase,
 	transactionUseCase usecase.TransactionUseCase,
+	idempotencyUseCase usecase.IdempotencyUseCase,
+	operationTypeUseCase usecase.OperationTypeUseCase,
+	invoiceUseCase usecase.InvoiceUseCase,
+	ledgerUseCase usecase.LedgerUseCase,
+	authorizationUseCase usecase.AuthorizationUseCase,
+	disputeUseCase usecase.DisputeUseCase,
+	healthUseCase usecase.HealthUseCase,
+	requestTimeout time.Duration,
 ) *Handlers {
 	return &Handlers{
-		accountHandler:     handler.NewAccountHandler(accountUseCase),
-		transactionHandler: handler.NewTransactionHandler(transactionUseCase),
+		accountHandler:       handler.NewAccountHandler(accountUseCase),
+		transactionHandler:   handler.NewTransactionHandler(transactionUseCase),
+		operationTypeHandler: handler.NewOperationTypeHandler(operationTypeUseCase),
+		invoiceHandler:       handler.NewInvoiceHandler(invoiceUseCase),
+		transferHandler:      handler.NewTransferHandler(transactionUseCase),
+		ledgerHandler:        handler.NewLedgerHandler(ledgerUseCase),
+		authorizationHandler: handler.NewAuthorizationHandler(authorizationUseCase),
+		disputeHandler:       handler.NewDisputeHandler(disputeUseCase),
+		healthHandler:        handler.NewHealthHandler(healthUseCase),
+		idempotency:          middleware.NewIdempotencyMiddleware(idempotencyUseCase),
+		timeout:              middleware.NewTimeoutMiddleware(requestTimeout),
 	}
 }
 
 func (h *Handlers) NewRoutes() *chi.Mux {
 	r := chi.NewRouter()
 
-	r.Use(middleware.LoggingMiddleware, middleware.RecoverMiddleware)
+	r.Use(middleware.TracingMiddleware, middleware.MetricsMiddleware, middleware.LoggingMiddleware, middleware.RecoverMiddleware)
 
 	r.Get("/swagger/*", httpSwagger.WrapHandler)
+	r.Handle("/metrics", metrics.Handler())
+	r.Get("/healthz", h.healthHandler.Liveness)
+	r.Get("/readyz", h.healthHandler.Readiness)
 
-	r.Route("/accounts", func(r chi.Router) {
-		r.Post("/", h.accountHandler.CreateAccount)
-		r.Get("/{id}", h.accountHandler.GetAccount)
-	})
+	// Only the API routes are bounded by the request timeout: probes and metrics scrapes
+	// have their own deadlines and must not be cut short by it.
+	r.Group(func(r chi.Router) {
+		r.Use(h.timeout)
+
+		r.Route("/accounts", func(r chi.Router) {
+			r.With(h.idempotency).Post("/", h.accountHandler.CreateAccount)
+			r.Get("/{id}", h.accountHandler.GetAccount)
+			r.Patch("/{id}/credit-limit", h.accountHandler.UpdateCreditLimit)
+			r.Patch("/{id}/billing-cycle", h.accountHandler.UpdateBillingCycle)
+			r.Patch("/{id}/status", h.accountHandler.UpdateStatus)
+			r.Get("/{id}/status-history", h.accountHandler.ListStatusChanges)
+			r.Get("/{id}/transactions", h.transactionHandler.ListTransactions)
+			r.Get("/{id}/balance", h.transactionHandler.GetAccountBalance)
+			r.Get("/{id}/invoices", h.invoiceHandler.ListInvoices)
+		})
+
+		r.Route("/transactions", func(r chi.Router) {
+			r.With(h.idempotency).Post("/", h.transactionHandler.CreateTransaction)
+			r.Get("/{id}", h.transactionHandler.GetTransaction)
+			r.Get("/{id}/installments", h.transactionHandler.ListInstallments)
+			r.With(h.idempotency).Post("/{id}/reversal", h.transactionHandler.ReverseTransaction)
+			r.With(h.idempotency).Post("/{id}/disputes", h.disputeHandler.OpenDispute)
+			r.Get("/{id}/disputes", h.disputeHandler.ListDisputes)
+		})
+
+		r.With(h.idempotency).Post("/transfers", h.transferHandler.CreateTransfer)
+
+		r.Route("/authorizations", func(r chi.Router) {
+			r.With(h.idempotency).Post("/", h.authorizationHandler.CreateAuthorization)
+			r.Get("/{id}", h.authorizationHandler.GetAuthorization)
+			r.With(h.idempotency).Post("/{id}/capture", h.authorizationHandler.CaptureAuthorization)
+			r.Post("/{id}/void", h.authorizationHandler.VoidAuthorization)
+		})
+
+		r.Route("/disputes", func(r chi.Router) {
+			r.Get("/{id}", h.disputeHandler.GetDispute)
+			r.With(h.idempotency).Post("/{id}/resolve", h.disputeHandler.ResolveDispute)
+		})
+
+		r.Get("/invoices/{id}", h.invoiceHandler.GetInvoice)
+
+		r.Get("/ledger/trial-balance", h.ledgerHandler.GetTrialBalance)
 
-	r.Route("/transactions", func(r chi.Router) {
-		r.Post("/", h.transactionHandler.CreateTransaction)
+		r.Route("/operation-types", func(r chi.Router) {
+			r.Get("/", h.operationTypeHandler.ListOperationTypes)
+			r.Post("/", h.operationTypeHandler.CreateOperationType)
+			r.Patch("/{id}", h.operationTypeHandler.UpdateOperationType)
+		})
 	})
 
 	return r
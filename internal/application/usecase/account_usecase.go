@@ -2,9 +2,11 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/VieiraVitor/transaction-flow/internal/domain"
 	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/tracing"
 )
 
 type accountUseCase struct {
@@ -17,11 +19,76 @@ func NewAccountUseCase(repo repository.AccountRepository) AccountUseCase {
 	}
 }
 
-func (a *accountUseCase) CreateAccount(ctx context.Context, documentNumber string) (int64, error) {
-	account := domain.NewAccount(documentNumber)
+// CreateAccount stores the document number without punctuation, so the same CPF or CNPJ
+// written in different formats maps to a single account.
+func (a *accountUseCase) CreateAccount(ctx context.Context, documentNumber string, availableCreditLimit domain.Money) (int64, error) {
+	ctx, span := tracing.StartSpan(ctx, "AccountUseCase.CreateAccount")
+	defer span.End()
+
+	normalized, documentType, err := domain.NormalizeDocument(documentNumber)
+	if err != nil {
+		return 0, err
+	}
+
+	account := domain.NewAccount(normalized, availableCreditLimit)
+	account.SetDocumentType(documentType)
 	return a.repo.CreateAccount(ctx, account)
 }
 
 func (a *accountUseCase) GetAccount(ctx context.Context, accountID int64) (*domain.Account, error) {
+	ctx, span := tracing.StartSpan(ctx, "AccountUseCase.GetAccount")
+	defer span.End()
+
 	return a.repo.GetAccount(ctx, accountID)
 }
+
+// UpdateCreditLimit changes the total credit limit of the account. The available limit
+// follows the change without giving back what is already in use.
+func (a *accountUseCase) UpdateCreditLimit(ctx context.Context, accountID int64, creditLimit domain.Money) error {
+	ctx, span := tracing.StartSpan(ctx, "AccountUseCase.UpdateCreditLimit")
+	defer span.End()
+
+	return a.repo.UpdateCreditLimit(ctx, accountID, creditLimit)
+}
+
+// UpdateBillingCycle only affects invoices that were not closed yet. Installments that were
+// not billed yet move to the due dates of the new cycle, one per invoice.
+func (a *accountUseCase) UpdateBillingCycle(ctx context.Context, accountID int64, closingDay int, dueDay int) error {
+	ctx, span := tracing.StartSpan(ctx, "AccountUseCase.UpdateBillingCycle")
+	defer span.End()
+
+	billingCycle, err := domain.NewBillingCycle(closingDay, dueDay)
+	if err != nil {
+		return err
+	}
+	return a.repo.UpdateBillingCycle(ctx, accountID, billingCycle)
+}
+
+// UpdateStatus moves the account to status, recording who changed it and why. The change
+// fails with ErrAccountStatusChanged if the status is changed by someone else meanwhile.
+func (a *accountUseCase) UpdateStatus(ctx context.Context, accountID int64, status domain.AccountStatus, reason string, actor string) error {
+	ctx, span := tracing.StartSpan(ctx, "AccountUseCase.UpdateStatus")
+	defer span.End()
+
+	account, err := a.repo.GetAccount(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	change, err := account.ChangeStatus(status, reason, actor, time.Now())
+	if err != nil {
+		return err
+	}
+	return a.repo.UpdateStatus(ctx, change)
+}
+
+// ListStatusChanges returns ErrAccountNotFound for unknown accounts instead of an empty history.
+func (a *accountUseCase) ListStatusChanges(ctx context.Context, accountID int64) ([]domain.AccountStatusChange, error) {
+	ctx, span := tracing.StartSpan(ctx, "AccountUseCase.ListStatusChanges")
+	defer span.End()
+
+	if _, err := a.repo.GetAccount(ctx, accountID); err != nil {
+		return nil, err
+	}
+	return a.repo.ListStatusChanges(ctx, accountID)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
 	"github.com/VieiraVitor/transaction-flow/internal/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -21,21 +22,44 @@ func TestAccountUseCase_CreateAccount_WhenValidInput_ShouldReturnID(t *testing.T
 	accountUsecase := NewAccountUseCase(mockRepo)
 
 	ctx := context.Background()
-	documentNumber := "123456789"
+	documentNumber := "123.456.789-09"
 	expectedID := int64(1)
 
 	mockRepo.EXPECT().
 		CreateAccount(gomock.Any(), gomock.Any()).
-		Return(expectedID, nil)
+		DoAndReturn(func(_ context.Context, account *domain.Account) (int64, error) {
+			assert.Equal(t, "12345678909", account.DocumentNumber())
+			assert.Equal(t, domain.DocumentTypeCPF, account.DocumentType())
+			return expectedID, nil
+		})
 
 	// Act
-	id, err := accountUsecase.CreateAccount(ctx, documentNumber)
+	id, err := accountUsecase.CreateAccount(ctx, documentNumber, domain.MustParseMoney("1000"))
 
 	// Assert
 	assert.NoError(t, err)
 	assert.Equal(t, expectedID, id)
 }
 
+func TestAccountUseCase_CreateAccount_WhenDocumentIsInvalid_ShouldReturnErrInvalidDocument(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAccountRepository(ctrl)
+	accountUsecase := NewAccountUseCase(mockRepo)
+
+	ctx := context.Background()
+
+	// Act
+	id, err := accountUsecase.CreateAccount(ctx, "123.456.789-00", domain.MustParseMoney("1000"))
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidDocument)
+	assert.Equal(t, "invalid document number: CPF check digits do not match", err.Error())
+	assert.Equal(t, int64(0), id)
+}
+
 func TestAccountUseCase_CreateAccount_WhenFailedToCreateAccount_ShouldReturnError(t *testing.T) {
 	// Arrange
 	ctrl := gomock.NewController(t)
@@ -45,7 +69,7 @@ func TestAccountUseCase_CreateAccount_WhenFailedToCreateAccount_ShouldReturnErro
 	accountUsecase := NewAccountUseCase(mockRepo)
 
 	ctx := context.Background()
-	documentNumber := "123456789"
+	documentNumber := "123.456.789-09"
 	expectedError := errors.New("failed to create account")
 
 	mockRepo.EXPECT().
@@ -53,7 +77,7 @@ func TestAccountUseCase_CreateAccount_WhenFailedToCreateAccount_ShouldReturnErro
 		Return(int64(0), expectedError)
 
 	// Act
-	id, err := accountUsecase.CreateAccount(ctx, documentNumber)
+	id, err := accountUsecase.CreateAccount(ctx, documentNumber, domain.MustParseMoney("1000"))
 
 	// Assert
 	assert.Error(t, err)
@@ -69,7 +93,7 @@ func TestAccountUseCase_GetAccount_WhenValidInput_ShouldReturnAccount(t *testing
 	mockRepo := mocks.NewMockAccountRepository(ctrl)
 	accountUsecase := NewAccountUseCase(mockRepo)
 	ctx := context.Background()
-	accountExpected := domain.NewAccount("123456789")
+	accountExpected := domain.NewAccount("123456789", domain.MustParseMoney("1000"))
 	accountExpected.SetID(1)
 	accountExpected.SetCreatedAt(time.Now())
 
@@ -85,6 +109,7 @@ func TestAccountUseCase_GetAccount_WhenValidInput_ShouldReturnAccount(t *testing
 	assert.NotNil(t, account)
 	assert.Equal(t, account.ID(), accountExpected.ID())
 	assert.Equal(t, account.DocumentNumber(), accountExpected.DocumentNumber())
+	assert.Equal(t, account.AvailableCreditLimit(), accountExpected.AvailableCreditLimit())
 	assert.Equal(t, account.CreatedAt(), accountExpected.CreatedAt())
 }
 
@@ -112,3 +137,134 @@ func TestAccountUseCase_GetAccount_WhenFailedToGetAccount_ShouldReturnError(t *t
 	assert.Nil(t, result)
 	assert.Equal(t, expectedError, err)
 }
+
+func TestAccountUseCase_UpdateCreditLimit_WhenValidInput_ShouldReturnNil(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAccountRepository(ctrl)
+	accountUsecase := NewAccountUseCase(mockRepo)
+
+	ctx := context.Background()
+
+	mockRepo.EXPECT().
+		UpdateCreditLimit(gomock.Any(), int64(1), domain.MustParseMoney("1500")).
+		Return(nil)
+
+	// Act
+	err := accountUsecase.UpdateCreditLimit(ctx, 1, domain.MustParseMoney("1500"))
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestAccountUseCase_UpdateBillingCycle_WhenValidInput_ShouldReturnNil(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAccountRepository(ctrl)
+	accountUsecase := NewAccountUseCase(mockRepo)
+
+	ctx := context.Background()
+
+	mockRepo.EXPECT().
+		UpdateBillingCycle(gomock.Any(), int64(1), domain.BillingCycle{ClosingDay: 20, DueDay: 5}).
+		Return(nil)
+
+	// Act
+	err := accountUsecase.UpdateBillingCycle(ctx, 1, 20, 5)
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestAccountUseCase_UpdateBillingCycle_WhenDaysAreInvalid_ShouldReturnErrInvalidBillingCycle(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAccountRepository(ctrl)
+	accountUsecase := NewAccountUseCase(mockRepo)
+
+	ctx := context.Background()
+
+	// Act
+	err := accountUsecase.UpdateBillingCycle(ctx, 1, 31, 5)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidBillingCycle)
+}
+
+func TestAccountUseCase_UpdateStatus_WhenTransitionIsAllowed_ShouldRecordChange(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAccountRepository(ctrl)
+	accountUsecase := NewAccountUseCase(mockRepo)
+
+	ctx := context.Background()
+	account := domain.NewAccount("12345678909", domain.MustParseMoney("1000"))
+	account.SetID(1)
+
+	mockRepo.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(account, nil)
+	mockRepo.EXPECT().
+		UpdateStatus(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, change domain.AccountStatusChange) error {
+			assert.Equal(t, int64(1), change.AccountID)
+			assert.Equal(t, domain.AccountStatusActive, change.FromStatus)
+			assert.Equal(t, domain.AccountStatusBlocked, change.ToStatus)
+			assert.Equal(t, "fraud suspicion", change.Reason)
+			assert.Equal(t, "analyst@bank", change.Actor)
+			return nil
+		})
+
+	// Act
+	err := accountUsecase.UpdateStatus(ctx, 1, domain.AccountStatusBlocked, "fraud suspicion", "analyst@bank")
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestAccountUseCase_UpdateStatus_WhenAccountIsClosed_ShouldReturnErrInvalidAccountStatusTransition(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAccountRepository(ctrl)
+	accountUsecase := NewAccountUseCase(mockRepo)
+
+	ctx := context.Background()
+	account := domain.NewAccount("12345678909", domain.MustParseMoney("1000"))
+	account.SetStatus(domain.AccountStatusClosed)
+
+	mockRepo.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(account, nil)
+
+	// Act
+	err := accountUsecase.UpdateStatus(ctx, 1, domain.AccountStatusActive, "reopen", "analyst@bank")
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidAccountStatusTransition)
+}
+
+func TestAccountUseCase_ListStatusChanges_WhenAccountNotFound_ShouldReturnError(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAccountRepository(ctrl)
+	accountUsecase := NewAccountUseCase(mockRepo)
+
+	ctx := context.Background()
+
+	mockRepo.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(nil, repository.ErrAccountNotFound)
+
+	// Act
+	changes, err := accountUsecase.ListStatusChanges(ctx, 1)
+
+	// Assert
+	assert.ErrorIs(t, err, repository.ErrAccountNotFound)
+	assert.Nil(t, changes)
+}
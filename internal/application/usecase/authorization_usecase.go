@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/metrics"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/tracing"
+)
+
+type authorizationUseCase struct {
+	repo        repository.AuthorizationRepository
+	accountRepo repository.AccountRepository
+	ttl         time.Duration
+}
+
+func NewAuthorizationUseCase(repo repository.AuthorizationRepository, accountRepo repository.AccountRepository, ttl time.Duration) AuthorizationUseCase {
+	return &authorizationUseCase{
+		repo:        repo,
+		accountRepo: accountRepo,
+		ttl:         ttl,
+	}
+}
+
+// CreateAuthorization holds amount against the account's available credit limit until the
+// authorization is captured, voided or expires after the configured TTL.
+func (a *authorizationUseCase) CreateAuthorization(ctx context.Context, accountID int64, amount domain.Money) (*domain.Authorization, error) {
+	ctx, span := tracing.StartSpan(ctx, "AuthorizationUseCase.CreateAuthorization")
+	defer span.End()
+
+	authorization, err := domain.NewAuthorization(accountID, amount, time.Now(), a.ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := ensureAccountExists(ctx, a.accountRepo, accountID); err != nil {
+		return nil, err
+	}
+
+	id, err := a.repo.CreateAuthorization(ctx, authorization)
+	if err != nil {
+		return nil, err
+	}
+	authorization.ID = id
+	return &authorization, nil
+}
+
+func (a *authorizationUseCase) GetAuthorization(ctx context.Context, authorizationID int64) (*domain.Authorization, error) {
+	ctx, span := tracing.StartSpan(ctx, "AuthorizationUseCase.GetAuthorization")
+	defer span.End()
+
+	return a.repo.GetAuthorization(ctx, authorizationID)
+}
+
+// CaptureAuthorization posts a CompraAVista for amount, or for the whole authorization when
+// amount is nil, and releases what was held. An authorization is captured only once.
+func (a *authorizationUseCase) CaptureAuthorization(ctx context.Context, authorizationID int64, amount *domain.Money) (*domain.Authorization, error) {
+	ctx, span := tracing.StartSpan(ctx, "AuthorizationUseCase.CaptureAuthorization")
+	defer span.End()
+
+	authorization, err := a.repo.GetAuthorization(ctx, authorizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	purchase, err := authorization.Capture(amount, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := ensureAccountExists(ctx, a.accountRepo, authorization.AccountID); err != nil {
+		return nil, err
+	}
+
+	transactionID, err := a.repo.CaptureAuthorization(ctx, *authorization, purchase)
+	if err != nil {
+		return nil, err
+	}
+	metrics.RecordTransactions(purchase)
+	authorization.TransactionID = &transactionID
+	return authorization, nil
+}
+
+// VoidAuthorization cancels a pending authorization and releases its hold.
+func (a *authorizationUseCase) VoidAuthorization(ctx context.Context, authorizationID int64) (*domain.Authorization, error) {
+	ctx, span := tracing.StartSpan(ctx, "AuthorizationUseCase.VoidAuthorization")
+	defer span.End()
+
+	authorization, err := a.repo.GetAuthorization(ctx, authorizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorization.Void(time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := a.repo.ReleaseAuthorization(ctx, *authorization); err != nil {
+		return nil, err
+	}
+	return authorization, nil
+}
+
+// ExpireAuthorizations releases the holds of the pending authorizations past their expiry.
+// Authorizations captured or voided meanwhile are skipped. It returns how many expired.
+func (a *authorizationUseCase) ExpireAuthorizations(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := tracing.StartSpan(ctx, "AuthorizationUseCase.ExpireAuthorizations")
+	defer span.End()
+
+	authorizations, err := a.repo.ListExpiredAuthorizations(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, authorization := range authorizations {
+		if err := authorization.Expire(now); err != nil {
+			continue
+		}
+
+		if err := a.repo.ReleaseAuthorization(ctx, authorization); err != nil {
+			if !errors.Is(err, domain.ErrAuthorizationNotPending) {
+				logger.Logger.ErrorContext(ctx, "error expiring authorization", slog.Int64("authorizationID", authorization.ID), slog.String("error", err.Error()))
+			}
+			continue
+		}
+		expired++
+	}
+
+	return expired, nil
+}
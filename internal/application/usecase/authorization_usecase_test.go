@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/VieiraVitor/transaction-flow/internal/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorizationUseCase_CreateAuthorization_WhenValidInput_ShouldHoldAmountUntilExpiry(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAuthorizationRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	authorizationUsecase := NewAuthorizationUseCase(mockRepo, mockAccountRepo, 7*24*time.Hour)
+	ctx := context.Background()
+
+	mockAccountRepo.EXPECT().
+		GetAccount(gomock.Any(), int64(1)).
+		Return(domain.NewAccount("12345678900", domain.MustParseMoney("1000")), nil)
+	mockRepo.EXPECT().
+		CreateAuthorization(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, authorization domain.Authorization) (int64, error) {
+			assert.Equal(t, int64(1), authorization.AccountID)
+			assert.Equal(t, domain.MustParseMoney("150"), authorization.Amount)
+			assert.Equal(t, domain.AuthorizationPending, authorization.Status)
+			assert.Equal(t, 7*24*time.Hour, authorization.ExpiresAt.Sub(authorization.CreatedAt))
+			return int64(3), nil
+		})
+
+	// Act
+	authorization, err := authorizationUsecase.CreateAuthorization(ctx, 1, domain.MustParseMoney("150"))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), authorization.ID)
+}
+
+func TestAuthorizationUseCase_CaptureAuthorization_WhenPartialAmount_ShouldPostPurchaseForIt(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAuthorizationRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	authorizationUsecase := NewAuthorizationUseCase(mockRepo, mockAccountRepo, time.Hour)
+	ctx := context.Background()
+
+	pending, _ := domain.NewAuthorization(1, domain.MustParseMoney("150"), time.Now(), time.Hour)
+	pending.ID = 3
+	amount := domain.MustParseMoney("120.50")
+
+	mockRepo.EXPECT().
+		GetAuthorization(gomock.Any(), int64(3)).
+		Return(&pending, nil)
+	mockAccountRepo.EXPECT().
+		GetAccount(gomock.Any(), int64(1)).
+		Return(domain.NewAccount("12345678900", domain.MustParseMoney("1000")), nil)
+	mockRepo.EXPECT().
+		CaptureAuthorization(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, authorization domain.Authorization, purchase domain.Transaction) (int64, error) {
+			assert.Equal(t, domain.AuthorizationCaptured, authorization.Status)
+			assert.Equal(t, amount, authorization.CapturedAmount)
+			assert.Equal(t, domain.CompraAVista, purchase.OperationTypeID())
+			assert.Equal(t, domain.MustParseMoney("-120.50"), purchase.Amount())
+			return int64(9), nil
+		})
+
+	// Act
+	authorization, err := authorizationUsecase.CaptureAuthorization(ctx, 3, &amount)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.AuthorizationCaptured, authorization.Status)
+	assert.Equal(t, int64(9), *authorization.TransactionID)
+}
+
+func TestAuthorizationUseCase_CaptureAuthorization_WhenExceedsAmount_ShouldReturnErrCaptureExceedsAuthorization(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAuthorizationRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	authorizationUsecase := NewAuthorizationUseCase(mockRepo, mockAccountRepo, time.Hour)
+	ctx := context.Background()
+
+	pending, _ := domain.NewAuthorization(1, domain.MustParseMoney("150"), time.Now(), time.Hour)
+	amount := domain.MustParseMoney("150.01")
+
+	mockRepo.EXPECT().
+		GetAuthorization(gomock.Any(), int64(3)).
+		Return(&pending, nil)
+
+	// Act
+	authorization, err := authorizationUsecase.CaptureAuthorization(ctx, 3, &amount)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrCaptureExceedsAuthorization)
+	assert.Nil(t, authorization)
+}
+
+func TestAuthorizationUseCase_VoidAuthorization_WhenPending_ShouldReleaseHold(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAuthorizationRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	authorizationUsecase := NewAuthorizationUseCase(mockRepo, mockAccountRepo, time.Hour)
+	ctx := context.Background()
+
+	pending, _ := domain.NewAuthorization(1, domain.MustParseMoney("150"), time.Now(), time.Hour)
+	pending.ID = 3
+
+	mockRepo.EXPECT().
+		GetAuthorization(gomock.Any(), int64(3)).
+		Return(&pending, nil)
+	mockRepo.EXPECT().
+		ReleaseAuthorization(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, authorization domain.Authorization) error {
+			assert.Equal(t, domain.AuthorizationVoided, authorization.Status)
+			return nil
+		})
+
+	// Act
+	authorization, err := authorizationUsecase.VoidAuthorization(ctx, 3)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.AuthorizationVoided, authorization.Status)
+}
+
+func TestAuthorizationUseCase_VoidAuthorization_WhenNotFound_ShouldReturnErrAuthorizationNotFound(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAuthorizationRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	authorizationUsecase := NewAuthorizationUseCase(mockRepo, mockAccountRepo, time.Hour)
+	ctx := context.Background()
+
+	mockRepo.EXPECT().
+		GetAuthorization(gomock.Any(), int64(3)).
+		Return(nil, repository.ErrAuthorizationNotFound)
+
+	// Act
+	authorization, err := authorizationUsecase.VoidAuthorization(ctx, 3)
+
+	// Assert
+	assert.ErrorIs(t, err, repository.ErrAuthorizationNotFound)
+	assert.Nil(t, authorization)
+}
+
+func TestAuthorizationUseCase_ExpireAuthorizations_ShouldReleaseExpiredHoldsAndSkipFinishedOnes(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAuthorizationRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	authorizationUsecase := NewAuthorizationUseCase(mockRepo, mockAccountRepo, time.Hour)
+	ctx := context.Background()
+
+	createdAt := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	now := createdAt.Add(2 * time.Hour)
+	first, _ := domain.NewAuthorization(1, domain.MustParseMoney("150"), createdAt, time.Hour)
+	first.ID = 3
+	second, _ := domain.NewAuthorization(2, domain.MustParseMoney("80"), createdAt, time.Hour)
+	second.ID = 4
+
+	mockRepo.EXPECT().
+		ListExpiredAuthorizations(gomock.Any(), now).
+		Return([]domain.Authorization{first, second}, nil)
+	mockRepo.EXPECT().
+		ReleaseAuthorization(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, authorization domain.Authorization) error {
+			assert.Equal(t, domain.AuthorizationExpired, authorization.Status)
+			if authorization.ID == 4 {
+				return fmt.Errorf("%w: authorization 4", domain.ErrAuthorizationNotPending)
+			}
+			return nil
+		}).
+		Times(2)
+
+	// Act
+	expired, err := authorizationUsecase.ExpireAuthorizations(ctx, now)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, expired)
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/metrics"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/tracing"
+)
+
+type chargeUseCase struct {
+	invoiceRepo     repository.InvoiceRepository
+	transactionRepo repository.TransactionRepository
+	monthlyRate     domain.InterestRate
+	lateFee         domain.Money
+}
+
+func NewChargeUseCase(invoiceRepo repository.InvoiceRepository, transactionRepo repository.TransactionRepository, monthlyRate domain.InterestRate, lateFee domain.Money) ChargeUseCase {
+	return &chargeUseCase{
+		invoiceRepo:     invoiceRepo,
+		transactionRepo: transactionRepo,
+		monthlyRate:     monthlyRate,
+		lateFee:         lateFee,
+	}
+}
+
+// AccrueCharges posts, up to the day of now, the charges of the invoices that were not paid
+// by their due date: the late fee on the day after the due date and revolving interest for
+// every day the invoice stays unpaid, on the amount unpaid at the start of that day. Days
+// missed since the last accrual are caught up, and charges already posted are skipped, so
+// the accrual can run any number of times a day. It returns how many charges were posted.
+func (c *chargeUseCase) AccrueCharges(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := tracing.StartSpan(ctx, "ChargeUseCase.AccrueCharges")
+	defer span.End()
+
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	overdue, err := c.invoiceRepo.ListOverdueInvoices(ctx, today)
+	if err != nil {
+		return 0, err
+	}
+
+	posted := 0
+	for _, overdueInvoice := range overdue {
+		if overdueInvoice.Invoice.TotalDue().IsZero() {
+			continue
+		}
+
+		count, err := c.accrueInvoice(ctx, overdueInvoice, today)
+		posted += count
+		if err != nil {
+			logger.Logger.ErrorContext(ctx, "error accruing charges", slog.Int64("invoiceID", overdueInvoice.Invoice.ID), slog.String("error", err.Error()))
+		}
+	}
+
+	return posted, nil
+}
+
+func (c *chargeUseCase) accrueInvoice(ctx context.Context, overdueInvoice domain.OverdueInvoice, today time.Time) (int, error) {
+	invoice := overdueInvoice.Invoice
+	lateFeeDay := invoice.DueDate.AddDate(0, 0, 1)
+
+	day := lateFeeDay
+	if overdueInvoice.LastInterestDate != nil && !overdueInvoice.LastInterestDate.Before(day) {
+		day = overdueInvoice.LastInterestDate.AddDate(0, 0, 1)
+	}
+
+	posted := 0
+	for ; !day.After(today); day = day.AddDate(0, 0, 1) {
+		paid, err := c.invoiceRepo.SumPayments(ctx, invoice.AccountID, invoice.ClosingDate, day)
+		if err != nil {
+			return posted, err
+		}
+
+		unpaid := invoice.TotalDue().Sub(paid)
+		if !unpaid.IsPositive() {
+			continue
+		}
+
+		charges := []domain.Transaction{}
+		if day.Equal(lateFeeDay) && !overdueInvoice.LateFeeCharged && c.lateFee.IsPositive() {
+			charges = append(charges, domain.NewLateFee(invoice, unpaid, c.lateFee, day))
+		}
+		if interest := domain.NewInterestCharge(invoice, unpaid, day, c.monthlyRate); !interest.Amount().IsZero() {
+			charges = append(charges, interest)
+		}
+
+		for _, charge := range charges {
+			if _, err := c.transactionRepo.CreateCharge(ctx, charge); err != nil {
+				if errors.Is(err, domain.ErrAlreadyExists) {
+					continue
+				}
+				return posted, err
+			}
+			metrics.RecordTransactions(charge)
+			posted++
+		}
+	}
+
+	return posted, nil
+}
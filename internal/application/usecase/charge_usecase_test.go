@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestOverdueInvoice() domain.OverdueInvoice {
+	return domain.OverdueInvoice{
+		Invoice: domain.Invoice{
+			ID:          1,
+			AccountID:   1,
+			ClosingDate: time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC),
+			DueDate:     time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC),
+			Balance:     domain.MustParseMoney("-1000"),
+		},
+	}
+}
+
+func TestChargeUseCase_AccrueCharges_WhenInvoiceIsUnpaid_ShouldPostLateFeeAndDailyInterest(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvoiceRepo := mocks.NewMockInvoiceRepository(ctrl)
+	mockTransactionRepo := mocks.NewMockTransactionRepository(ctrl)
+	chargeUseCase := NewChargeUseCase(mockInvoiceRepo, mockTransactionRepo, domain.InterestRate(1200), domain.MustParseMoney("10"))
+	ctx := context.Background()
+
+	closingDate := time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)
+	firstDay := time.Date(2025, 2, 11, 0, 0, 0, 0, time.UTC)
+	secondDay := time.Date(2025, 2, 12, 0, 0, 0, 0, time.UTC)
+
+	mockInvoiceRepo.EXPECT().
+		ListOverdueInvoices(gomock.Any(), secondDay).
+		Return([]domain.OverdueInvoice{newTestOverdueInvoice()}, nil)
+	mockInvoiceRepo.EXPECT().
+		SumPayments(gomock.Any(), int64(1), closingDate, firstDay).
+		Return(domain.Money{}, nil)
+	mockInvoiceRepo.EXPECT().
+		SumPayments(gomock.Any(), int64(1), closingDate, secondDay).
+		Return(domain.MustParseMoney("400"), nil)
+
+	charges := []domain.Transaction{}
+	mockTransactionRepo.EXPECT().
+		CreateCharge(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, charge domain.Transaction) (int64, error) {
+			charges = append(charges, charge)
+			return int64(len(charges)), nil
+		}).
+		Times(3)
+
+	// Act
+	posted, err := chargeUseCase.AccrueCharges(ctx, time.Date(2025, 2, 12, 15, 0, 0, 0, time.UTC))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 3, posted)
+	assert.Equal(t, domain.MultaPorAtraso, charges[0].OperationTypeID())
+	assert.Equal(t, domain.MustParseMoney("-10"), charges[0].Amount())
+	assert.Equal(t, domain.JurosRotativos, charges[1].OperationTypeID())
+	assert.Equal(t, domain.MustParseMoney("-4"), charges[1].Amount())
+	assert.Equal(t, firstDay, charges[1].EventDate())
+	assert.Equal(t, domain.MustParseMoney("-2.40"), charges[2].Amount())
+	assert.Equal(t, secondDay, charges[2].EventDate())
+}
+
+func TestChargeUseCase_AccrueCharges_WhenInterestWasAccrued_ShouldResumeOnTheNextDay(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvoiceRepo := mocks.NewMockInvoiceRepository(ctrl)
+	mockTransactionRepo := mocks.NewMockTransactionRepository(ctrl)
+	chargeUseCase := NewChargeUseCase(mockInvoiceRepo, mockTransactionRepo, domain.InterestRate(1200), domain.MustParseMoney("10"))
+	ctx := context.Background()
+
+	lastInterestDate := time.Date(2025, 2, 11, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2025, 2, 12, 0, 0, 0, 0, time.UTC)
+	overdue := newTestOverdueInvoice()
+	overdue.LastInterestDate = &lastInterestDate
+	overdue.LateFeeCharged = true
+
+	mockInvoiceRepo.EXPECT().
+		ListOverdueInvoices(gomock.Any(), today).
+		Return([]domain.OverdueInvoice{overdue}, nil)
+	mockInvoiceRepo.EXPECT().
+		SumPayments(gomock.Any(), int64(1), overdue.Invoice.ClosingDate, today).
+		Return(domain.Money{}, nil)
+	mockTransactionRepo.EXPECT().
+		CreateCharge(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, charge domain.Transaction) (int64, error) {
+			assert.Equal(t, domain.JurosRotativos, charge.OperationTypeID())
+			assert.Equal(t, today, charge.EventDate())
+			return int64(1), nil
+		})
+
+	// Act
+	posted, err := chargeUseCase.AccrueCharges(ctx, today)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, posted)
+}
+
+func TestChargeUseCase_AccrueCharges_WhenInvoiceIsPaid_ShouldNotPostCharges(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvoiceRepo := mocks.NewMockInvoiceRepository(ctrl)
+	mockTransactionRepo := mocks.NewMockTransactionRepository(ctrl)
+	chargeUseCase := NewChargeUseCase(mockInvoiceRepo, mockTransactionRepo, domain.InterestRate(1200), domain.MustParseMoney("10"))
+	ctx := context.Background()
+
+	today := time.Date(2025, 2, 11, 0, 0, 0, 0, time.UTC)
+
+	mockInvoiceRepo.EXPECT().
+		ListOverdueInvoices(gomock.Any(), today).
+		Return([]domain.OverdueInvoice{newTestOverdueInvoice()}, nil)
+	mockInvoiceRepo.EXPECT().
+		SumPayments(gomock.Any(), int64(1), gomock.Any(), today).
+		Return(domain.MustParseMoney("1000"), nil)
+
+	// Act
+	posted, err := chargeUseCase.AccrueCharges(ctx, today)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, posted)
+}
+
+func TestChargeUseCase_AccrueCharges_WhenChargeAlreadyPosted_ShouldSkipIt(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvoiceRepo := mocks.NewMockInvoiceRepository(ctrl)
+	mockTransactionRepo := mocks.NewMockTransactionRepository(ctrl)
+	chargeUseCase := NewChargeUseCase(mockInvoiceRepo, mockTransactionRepo, domain.InterestRate(1200), domain.MustParseMoney("10"))
+	ctx := context.Background()
+
+	today := time.Date(2025, 2, 11, 0, 0, 0, 0, time.UTC)
+
+	mockInvoiceRepo.EXPECT().
+		ListOverdueInvoices(gomock.Any(), today).
+		Return([]domain.OverdueInvoice{newTestOverdueInvoice()}, nil)
+	mockInvoiceRepo.EXPECT().
+		SumPayments(gomock.Any(), int64(1), gomock.Any(), today).
+		Return(domain.Money{}, nil)
+	mockTransactionRepo.EXPECT().
+		CreateCharge(gomock.Any(), gomock.Any()).
+		Return(int64(0), domain.ErrAlreadyExists).
+		Times(2)
+
+	// Act
+	posted, err := chargeUseCase.AccrueCharges(ctx, today)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, posted)
+}
+
+func TestChargeUseCase_AccrueCharges_WhenFailedToListOverdueInvoices_ShouldReturnError(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvoiceRepo := mocks.NewMockInvoiceRepository(ctrl)
+	mockTransactionRepo := mocks.NewMockTransactionRepository(ctrl)
+	chargeUseCase := NewChargeUseCase(mockInvoiceRepo, mockTransactionRepo, domain.InterestRate(1200), domain.MustParseMoney("10"))
+	ctx := context.Background()
+
+	mockInvoiceRepo.EXPECT().
+		ListOverdueInvoices(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("db error"))
+
+	// Act
+	posted, err := chargeUseCase.AccrueCharges(ctx, time.Now())
+
+	// Assert
+	assert.EqualError(t, err, "db error")
+	assert.Equal(t, 0, posted)
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/metrics"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/tracing"
+)
+
+type disputeUseCase struct {
+	repo            repository.DisputeRepository
+	transactionRepo repository.TransactionRepository
+	accountRepo     repository.AccountRepository
+}
+
+func NewDisputeUseCase(repo repository.DisputeRepository, transactionRepo repository.TransactionRepository, accountRepo repository.AccountRepository) DisputeUseCase {
+	return &disputeUseCase{
+		repo:            repo,
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+	}
+}
+
+// OpenDispute disputes amount of the transaction, or all of it when amount is nil, and
+// credits it provisionally to the account until the dispute is resolved.
+func (d *disputeUseCase) OpenDispute(ctx context.Context, transactionID int64, amount *domain.Money, reason string) (*domain.Dispute, error) {
+	ctx, span := tracing.StartSpan(ctx, "DisputeUseCase.OpenDispute")
+	defer span.End()
+
+	transaction, err := d.transactionRepo.GetTransaction(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	dispute, err := domain.NewDispute(*transaction, amount, reason, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := ensureAccountExists(ctx, d.accountRepo, dispute.AccountID); err != nil {
+		return nil, err
+	}
+
+	credit, err := dispute.GrantProvisionalCredit()
+	if err != nil {
+		return nil, err
+	}
+	opened, err := d.repo.OpenDispute(ctx, dispute, credit)
+	if err != nil {
+		return nil, err
+	}
+	metrics.RecordTransactions(credit)
+	return opened, nil
+}
+
+// ResolveDispute closes the dispute with outcome. A won dispute keeps its provisional
+// credit; a lost one has it reversed, even if the account is blocked or closed meanwhile.
+func (d *disputeUseCase) ResolveDispute(ctx context.Context, disputeID int64, outcome domain.DisputeStatus) (*domain.Dispute, error) {
+	ctx, span := tracing.StartSpan(ctx, "DisputeUseCase.ResolveDispute")
+	defer span.End()
+
+	dispute, err := d.repo.GetDispute(ctx, disputeID)
+	if err != nil {
+		return nil, err
+	}
+
+	reversal, err := dispute.Resolve(outcome, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := d.repo.ResolveDispute(ctx, *dispute, reversal)
+	if err != nil {
+		return nil, err
+	}
+	if reversal != nil {
+		metrics.RecordTransactions(*reversal)
+	}
+	return resolved, nil
+}
+
+func (d *disputeUseCase) GetDispute(ctx context.Context, disputeID int64) (*domain.Dispute, error) {
+	ctx, span := tracing.StartSpan(ctx, "DisputeUseCase.GetDispute")
+	defer span.End()
+
+	return d.repo.GetDispute(ctx, disputeID)
+}
+
+// ListDisputes returns ErrTransactionNotFound for unknown transactions and every dispute of
+// the transaction, with its events, otherwise.
+func (d *disputeUseCase) ListDisputes(ctx context.Context, transactionID int64) ([]domain.Dispute, error) {
+	ctx, span := tracing.StartSpan(ctx, "DisputeUseCase.ListDisputes")
+	defer span.End()
+
+	if _, err := d.transactionRepo.GetTransaction(ctx, transactionID); err != nil {
+		return nil, err
+	}
+	return d.repo.ListDisputes(ctx, transactionID)
+}
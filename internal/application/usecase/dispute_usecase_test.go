@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/VieiraVitor/transaction-flow/internal/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisputeUseCase_OpenDispute_WhenValidInput_ShouldGrantProvisionalCredit(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockDisputeRepository(ctrl)
+	mockTransactionRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	disputeUsecase := NewDisputeUseCase(mockRepo, mockTransactionRepo, mockAccountRepo)
+	ctx := context.Background()
+
+	purchase := domain.NewTransaction(1, domain.CompraAVista, domain.MustParseMoney("-100"))
+	purchase.SetID(7)
+	amount := domain.MustParseMoney("40")
+
+	mockTransactionRepo.EXPECT().
+		GetTransaction(gomock.Any(), int64(7)).
+		Return(&purchase, nil)
+	mockAccountRepo.EXPECT().
+		GetAccount(gomock.Any(), int64(1)).
+		Return(domain.NewAccount("12345678900", domain.MustParseMoney("1000")), nil)
+	mockRepo.EXPECT().
+		OpenDispute(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, dispute domain.Dispute, credit domain.Transaction) (*domain.Dispute, error) {
+			assert.Equal(t, int64(7), dispute.TransactionID)
+			assert.Equal(t, domain.DisputeProvisionalCredit, dispute.Status)
+			assert.Equal(t, "not recognized", dispute.Reason)
+			assert.Equal(t, domain.CreditoProvisorio, credit.OperationTypeID())
+			assert.Equal(t, amount, credit.Amount())
+			dispute.ID = 4
+			return &dispute, nil
+		})
+
+	// Act
+	dispute, err := disputeUsecase.OpenDispute(ctx, 7, &amount, "not recognized")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), dispute.ID)
+}
+
+func TestDisputeUseCase_OpenDispute_WhenTransactionWasReversed_ShouldReturnErrDisputeExceedsAmount(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockDisputeRepository(ctrl)
+	mockTransactionRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	disputeUsecase := NewDisputeUseCase(mockRepo, mockTransactionRepo, mockAccountRepo)
+	ctx := context.Background()
+
+	purchase := domain.NewTransaction(1, domain.CompraAVista, domain.MustParseMoney("-100"))
+	purchase.SetID(7)
+
+	mockTransactionRepo.EXPECT().
+		GetTransaction(gomock.Any(), int64(7)).
+		Return(&purchase, nil)
+	mockAccountRepo.EXPECT().
+		GetAccount(gomock.Any(), int64(1)).
+		Return(domain.NewAccount("12345678900", domain.MustParseMoney("1000")), nil)
+	mockRepo.EXPECT().
+		OpenDispute(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, fmt.Errorf("%w: 100.00 disputed, 60.00 left", repository.ErrDisputeExceedsAmount))
+
+	// Act
+	dispute, err := disputeUsecase.OpenDispute(ctx, 7, nil, "not recognized")
+
+	// Assert
+	assert.ErrorIs(t, err, repository.ErrDisputeExceedsAmount)
+	assert.Nil(t, dispute)
+}
+
+func TestDisputeUseCase_OpenDispute_WhenTransactionIsPayment_ShouldReturnErrTransactionNotDisputable(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockDisputeRepository(ctrl)
+	mockTransactionRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	disputeUsecase := NewDisputeUseCase(mockRepo, mockTransactionRepo, mockAccountRepo)
+	ctx := context.Background()
+
+	payment := domain.NewTransaction(1, domain.Pagamento, domain.MustParseMoney("100"))
+	mockTransactionRepo.EXPECT().
+		GetTransaction(gomock.Any(), int64(7)).
+		Return(&payment, nil)
+
+	// Act
+	dispute, err := disputeUsecase.OpenDispute(ctx, 7, nil, "not recognized")
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrTransactionNotDisputable)
+	assert.Nil(t, dispute)
+}
+
+func TestDisputeUseCase_ResolveDispute_WhenLost_ShouldReverseProvisionalCredit(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockDisputeRepository(ctrl)
+	disputeUsecase := NewDisputeUseCase(mockRepo, mocks.NewMockTransactionRepository(ctrl), mocks.NewMockAccountRepository(ctrl))
+	ctx := context.Background()
+
+	creditID := int64(8)
+	mockRepo.EXPECT().
+		GetDispute(gomock.Any(), int64(4)).
+		Return(&domain.Dispute{ID: 4, AccountID: 1, Amount: domain.MustParseMoney("40"), Status: domain.DisputeProvisionalCredit, ProvisionalCreditTransactionID: &creditID}, nil)
+	mockRepo.EXPECT().
+		ResolveDispute(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, dispute domain.Dispute, reversal *domain.Transaction) (*domain.Dispute, error) {
+			assert.Equal(t, domain.DisputeLost, dispute.Status)
+			assert.Equal(t, domain.EstornoCreditoProvisorio, reversal.OperationTypeID())
+			assert.Equal(t, domain.MustParseMoney("-40"), reversal.Amount())
+			return &dispute, nil
+		})
+
+	// Act
+	dispute, err := disputeUsecase.ResolveDispute(ctx, 4, domain.DisputeLost)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DisputeLost, dispute.Status)
+}
+
+func TestDisputeUseCase_ResolveDispute_WhenAlreadyResolved_ShouldReturnErrDisputeNotResolvable(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockDisputeRepository(ctrl)
+	disputeUsecase := NewDisputeUseCase(mockRepo, mocks.NewMockTransactionRepository(ctrl), mocks.NewMockAccountRepository(ctrl))
+	ctx := context.Background()
+
+	creditID := int64(8)
+	mockRepo.EXPECT().
+		GetDispute(gomock.Any(), int64(4)).
+		Return(&domain.Dispute{ID: 4, Status: domain.DisputeWon, ProvisionalCreditTransactionID: &creditID}, nil)
+
+	// Act
+	dispute, err := disputeUsecase.ResolveDispute(ctx, 4, domain.DisputeLost)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrDisputeNotResolvable)
+	assert.Nil(t, dispute)
+}
+
+func TestDisputeUseCase_ListDisputes_WhenTransactionNotFound_ShouldReturnErrTransactionNotFound(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionRepo := mocks.NewMockTransactionRepository(ctrl)
+	disputeUsecase := NewDisputeUseCase(mocks.NewMockDisputeRepository(ctrl), mockTransactionRepo, mocks.NewMockAccountRepository(ctrl))
+	ctx := context.Background()
+
+	mockTransactionRepo.EXPECT().
+		GetTransaction(gomock.Any(), int64(7)).
+		Return(nil, repository.ErrTransactionNotFound)
+
+	// Act
+	disputes, err := disputeUsecase.ListDisputes(ctx, 7)
+
+	// Assert
+	assert.ErrorIs(t, err, repository.ErrTransactionNotFound)
+	assert.Nil(t, disputes)
+}
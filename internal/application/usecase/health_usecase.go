@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/tracing"
+)
+
+type healthUseCase struct {
+	repo                     repository.HealthRepository
+	expectedMigrationVersion uint
+	timeout                  time.Duration
+	shuttingDown             atomic.Bool
+}
+
+// NewHealthUseCase checks the database within timeout and expects its schema to be at least
+// expectedMigrationVersion.
+func NewHealthUseCase(repo repository.HealthRepository, expectedMigrationVersion uint, timeout time.Duration) HealthUseCase {
+	return &healthUseCase{
+		repo:                     repo,
+		expectedMigrationVersion: expectedMigrationVersion,
+		timeout:                  timeout,
+	}
+}
+
+// CheckReadiness reports the instance ready when the database answers, its schema is at the
+// expected version and the instance is not shutting down. Every check runs, so the report
+// shows all that fail.
+func (h *healthUseCase) CheckReadiness(ctx context.Context) domain.Readiness {
+	ctx, span := tracing.StartSpan(ctx, "HealthUseCase.CheckReadiness")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	return domain.NewReadiness([]domain.HealthCheck{
+		h.checkDatabase(ctx),
+		h.checkMigrations(ctx),
+		h.checkShutdown(),
+	})
+}
+
+// BeginShutdown makes the instance report itself not ready from now on, so load balancers
+// stop sending it traffic while in-flight requests finish.
+func (h *healthUseCase) BeginShutdown() {
+	h.shuttingDown.Store(true)
+}
+
+func (h *healthUseCase) checkDatabase(ctx context.Context) domain.HealthCheck {
+	check := domain.HealthCheck{Name: domain.HealthCheckDatabase, Status: domain.HealthUp}
+	if err := h.repo.Ping(ctx); err != nil {
+		check.Status = domain.HealthDown
+		check.Detail = err.Error()
+	}
+	return check
+}
+
+func (h *healthUseCase) checkMigrations(ctx context.Context) domain.HealthCheck {
+	check := domain.HealthCheck{Name: domain.HealthCheckMigrations, Status: domain.HealthDown}
+
+	version, dirty, err := h.repo.GetMigrationVersion(ctx)
+	switch {
+	case err != nil:
+		check.Detail = err.Error()
+	case dirty:
+		check.Detail = fmt.Sprintf("migration %d failed and left the schema dirty", version)
+	case version < h.expectedMigrationVersion:
+		// A newer schema is fine: during a rolling deploy the new release migrates the
+		// database while instances of this one still serve traffic.
+		check.Detail = fmt.Sprintf("schema is at version %d, expected at least %d", version, h.expectedMigrationVersion)
+	default:
+		check.Status = domain.HealthUp
+	}
+	return check
+}
+
+func (h *healthUseCase) checkShutdown() domain.HealthCheck {
+	if h.shuttingDown.Load() {
+		return domain.HealthCheck{Name: domain.HealthCheckShutdown, Status: domain.HealthDown, Detail: "shutting down"}
+	}
+	return domain.HealthCheck{Name: domain.HealthCheckShutdown, Status: domain.HealthUp}
+}
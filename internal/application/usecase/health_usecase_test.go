@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthUseCase_CheckReadiness_WhenEverythingIsUp_ShouldBeReady(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockHealthRepository(ctrl)
+	healthUseCase := NewHealthUseCase(mockRepo, 20, time.Second)
+	ctx := context.Background()
+
+	mockRepo.EXPECT().Ping(gomock.Any()).Return(nil)
+	mockRepo.EXPECT().GetMigrationVersion(gomock.Any()).Return(uint(20), false, nil)
+
+	// Act
+	readiness := healthUseCase.CheckReadiness(ctx)
+
+	// Assert
+	assert.True(t, readiness.IsReady())
+	assert.Equal(t, []domain.HealthCheck{
+		{Name: domain.HealthCheckDatabase, Status: domain.HealthUp},
+		{Name: domain.HealthCheckMigrations, Status: domain.HealthUp},
+		{Name: domain.HealthCheckShutdown, Status: domain.HealthUp},
+	}, readiness.Checks)
+}
+
+func TestHealthUseCase_CheckReadiness_WhenSchemaIsNewer_ShouldBeReady(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockHealthRepository(ctrl)
+	healthUseCase := NewHealthUseCase(mockRepo, 20, time.Second)
+	ctx := context.Background()
+
+	mockRepo.EXPECT().Ping(gomock.Any()).Return(nil)
+	mockRepo.EXPECT().GetMigrationVersion(gomock.Any()).Return(uint(21), false, nil)
+
+	// Act
+	readiness := healthUseCase.CheckReadiness(ctx)
+
+	// Assert
+	assert.True(t, readiness.IsReady())
+	assert.Equal(t, domain.HealthCheck{Name: domain.HealthCheckMigrations, Status: domain.HealthUp}, readiness.Checks[1])
+}
+
+func TestHealthUseCase_CheckReadiness_WhenChecksFail_ShouldReportEachFailure(t *testing.T) {
+	tests := []struct {
+		name             string
+		pingErr          error
+		version          uint
+		dirty            bool
+		versionErr       error
+		expectedDatabase domain.HealthCheck
+		expectedSchema   domain.HealthCheck
+	}{
+		{
+			name:             "DatabaseDown",
+			pingErr:          errors.New("connection refused"),
+			versionErr:       errors.New("connection refused"),
+			expectedDatabase: domain.HealthCheck{Name: domain.HealthCheckDatabase, Status: domain.HealthDown, Detail: "connection refused"},
+			expectedSchema:   domain.HealthCheck{Name: domain.HealthCheckMigrations, Status: domain.HealthDown, Detail: "connection refused"},
+		},
+		{
+			name:             "SchemaBehind",
+			version:          19,
+			expectedDatabase: domain.HealthCheck{Name: domain.HealthCheckDatabase, Status: domain.HealthUp},
+			expectedSchema:   domain.HealthCheck{Name: domain.HealthCheckMigrations, Status: domain.HealthDown, Detail: "schema is at version 19, expected at least 20"},
+		},
+		{
+			name:             "SchemaDirty",
+			version:          20,
+			dirty:            true,
+			expectedDatabase: domain.HealthCheck{Name: domain.HealthCheckDatabase, Status: domain.HealthUp},
+			expectedSchema:   domain.HealthCheck{Name: domain.HealthCheckMigrations, Status: domain.HealthDown, Detail: "migration 20 failed and left the schema dirty"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockHealthRepository(ctrl)
+			healthUseCase := NewHealthUseCase(mockRepo, 20, time.Second)
+			ctx := context.Background()
+
+			mockRepo.EXPECT().Ping(gomock.Any()).Return(tt.pingErr)
+			mockRepo.EXPECT().GetMigrationVersion(gomock.Any()).Return(tt.version, tt.dirty, tt.versionErr)
+
+			// Act
+			readiness := healthUseCase.CheckReadiness(ctx)
+
+			// Assert
+			assert.False(t, readiness.IsReady())
+			assert.Equal(t, tt.expectedDatabase, readiness.Checks[0])
+			assert.Equal(t, tt.expectedSchema, readiness.Checks[1])
+		})
+	}
+}
+
+func TestHealthUseCase_CheckReadiness_WhenShuttingDown_ShouldNotBeReady(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockHealthRepository(ctrl)
+	healthUseCase := NewHealthUseCase(mockRepo, 20, time.Second)
+	ctx := context.Background()
+
+	mockRepo.EXPECT().Ping(gomock.Any()).Return(nil)
+	mockRepo.EXPECT().GetMigrationVersion(gomock.Any()).Return(uint(20), false, nil)
+
+	// Act
+	healthUseCase.BeginShutdown()
+	readiness := healthUseCase.CheckReadiness(ctx)
+
+	// Assert
+	assert.False(t, readiness.IsReady())
+	assert.Equal(t, domain.HealthCheck{Name: domain.HealthCheckShutdown, Status: domain.HealthDown, Detail: "shutting down"}, readiness.Checks[2])
+}
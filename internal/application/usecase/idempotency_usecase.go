@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/tracing"
+	"github.com/google/uuid"
+)
+
+type idempotencyUseCase struct {
+	repo  repository.IdempotencyRepository
+	ttl   time.Duration
+	lease time.Duration
+}
+
+// NewIdempotencyUseCase keeps responses for ttl. A reservation without a response is taken
+// over by a retry once lease has passed, so lease must outlast the slowest request.
+func NewIdempotencyUseCase(repo repository.IdempotencyRepository, ttl time.Duration, lease time.Duration) IdempotencyUseCase {
+	return &idempotencyUseCase{
+		repo:  repo,
+		ttl:   ttl,
+		lease: lease,
+	}
+}
+
+// BeginRequest reserves the key for the request body. A nil response means the request
+// must be processed and then finished with CompleteRequest or AbortRequest, passing the
+// returned claim token so that a request that outlived its lease cannot finish the claim
+// of the retry that took the key over.
+func (i *idempotencyUseCase) BeginRequest(ctx context.Context, scope string, key string, requestBody []byte) (*domain.IdempotentResponse, string, error) {
+	ctx, span := tracing.StartSpan(ctx, "IdempotencyUseCase.BeginRequest")
+	defer span.End()
+
+	hash := sha256.Sum256(requestBody)
+	claimToken := uuid.NewString()
+	recorded, err := i.repo.ReserveKey(ctx, scope, key, hex.EncodeToString(hash[:]), claimToken, i.ttl, i.lease)
+	if err != nil || recorded != nil {
+		return recorded, "", err
+	}
+	return nil, claimToken, nil
+}
+
+// CompleteRequest records the response for replays. Server errors are not recorded so the
+// client can retry the request with the same key.
+func (i *idempotencyUseCase) CompleteRequest(ctx context.Context, scope string, key string, claimToken string, response domain.IdempotentResponse) error {
+	ctx, span := tracing.StartSpan(ctx, "IdempotencyUseCase.CompleteRequest")
+	defer span.End()
+
+	if response.StatusCode >= http.StatusInternalServerError {
+		return i.repo.ReleaseKey(ctx, scope, key, claimToken)
+	}
+	return i.repo.SaveResponse(ctx, scope, key, claimToken, response)
+}
+
+func (i *idempotencyUseCase) AbortRequest(ctx context.Context, scope string, key string, claimToken string) error {
+	ctx, span := tracing.StartSpan(ctx, "IdempotencyUseCase.AbortRequest")
+	defer span.End()
+
+	return i.repo.ReleaseKey(ctx, scope, key, claimToken)
+}
+
+func (i *idempotencyUseCase) PurgeExpiredKeys(ctx context.Context) (int64, error) {
+	ctx, span := tracing.StartSpan(ctx, "IdempotencyUseCase.PurgeExpiredKeys")
+	defer span.End()
+
+	return i.repo.DeleteExpiredKeys(ctx)
+}
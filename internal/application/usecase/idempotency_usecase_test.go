@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyUseCase_BeginRequest_ShouldReserveKeyWithRequestHash(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIdempotencyRepository(ctrl)
+	idempotencyUseCase := NewIdempotencyUseCase(mockRepo, time.Hour, time.Minute)
+
+	body := []byte(`{"amount": 10}`)
+	hash := sha256.Sum256(body)
+	var reservedToken string
+
+	mockRepo.EXPECT().
+		ReserveKey(gomock.Any(), "POST /transactions", "key-1", hex.EncodeToString(hash[:]), gomock.Any(), time.Hour, time.Minute).
+		DoAndReturn(func(_ context.Context, _ string, _ string, _ string, claimToken string, _ time.Duration, _ time.Duration) (*domain.IdempotentResponse, error) {
+			reservedToken = claimToken
+			return nil, nil
+		})
+
+	// Act
+	recorded, claimToken, err := idempotencyUseCase.BeginRequest(context.Background(), "POST /transactions", "key-1", body)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, recorded)
+	assert.NotEmpty(t, claimToken)
+	assert.Equal(t, reservedToken, claimToken)
+}
+
+func TestIdempotencyUseCase_BeginRequest_ShouldClaimEachRequestWithItsOwnToken(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIdempotencyRepository(ctrl)
+	idempotencyUseCase := NewIdempotencyUseCase(mockRepo, time.Hour, time.Minute)
+
+	mockRepo.EXPECT().
+		ReserveKey(gomock.Any(), "POST /transactions", "key-1", gomock.Any(), gomock.Any(), time.Hour, time.Minute).
+		Return(nil, nil).
+		Times(2)
+
+	// Act
+	_, firstToken, firstErr := idempotencyUseCase.BeginRequest(context.Background(), "POST /transactions", "key-1", []byte(`{}`))
+	_, secondToken, secondErr := idempotencyUseCase.BeginRequest(context.Background(), "POST /transactions", "key-1", []byte(`{}`))
+
+	// Assert
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.NotEqual(t, firstToken, secondToken)
+}
+
+func TestIdempotencyUseCase_CompleteRequest_WhenClientResponse_ShouldSaveResponse(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIdempotencyRepository(ctrl)
+	idempotencyUseCase := NewIdempotencyUseCase(mockRepo, time.Hour, time.Minute)
+
+	response := domain.IdempotentResponse{StatusCode: http.StatusUnprocessableEntity, Body: []byte(`{}`)}
+
+	mockRepo.EXPECT().
+		SaveResponse(gomock.Any(), "POST /transactions", "key-1", "claim-1", response).
+		Return(nil)
+
+	// Act
+	err := idempotencyUseCase.CompleteRequest(context.Background(), "POST /transactions", "key-1", "claim-1", response)
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestIdempotencyUseCase_CompleteRequest_WhenServerError_ShouldReleaseKey(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIdempotencyRepository(ctrl)
+	idempotencyUseCase := NewIdempotencyUseCase(mockRepo, time.Hour, time.Minute)
+
+	mockRepo.EXPECT().
+		ReleaseKey(gomock.Any(), "POST /transactions", "key-1", "claim-1").
+		Return(nil)
+
+	// Act
+	err := idempotencyUseCase.CompleteRequest(context.Background(), "POST /transactions", "key-1", "claim-1", domain.IdempotentResponse{StatusCode: http.StatusInternalServerError})
+
+	// Assert
+	assert.NoError(t, err)
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/tracing"
+)
+
+type invoiceUseCase struct {
+	repo        repository.InvoiceRepository
+	accountRepo repository.AccountRepository
+}
+
+func NewInvoiceUseCase(repo repository.InvoiceRepository, accountRepo repository.AccountRepository) InvoiceUseCase {
+	return &invoiceUseCase{
+		repo:        repo,
+		accountRepo: accountRepo,
+	}
+}
+
+// CloseInvoices closes every billing cycle that ended up to now and returns how many
+// invoices were closed. Accounts that missed several closings are caught up one cycle at a
+// time. A failure is logged and only stops the account it happened on.
+func (i *invoiceUseCase) CloseInvoices(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := tracing.StartSpan(ctx, "InvoiceUseCase.CloseInvoices")
+	defer span.End()
+
+	states, err := i.repo.ListBillingStates(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	closed := 0
+	for _, state := range states {
+		periodStart, previousBalance := state.PeriodStart, state.PreviousBalance
+		for closingDate := state.Cycle.NextClosingDate(periodStart); !closingDate.After(now); closingDate = closingDate.AddDate(0, 1, 0) {
+			invoice, err := i.closeInvoice(ctx, state.AccountID, state.Cycle, periodStart, closingDate, previousBalance)
+			if err != nil {
+				if !errors.Is(err, domain.ErrAlreadyExists) {
+					logger.Logger.ErrorContext(ctx, "error closing invoice", slog.Int64("accountID", state.AccountID), slog.Time("closingDate", closingDate), slog.String("error", err.Error()))
+				}
+				break
+			}
+
+			closed++
+			periodStart, previousBalance = closingDate, invoice.Balance
+		}
+	}
+
+	return closed, nil
+}
+
+// closeInvoice bills the transactions of the period, the charges posted late on earlier
+// periods and the installments due up to the due date of the invoice that were not billed
+// yet.
+func (i *invoiceUseCase) closeInvoice(ctx context.Context, accountID int64, cycle domain.BillingCycle, periodStart time.Time, closingDate time.Time, previousBalance domain.Money) (*domain.Invoice, error) {
+	dueDate := cycle.DueDate(closingDate)
+	items, err := i.repo.ListBillableItems(ctx, accountID, periodStart, closingDate, dueDate)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice := domain.NewInvoice(accountID, periodStart, closingDate, cycle, previousBalance, items)
+	id, err := i.repo.CreateInvoice(ctx, invoice)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice.ID = id
+	return &invoice, nil
+}
+
+func (i *invoiceUseCase) GetInvoice(ctx context.Context, invoiceID int64) (*domain.Invoice, error) {
+	ctx, span := tracing.StartSpan(ctx, "InvoiceUseCase.GetInvoice")
+	defer span.End()
+
+	return i.repo.GetInvoice(ctx, invoiceID)
+}
+
+// ListInvoices returns ErrAccountNotFound for unknown accounts instead of an empty list.
+func (i *invoiceUseCase) ListInvoices(ctx context.Context, accountID int64) ([]domain.Invoice, error) {
+	ctx, span := tracing.StartSpan(ctx, "InvoiceUseCase.ListInvoices")
+	defer span.End()
+
+	if _, err := i.accountRepo.GetAccount(ctx, accountID); err != nil {
+		return nil, err
+	}
+	return i.repo.ListInvoices(ctx, accountID)
+}
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/VieiraVitor/transaction-flow/internal/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvoiceUseCase_CloseInvoices_WhenClosingsWereMissed_ShouldCloseEachCycleCarryingBalance(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockInvoiceRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	invoiceUseCase := NewInvoiceUseCase(mockRepo, mockAccountRepo)
+	ctx := context.Background()
+
+	cycle := domain.BillingCycle{ClosingDay: 3, DueDay: 10}
+	createdAt := time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC)
+	firstClosing := time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)
+	secondClosing := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+
+	mockRepo.EXPECT().
+		ListBillingStates(gomock.Any()).
+		Return([]domain.BillingState{{AccountID: 1, Cycle: cycle, PeriodStart: createdAt}}, nil)
+	gomock.InOrder(
+		mockRepo.EXPECT().
+			ListBillableItems(gomock.Any(), int64(1), createdAt, firstClosing, time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)).
+			Return([]domain.InvoiceItem{{TransactionID: 1, Amount: domain.MustParseMoney("-100")}}, nil),
+		mockRepo.EXPECT().
+			CreateInvoice(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, invoice domain.Invoice) (int64, error) {
+				assert.Equal(t, domain.MustParseMoney("-100"), invoice.Balance)
+				return int64(1), nil
+			}),
+		mockRepo.EXPECT().
+			ListBillableItems(gomock.Any(), int64(1), firstClosing, secondClosing, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)).
+			Return([]domain.InvoiceItem{{TransactionID: 2, Amount: domain.MustParseMoney("40")}}, nil),
+		mockRepo.EXPECT().
+			CreateInvoice(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, invoice domain.Invoice) (int64, error) {
+				assert.Equal(t, domain.MustParseMoney("-100"), invoice.PreviousBalance)
+				assert.Equal(t, domain.MustParseMoney("-60"), invoice.Balance)
+				return int64(2), nil
+			}),
+	)
+
+	// Act
+	closed, err := invoiceUseCase.CloseInvoices(ctx, time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, closed)
+}
+
+func TestInvoiceUseCase_CloseInvoices_WhenInvoiceFailsToClose_ShouldContinueWithOtherAccounts(t *testing.T) {
+	// Arrange
+	logger.InitLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockInvoiceRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	invoiceUseCase := NewInvoiceUseCase(mockRepo, mockAccountRepo)
+	ctx := context.Background()
+
+	cycle := domain.BillingCycle{ClosingDay: 3, DueDay: 10}
+	periodStart := time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)
+
+	mockRepo.EXPECT().
+		ListBillingStates(gomock.Any()).
+		Return([]domain.BillingState{
+			{AccountID: 1, Cycle: cycle, PeriodStart: periodStart},
+			{AccountID: 2, Cycle: cycle, PeriodStart: periodStart},
+		}, nil)
+	mockRepo.EXPECT().
+		ListBillableItems(gomock.Any(), int64(1), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("failed to list billable items"))
+	mockRepo.EXPECT().
+		ListBillableItems(gomock.Any(), int64(2), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]domain.InvoiceItem{}, nil)
+	mockRepo.EXPECT().
+		CreateInvoice(gomock.Any(), gomock.Any()).
+		Return(int64(0), domain.ErrAlreadyExists)
+
+	// Act
+	closed, err := invoiceUseCase.CloseInvoices(ctx, time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, closed)
+}
+
+func TestInvoiceUseCase_CloseInvoices_WhenFailedToListBillingStates_ShouldReturnError(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockInvoiceRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	invoiceUseCase := NewInvoiceUseCase(mockRepo, mockAccountRepo)
+	ctx := context.Background()
+
+	mockRepo.EXPECT().
+		ListBillingStates(gomock.Any()).
+		Return(nil, errors.New("failed to list billing states"))
+
+	// Act
+	closed, err := invoiceUseCase.CloseInvoices(ctx, time.Now())
+
+	// Assert
+	assert.EqualError(t, err, "failed to list billing states")
+	assert.Equal(t, 0, closed)
+}
+
+func TestInvoiceUseCase_ListInvoices_WhenAccountDoesNotExist_ShouldReturnErrAccountNotFound(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockInvoiceRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	invoiceUseCase := NewInvoiceUseCase(mockRepo, mockAccountRepo)
+	ctx := context.Background()
+
+	mockAccountRepo.EXPECT().
+		GetAccount(gomock.Any(), int64(9)).
+		Return(nil, repository.ErrAccountNotFound)
+
+	// Act
+	invoices, err := invoiceUseCase.ListInvoices(ctx, 9)
+
+	// Assert
+	assert.ErrorIs(t, err, repository.ErrAccountNotFound)
+	assert.Nil(t, invoices)
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/tracing"
+)
+
+type ledgerUseCase struct {
+	repo repository.LedgerRepository
+}
+
+func NewLedgerUseCase(repo repository.LedgerRepository) LedgerUseCase {
+	return &ledgerUseCase{repo: repo}
+}
+
+// GetTrialBalance totals the general ledger, up to asOf when it is set. The postings of
+// every journal entry balance, so the totals of an intact ledger always match.
+func (l *ledgerUseCase) GetTrialBalance(ctx context.Context, asOf *time.Time) (*domain.TrialBalance, error) {
+	ctx, span := tracing.StartSpan(ctx, "LedgerUseCase.GetTrialBalance")
+	defer span.End()
+
+	lines, err := l.repo.GetTrialBalance(ctx, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	trialBalance := domain.NewTrialBalance(lines, asOf)
+	return &trialBalance, nil
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedgerUseCase_GetTrialBalance_WhenLedgerHasPostings_ShouldTotalThem(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockLedgerRepository(ctrl)
+	ledgerUseCase := NewLedgerUseCase(mockRepo)
+	ctx := context.Background()
+	asOf := time.Date(2025, 1, 31, 23, 59, 59, 0, time.UTC)
+
+	mockRepo.EXPECT().
+		GetTrialBalance(gomock.Any(), &asOf).
+		Return([]domain.TrialBalanceLine{
+			{LedgerAccount: domain.LedgerCash, Debits: domain.MustParseMoney("30"), Credits: domain.MustParseMoney("50"), Balance: domain.MustParseMoney("-20")},
+			{LedgerAccount: domain.LedgerReceivables, Debits: domain.MustParseMoney("50"), Credits: domain.MustParseMoney("30"), Balance: domain.MustParseMoney("20")},
+		}, nil)
+
+	// Act
+	trialBalance, err := ledgerUseCase.GetTrialBalance(ctx, &asOf)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, trialBalance.Lines, 2)
+	assert.Equal(t, domain.MustParseMoney("80"), trialBalance.TotalDebits)
+	assert.True(t, trialBalance.Balanced())
+	assert.Equal(t, &asOf, trialBalance.AsOf)
+}
+
+func TestLedgerUseCase_GetTrialBalance_WhenFailedToGetTrialBalance_ShouldReturnError(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockLedgerRepository(ctrl)
+	ledgerUseCase := NewLedgerUseCase(mockRepo)
+	ctx := context.Background()
+
+	mockRepo.EXPECT().
+		GetTrialBalance(gomock.Any(), gomock.Nil()).
+		Return(nil, errors.New("db error"))
+
+	// Act
+	trialBalance, err := ledgerUseCase.GetTrialBalance(ctx, nil)
+
+	// Assert
+	assert.EqualError(t, err, "db error")
+	assert.Nil(t, trialBalance)
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+)
+
+// OperationTypeCatalog caches the operation types so that creating a transaction does not
+// query them every time. It is refreshed whenever an operation type changes through this
+// instance, and reloaded once it is older than maxAge to pick up changes made by others.
+type OperationTypeCatalog struct {
+	repo   repository.OperationTypeRepository
+	maxAge time.Duration
+
+	mu             sync.RWMutex
+	operationTypes map[domain.OperationType]domain.OperationTypeDefinition
+	loadedAt       time.Time
+}
+
+func NewOperationTypeCatalog(repo repository.OperationTypeRepository, maxAge time.Duration) *OperationTypeCatalog {
+	return &OperationTypeCatalog{
+		repo:   repo,
+		maxAge: maxAge,
+	}
+}
+
+// Get returns the operation type or repository.ErrOperationTypeNotFound.
+func (c *OperationTypeCatalog) Get(ctx context.Context, id domain.OperationType) (*domain.OperationTypeDefinition, error) {
+	c.mu.RLock()
+	fresh := c.operationTypes != nil && time.Since(c.loadedAt) < c.maxAge
+	c.mu.RUnlock()
+
+	if !fresh {
+		if err := c.Refresh(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	c.mu.RLock()
+	operationType, found := c.operationTypes[id]
+	c.mu.RUnlock()
+
+	if !found {
+		return nil, repository.ErrOperationTypeNotFound
+	}
+	return &operationType, nil
+}
+
+func (c *OperationTypeCatalog) Refresh(ctx context.Context) error {
+	operationTypes, err := c.repo.ListOperationTypes(ctx)
+	if err != nil {
+		return err
+	}
+
+	catalog := make(map[domain.OperationType]domain.OperationTypeDefinition, len(operationTypes))
+	for _, operationType := range operationTypes {
+		catalog[operationType.ID()] = operationType
+	}
+
+	c.mu.Lock()
+	c.operationTypes = catalog
+	c.loadedAt = time.Now()
+	c.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/tracing"
+)
+
+var ErrSystemOperationType = errors.New("system operation types cannot be changed")
+
+type operationTypeUseCase struct {
+	repo    repository.OperationTypeRepository
+	catalog *OperationTypeCatalog
+}
+
+func NewOperationTypeUseCase(repo repository.OperationTypeRepository, catalog *OperationTypeCatalog) OperationTypeUseCase {
+	return &operationTypeUseCase{
+		repo:    repo,
+		catalog: catalog,
+	}
+}
+
+func (o *operationTypeUseCase) CreateOperationType(ctx context.Context, description string, direction domain.OperationDirection) (domain.OperationType, error) {
+	ctx, span := tracing.StartSpan(ctx, "OperationTypeUseCase.CreateOperationType")
+	defer span.End()
+
+	id, err := o.repo.CreateOperationType(ctx, domain.NewOperationTypeDefinition(description, direction))
+	if err != nil {
+		return 0, err
+	}
+
+	o.refreshCatalog(ctx)
+	return id, nil
+}
+
+func (o *operationTypeUseCase) ListOperationTypes(ctx context.Context) ([]domain.OperationTypeDefinition, error) {
+	ctx, span := tracing.StartSpan(ctx, "OperationTypeUseCase.ListOperationTypes")
+	defer span.End()
+
+	return o.repo.ListOperationTypes(ctx)
+}
+
+// UpdateOperationType changes only the fields that are informed. System types are
+// rejected, since the application relies on them to post reversals, charges, transfers
+// and disputes.
+func (o *operationTypeUseCase) UpdateOperationType(ctx context.Context, id domain.OperationType, description *string, active *bool) (*domain.OperationTypeDefinition, error) {
+	ctx, span := tracing.StartSpan(ctx, "OperationTypeUseCase.UpdateOperationType")
+	defer span.End()
+
+	if id.IsSystem() {
+		return nil, ErrSystemOperationType
+	}
+
+	operationType, err := o.repo.GetOperationType(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if description != nil {
+		operationType.SetDescription(*description)
+	}
+	if active != nil {
+		operationType.SetActive(*active)
+	}
+
+	if err := o.repo.UpdateOperationType(ctx, operationType); err != nil {
+		return nil, err
+	}
+
+	o.refreshCatalog(ctx)
+	return operationType, nil
+}
+
+// refreshCatalog does not fail the change that triggered it: the catalog reloads itself
+// once it expires anyway.
+func (o *operationTypeUseCase) refreshCatalog(ctx context.Context) {
+	if err := o.catalog.Refresh(ctx); err != nil {
+		logger.Logger.ErrorContext(ctx, "failed to refresh operation types catalog", slog.String("error", err.Error()))
+	}
+}
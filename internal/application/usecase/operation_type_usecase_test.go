@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/VieiraVitor/transaction-flow/internal/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationTypeUseCase_CreateOperationType_WhenCreated_ShouldRefreshCatalog(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOperationTypeRepository(ctrl)
+	catalog := NewOperationTypeCatalog(mockRepo, time.Hour)
+	operationTypeUseCase := NewOperationTypeUseCase(mockRepo, catalog)
+	ctx := context.Background()
+
+	created := newTestOperationType(5, "TARIFA", domain.DirectionDebit)
+	mockRepo.EXPECT().
+		CreateOperationType(gomock.Any(), domain.NewOperationTypeDefinition("TARIFA", domain.DirectionDebit)).
+		Return(domain.OperationType(5), nil)
+	mockRepo.EXPECT().
+		ListOperationTypes(gomock.Any()).
+		Return([]domain.OperationTypeDefinition{*created}, nil)
+
+	// Act
+	id, err := operationTypeUseCase.CreateOperationType(ctx, "TARIFA", domain.DirectionDebit)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.OperationType(5), id)
+
+	cached, err := catalog.Get(ctx, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, created, cached)
+}
+
+func TestOperationTypeUseCase_CreateOperationType_WhenFailedToCreate_ShouldReturnError(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOperationTypeRepository(ctrl)
+	operationTypeUseCase := NewOperationTypeUseCase(mockRepo, NewOperationTypeCatalog(mockRepo, time.Hour))
+	ctx := context.Background()
+
+	mockRepo.EXPECT().
+		CreateOperationType(gomock.Any(), gomock.Any()).
+		Return(domain.OperationType(0), domain.ErrAlreadyExists)
+
+	// Act
+	id, err := operationTypeUseCase.CreateOperationType(ctx, "SAQUE", domain.DirectionDebit)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+	assert.Equal(t, domain.OperationType(0), id)
+}
+
+func TestOperationTypeUseCase_UpdateOperationType_WhenDeactivated_ShouldRemoveItFromActiveCatalog(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOperationTypeRepository(ctrl)
+	catalog := NewOperationTypeCatalog(mockRepo, time.Hour)
+	operationTypeUseCase := NewOperationTypeUseCase(mockRepo, catalog)
+	ctx := context.Background()
+	active := false
+
+	updated := newTestOperationType(domain.Saque, "SAQUE", domain.DirectionDebit)
+	updated.SetActive(false)
+	mockRepo.EXPECT().
+		GetOperationType(gomock.Any(), domain.Saque).
+		Return(newTestOperationType(domain.Saque, "SAQUE", domain.DirectionDebit), nil)
+	mockRepo.EXPECT().
+		UpdateOperationType(gomock.Any(), updated).
+		Return(nil)
+	mockRepo.EXPECT().
+		ListOperationTypes(gomock.Any()).
+		Return([]domain.OperationTypeDefinition{*updated}, nil)
+
+	// Act
+	operationType, err := operationTypeUseCase.UpdateOperationType(ctx, domain.Saque, nil, &active)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, updated, operationType)
+
+	cached, err := catalog.Get(ctx, domain.Saque)
+	assert.NoError(t, err)
+	assert.False(t, cached.Active())
+}
+
+func TestOperationTypeUseCase_UpdateOperationType_WhenNotFound_ShouldReturnErrOperationTypeNotFound(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOperationTypeRepository(ctrl)
+	operationTypeUseCase := NewOperationTypeUseCase(mockRepo, NewOperationTypeCatalog(mockRepo, time.Hour))
+	ctx := context.Background()
+	description := "TARIFA"
+
+	mockRepo.EXPECT().
+		GetOperationType(gomock.Any(), domain.OperationType(13)).
+		Return(nil, repository.ErrOperationTypeNotFound)
+
+	// Act
+	operationType, err := operationTypeUseCase.UpdateOperationType(ctx, 13, &description, nil)
+
+	// Assert
+	assert.ErrorIs(t, err, repository.ErrOperationTypeNotFound)
+	assert.Nil(t, operationType)
+}
+
+func TestOperationTypeUseCase_UpdateOperationType_WhenSystemType_ShouldReturnErrSystemOperationType(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOperationTypeRepository(ctrl)
+	operationTypeUseCase := NewOperationTypeUseCase(mockRepo, NewOperationTypeCatalog(mockRepo, time.Hour))
+	ctx := context.Background()
+	active := false
+
+	for _, id := range []domain.OperationType{domain.Estorno, domain.MultaPorAtraso, domain.TransferenciaRecebida, domain.EstornoCreditoProvisorio} {
+		// Act
+		operationType, err := operationTypeUseCase.UpdateOperationType(ctx, id, nil, &active)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrSystemOperationType)
+		assert.Nil(t, operationType)
+	}
+}
+
+func TestOperationTypeCatalog_Get_WhenLoadedRecently_ShouldNotQueryAgain(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOperationTypeRepository(ctrl)
+	catalog := NewOperationTypeCatalog(mockRepo, time.Hour)
+	ctx := context.Background()
+
+	mockRepo.EXPECT().
+		ListOperationTypes(gomock.Any()).
+		Return([]domain.OperationTypeDefinition{*newTestOperationType(domain.Pagamento, "PAGAMENTO", domain.DirectionCredit)}, nil).
+		Times(1)
+
+	// Act
+	_, firstErr := catalog.Get(ctx, domain.Pagamento)
+	_, secondErr := catalog.Get(ctx, domain.Saque)
+
+	// Assert
+	assert.NoError(t, firstErr)
+	assert.ErrorIs(t, secondErr, repository.ErrOperationTypeNotFound)
+}
+
+func TestOperationTypeCatalog_Get_WhenExpired_ShouldReload(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOperationTypeRepository(ctrl)
+	catalog := NewOperationTypeCatalog(mockRepo, 0)
+	ctx := context.Background()
+
+	gomock.InOrder(
+		mockRepo.EXPECT().
+			ListOperationTypes(gomock.Any()).
+			Return([]domain.OperationTypeDefinition{}, nil),
+		mockRepo.EXPECT().
+			ListOperationTypes(gomock.Any()).
+			Return(nil, errors.New("connection reset")),
+	)
+
+	// Act
+	_, firstErr := catalog.Get(ctx, domain.Pagamento)
+	_, secondErr := catalog.Get(ctx, domain.Pagamento)
+
+	// Assert
+	assert.ErrorIs(t, firstErr, repository.ErrOperationTypeNotFound)
+	assert.EqualError(t, secondErr, "connection reset")
+}
@@ -2,38 +2,260 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/metrics"
 	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/tracing"
+)
+
+var (
+	ErrTransactionAccountNotFound = errors.New("account of the transaction does not exist")
+	ErrInvalidOperationType       = errors.New("invalid operation type")
+	ErrReversalOfReversal         = errors.New("a reversal cannot be reversed")
 )
 
 type transactionUseCase struct {
-	repo repository.TransactionRepository
+	repo           repository.TransactionRepository
+	accountRepo    repository.AccountRepository
+	operationTypes *OperationTypeCatalog
 }
 
-func NewTransactionUseCase(repo repository.TransactionRepository) TransactionUseCase {
+func NewTransactionUseCase(repo repository.TransactionRepository, accountRepo repository.AccountRepository, operationTypes *OperationTypeCatalog) TransactionUseCase {
 	return &transactionUseCase{
-		repo: repo,
+		repo:           repo,
+		accountRepo:    accountRepo,
+		operationTypes: operationTypes,
+	}
+}
+
+func (t *transactionUseCase) CreateTransaction(ctx context.Context, accountID int64, operationTypeID int, amount domain.Money) (int64, error) {
+	ctx, span := tracing.StartSpan(ctx, "TransactionUseCase.CreateTransaction")
+	defer span.End()
+
+	operationType, err := t.activeOperationType(ctx, domain.OperationType(operationTypeID))
+	if err != nil {
+		return 0, err
+	}
+
+	if operationType.ID().IsReversal() {
+		return 0, fmt.Errorf("%w: %d is only created by reversals", ErrInvalidOperationType, operationTypeID)
+	}
+	if operationType.ID().IsCharge() {
+		return 0, fmt.Errorf("%w: %d is only created by the charge accrual", ErrInvalidOperationType, operationTypeID)
+	}
+	if operationType.ID().IsTransfer() {
+		return 0, fmt.Errorf("%w: %d is only created by transfers", ErrInvalidOperationType, operationTypeID)
+	}
+	if operationType.ID().IsDispute() {
+		return 0, fmt.Errorf("%w: %d is only created by disputes", ErrInvalidOperationType, operationTypeID)
+	}
+
+	if operationType.ID() == domain.CompraParcelada {
+		return t.CreateInstallmentPurchase(ctx, accountID, amount, 1)
+	}
+
+	if _, err := ensureAccountExists(ctx, t.accountRepo, accountID); err != nil {
+		return 0, err
+	}
+
+	transaction := domain.NewTransaction(accountID, operationType.ID(), operationType.ApplySign(amount), time.Now())
+	id, err := t.repo.CreateTransaction(ctx, transaction)
+	if err != nil {
+		return 0, err
+	}
+	metrics.RecordTransactions(transaction)
+	return id, nil
+}
+
+// CreateInstallmentPurchase creates a CompraParcelada for the full amount together with its
+// schedule of installments, due on the account's due day.
+func (t *transactionUseCase) CreateInstallmentPurchase(ctx context.Context, accountID int64, amount domain.Money, installments int) (int64, error) {
+	ctx, span := tracing.StartSpan(ctx, "TransactionUseCase.CreateInstallmentPurchase")
+	defer span.End()
+
+	operationType, err := t.activeOperationType(ctx, domain.CompraParcelada)
+	if err != nil {
+		return 0, err
+	}
+
+	account, err := ensureAccountExists(ctx, t.accountRepo, accountID)
+	if err != nil {
+		return 0, err
+	}
+
+	purchase := domain.NewTransaction(accountID, operationType.ID(), operationType.ApplySign(amount), time.Now())
+	schedule, err := domain.NewInstallmentSchedule(purchase.Amount(), installments, purchase.EventDate(), account.BillingCycle())
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := t.repo.CreateInstallmentPurchase(ctx, purchase, schedule)
+	if err != nil {
+		return 0, err
 	}
+	metrics.RecordTransactions(purchase)
+	return id, nil
 }
 
-func (t *transactionUseCase) CreateTransaction(ctx context.Context, accountID int64, operationTypeID int, amount float64) (int64, error) {
-	operationType := domain.OperationType(operationTypeID)
+// ListInstallments returns ErrTransactionNotFound for unknown transactions and an empty
+// schedule for transactions that were not paid in installments.
+func (t *transactionUseCase) ListInstallments(ctx context.Context, transactionID int64) ([]domain.Installment, error) {
+	ctx, span := tracing.StartSpan(ctx, "TransactionUseCase.ListInstallments")
+	defer span.End()
 
-	if !operationType.IsValid() {
-		return 0, fmt.Errorf("invalid operation type: %v", operationType)
+	if _, err := t.repo.GetTransaction(ctx, transactionID); err != nil {
+		return nil, err
+	}
+	return t.repo.ListInstallments(ctx, transactionID)
+}
+
+// ReverseTransaction compensates amount of the transaction, or all of it when amount is nil.
+// Partial reversals are allowed as long as together they do not exceed the original amount
+// less what is disputed and not lost.
+func (t *transactionUseCase) ReverseTransaction(ctx context.Context, transactionID int64, amount *domain.Money) (int64, error) {
+	ctx, span := tracing.StartSpan(ctx, "TransactionUseCase.ReverseTransaction")
+	defer span.End()
+
+	original, err := t.repo.GetTransaction(ctx, transactionID)
+	if err != nil {
+		return 0, err
+	}
+
+	if original.ReversesTransactionID() != nil {
+		return 0, fmt.Errorf("%w: transaction %d reverses transaction %d", ErrReversalOfReversal, transactionID, *original.ReversesTransactionID())
+	}
+	if original.OperationTypeID().IsDispute() {
+		return 0, fmt.Errorf("%w: transaction %d is only reversed by losing its dispute", ErrInvalidOperationType, transactionID)
+	}
+	if original.OperationTypeID().IsTransfer() {
+		return 0, fmt.Errorf("%w: transaction %d is one leg of a transfer", ErrInvalidOperationType, transactionID)
+	}
+	// The installments not billed yet would still be charged after the purchase is
+	// credited back.
+	if original.OperationTypeID() == domain.CompraParcelada {
+		return 0, fmt.Errorf("%w: transaction %d is an installment purchase", ErrInvalidOperationType, transactionID)
 	}
 
-	if operationType.IsPayment() && amount < 0 {
-		amount = -amount
+	reversalAmount := original.Amount().Abs()
+	if amount != nil {
+		reversalAmount = *amount
 	}
 
-	if operationType.IsPurchaseOrWithdraw() && amount > 0 {
-		amount = -amount
+	reversal := domain.NewReversal(*original, reversalAmount, time.Now())
+	if _, err := ensureAccountExists(ctx, t.accountRepo, reversal.AccountID()); err != nil {
+		return 0, err
 	}
 
-	transaction := domain.NewTransaction(accountID, operationType, amount, time.Now())
-	return t.repo.CreateTransaction(ctx, transaction)
+	id, err := t.repo.CreateReversal(ctx, reversal)
+	if err != nil {
+		return 0, err
+	}
+	metrics.RecordTransactions(reversal)
+	return id, nil
+}
+
+// CreateTransfer moves amount from one account to the other, debiting the sender and
+// crediting the receiver at once.
+func (t *transactionUseCase) CreateTransfer(ctx context.Context, fromAccountID int64, toAccountID int64, amount domain.Money) (*domain.Transfer, error) {
+	ctx, span := tracing.StartSpan(ctx, "TransactionUseCase.CreateTransfer")
+	defer span.End()
+
+	transfer, err := domain.NewTransfer(fromAccountID, toAccountID, amount, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := ensureAccountExists(ctx, t.accountRepo, fromAccountID); err != nil {
+		return nil, err
+	}
+	if _, err := ensureAccountExists(ctx, t.accountRepo, toAccountID); err != nil {
+		return nil, err
+	}
+
+	created, err := t.repo.CreateTransfer(ctx, transfer)
+	if err != nil {
+		return nil, err
+	}
+	metrics.RecordTransactions(transfer.Legs())
+	return created, nil
+}
+
+// activeOperationType looks the operation type up in the catalog, rejecting unknown and
+// inactive types with ErrInvalidOperationType.
+func (t *transactionUseCase) activeOperationType(ctx context.Context, id domain.OperationType) (*domain.OperationTypeDefinition, error) {
+	operationType, err := t.operationTypes.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrOperationTypeNotFound) {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidOperationType, id)
+		}
+		return nil, err
+	}
+
+	if !operationType.Active() {
+		return nil, fmt.Errorf("%w: %d is inactive", ErrInvalidOperationType, id)
+	}
+
+	return operationType, nil
+}
+
+// ensureAccountExists rejects transactions for accounts that do not exist before anything
+// is written, instead of relying on the foreign key. Whether the account status accepts the
+// transaction is checked by the repository under the account lock.
+func ensureAccountExists(ctx context.Context, accountRepo repository.AccountRepository, accountID int64) (*domain.Account, error) {
+	account, err := accountRepo.GetAccount(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, repository.ErrAccountNotFound) {
+			return nil, fmt.Errorf("%w: %d", ErrTransactionAccountNotFound, accountID)
+		}
+		return nil, err
+	}
+	return account, nil
+}
+
+func (t *transactionUseCase) GetTransaction(ctx context.Context, transactionID int64) (*domain.Transaction, error) {
+	ctx, span := tracing.StartSpan(ctx, "TransactionUseCase.GetTransaction")
+	defer span.End()
+
+	return t.repo.GetTransaction(ctx, transactionID)
+}
+
+// ListTransactions returns one page of transactions and the cursor of the next page,
+// which is nil when there are no more transactions to read.
+func (t *transactionUseCase) ListTransactions(ctx context.Context, filter domain.TransactionFilter) ([]domain.Transaction, *domain.TransactionCursor, error) {
+	ctx, span := tracing.StartSpan(ctx, "TransactionUseCase.ListTransactions")
+	defer span.End()
+
+	pageSize := filter.Limit
+	if pageSize <= 0 {
+		pageSize = domain.DefaultTransactionPageSize
+	}
+	filter.Limit = pageSize + 1
+
+	transactions, err := t.repo.ListTransactions(ctx, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(transactions) <= pageSize {
+		return transactions, nil, nil
+	}
+
+	transactions = transactions[:pageSize]
+	last := transactions[pageSize-1]
+	return transactions, &domain.TransactionCursor{EventDate: last.EventDate(), ID: last.ID()}, nil
+}
+
+// GetAccountBalance returns ErrAccountNotFound for unknown accounts instead of an empty balance.
+func (t *transactionUseCase) GetAccountBalance(ctx context.Context, accountID int64, asOf *time.Time) (*domain.AccountBalance, error) {
+	ctx, span := tracing.StartSpan(ctx, "TransactionUseCase.GetAccountBalance")
+	defer span.End()
+
+	if _, err := t.accountRepo.GetAccount(ctx, accountID); err != nil {
+		return nil, err
+	}
+	return t.repo.GetAccountBalance(ctx, accountID, asOf)
 }
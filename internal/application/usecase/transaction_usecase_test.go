@@ -5,10 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/metrics"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
 	"github.com/VieiraVitor/transaction-flow/internal/mocks"
 	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -18,17 +22,21 @@ func TestTransactionUseCase_CreateTransaction_WhenValidInput_ShouldReturnID(t *t
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockTransactionRepository(ctrl)
-	transactionUsecase := NewTransactionUseCase(mockRepo)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
 
 	ctx := context.Background()
 	expectedID := int64(1)
 
+	mockAccountRepo.EXPECT().
+		GetAccount(gomock.Any(), gomock.Any()).
+		Return(domain.NewAccount("12345678900", domain.MustParseMoney("1000")), nil)
 	mockRepo.EXPECT().
 		CreateTransaction(gomock.Any(), gomock.Any()).
 		Return(expectedID, nil)
 
 	// Act
-	id, err := transactionUsecase.CreateTransaction(ctx, int64(2), int(domain.Pagamento), 100)
+	id, err := transactionUsecase.CreateTransaction(ctx, int64(2), int(domain.Pagamento), domain.MustParseMoney("100"))
 
 	// Assert
 	assert.NoError(t, err)
@@ -41,17 +49,21 @@ func TestTransactionUseCase_CreateTransaction_WhenFailedToCreateTransaction_Shou
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockTransactionRepository(ctrl)
-	transactionUsecase := NewTransactionUseCase(mockRepo)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
 
 	ctx := context.Background()
 	expectedError := errors.New("failed to create transaction")
 
+	mockAccountRepo.EXPECT().
+		GetAccount(gomock.Any(), gomock.Any()).
+		Return(domain.NewAccount("12345678900", domain.MustParseMoney("1000")), nil)
 	mockRepo.EXPECT().
 		CreateTransaction(gomock.Any(), gomock.Any()).
 		Return(int64(0), expectedError)
 
 	// Act
-	id, err := transactionUsecase.CreateTransaction(ctx, int64(1), int(domain.Saque), -10)
+	id, err := transactionUsecase.CreateTransaction(ctx, int64(1), int(domain.Saque), domain.MustParseMoney("-10"))
 
 	// Assert
 	assert.Error(t, err)
@@ -59,16 +71,50 @@ func TestTransactionUseCase_CreateTransaction_WhenFailedToCreateTransaction_Shou
 	assert.Equal(t, "failed to create transaction", err.Error())
 }
 
+func TestTransactionUseCase_CreateTransaction_WhenCreated_ShouldRecordMetrics(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
+
+	ctx := context.Background()
+	created := metrics.TransactionsCreatedTotal.WithLabelValues("3")
+	amount := metrics.TransactionAmountTotal.WithLabelValues("3")
+	createdBefore, amountBefore := testutil.ToFloat64(created), testutil.ToFloat64(amount)
+
+	mockAccountRepo.EXPECT().
+		GetAccount(gomock.Any(), gomock.Any()).
+		Return(domain.NewAccount("12345678900", domain.MustParseMoney("1000")), nil)
+	mockRepo.EXPECT().
+		CreateTransaction(gomock.Any(), gomock.Any()).
+		Return(int64(1), nil)
+
+	// Act
+	_, err := transactionUsecase.CreateTransaction(ctx, int64(1), int(domain.Saque), domain.MustParseMoney("12.50"))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, createdBefore+1, testutil.ToFloat64(created))
+	assert.Equal(t, amountBefore+12.5, testutil.ToFloat64(amount))
+}
+
 func TestTransactionUseCase_CreateTransaction_WhenIsPurchaseOrdWithdraw_ShouldEnsureAmountIsNegative(t *testing.T) {
 	// Arrange
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockTransactionRepository(ctrl)
-	transactionUsecase := NewTransactionUseCase(mockRepo)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
 	ctx := context.Background()
 
-	expectedAmount := -100.50
+	expectedAmount := domain.MustParseMoney("-100.5")
+	mockAccountRepo.EXPECT().
+		GetAccount(gomock.Any(), gomock.Any()).
+		Return(domain.NewAccount("12345678900", domain.MustParseMoney("1000")), nil)
 	mockRepo.EXPECT().
 		CreateTransaction(gomock.Any(), gomock.Any()).
 		DoAndReturn(func(_ context.Context, transaction domain.Transaction) (int64, error) {
@@ -77,7 +123,7 @@ func TestTransactionUseCase_CreateTransaction_WhenIsPurchaseOrdWithdraw_ShouldEn
 		})
 
 	// Act
-	id, err := transactionUsecase.CreateTransaction(ctx, 1, int(domain.CompraAVista), 100.50)
+	id, err := transactionUsecase.CreateTransaction(ctx, 1, int(domain.CompraAVista), domain.MustParseMoney("100.5"))
 
 	// Assert
 	assert.NoError(t, err)
@@ -90,10 +136,14 @@ func TestTransactionUseCase_CreateTransaction_WhenIsPayment_ShouldEnsureAmountIs
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockTransactionRepository(ctrl)
-	transactionUsecase := NewTransactionUseCase(mockRepo)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
 	ctx := context.Background()
 
-	expectedAmount := 100.50
+	expectedAmount := domain.MustParseMoney("100.5")
+	mockAccountRepo.EXPECT().
+		GetAccount(gomock.Any(), gomock.Any()).
+		Return(domain.NewAccount("12345678900", domain.MustParseMoney("1000")), nil)
 	mockRepo.EXPECT().
 		CreateTransaction(gomock.Any(), gomock.Any()).
 		DoAndReturn(func(_ context.Context, transaction domain.Transaction) (int64, error) {
@@ -102,7 +152,7 @@ func TestTransactionUseCase_CreateTransaction_WhenIsPayment_ShouldEnsureAmountIs
 		})
 
 	// Act
-	id, err := transactionUsecase.CreateTransaction(ctx, 1, int(domain.Pagamento), -100.50)
+	id, err := transactionUsecase.CreateTransaction(ctx, 1, int(domain.Pagamento), domain.MustParseMoney("-100.5"))
 
 	// Assert
 	assert.NoError(t, err)
@@ -115,16 +165,767 @@ func TestTransactionUseCase_CreateTransaction_WhenInvalidInput_ShouldReturnError
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockTransactionRepository(ctrl)
-	transactionUsecase := NewTransactionUseCase(mockRepo)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
 
 	ctx := context.Background()
-	expectedError := fmt.Errorf("invalid operation type: %v", 10)
 
 	// Act
-	id, err := transactionUsecase.CreateTransaction(ctx, int64(1), 10, 50)
+	id, err := transactionUsecase.CreateTransaction(ctx, int64(1), 10, domain.MustParseMoney("50"))
 
 	// Assert
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, ErrInvalidOperationType)
+	assert.EqualError(t, err, "invalid operation type: 10")
+	assert.Equal(t, int64(0), id)
+}
+
+func TestTransactionUseCase_CreateTransaction_WhenOperationTypeIsInactive_ShouldReturnErrInvalidOperationType(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	mockOperationTypeRepo := mocks.NewMockOperationTypeRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, NewOperationTypeCatalog(mockOperationTypeRepo, time.Minute))
+	ctx := context.Background()
+
+	inactive := newTestOperationType(13, "TARIFA", domain.DirectionDebit)
+	inactive.SetActive(false)
+	mockOperationTypeRepo.EXPECT().
+		ListOperationTypes(gomock.Any()).
+		Return([]domain.OperationTypeDefinition{*inactive}, nil)
+
+	// Act
+	id, err := transactionUsecase.CreateTransaction(ctx, int64(1), 13, domain.MustParseMoney("50"))
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidOperationType)
+	assert.Equal(t, int64(0), id)
+}
+
+func TestTransactionUseCase_CreateTransaction_WhenCustomCreditOperationType_ShouldEnsureAmountIsPositive(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	mockOperationTypeRepo := mocks.NewMockOperationTypeRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, NewOperationTypeCatalog(mockOperationTypeRepo, time.Minute))
+	ctx := context.Background()
+
+	mockOperationTypeRepo.EXPECT().
+		ListOperationTypes(gomock.Any()).
+		Return([]domain.OperationTypeDefinition{*newTestOperationType(13, "CASHBACK", domain.DirectionCredit)}, nil)
+	mockAccountRepo.EXPECT().
+		GetAccount(gomock.Any(), gomock.Any()).
+		Return(domain.NewAccount("12345678900", domain.MustParseMoney("1000")), nil)
+	mockRepo.EXPECT().
+		CreateTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, transaction domain.Transaction) (int64, error) {
+			assert.Equal(t, domain.OperationType(13), transaction.OperationTypeID())
+			assert.Equal(t, domain.MustParseMoney("20"), transaction.Amount())
+			return int64(1), nil
+		})
+
+	// Act
+	id, err := transactionUsecase.CreateTransaction(ctx, 1, 13, domain.MustParseMoney("-20"))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), id)
+}
+
+func TestTransactionUseCase_CreateTransaction_WhenFailedToLoadOperationTypes_ShouldReturnError(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	mockOperationTypeRepo := mocks.NewMockOperationTypeRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, NewOperationTypeCatalog(mockOperationTypeRepo, time.Minute))
+	ctx := context.Background()
+	expectedError := errors.New("connection reset")
+
+	mockOperationTypeRepo.EXPECT().
+		ListOperationTypes(gomock.Any()).
+		Return(nil, expectedError)
+
+	// Act
+	id, err := transactionUsecase.CreateTransaction(ctx, int64(1), int(domain.CompraAVista), domain.MustParseMoney("50"))
+
+	// Assert
+	assert.Equal(t, expectedError, err)
+	assert.NotErrorIs(t, err, ErrInvalidOperationType)
+	assert.Equal(t, int64(0), id)
+}
+
+func TestTransactionUseCase_CreateTransaction_WhenAccountDoesNotExist_ShouldReturnErrTransactionAccountNotFound(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
+	ctx := context.Background()
+
+	mockAccountRepo.EXPECT().
+		GetAccount(gomock.Any(), int64(99)).
+		Return(nil, repository.ErrAccountNotFound)
+
+	// Act
+	id, err := transactionUsecase.CreateTransaction(ctx, int64(99), int(domain.CompraAVista), domain.MustParseMoney("50"))
+
+	// Assert
+	assert.ErrorIs(t, err, ErrTransactionAccountNotFound)
 	assert.Equal(t, int64(0), id)
+}
+
+func TestTransactionUseCase_CreateTransaction_WhenFailedToGetAccount_ShouldReturnError(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
+	ctx := context.Background()
+	expectedError := errors.New("connection reset")
+
+	mockAccountRepo.EXPECT().
+		GetAccount(gomock.Any(), int64(1)).
+		Return(nil, expectedError)
+
+	// Act
+	id, err := transactionUsecase.CreateTransaction(ctx, int64(1), int(domain.CompraAVista), domain.MustParseMoney("50"))
+
+	// Assert
 	assert.Equal(t, expectedError, err)
+	assert.NotErrorIs(t, err, ErrTransactionAccountNotFound)
+	assert.Equal(t, int64(0), id)
+}
+
+func TestTransactionUseCase_CreateTransaction_WhenReversalOperationType_ShouldReturnErrInvalidOperationType(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	mockOperationTypeRepo := mocks.NewMockOperationTypeRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, NewOperationTypeCatalog(mockOperationTypeRepo, time.Minute))
+	ctx := context.Background()
+
+	mockOperationTypeRepo.EXPECT().
+		ListOperationTypes(gomock.Any()).
+		Return([]domain.OperationTypeDefinition{*newTestOperationType(domain.Estorno, "ESTORNO", domain.DirectionCredit)}, nil)
+
+	// Act
+	id, err := transactionUsecase.CreateTransaction(ctx, int64(1), int(domain.Estorno), domain.MustParseMoney("50"))
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidOperationType)
+	assert.EqualError(t, err, "invalid operation type: 5 is only created by reversals")
+	assert.Equal(t, int64(0), id)
+}
+
+func TestTransactionUseCase_CreateTransaction_WhenChargeOperationType_ShouldReturnErrInvalidOperationType(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	mockOperationTypeRepo := mocks.NewMockOperationTypeRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, NewOperationTypeCatalog(mockOperationTypeRepo, time.Minute))
+	ctx := context.Background()
+
+	mockOperationTypeRepo.EXPECT().
+		ListOperationTypes(gomock.Any()).
+		Return([]domain.OperationTypeDefinition{*newTestOperationType(domain.JurosRotativos, "JUROS ROTATIVOS", domain.DirectionDebit)}, nil)
+
+	// Act
+	id, err := transactionUsecase.CreateTransaction(ctx, int64(1), int(domain.JurosRotativos), domain.MustParseMoney("50"))
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidOperationType)
+	assert.EqualError(t, err, "invalid operation type: 7 is only created by the charge accrual")
+	assert.Equal(t, int64(0), id)
+}
+
+func TestTransactionUseCase_ReverseTransaction_WhenAmountNotInformed_ShouldReverseWholeTransaction(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
+	ctx := context.Background()
+
+	original := domain.NewTransaction(1, domain.CompraAVista, domain.MustParseMoney("-80"))
+	original.SetID(7)
+
+	mockRepo.EXPECT().
+		GetTransaction(gomock.Any(), int64(7)).
+		Return(&original, nil)
+	mockAccountRepo.EXPECT().
+		GetAccount(gomock.Any(), int64(1)).
+		Return(domain.NewAccount("12345678900", domain.MustParseMoney("1000")), nil)
+	mockRepo.EXPECT().
+		CreateReversal(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, reversal domain.Transaction) (int64, error) {
+			assert.Equal(t, domain.Estorno, reversal.OperationTypeID())
+			assert.Equal(t, domain.MustParseMoney("80"), reversal.Amount())
+			assert.Equal(t, int64(7), *reversal.ReversesTransactionID())
+			return int64(8), nil
+		})
+
+	// Act
+	id, err := transactionUsecase.ReverseTransaction(ctx, 7, nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8), id)
+}
+
+func TestTransactionUseCase_ReverseTransaction_WhenPartialAmount_ShouldReverseOnlyIt(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
+	ctx := context.Background()
+
+	original := domain.NewTransaction(1, domain.Pagamento, domain.MustParseMoney("80"))
+	original.SetID(7)
+	amount := domain.MustParseMoney("30")
+
+	mockRepo.EXPECT().
+		GetTransaction(gomock.Any(), int64(7)).
+		Return(&original, nil)
+	mockAccountRepo.EXPECT().
+		GetAccount(gomock.Any(), int64(1)).
+		Return(domain.NewAccount("12345678900", domain.MustParseMoney("1000")), nil)
+	mockRepo.EXPECT().
+		CreateReversal(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, reversal domain.Transaction) (int64, error) {
+			assert.Equal(t, domain.EstornoPagamento, reversal.OperationTypeID())
+			assert.Equal(t, domain.MustParseMoney("-30"), reversal.Amount())
+			return int64(8), nil
+		})
+
+	// Act
+	id, err := transactionUsecase.ReverseTransaction(ctx, 7, &amount)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8), id)
+}
+
+func TestTransactionUseCase_ReverseTransaction_WhenTransactionIsDisputed_ShouldReturnErrTransactionDisputed(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
+	ctx := context.Background()
+
+	original := domain.NewTransaction(1, domain.CompraAVista, domain.MustParseMoney("-100"))
+	original.SetID(7)
+
+	mockRepo.EXPECT().
+		GetTransaction(gomock.Any(), int64(7)).
+		Return(&original, nil)
+	mockAccountRepo.EXPECT().
+		GetAccount(gomock.Any(), int64(1)).
+		Return(domain.NewAccount("12345678900", domain.MustParseMoney("1000")), nil)
+	mockRepo.EXPECT().
+		CreateReversal(gomock.Any(), gomock.Any()).
+		Return(int64(0), fmt.Errorf("%w: 100.00 of transaction 7 is disputed", repository.ErrTransactionDisputed))
+
+	// Act
+	id, err := transactionUsecase.ReverseTransaction(ctx, 7, nil)
+
+	// Assert
+	assert.ErrorIs(t, err, repository.ErrTransactionDisputed)
+	assert.Equal(t, int64(0), id)
+}
+
+func TestTransactionUseCase_ReverseTransaction_WhenTransactionIsReversal_ShouldReturnErrReversalOfReversal(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
+	ctx := context.Background()
+
+	original := domain.NewTransaction(1, domain.CompraAVista, domain.MustParseMoney("-80"))
+	original.SetID(7)
+	reversal := domain.NewReversal(original, domain.MustParseMoney("80"), time.Now())
+	reversal.SetID(8)
+
+	mockRepo.EXPECT().
+		GetTransaction(gomock.Any(), int64(8)).
+		Return(&reversal, nil)
+
+	// Act
+	id, err := transactionUsecase.ReverseTransaction(ctx, 8, nil)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrReversalOfReversal)
+	assert.EqualError(t, err, "a reversal cannot be reversed: transaction 8 reverses transaction 7")
+	assert.Equal(t, int64(0), id)
+}
+
+func TestTransactionUseCase_ReverseTransaction_WhenProvisionalCredit_ShouldReturnErrInvalidOperationType(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
+	ctx := context.Background()
+
+	credit := domain.NewTransaction(1, domain.CreditoProvisorio, domain.MustParseMoney("80"))
+	credit.SetID(8)
+
+	mockRepo.EXPECT().
+		GetTransaction(gomock.Any(), int64(8)).
+		Return(&credit, nil)
+
+	// Act
+	id, err := transactionUsecase.ReverseTransaction(ctx, 8, nil)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidOperationType)
+	assert.Equal(t, int64(0), id)
+}
+
+func TestTransactionUseCase_ReverseTransaction_WhenTransferOrInstallmentPurchase_ShouldReturnErrInvalidOperationType(t *testing.T) {
+	tests := []struct {
+		name          string
+		operationType domain.OperationType
+		amount        string
+	}{
+		{name: "sent transfer", operationType: domain.TransferenciaEnviada, amount: "-50"},
+		{name: "received transfer", operationType: domain.TransferenciaRecebida, amount: "50"},
+		{name: "installment purchase", operationType: domain.CompraParcelada, amount: "-300"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockTransactionRepository(ctrl)
+			mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+			transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
+			ctx := context.Background()
+
+			original := domain.NewTransaction(1, tt.operationType, domain.MustParseMoney(tt.amount))
+			original.SetID(12)
+
+			mockRepo.EXPECT().
+				GetTransaction(gomock.Any(), int64(12)).
+				Return(&original, nil)
+
+			// Act
+			id, err := transactionUsecase.ReverseTransaction(ctx, 12, nil)
+
+			// Assert
+			assert.ErrorIs(t, err, ErrInvalidOperationType)
+			assert.Equal(t, int64(0), id)
+		})
+	}
+}
+
+func TestTransactionUseCase_ReverseTransaction_WhenTransactionNotFound_ShouldReturnErrTransactionNotFound(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
+	ctx := context.Background()
+
+	mockRepo.EXPECT().
+		GetTransaction(gomock.Any(), int64(9)).
+		Return(nil, repository.ErrTransactionNotFound)
+
+	// Act
+	id, err := transactionUsecase.ReverseTransaction(ctx, 9, nil)
+
+	// Assert
+	assert.ErrorIs(t, err, repository.ErrTransactionNotFound)
+	assert.Equal(t, int64(0), id)
+}
+
+func TestTransactionUseCase_CreateInstallmentPurchase_WhenValidInput_ShouldCreateSchedule(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
+	ctx := context.Background()
+
+	account := domain.NewAccount("12345678900", domain.MustParseMoney("1000"))
+	account.SetBillingCycle(domain.BillingCycle{ClosingDay: 8, DueDay: 15})
+
+	mockAccountRepo.EXPECT().
+		GetAccount(gomock.Any(), int64(1)).
+		Return(account, nil)
+	mockRepo.EXPECT().
+		CreateInstallmentPurchase(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, purchase domain.Transaction, installments []domain.Installment) (int64, error) {
+			assert.Equal(t, domain.CompraParcelada, purchase.OperationTypeID())
+			assert.Equal(t, domain.MustParseMoney("-100"), purchase.Amount())
+			assert.Len(t, installments, 3)
+			assert.Equal(t, domain.MustParseMoney("33.34"), installments[0].Amount)
+			assert.Equal(t, 15, installments[2].DueDate.Day())
+			return int64(3), nil
+		})
+
+	// Act
+	id, err := transactionUsecase.CreateInstallmentPurchase(ctx, 1, domain.MustParseMoney("100"), 3)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), id)
+}
+
+func TestTransactionUseCase_CreateTransaction_WhenCompraParcelada_ShouldCreateSingleInstallment(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
+	ctx := context.Background()
+
+	mockAccountRepo.EXPECT().
+		GetAccount(gomock.Any(), int64(1)).
+		Return(domain.NewAccount("12345678900", domain.MustParseMoney("1000")), nil)
+	mockRepo.EXPECT().
+		CreateInstallmentPurchase(gomock.Any(), gomock.Any(), gomock.Len(1)).
+		Return(int64(4), nil)
+
+	// Act
+	id, err := transactionUsecase.CreateTransaction(ctx, 1, int(domain.CompraParcelada), domain.MustParseMoney("50"))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), id)
+}
+
+func TestTransactionUseCase_CreateInstallmentPurchase_WhenTooManyInstallments_ShouldReturnErrInvalidInstallments(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
+	ctx := context.Background()
+
+	mockAccountRepo.EXPECT().
+		GetAccount(gomock.Any(), int64(1)).
+		Return(domain.NewAccount("12345678900", domain.MustParseMoney("1000")), nil)
+
+	// Act
+	id, err := transactionUsecase.CreateInstallmentPurchase(ctx, 1, domain.MustParseMoney("100"), domain.MaxInstallments+1)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidInstallments)
+	assert.Equal(t, int64(0), id)
+}
+
+func TestTransactionUseCase_ListInstallments_WhenTransactionNotFound_ShouldReturnErrTransactionNotFound(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
+	ctx := context.Background()
+
+	mockRepo.EXPECT().
+		GetTransaction(gomock.Any(), int64(9)).
+		Return(nil, repository.ErrTransactionNotFound)
+
+	// Act
+	installments, err := transactionUsecase.ListInstallments(ctx, 9)
+
+	// Assert
+	assert.ErrorIs(t, err, repository.ErrTransactionNotFound)
+	assert.Nil(t, installments)
+}
+
+func TestTransactionUseCase_ListTransactions_WhenMoreTransactionsThanLimit_ShouldReturnNextCursor(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
+	ctx := context.Background()
+
+	eventDate := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	transactions := make([]domain.Transaction, 3)
+	for i := range transactions {
+		transactions[i] = domain.NewTransaction(1, domain.CompraAVista, domain.MustParseMoney("-10"), eventDate.Add(-time.Duration(i)*time.Hour))
+		transactions[i].SetID(int64(3 - i))
+	}
+
+	mockRepo.EXPECT().
+		ListTransactions(gomock.Any(), domain.TransactionFilter{AccountID: 1, Limit: 3}).
+		Return(transactions, nil)
+
+	// Act
+	page, next, err := transactionUsecase.ListTransactions(ctx, domain.TransactionFilter{AccountID: 1, Limit: 2})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, page, 2)
+	assert.Equal(t, &domain.TransactionCursor{EventDate: transactions[1].EventDate(), ID: 2}, next)
+}
+
+func TestTransactionUseCase_ListTransactions_WhenLastPage_ShouldReturnNilCursor(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
+	ctx := context.Background()
+
+	transactions := []domain.Transaction{domain.NewTransaction(1, domain.Pagamento, domain.MustParseMoney("10"))}
+
+	mockRepo.EXPECT().
+		ListTransactions(gomock.Any(), domain.TransactionFilter{AccountID: 1, Limit: domain.DefaultTransactionPageSize + 1}).
+		Return(transactions, nil)
+
+	// Act
+	page, next, err := transactionUsecase.ListTransactions(ctx, domain.TransactionFilter{AccountID: 1})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, page, 1)
+	assert.Nil(t, next)
+}
+
+func TestTransactionUseCase_ListTransactions_WhenFailedToListTransactions_ShouldReturnError(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
+	ctx := context.Background()
+	expectedError := errors.New("failed to list transactions")
+
+	mockRepo.EXPECT().
+		ListTransactions(gomock.Any(), gomock.Any()).
+		Return(nil, expectedError)
+
+	// Act
+	page, next, err := transactionUsecase.ListTransactions(ctx, domain.TransactionFilter{AccountID: 1, Limit: 10})
+
+	// Assert
+	assert.Equal(t, expectedError, err)
+	assert.Nil(t, page)
+	assert.Nil(t, next)
+}
+
+func TestTransactionUseCase_GetTransaction_WhenValidInput_ShouldReturnTransaction(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
+	ctx := context.Background()
+
+	expected := domain.NewTransaction(1, domain.Saque, domain.MustParseMoney("-30"))
+	expected.SetID(7)
+
+	mockRepo.EXPECT().
+		GetTransaction(gomock.Any(), int64(7)).
+		Return(&expected, nil)
+
+	// Act
+	transaction, err := transactionUsecase.GetTransaction(ctx, 7)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, &expected, transaction)
+}
+
+func TestTransactionUseCase_GetAccountBalance_WhenAccountExists_ShouldReturnBalance(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
+	ctx := context.Background()
+
+	asOf := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	expected := &domain.AccountBalance{AccountID: 1, Balance: domain.MustParseMoney("-20"), Debits: domain.MustParseMoney("20"), AsOf: &asOf}
+
+	mockAccountRepo.EXPECT().
+		GetAccount(gomock.Any(), int64(1)).
+		Return(domain.NewAccount("12345678909", domain.MustParseMoney("100")), nil)
+	mockRepo.EXPECT().
+		GetAccountBalance(gomock.Any(), int64(1), &asOf).
+		Return(expected, nil)
+
+	// Act
+	balance, err := transactionUsecase.GetAccountBalance(ctx, 1, &asOf)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, expected, balance)
+}
+
+func TestTransactionUseCase_GetAccountBalance_WhenAccountDoesNotExist_ShouldReturnErrAccountNotFound(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
+	ctx := context.Background()
+
+	mockAccountRepo.EXPECT().
+		GetAccount(gomock.Any(), int64(1)).
+		Return(nil, repository.ErrAccountNotFound)
+
+	// Act
+	balance, err := transactionUsecase.GetAccountBalance(ctx, 1, nil)
+
+	// Assert
+	assert.ErrorIs(t, err, repository.ErrAccountNotFound)
+	assert.Nil(t, balance)
+}
+
+func TestTransactionUseCase_CreateTransfer_WhenValidInput_ShouldCreateTransfer(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
+	ctx := context.Background()
+
+	mockAccountRepo.EXPECT().
+		GetAccount(gomock.Any(), int64(1)).
+		Return(domain.NewAccount("12345678900", domain.MustParseMoney("1000")), nil)
+	mockAccountRepo.EXPECT().
+		GetAccount(gomock.Any(), int64(2)).
+		Return(domain.NewAccount("98765432100", domain.MustParseMoney("1000")), nil)
+	mockRepo.EXPECT().
+		CreateTransfer(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, transfer domain.Transfer) (*domain.Transfer, error) {
+			assert.Equal(t, int64(1), transfer.FromAccountID)
+			assert.Equal(t, int64(2), transfer.ToAccountID)
+			assert.Equal(t, domain.MustParseMoney("30"), transfer.Amount)
+			transfer.ID = 5
+			return &transfer, nil
+		})
+
+	// Act
+	transfer, err := transactionUsecase.CreateTransfer(ctx, 1, 2, domain.MustParseMoney("30"))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), transfer.ID)
+}
+
+func TestTransactionUseCase_CreateTransfer_WhenSameAccount_ShouldReturnErrInvalidTransfer(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
+	ctx := context.Background()
+
+	// Act
+	transfer, err := transactionUsecase.CreateTransfer(ctx, 1, 1, domain.MustParseMoney("30"))
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidTransfer)
+	assert.Nil(t, transfer)
+}
+
+func TestTransactionUseCase_CreateTransfer_WhenReceiverDoesNotExist_ShouldReturnErrTransactionAccountNotFound(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	transactionUsecase := NewTransactionUseCase(mockRepo, mockAccountRepo, newTestOperationTypeCatalog(ctrl))
+	ctx := context.Background()
+
+	mockAccountRepo.EXPECT().
+		GetAccount(gomock.Any(), int64(1)).
+		Return(domain.NewAccount("12345678900", domain.MustParseMoney("1000")), nil)
+	mockAccountRepo.EXPECT().
+		GetAccount(gomock.Any(), int64(99)).
+		Return(nil, repository.ErrAccountNotFound)
+
+	// Act
+	transfer, err := transactionUsecase.CreateTransfer(ctx, 1, 99, domain.MustParseMoney("30"))
+
+	// Assert
+	assert.ErrorIs(t, err, ErrTransactionAccountNotFound)
+	assert.EqualError(t, err, "account of the transaction does not exist: 99")
+	assert.Nil(t, transfer)
+}
+
+func newTestOperationType(id domain.OperationType, description string, direction domain.OperationDirection) *domain.OperationTypeDefinition {
+	operationType := domain.NewOperationTypeDefinition(description, direction)
+	operationType.SetID(id)
+	return operationType
+}
+
+// newTestOperationTypeCatalog returns a catalog with the four operation types seeded by the migrations.
+func newTestOperationTypeCatalog(ctrl *gomock.Controller) *OperationTypeCatalog {
+	mockOperationTypeRepo := mocks.NewMockOperationTypeRepository(ctrl)
+	mockOperationTypeRepo.EXPECT().
+		ListOperationTypes(gomock.Any()).
+		Return([]domain.OperationTypeDefinition{
+			*newTestOperationType(domain.CompraAVista, "COMPRA A VISTA", domain.DirectionDebit),
+			*newTestOperationType(domain.CompraParcelada, "COMPRA PARCELADA", domain.DirectionDebit),
+			*newTestOperationType(domain.Saque, "SAQUE", domain.DirectionDebit),
+			*newTestOperationType(domain.Pagamento, "PAGAMENTO", domain.DirectionCredit),
+		}, nil).
+		AnyTimes()
+	return NewOperationTypeCatalog(mockOperationTypeRepo, time.Minute)
 }
@@ -2,15 +2,74 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/VieiraVitor/transaction-flow/internal/domain"
 )
 
 type AccountUseCase interface {
-	CreateAccount(ctx context.Context, documentNumber string) (int64, error)
+	CreateAccount(ctx context.Context, documentNumber string, availableCreditLimit domain.Money) (int64, error)
 	GetAccount(ctx context.Context, accountID int64) (*domain.Account, error)
+	UpdateCreditLimit(ctx context.Context, accountID int64, creditLimit domain.Money) error
+	UpdateBillingCycle(ctx context.Context, accountID int64, closingDay int, dueDay int) error
+	UpdateStatus(ctx context.Context, accountID int64, status domain.AccountStatus, reason string, actor string) error
+	ListStatusChanges(ctx context.Context, accountID int64) ([]domain.AccountStatusChange, error)
 }
 
 type TransactionUseCase interface {
-	CreateTransaction(ctx context.Context, accountID int64, operationTypeID int, amount float64) (int64, error)
+	CreateTransaction(ctx context.Context, accountID int64, operationTypeID int, amount domain.Money) (int64, error)
+	CreateInstallmentPurchase(ctx context.Context, accountID int64, amount domain.Money, installments int) (int64, error)
+	ReverseTransaction(ctx context.Context, transactionID int64, amount *domain.Money) (int64, error)
+	CreateTransfer(ctx context.Context, fromAccountID int64, toAccountID int64, amount domain.Money) (*domain.Transfer, error)
+	GetTransaction(ctx context.Context, transactionID int64) (*domain.Transaction, error)
+	ListTransactions(ctx context.Context, filter domain.TransactionFilter) ([]domain.Transaction, *domain.TransactionCursor, error)
+	ListInstallments(ctx context.Context, transactionID int64) ([]domain.Installment, error)
+	GetAccountBalance(ctx context.Context, accountID int64, asOf *time.Time) (*domain.AccountBalance, error)
+}
+
+type InvoiceUseCase interface {
+	CloseInvoices(ctx context.Context, now time.Time) (int, error)
+	GetInvoice(ctx context.Context, invoiceID int64) (*domain.Invoice, error)
+	ListInvoices(ctx context.Context, accountID int64) ([]domain.Invoice, error)
+}
+
+type HealthUseCase interface {
+	CheckReadiness(ctx context.Context) domain.Readiness
+	BeginShutdown()
+}
+
+type LedgerUseCase interface {
+	GetTrialBalance(ctx context.Context, asOf *time.Time) (*domain.TrialBalance, error)
+}
+
+type AuthorizationUseCase interface {
+	CreateAuthorization(ctx context.Context, accountID int64, amount domain.Money) (*domain.Authorization, error)
+	GetAuthorization(ctx context.Context, authorizationID int64) (*domain.Authorization, error)
+	CaptureAuthorization(ctx context.Context, authorizationID int64, amount *domain.Money) (*domain.Authorization, error)
+	VoidAuthorization(ctx context.Context, authorizationID int64) (*domain.Authorization, error)
+	ExpireAuthorizations(ctx context.Context, now time.Time) (int, error)
+}
+
+type DisputeUseCase interface {
+	OpenDispute(ctx context.Context, transactionID int64, amount *domain.Money, reason string) (*domain.Dispute, error)
+	ResolveDispute(ctx context.Context, disputeID int64, outcome domain.DisputeStatus) (*domain.Dispute, error)
+	GetDispute(ctx context.Context, disputeID int64) (*domain.Dispute, error)
+	ListDisputes(ctx context.Context, transactionID int64) ([]domain.Dispute, error)
+}
+
+type ChargeUseCase interface {
+	AccrueCharges(ctx context.Context, now time.Time) (int, error)
+}
+
+type IdempotencyUseCase interface {
+	BeginRequest(ctx context.Context, scope string, key string, requestBody []byte) (*domain.IdempotentResponse, string, error)
+	CompleteRequest(ctx context.Context, scope string, key string, claimToken string, response domain.IdempotentResponse) error
+	AbortRequest(ctx context.Context, scope string, key string, claimToken string) error
+	PurgeExpiredKeys(ctx context.Context) (int64, error)
+}
+
+type OperationTypeUseCase interface {
+	CreateOperationType(ctx context.Context, description string, direction domain.OperationDirection) (domain.OperationType, error)
+	ListOperationTypes(ctx context.Context) ([]domain.OperationTypeDefinition, error)
+	UpdateOperationType(ctx context.Context, id domain.OperationType, description *string, active *bool) (*domain.OperationTypeDefinition, error)
 }
@@ -3,14 +3,25 @@ package domain
 import "time"
 
 type Account struct {
-	id             int64
-	documentNumber string
-	createdAt      time.Time
+	id                   int64
+	documentNumber       string
+	documentType         DocumentType
+	creditLimit          Money
+	availableCreditLimit Money
+	creditBalance        Money
+	billingCycle         BillingCycle
+	status               AccountStatus
+	createdAt            time.Time
 }
 
-func NewAccount(documentNumber string) *Account {
+// NewAccount creates an account with creditLimit, all of it still available.
+func NewAccount(documentNumber string, creditLimit Money) *Account {
 	return &Account{
-		documentNumber: documentNumber,
+		documentNumber:       documentNumber,
+		creditLimit:          creditLimit,
+		availableCreditLimit: creditLimit,
+		billingCycle:         BillingCycle{ClosingDay: DefaultClosingDay, DueDay: DefaultDueDay},
+		status:               AccountStatusActive,
 	}
 }
 
@@ -22,6 +33,38 @@ func (a *Account) DocumentNumber() string {
 	return a.documentNumber
 }
 
+// DocumentType tells whether the document number is a CPF or a CNPJ. It is empty for
+// accounts created before document numbers were validated whose number is not valid.
+func (a *Account) DocumentType() DocumentType {
+	return a.documentType
+}
+
+// CreditLimit is the total limit granted to the account.
+func (a *Account) CreditLimit() Money {
+	return a.creditLimit
+}
+
+// AvailableCreditLimit is what is left of the credit limit after open debits and pending
+// authorizations. It never goes above the credit limit, but charges and lost disputes may
+// take it below zero.
+func (a *Account) AvailableCreditLimit() Money {
+	return a.availableCreditLimit
+}
+
+// CreditBalance is what credits paid beyond the credit limit. It is spent before the
+// available credit limit.
+func (a *Account) CreditBalance() Money {
+	return a.creditBalance
+}
+
+func (a *Account) BillingCycle() BillingCycle {
+	return a.billingCycle
+}
+
+func (a *Account) Status() AccountStatus {
+	return a.status
+}
+
 func (a *Account) CreatedAt() time.Time {
 	return a.createdAt
 }
@@ -30,6 +73,26 @@ func (a *Account) SetID(id int64) {
 	a.id = id
 }
 
+func (a *Account) SetDocumentType(documentType DocumentType) {
+	a.documentType = documentType
+}
+
+func (a *Account) SetAvailableCreditLimit(availableCreditLimit Money) {
+	a.availableCreditLimit = availableCreditLimit
+}
+
+func (a *Account) SetCreditBalance(creditBalance Money) {
+	a.creditBalance = creditBalance
+}
+
+func (a *Account) SetBillingCycle(billingCycle BillingCycle) {
+	a.billingCycle = billingCycle
+}
+
+func (a *Account) SetStatus(status AccountStatus) {
+	a.status = status
+}
+
 func (a *Account) SetCreatedAt(createdAt time.Time) {
 	a.createdAt = createdAt
 }
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// AccountBalance aggregates the transactions of an account. Debits and Credits are the
+// totals of each side as positive amounts and Balance is their signed difference, negative
+// when the account owes money. AsOf is set when only transactions up to that event date
+// were considered.
+type AccountBalance struct {
+	AccountID         int64
+	Balance           Money
+	Debits            Money
+	Credits           Money
+	LastTransactionAt *time.Time
+	AsOf              *time.Time
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// AccountStatus controls which transactions an account accepts. Active accounts accept
+// everything, blocked accounts only accept credits such as payments and closed accounts
+// accept nothing.
+type AccountStatus string
+
+const (
+	AccountStatusActive  AccountStatus = "ACTIVE"
+	AccountStatusBlocked AccountStatus = "BLOCKED"
+	AccountStatusClosed  AccountStatus = "CLOSED"
+)
+
+var (
+	ErrInvalidAccountStatus           = errors.New("invalid account status")
+	ErrInvalidAccountStatusTransition = errors.New("invalid account status transition")
+	ErrAccountBlocked                 = errors.New("account is blocked")
+	ErrAccountClosed                  = errors.New("account is closed")
+)
+
+func (s AccountStatus) IsValid() bool {
+	return s == AccountStatusActive || s == AccountStatusBlocked || s == AccountStatusClosed
+}
+
+// CanTransitionTo tells whether an account may move from s to status. Closing is final.
+func (s AccountStatus) CanTransitionTo(status AccountStatus) bool {
+	switch s {
+	case AccountStatusActive:
+		return status == AccountStatusBlocked || status == AccountStatusClosed
+	case AccountStatusBlocked:
+		return status == AccountStatusActive || status == AccountStatusClosed
+	default:
+		return false
+	}
+}
+
+// AccountStatusChange records who moved an account from one status to another and why.
+type AccountStatusChange struct {
+	ID         int64
+	AccountID  int64
+	FromStatus AccountStatus
+	ToStatus   AccountStatus
+	Reason     string
+	Actor      string
+	ChangedAt  time.Time
+}
+
+// ChangeStatus moves the account to status and returns the change to be recorded in its
+// history.
+func (a *Account) ChangeStatus(status AccountStatus, reason string, actor string, changedAt time.Time) (AccountStatusChange, error) {
+	if !status.IsValid() {
+		return AccountStatusChange{}, fmt.Errorf("%w: %q", ErrInvalidAccountStatus, status)
+	}
+	if !a.status.CanTransitionTo(status) {
+		return AccountStatusChange{}, fmt.Errorf("%w: from %s to %s", ErrInvalidAccountStatusTransition, a.status, status)
+	}
+
+	change := AccountStatusChange{
+		AccountID:  a.id,
+		FromStatus: a.status,
+		ToStatus:   status,
+		Reason:     reason,
+		Actor:      actor,
+		ChangedAt:  changedAt,
+	}
+	a.status = status
+	return change, nil
+}
+
+// AcceptsTransactions tells whether the account takes new transactions in the given
+// direction.
+func (a *Account) AcceptsTransactions(direction OperationDirection) error {
+	return a.status.AcceptsTransactions(a.id, direction)
+}
+
+// AcceptsTransactions tells whether an account with status s takes new transactions in the
+// given direction. It lets the repositories check the status read under the account lock
+// without loading the whole account.
+func (s AccountStatus) AcceptsTransactions(accountID int64, direction OperationDirection) error {
+	switch s {
+	case AccountStatusClosed:
+		return fmt.Errorf("%w: account %d", ErrAccountClosed, accountID)
+	case AccountStatusBlocked:
+		if direction == DirectionDebit {
+			return fmt.Errorf("%w: account %d only accepts credits", ErrAccountBlocked, accountID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccount_ChangeStatus_WhenTransitionIsAllowed_ShouldReturnChange(t *testing.T) {
+	tests := map[string]struct {
+		from AccountStatus
+		to   AccountStatus
+	}{
+		"ActiveToBlocked": {from: AccountStatusActive, to: AccountStatusBlocked},
+		"ActiveToClosed":  {from: AccountStatusActive, to: AccountStatusClosed},
+		"BlockedToActive": {from: AccountStatusBlocked, to: AccountStatusActive},
+		"BlockedToClosed": {from: AccountStatusBlocked, to: AccountStatusClosed},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			changedAt := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+			account := NewAccount("12345678909", MustParseMoney("1000"))
+			account.SetID(1)
+			account.SetStatus(tt.from)
+
+			// Act
+			change, err := account.ChangeStatus(tt.to, "fraud suspicion", "analyst@bank", changedAt)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.to, account.Status())
+			assert.Equal(t, AccountStatusChange{
+				AccountID:  1,
+				FromStatus: tt.from,
+				ToStatus:   tt.to,
+				Reason:     "fraud suspicion",
+				Actor:      "analyst@bank",
+				ChangedAt:  changedAt,
+			}, change)
+		})
+	}
+}
+
+func TestAccount_ChangeStatus_WhenTransitionIsNotAllowed_ShouldReturnError(t *testing.T) {
+	tests := map[string]struct {
+		from     AccountStatus
+		to       AccountStatus
+		expected error
+	}{
+		"ActiveToActive":   {from: AccountStatusActive, to: AccountStatusActive, expected: ErrInvalidAccountStatusTransition},
+		"BlockedToBlocked": {from: AccountStatusBlocked, to: AccountStatusBlocked, expected: ErrInvalidAccountStatusTransition},
+		"ClosedToActive":   {from: AccountStatusClosed, to: AccountStatusActive, expected: ErrInvalidAccountStatusTransition},
+		"ClosedToBlocked":  {from: AccountStatusClosed, to: AccountStatusBlocked, expected: ErrInvalidAccountStatusTransition},
+		"UnknownStatus":    {from: AccountStatusActive, to: AccountStatus("SUSPENDED"), expected: ErrInvalidAccountStatus},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			account := NewAccount("12345678909", MustParseMoney("1000"))
+			account.SetStatus(tt.from)
+
+			// Act
+			_, err := account.ChangeStatus(tt.to, "reason", "actor", time.Now())
+
+			// Assert
+			assert.ErrorIs(t, err, tt.expected)
+			assert.Equal(t, tt.from, account.Status())
+		})
+	}
+}
+
+func TestAccount_AcceptsTransactions(t *testing.T) {
+	tests := map[string]struct {
+		status    AccountStatus
+		direction OperationDirection
+		expected  error
+	}{
+		"ActiveDebit":   {status: AccountStatusActive, direction: DirectionDebit},
+		"ActiveCredit":  {status: AccountStatusActive, direction: DirectionCredit},
+		"BlockedDebit":  {status: AccountStatusBlocked, direction: DirectionDebit, expected: ErrAccountBlocked},
+		"BlockedCredit": {status: AccountStatusBlocked, direction: DirectionCredit},
+		"ClosedDebit":   {status: AccountStatusClosed, direction: DirectionDebit, expected: ErrAccountClosed},
+		"ClosedCredit":  {status: AccountStatusClosed, direction: DirectionCredit, expected: ErrAccountClosed},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			account := NewAccount("12345678909", MustParseMoney("1000"))
+			account.SetStatus(tt.status)
+
+			// Act
+			err := account.AcceptsTransactions(tt.direction)
+
+			// Assert
+			if tt.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// AuthorizationStatus tells what happened to the credit an authorization holds. Only
+// pending authorizations hold credit; the other statuses are final.
+type AuthorizationStatus string
+
+const (
+	AuthorizationPending  AuthorizationStatus = "PENDING"
+	AuthorizationCaptured AuthorizationStatus = "CAPTURED"
+	AuthorizationVoided   AuthorizationStatus = "VOIDED"
+	AuthorizationExpired  AuthorizationStatus = "EXPIRED"
+)
+
+var (
+	ErrInvalidAuthorization        = errors.New("invalid authorization")
+	ErrAuthorizationNotPending     = errors.New("authorization is not pending")
+	ErrAuthorizationExpired        = errors.New("authorization expired")
+	ErrCaptureExceedsAuthorization = errors.New("capture exceeds the authorized amount")
+)
+
+// Authorization reserves part of the available credit limit of an account for a purchase
+// whose final amount is not known yet. The hold is released when the authorization is
+// captured, voided or expires; capturing also posts a CompraAVista for the final amount.
+type Authorization struct {
+	ID             int64
+	AccountID      int64
+	Amount         Money
+	CapturedAmount Money
+	Status         AuthorizationStatus
+	TransactionID  *int64
+	ExpiresAt      time.Time
+	CreatedAt      time.Time
+}
+
+func NewAuthorization(accountID int64, amount Money, createdAt time.Time, ttl time.Duration) (Authorization, error) {
+	if !amount.IsPositive() {
+		return Authorization{}, fmt.Errorf("%w: amount must be positive", ErrInvalidAuthorization)
+	}
+
+	return Authorization{
+		AccountID: accountID,
+		Amount:    amount,
+		Status:    AuthorizationPending,
+		ExpiresAt: createdAt.Add(ttl),
+		CreatedAt: createdAt,
+	}, nil
+}
+
+// IsExpired tells whether a pending authorization is past its expiry at the given moment.
+func (a Authorization) IsExpired(at time.Time) bool {
+	return a.Status == AuthorizationPending && !at.Before(a.ExpiresAt)
+}
+
+// Capture marks the authorization as captured for amount, or for all of it when amount is
+// nil, and returns the purchase to be posted. Whatever is not captured is released.
+func (a *Authorization) Capture(amount *Money, capturedAt time.Time) (Transaction, error) {
+	if err := a.ensurePending(capturedAt); err != nil {
+		return Transaction{}, err
+	}
+
+	capturedAmount := a.Amount
+	if amount != nil {
+		capturedAmount = *amount
+	}
+	if !capturedAmount.IsPositive() {
+		return Transaction{}, fmt.Errorf("%w: capture amount must be positive", ErrInvalidAuthorization)
+	}
+	if a.Amount.Sub(capturedAmount).IsNegative() {
+		return Transaction{}, fmt.Errorf("%w: %s is more than %s", ErrCaptureExceedsAuthorization, capturedAmount, a.Amount)
+	}
+
+	a.Status = AuthorizationCaptured
+	a.CapturedAmount = capturedAmount
+	return NewTransaction(a.AccountID, CompraAVista, capturedAmount.Neg(), capturedAt), nil
+}
+
+// Void cancels the authorization, releasing the whole hold.
+func (a *Authorization) Void(voidedAt time.Time) error {
+	if err := a.ensurePending(voidedAt); err != nil {
+		return err
+	}
+	a.Status = AuthorizationVoided
+	return nil
+}
+
+// Expire releases the hold of a pending authorization that is past its expiry.
+func (a *Authorization) Expire(at time.Time) error {
+	if a.Status != AuthorizationPending {
+		return fmt.Errorf("%w: authorization %d is %s", ErrAuthorizationNotPending, a.ID, a.Status)
+	}
+	if !a.IsExpired(at) {
+		return fmt.Errorf("%w: authorization %d only expires at %s", ErrInvalidAuthorization, a.ID, a.ExpiresAt.Format(time.RFC3339))
+	}
+	a.Status = AuthorizationExpired
+	return nil
+}
+
+func (a Authorization) ensurePending(at time.Time) error {
+	if a.Status != AuthorizationPending {
+		return fmt.Errorf("%w: authorization %d is %s", ErrAuthorizationNotPending, a.ID, a.Status)
+	}
+	if a.IsExpired(at) {
+		return fmt.Errorf("%w: authorization %d expired at %s", ErrAuthorizationExpired, a.ID, a.ExpiresAt.Format(time.RFC3339))
+	}
+	return nil
+}
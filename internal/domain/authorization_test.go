@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuthorization_WhenAmountIsNotPositive_ShouldReturnErrInvalidAuthorization(t *testing.T) {
+	tests := map[string]struct {
+		amount Money
+	}{
+		"ZeroAmount":     {amount: MustParseMoney("0")},
+		"NegativeAmount": {amount: MustParseMoney("-10")},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			_, err := NewAuthorization(1, tt.amount, time.Now(), time.Hour)
+
+			// Assert
+			assert.ErrorIs(t, err, ErrInvalidAuthorization)
+		})
+	}
+}
+
+func TestAuthorization_Capture_ShouldReturnPurchaseForCapturedAmount(t *testing.T) {
+	tests := map[string]struct {
+		amount   *Money
+		expected string
+	}{
+		"FullCapture":    {amount: nil, expected: "100"},
+		"PartialCapture": {amount: moneyPtr(MustParseMoney("72.30")), expected: "72.30"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			createdAt := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+			authorization, _ := NewAuthorization(1, MustParseMoney("100"), createdAt, 24*time.Hour)
+			capturedAt := createdAt.Add(time.Hour)
+
+			// Act
+			purchase, err := authorization.Capture(tt.amount, capturedAt)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, AuthorizationCaptured, authorization.Status)
+			assert.Equal(t, MustParseMoney(tt.expected), authorization.CapturedAmount)
+			assert.Equal(t, int64(1), purchase.AccountID())
+			assert.Equal(t, CompraAVista, purchase.OperationTypeID())
+			assert.Equal(t, MustParseMoney(tt.expected).Neg(), purchase.Amount())
+			assert.Equal(t, capturedAt, purchase.EventDate())
+		})
+	}
+}
+
+func TestAuthorization_Capture_WhenNotAllowed_ShouldReturnError(t *testing.T) {
+	createdAt := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		status     AuthorizationStatus
+		amount     Money
+		capturedAt time.Time
+		expected   error
+	}{
+		"ExceedsAmount":  {status: AuthorizationPending, amount: MustParseMoney("100.01"), capturedAt: createdAt, expected: ErrCaptureExceedsAuthorization},
+		"ZeroAmount":     {status: AuthorizationPending, amount: MustParseMoney("0"), capturedAt: createdAt, expected: ErrInvalidAuthorization},
+		"AlreadyVoided":  {status: AuthorizationVoided, amount: MustParseMoney("10"), capturedAt: createdAt, expected: ErrAuthorizationNotPending},
+		"PastExpiration": {status: AuthorizationPending, amount: MustParseMoney("10"), capturedAt: createdAt.Add(24 * time.Hour), expected: ErrAuthorizationExpired},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			authorization, _ := NewAuthorization(1, MustParseMoney("100"), createdAt, 24*time.Hour)
+			authorization.Status = tt.status
+
+			// Act
+			_, err := authorization.Capture(&tt.amount, tt.capturedAt)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.expected)
+			assert.Equal(t, tt.status, authorization.Status)
+		})
+	}
+}
+
+func TestAuthorization_Void_WhenPending_ShouldMarkAsVoided(t *testing.T) {
+	// Arrange
+	authorization, _ := NewAuthorization(1, MustParseMoney("100"), time.Now(), time.Hour)
+
+	// Act
+	err := authorization.Void(time.Now())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, AuthorizationVoided, authorization.Status)
+}
+
+func TestAuthorization_Expire(t *testing.T) {
+	createdAt := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		at       time.Time
+		expected AuthorizationStatus
+		err      error
+	}{
+		"BeforeExpiration": {at: createdAt.Add(time.Hour - time.Second), expected: AuthorizationPending, err: ErrInvalidAuthorization},
+		"AtExpiration":     {at: createdAt.Add(time.Hour), expected: AuthorizationExpired},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			authorization, _ := NewAuthorization(1, MustParseMoney("100"), createdAt, time.Hour)
+
+			// Act
+			err := authorization.Expire(tt.at)
+
+			// Assert
+			if tt.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.err)
+			}
+			assert.Equal(t, tt.expected, authorization.Status)
+		})
+	}
+}
+
+func moneyPtr(m Money) *Money {
+	return &m
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultClosingDay and DefaultDueDay configure the billing cycle of accounts that do not
+// choose another one: invoices close on the 3rd and are due on the 10th.
+const (
+	DefaultClosingDay = 3
+	DefaultDueDay     = 10
+)
+
+var ErrInvalidBillingCycle = errors.New("invalid billing cycle")
+
+// BillingCycle tells when the invoices of an account close and fall due. Both days are
+// between 1 and 28 so that every month has them.
+type BillingCycle struct {
+	ClosingDay int
+	DueDay     int
+}
+
+func NewBillingCycle(closingDay int, dueDay int) (BillingCycle, error) {
+	if closingDay < 1 || closingDay > 28 {
+		return BillingCycle{}, fmt.Errorf("%w: closing day must be between 1 and 28", ErrInvalidBillingCycle)
+	}
+	if dueDay < 1 || dueDay > 28 {
+		return BillingCycle{}, fmt.Errorf("%w: due day must be between 1 and 28", ErrInvalidBillingCycle)
+	}
+	if closingDay == dueDay {
+		return BillingCycle{}, fmt.Errorf("%w: closing day and due day must differ", ErrInvalidBillingCycle)
+	}
+	return BillingCycle{ClosingDay: closingDay, DueDay: dueDay}, nil
+}
+
+// NextClosingDate returns the first closing date after t. Invoices close at midnight UTC
+// of the closing day, so transactions made on the closing day belong to the next invoice.
+func (c BillingCycle) NextClosingDate(t time.Time) time.Time {
+	t = t.UTC()
+	closingDate := time.Date(t.Year(), t.Month(), c.ClosingDay, 0, 0, 0, 0, time.UTC)
+	if !closingDate.After(t) {
+		closingDate = closingDate.AddDate(0, 1, 0)
+	}
+	return closingDate
+}
+
+// DueDate returns the due date of the invoice closing on closingDate: the first due day
+// after it.
+func (c BillingCycle) DueDate(closingDate time.Time) time.Time {
+	closingDate = closingDate.UTC()
+	dueDate := time.Date(closingDate.Year(), closingDate.Month(), c.DueDay, 0, 0, 0, 0, time.UTC)
+	if !dueDate.After(closingDate) {
+		dueDate = dueDate.AddDate(0, 1, 0)
+	}
+	return dueDate
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBillingCycle_WhenInvalidDays_ShouldReturnErrInvalidBillingCycle(t *testing.T) {
+	tests := map[string]struct {
+		closingDay int
+		dueDay     int
+	}{
+		"ClosingDayZero":    {0, 10},
+		"ClosingDayAfter28": {29, 10},
+		"DueDayAfter28":     {3, 31},
+		"SameClosingAndDue": {10, 10},
+		"NegativeDueDay":    {3, -1},
+	}
+
+	for name, tt := range tests {
+		// Act
+		_, err := NewBillingCycle(tt.closingDay, tt.dueDay)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidBillingCycle, name)
+	}
+}
+
+func TestBillingCycle_NextClosingDate_ShouldReturnFirstClosingAfterDate(t *testing.T) {
+	cycle := BillingCycle{ClosingDay: 3, DueDay: 10}
+
+	tests := map[string]struct {
+		date     time.Time
+		expected time.Time
+	}{
+		"BeforeClosingDay": {time.Date(2025, 1, 2, 23, 0, 0, 0, time.UTC), time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)},
+		"OnClosingDate":    {time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)},
+		"AfterClosingDay":  {time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for name, tt := range tests {
+		// Act
+		closingDate := cycle.NextClosingDate(tt.date)
+
+		// Assert
+		assert.Equal(t, tt.expected, closingDate, name)
+	}
+}
+
+func TestBillingCycle_DueDate_ShouldReturnFirstDueDayAfterClosing(t *testing.T) {
+	closingDate := time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	sameMonth := BillingCycle{ClosingDay: 20, DueDay: 27}.DueDate(closingDate)
+	nextMonth := BillingCycle{ClosingDay: 20, DueDay: 5}.DueDate(closingDate)
+
+	// Assert
+	assert.Equal(t, time.Date(2025, 12, 27, 0, 0, 0, 0, time.UTC), sameMonth)
+	assert.Equal(t, time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), nextMonth)
+}
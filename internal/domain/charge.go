@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrInvalidInterestRate = errors.New("interest rate must be a non-negative percentage with at most two fractional digits")
+
+// InterestRate is a rate in basis points, 1200 being 12.00%.
+type InterestRate int64
+
+// ParseInterestRate parses a percentage with up to two decimals, "12.5" and "12.5%" being
+// 12.50%.
+func ParseInterestRate(value string) (InterestRate, error) {
+	digits := strings.TrimSuffix(strings.TrimSpace(value), "%")
+	if strings.HasPrefix(digits, "-") {
+		return 0, ErrInvalidInterestRate
+	}
+
+	percent, err := ParseMoney(digits)
+	if err != nil {
+		return 0, ErrInvalidInterestRate
+	}
+
+	return InterestRate(percent.Cents()), nil
+}
+
+func (r InterestRate) String() string {
+	return fmt.Sprintf("%d.%02d%%", r/100, r%100)
+}
+
+// OverdueInvoice is the latest invoice of an account that is past its due date, with the
+// charges already accrued on it.
+type OverdueInvoice struct {
+	Invoice          Invoice
+	LastInterestDate *time.Time
+	LateFeeCharged   bool
+}
+
+// DailyInterest is one day of the monthly rate on the unpaid amount, pro rata over a
+// 30-day month and rounded half up to the cent.
+func DailyInterest(unpaid Money, monthlyRate InterestRate) Money {
+	return NewMoneyFromCents((unpaid.Cents()*int64(monthlyRate) + 150000) / 300000)
+}
+
+// NewInterestCharge builds the revolving interest (juros rotativos) debit accrued on day
+// for the amount of the invoice still unpaid at the start of that day.
+func NewInterestCharge(invoice Invoice, unpaid Money, day time.Time, monthlyRate InterestRate) Transaction {
+	charge := NewTransaction(invoice.AccountID, JurosRotativos, DailyInterest(unpaid, monthlyRate).Neg(), day)
+	charge.invoiceID = &invoice.ID
+	charge.explanation = fmt.Sprintf(
+		"revolving interest for %s on %s unpaid of invoice %d at %s per month",
+		day.Format(time.DateOnly), unpaid, invoice.ID, monthlyRate,
+	)
+	return charge
+}
+
+// NewLateFee builds the fixed late fee (multa por atraso) debit charged once on the day
+// after the due date of an invoice that was not fully paid.
+func NewLateFee(invoice Invoice, unpaid Money, fee Money, day time.Time) Transaction {
+	charge := NewTransaction(invoice.AccountID, MultaPorAtraso, fee.Abs().Neg(), day)
+	charge.invoiceID = &invoice.ID
+	charge.explanation = fmt.Sprintf(
+		"late fee for invoice %d due on %s with %s unpaid",
+		invoice.ID, invoice.DueDate.Format(time.DateOnly), unpaid,
+	)
+	return charge
+}
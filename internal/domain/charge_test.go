@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInterestRate_WhenValidPercentage_ShouldReturnBasisPoints(t *testing.T) {
+	tests := map[string]InterestRate{
+		"0":      0,
+		"12":     1200,
+		"12.5":   1250,
+		"12.05%": 1205,
+		" 0.1 ":  10,
+	}
+
+	for input, expected := range tests {
+		// Act
+		rate, err := ParseInterestRate(input)
+
+		// Assert
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, rate, input)
+	}
+}
+
+func TestParseInterestRate_WhenInvalidPercentage_ShouldReturnError(t *testing.T) {
+	for _, input := range []string{"", "%", "-1", "-0", "12.005", "1e3", "abc", ".5", "12."} {
+		// Act
+		_, err := ParseInterestRate(input)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidInterestRate, input)
+	}
+}
+
+func TestDailyInterest_ShouldProRateMonthlyRateAndRoundHalfUp(t *testing.T) {
+	tests := map[string]struct {
+		unpaid   string
+		rate     InterestRate
+		expected string
+	}{
+		"ExactCents":       {"1000", 1200, "4"},
+		"RoundsHalfUp":     {"83.34", 1200, "0.33"},
+		"TooSmallToCharge": {"0.50", 1200, "0"},
+	}
+
+	for name, tt := range tests {
+		// Act
+		interest := DailyInterest(MustParseMoney(tt.unpaid), tt.rate)
+
+		// Assert
+		assert.Equal(t, MustParseMoney(tt.expected), interest, name)
+	}
+}
+
+func TestNewInterestCharge_ShouldDebitInterestAndExplainIt(t *testing.T) {
+	// Arrange
+	invoice := Invoice{ID: 7, AccountID: 1}
+	day := time.Date(2025, 2, 11, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	charge := NewInterestCharge(invoice, MustParseMoney("1000"), day, 1250)
+
+	// Assert
+	assert.Equal(t, JurosRotativos, charge.OperationTypeID())
+	assert.Equal(t, MustParseMoney("-4.17"), charge.Amount())
+	assert.Equal(t, MustParseMoney("-4.17"), charge.Balance())
+	assert.Equal(t, day, charge.EventDate())
+	assert.Equal(t, int64(7), *charge.InvoiceID())
+	assert.Equal(t, "revolving interest for 2025-02-11 on 1000.00 unpaid of invoice 7 at 12.50% per month", charge.Explanation())
+}
+
+func TestNewLateFee_ShouldDebitFixedFee(t *testing.T) {
+	// Arrange
+	invoice := Invoice{ID: 7, AccountID: 1, DueDate: time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)}
+
+	// Act
+	fee := NewLateFee(invoice, MustParseMoney("83.34"), MustParseMoney("10"), invoice.DueDate.AddDate(0, 0, 1))
+
+	// Assert
+	assert.Equal(t, MultaPorAtraso, fee.OperationTypeID())
+	assert.Equal(t, MustParseMoney("-10"), fee.Amount())
+	assert.Equal(t, int64(7), *fee.InvoiceID())
+	assert.Equal(t, "late fee for invoice 7 due on 2025-02-10 with 83.34 unpaid", fee.Explanation())
+}
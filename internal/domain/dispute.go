@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DisputeStatus tells where a dispute is in its lifecycle. A dispute is opened and gets its
+// provisional credit at once, and stays with it until resolved as won or lost.
+type DisputeStatus string
+
+const (
+	DisputeOpened            DisputeStatus = "OPENED"
+	DisputeProvisionalCredit DisputeStatus = "PROVISIONAL_CREDIT"
+	DisputeWon               DisputeStatus = "WON"
+	DisputeLost              DisputeStatus = "LOST"
+)
+
+var (
+	ErrInvalidDispute           = errors.New("invalid dispute")
+	ErrDisputeNotResolvable     = errors.New("dispute cannot be resolved")
+	ErrInvalidDisputeOutcome    = errors.New("invalid dispute outcome")
+	ErrTransactionNotDisputable = errors.New("transaction cannot be disputed")
+)
+
+// IsOutcome tells whether s is one of the final statuses a dispute is resolved with.
+func (s DisputeStatus) IsOutcome() bool {
+	return s == DisputeWon || s == DisputeLost
+}
+
+// Dispute is a customer's claim against a debit. Opening it credits the disputed amount
+// provisionally; the credit is kept when the dispute is won and reversed when it is lost.
+// Events records every status change, oldest first.
+type Dispute struct {
+	ID                             int64
+	TransactionID                  int64
+	AccountID                      int64
+	Amount                         Money
+	Reason                         string
+	Status                         DisputeStatus
+	ProvisionalCreditTransactionID *int64
+	ReversalTransactionID          *int64
+	OpenedAt                       time.Time
+	ResolvedAt                     *time.Time
+	Events                         []DisputeEvent
+}
+
+// DisputeEvent records a status change of a dispute and the transaction it posted, if any.
+// FromStatus is empty for the event that opens the dispute.
+type DisputeEvent struct {
+	ID            int64
+	DisputeID     int64
+	FromStatus    DisputeStatus
+	ToStatus      DisputeStatus
+	TransactionID *int64
+	Note          string
+	CreatedAt     time.Time
+}
+
+// NewDispute opens a dispute for amount of the transaction, or all of it when amount is
+// nil. Only purchases and withdrawals can be disputed, not the transactions the system
+// creates for reversals, charges, transfers or other disputes.
+func NewDispute(transaction Transaction, amount *Money, reason string, openedAt time.Time) (Dispute, error) {
+	operationType := transaction.OperationTypeID()
+	if !transaction.Amount().IsNegative() || operationType.IsReversal() || operationType.IsCharge() ||
+		operationType.IsTransfer() || operationType.IsDispute() {
+		return Dispute{}, fmt.Errorf("%w: transaction %d is not a purchase or withdrawal", ErrTransactionNotDisputable, transaction.ID())
+	}
+	if reason == "" {
+		return Dispute{}, fmt.Errorf("%w: reason is mandatory", ErrInvalidDispute)
+	}
+
+	disputedAmount := transaction.Amount().Abs()
+	if amount != nil {
+		disputedAmount = *amount
+	}
+	if !disputedAmount.IsPositive() {
+		return Dispute{}, fmt.Errorf("%w: amount must be positive", ErrInvalidDispute)
+	}
+	if transaction.Amount().Abs().Sub(disputedAmount).IsNegative() {
+		return Dispute{}, fmt.Errorf("%w: %s is more than the %s transaction", ErrInvalidDispute, disputedAmount, transaction.Amount().Abs())
+	}
+
+	return Dispute{
+		TransactionID: transaction.ID(),
+		AccountID:     transaction.AccountID(),
+		Amount:        disputedAmount,
+		Reason:        reason,
+		Status:        DisputeOpened,
+		OpenedAt:      openedAt,
+	}, nil
+}
+
+// GrantProvisionalCredit moves an opened dispute to PROVISIONAL_CREDIT and returns the
+// CreditoProvisorio to be posted for the disputed amount.
+func (d *Dispute) GrantProvisionalCredit() (Transaction, error) {
+	if d.Status != DisputeOpened {
+		return Transaction{}, fmt.Errorf("%w: dispute %d is %s", ErrInvalidDispute, d.ID, d.Status)
+	}
+	d.Status = DisputeProvisionalCredit
+	return NewTransaction(d.AccountID, CreditoProvisorio, d.Amount, d.OpenedAt), nil
+}
+
+// Resolve closes the dispute with outcome. A lost dispute returns the
+// EstornoCreditoProvisorio that takes the provisional credit back; a won one keeps the
+// credit and returns nil.
+func (d *Dispute) Resolve(outcome DisputeStatus, resolvedAt time.Time) (*Transaction, error) {
+	if !outcome.IsOutcome() {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidDisputeOutcome, outcome)
+	}
+	if d.Status != DisputeProvisionalCredit || d.ProvisionalCreditTransactionID == nil {
+		return nil, fmt.Errorf("%w: dispute %d is %s", ErrDisputeNotResolvable, d.ID, d.Status)
+	}
+
+	d.Status = outcome
+	d.ResolvedAt = &resolvedAt
+	if outcome == DisputeWon {
+		return nil, nil
+	}
+
+	reversal := NewTransaction(d.AccountID, EstornoCreditoProvisorio, d.Amount.Neg(), resolvedAt)
+	creditID := *d.ProvisionalCreditTransactionID
+	reversal.reversesTransactionID = &creditID
+	return &reversal, nil
+}
@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDispute_ShouldDisputeTheRequestedAmount(t *testing.T) {
+	tests := map[string]struct {
+		amount   *Money
+		expected string
+	}{
+		"FullAmount":    {amount: nil, expected: "100"},
+		"PartialAmount": {amount: moneyPtr(MustParseMoney("40.50")), expected: "40.50"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			openedAt := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+			purchase := NewTransaction(3, CompraAVista, MustParseMoney("-100"), openedAt)
+			purchase.SetID(7)
+
+			// Act
+			dispute, err := NewDispute(purchase, tt.amount, "not recognized", openedAt)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, Dispute{
+				TransactionID: 7,
+				AccountID:     3,
+				Amount:        MustParseMoney(tt.expected),
+				Reason:        "not recognized",
+				Status:        DisputeOpened,
+				OpenedAt:      openedAt,
+			}, dispute)
+		})
+	}
+}
+
+func TestNewDispute_WhenNotAllowed_ShouldReturnError(t *testing.T) {
+	tests := map[string]struct {
+		operationType OperationType
+		amount        Money
+		disputed      *Money
+		reason        string
+		expected      error
+	}{
+		"Payment":           {operationType: Pagamento, amount: MustParseMoney("100"), reason: "reason", expected: ErrTransactionNotDisputable},
+		"Charge":            {operationType: JurosRotativos, amount: MustParseMoney("-100"), reason: "reason", expected: ErrTransactionNotDisputable},
+		"Transfer":          {operationType: TransferenciaEnviada, amount: MustParseMoney("-100"), reason: "reason", expected: ErrTransactionNotDisputable},
+		"ProvisionalCredit": {operationType: EstornoCreditoProvisorio, amount: MustParseMoney("-100"), reason: "reason", expected: ErrTransactionNotDisputable},
+		"MissingReason":     {operationType: CompraAVista, amount: MustParseMoney("-100"), reason: "", expected: ErrInvalidDispute},
+		"ZeroAmount":        {operationType: CompraAVista, amount: MustParseMoney("-100"), disputed: moneyPtr(MustParseMoney("0")), reason: "reason", expected: ErrInvalidDispute},
+		"ExceedsAmount":     {operationType: Saque, amount: MustParseMoney("-100"), disputed: moneyPtr(MustParseMoney("100.01")), reason: "reason", expected: ErrInvalidDispute},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			transaction := NewTransaction(3, tt.operationType, tt.amount, time.Now())
+
+			// Act
+			_, err := NewDispute(transaction, tt.disputed, tt.reason, time.Now())
+
+			// Assert
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestDispute_GrantProvisionalCredit_ShouldReturnCreditForDisputedAmount(t *testing.T) {
+	// Arrange
+	openedAt := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	dispute := Dispute{AccountID: 3, Amount: MustParseMoney("40"), Status: DisputeOpened, OpenedAt: openedAt}
+
+	// Act
+	credit, err := dispute.GrantProvisionalCredit()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, DisputeProvisionalCredit, dispute.Status)
+	assert.Equal(t, int64(3), credit.AccountID())
+	assert.Equal(t, CreditoProvisorio, credit.OperationTypeID())
+	assert.Equal(t, MustParseMoney("40"), credit.Amount())
+	assert.Equal(t, openedAt, credit.EventDate())
+}
+
+func TestDispute_Resolve(t *testing.T) {
+	tests := map[string]struct {
+		outcome          DisputeStatus
+		expectedReversal bool
+	}{
+		"Won":  {outcome: DisputeWon},
+		"Lost": {outcome: DisputeLost, expectedReversal: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			creditID := int64(8)
+			resolvedAt := time.Date(2025, 2, 15, 12, 0, 0, 0, time.UTC)
+			dispute := Dispute{ID: 1, AccountID: 3, Amount: MustParseMoney("40"), Status: DisputeProvisionalCredit, ProvisionalCreditTransactionID: &creditID}
+
+			// Act
+			reversal, err := dispute.Resolve(tt.outcome, resolvedAt)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.outcome, dispute.Status)
+			assert.Equal(t, &resolvedAt, dispute.ResolvedAt)
+			if !tt.expectedReversal {
+				assert.Nil(t, reversal)
+				return
+			}
+			assert.Equal(t, EstornoCreditoProvisorio, reversal.OperationTypeID())
+			assert.Equal(t, MustParseMoney("-40"), reversal.Amount())
+			assert.Equal(t, &creditID, reversal.ReversesTransactionID())
+			assert.Equal(t, resolvedAt, reversal.EventDate())
+		})
+	}
+}
+
+func TestDispute_Resolve_WhenNotAllowed_ShouldReturnError(t *testing.T) {
+	creditID := int64(8)
+	tests := map[string]struct {
+		status   DisputeStatus
+		outcome  DisputeStatus
+		expected error
+	}{
+		"InvalidOutcome": {status: DisputeProvisionalCredit, outcome: DisputeOpened, expected: ErrInvalidDisputeOutcome},
+		"AlreadyWon":     {status: DisputeWon, outcome: DisputeLost, expected: ErrDisputeNotResolvable},
+		"AlreadyLost":    {status: DisputeLost, outcome: DisputeWon, expected: ErrDisputeNotResolvable},
+		"NotCredited":    {status: DisputeOpened, outcome: DisputeWon, expected: ErrDisputeNotResolvable},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			dispute := Dispute{ID: 1, AccountID: 3, Amount: MustParseMoney("40"), Status: tt.status, ProvisionalCreditTransactionID: &creditID}
+
+			// Act
+			_, err := dispute.Resolve(tt.outcome, time.Now())
+
+			// Assert
+			assert.ErrorIs(t, err, tt.expected)
+			assert.Equal(t, tt.status, dispute.Status)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type DocumentType string
+
+const (
+	DocumentTypeCPF  DocumentType = "CPF"
+	DocumentTypeCNPJ DocumentType = "CNPJ"
+)
+
+const (
+	cpfLength  = 11
+	cnpjLength = 14
+)
+
+var ErrInvalidDocument = errors.New("invalid document number")
+
+var (
+	cnpjFirstDigitWeights  = []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	cnpjSecondDigitWeights = []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+)
+
+func (d DocumentType) IsValid() bool {
+	return d == DocumentTypeCPF || d == DocumentTypeCNPJ
+}
+
+// NormalizeDocument strips the usual CPF/CNPJ punctuation from the document number and
+// validates its check digits. It returns the bare digits and whether they are a CPF or a
+// CNPJ; invalid documents are reported with an error wrapping ErrInvalidDocument.
+func NormalizeDocument(documentNumber string) (string, DocumentType, error) {
+	var digits strings.Builder
+	for _, r := range documentNumber {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '.' || r == '-' || r == '/' || r == ' ':
+		default:
+			return "", "", fmt.Errorf("%w: must contain only digits and the separators '.', '-' and '/'", ErrInvalidDocument)
+		}
+	}
+
+	normalized := digits.String()
+	var documentType DocumentType
+	switch len(normalized) {
+	case cpfLength:
+		documentType = DocumentTypeCPF
+	case cnpjLength:
+		documentType = DocumentTypeCNPJ
+	default:
+		return "", "", fmt.Errorf("%w: must have 11 digits (CPF) or 14 digits (CNPJ), got %d", ErrInvalidDocument, len(normalized))
+	}
+
+	if strings.Count(normalized, normalized[:1]) == len(normalized) {
+		return "", "", fmt.Errorf("%w: %s must not have all digits equal", ErrInvalidDocument, documentType)
+	}
+
+	if !hasValidCheckDigits(normalized, documentType) {
+		return "", "", fmt.Errorf("%w: %s check digits do not match", ErrInvalidDocument, documentType)
+	}
+
+	return normalized, documentType, nil
+}
+
+func hasValidCheckDigits(digits string, documentType DocumentType) bool {
+	body := len(digits) - 2
+
+	var first, second int
+	if documentType == DocumentTypeCPF {
+		first = checkDigit(digits[:body], descendingWeights(body+1))
+		second = checkDigit(digits[:body+1], descendingWeights(body+2))
+	} else {
+		first = checkDigit(digits[:body], cnpjFirstDigitWeights)
+		second = checkDigit(digits[:body+1], cnpjSecondDigitWeights)
+	}
+
+	return int(digits[body]-'0') == first && int(digits[body+1]-'0') == second
+}
+
+// checkDigit computes a modulo 11 check digit, as defined for both CPF and CNPJ.
+func checkDigit(digits string, weights []int) int {
+	sum := 0
+	for i := range digits {
+		sum += int(digits[i]-'0') * weights[i]
+	}
+
+	remainder := sum % 11
+	if remainder < 2 {
+		return 0
+	}
+	return 11 - remainder
+}
+
+// descendingWeights returns the CPF weights, from start down to 2.
+func descendingWeights(start int) []int {
+	weights := make([]int, start-1)
+	for i := range weights {
+		weights[i] = start - i
+	}
+	return weights
+}
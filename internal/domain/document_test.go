@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeDocument_WhenValidDocument_ShouldReturnDigitsAndType(t *testing.T) {
+	tests := []struct {
+		input        string
+		expected     string
+		documentType DocumentType
+	}{
+		{"12345678909", "12345678909", DocumentTypeCPF},
+		{"123.456.789-09", "12345678909", DocumentTypeCPF},
+		{"529.982.247-25", "52998224725", DocumentTypeCPF},
+		{"11222333000181", "11222333000181", DocumentTypeCNPJ},
+		{"11.222.333/0001-81", "11222333000181", DocumentTypeCNPJ},
+	}
+
+	for _, tt := range tests {
+		// Act
+		normalized, documentType, err := NormalizeDocument(tt.input)
+
+		// Assert
+		assert.NoError(t, err, tt.input)
+		assert.Equal(t, tt.expected, normalized, tt.input)
+		assert.Equal(t, tt.documentType, documentType, tt.input)
+	}
+}
+
+func TestNormalizeDocument_WhenInvalidDocument_ShouldReturnReason(t *testing.T) {
+	tests := map[string]string{
+		"123":                "invalid document number: must have 11 digits (CPF) or 14 digits (CNPJ), got 3",
+		"123.456.789-0a":     "invalid document number: must contain only digits and the separators '.', '-' and '/'",
+		"111.111.111-11":     "invalid document number: CPF must not have all digits equal",
+		"123.456.789-00":     "invalid document number: CPF check digits do not match",
+		"11.222.333/0001-80": "invalid document number: CNPJ check digits do not match",
+	}
+
+	for input, reason := range tests {
+		// Act
+		_, _, err := NormalizeDocument(input)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidDocument, input)
+		assert.EqualError(t, err, reason, input)
+	}
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors raised when a write breaks one of the database constraints. Repositories translate
+// the driver errors into these so callers do not depend on Postgres error codes.
+var (
+	ErrAlreadyExists       = errors.New("resource already exists")
+	ErrReferenceNotFound   = errors.New("referenced resource does not exist")
+	ErrConstraintViolation = errors.New("value violates a data constraint")
+)
+
+var ErrAccountAlreadyExists = errors.New("account already exists")
+
+// AccountAlreadyExistsError is returned when an account is created with a document number
+// that is already registered. It carries the existing account so clients can recover.
+type AccountAlreadyExistsError struct {
+	AccountID      int64
+	DocumentNumber string
+}
+
+func (e *AccountAlreadyExistsError) Error() string {
+	return fmt.Sprintf("an account with document number %s already exists", e.DocumentNumber)
+}
+
+func (e *AccountAlreadyExistsError) Is(target error) bool {
+	return target == ErrAccountAlreadyExists || target == ErrAlreadyExists
+}
@@ -0,0 +1,42 @@
+package domain
+
+type HealthStatus string
+
+const (
+	HealthUp   HealthStatus = "UP"
+	HealthDown HealthStatus = "DOWN"
+)
+
+const (
+	HealthCheckDatabase   = "database"
+	HealthCheckMigrations = "migrations"
+	HealthCheckShutdown   = "shutdown"
+)
+
+// HealthCheck is the outcome of checking one dependency. Detail explains a failing check.
+type HealthCheck struct {
+	Name   string
+	Status HealthStatus
+	Detail string
+}
+
+// Readiness tells whether the instance can serve traffic, which it can only when every one
+// of its checks is up.
+type Readiness struct {
+	Status HealthStatus
+	Checks []HealthCheck
+}
+
+func NewReadiness(checks []HealthCheck) Readiness {
+	status := HealthUp
+	for _, check := range checks {
+		if check.Status != HealthUp {
+			status = HealthDown
+		}
+	}
+	return Readiness{Status: status, Checks: checks}
+}
+
+func (r Readiness) IsReady() bool {
+	return r.Status == HealthUp
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReadiness_ShouldBeReadyOnlyWhenEveryCheckIsUp(t *testing.T) {
+	tests := map[string]struct {
+		checks        []HealthCheck
+		expectedReady bool
+	}{
+		"AllChecksUp": {
+			checks:        []HealthCheck{{Name: HealthCheckDatabase, Status: HealthUp}, {Name: HealthCheckMigrations, Status: HealthUp}},
+			expectedReady: true,
+		},
+		"OneCheckDown": {
+			checks:        []HealthCheck{{Name: HealthCheckDatabase, Status: HealthUp}, {Name: HealthCheckShutdown, Status: HealthDown, Detail: "shutting down"}},
+			expectedReady: false,
+		},
+		"NoChecks": {
+			checks:        nil,
+			expectedReady: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			readiness := NewReadiness(tt.checks)
+
+			// Assert
+			assert.Equal(t, tt.expectedReady, readiness.IsReady())
+			assert.Equal(t, tt.checks, readiness.Checks)
+		})
+	}
+}
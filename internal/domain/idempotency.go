@@ -0,0 +1,8 @@
+package domain
+
+// IdempotentResponse is the response recorded for an Idempotency-Key so that retries of
+// the same request are answered without executing it again.
+type IdempotentResponse struct {
+	StatusCode int
+	Body       []byte
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const MaxInstallments = 24
+
+var ErrInvalidInstallments = errors.New("invalid installments")
+
+// Installment is one of the monthly parts an installment purchase (CompraParcelada) is
+// paid in. Amounts are positive.
+type Installment struct {
+	ID            int64
+	TransactionID int64
+	Number        int
+	Amount        Money
+	DueDate       time.Time
+}
+
+// NewInstallmentSchedule splits the total into count installments. The cents that do not
+// divide evenly are added to the first installment, so no installment is ever smaller than
+// the following ones. The first installment is billed on the invoice the purchase falls in
+// and each of the others on the following invoice, so the n-th installment is due on the
+// due date of the n-th invoice closing after the purchase.
+func NewInstallmentSchedule(total Money, count int, purchaseDate time.Time, cycle BillingCycle) ([]Installment, error) {
+	if count < 1 || count > MaxInstallments {
+		return nil, fmt.Errorf("%w: must be between 1 and %d", ErrInvalidInstallments, MaxInstallments)
+	}
+
+	cents := total.Abs().Cents()
+	if cents < int64(count) {
+		return nil, fmt.Errorf("%w: %s cannot be split into %d installments", ErrInvalidInstallments, total.Abs(), count)
+	}
+
+	base := cents / int64(count)
+	remainder := cents - base*int64(count)
+
+	firstClosingDate := cycle.NextClosingDate(purchaseDate)
+	schedule := make([]Installment, count)
+	for i := range schedule {
+		amount := base
+		if i == 0 {
+			amount += remainder
+		}
+
+		schedule[i] = Installment{
+			Number:  i + 1,
+			Amount:  NewMoneyFromCents(amount),
+			DueDate: cycle.DueDate(firstClosingDate.AddDate(0, i, 0)),
+		}
+	}
+
+	return schedule, nil
+}
+
+// RescheduleInstallments moves the installments of a purchase that were not billed yet to
+// cycle, keeping one installment per invoice: the first of them is due on the invoice
+// closing after from and each of the others on the following invoice. from is when the
+// purchase was made or, if part of it was already billed, the closing date of the last
+// invoice. pending must be ordered by number.
+func RescheduleInstallments(pending []Installment, from time.Time, cycle BillingCycle) []Installment {
+	firstClosingDate := cycle.NextClosingDate(from)
+	schedule := make([]Installment, len(pending))
+	for i, installment := range pending {
+		installment.DueDate = cycle.DueDate(firstClosingDate.AddDate(0, i, 0))
+		schedule[i] = installment
+	}
+	return schedule
+}
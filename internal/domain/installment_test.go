@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewInstallmentSchedule_WhenAmountDoesNotDivideEvenly_ShouldAddRemainderToFirstInstallment(t *testing.T) {
+	// Arrange
+	purchaseDate := time.Date(2025, 11, 20, 15, 30, 0, 0, time.UTC)
+
+	// Act
+	schedule, err := NewInstallmentSchedule(MustParseMoney("-100"), 3, purchaseDate, BillingCycle{ClosingDay: 3, DueDay: 10})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, schedule, 3)
+	assert.Equal(t, MustParseMoney("33.34"), schedule[0].Amount)
+	assert.Equal(t, MustParseMoney("33.33"), schedule[1].Amount)
+	assert.Equal(t, MustParseMoney("33.33"), schedule[2].Amount)
+	assert.Equal(t, time.Date(2025, 12, 10, 0, 0, 0, 0, time.UTC), schedule[0].DueDate)
+	assert.Equal(t, time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC), schedule[1].DueDate)
+	assert.Equal(t, time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC), schedule[2].DueDate)
+	assert.Equal(t, []int{1, 2, 3}, []int{schedule[0].Number, schedule[1].Number, schedule[2].Number})
+}
+
+func TestNewInstallmentSchedule_WhenPurchasedBeforeClosingDay_ShouldBillFirstInstallmentOnCurrentInvoice(t *testing.T) {
+	tests := map[string]struct {
+		purchaseDate     time.Time
+		cycle            BillingCycle
+		expectedDueDates []time.Time
+	}{
+		"BeforeClosingDay": {
+			purchaseDate:     time.Date(2025, 11, 2, 23, 59, 0, 0, time.UTC),
+			cycle:            BillingCycle{ClosingDay: 3, DueDay: 10},
+			expectedDueDates: []time.Time{time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 10, 0, 0, 0, 0, time.UTC)},
+		},
+		"OnClosingDay": {
+			purchaseDate:     time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC),
+			cycle:            BillingCycle{ClosingDay: 3, DueDay: 10},
+			expectedDueDates: []time.Time{time.Date(2025, 12, 10, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)},
+		},
+		"DueDayBeforeClosingDay": {
+			purchaseDate:     time.Date(2025, 12, 10, 0, 0, 0, 0, time.UTC),
+			cycle:            BillingCycle{ClosingDay: 25, DueDay: 5},
+			expectedDueDates: []time.Time{time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 5, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	for name, tt := range tests {
+		// Act
+		schedule, err := NewInstallmentSchedule(MustParseMoney("100"), 2, tt.purchaseDate, tt.cycle)
+
+		// Assert
+		assert.NoError(t, err, name)
+		assert.Equal(t, tt.expectedDueDates, []time.Time{schedule[0].DueDate, schedule[1].DueDate}, name)
+	}
+}
+
+func TestNewInstallmentSchedule_WhenSingleInstallment_ShouldKeepTotal(t *testing.T) {
+	// Act
+	schedule, err := NewInstallmentSchedule(MustParseMoney("59.9"), 1, time.Now(), BillingCycle{ClosingDay: DefaultClosingDay, DueDay: DefaultDueDay})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, schedule, 1)
+	assert.Equal(t, MustParseMoney("59.9"), schedule[0].Amount)
+}
+
+func TestNewInstallmentSchedule_WhenInvalid_ShouldReturnErrInvalidInstallments(t *testing.T) {
+	tests := map[string]struct {
+		total string
+		count int
+	}{
+		"ZeroInstallments":    {"100", 0},
+		"TooManyInstallments": {"100", MaxInstallments + 1},
+		"LessThanOneCentEach": {"0.02", 3},
+	}
+
+	for name, tt := range tests {
+		// Act
+		schedule, err := NewInstallmentSchedule(MustParseMoney(tt.total), tt.count, time.Now(), BillingCycle{ClosingDay: DefaultClosingDay, DueDay: DefaultDueDay})
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidInstallments, name)
+		assert.Nil(t, schedule, name)
+	}
+}
+
+func TestRescheduleInstallments_WhenCycleChanges_ShouldDueOneInstallmentPerInvoiceOfNewCycle(t *testing.T) {
+	// Arrange
+	pending := []Installment{
+		{ID: 2, TransactionID: 10, Number: 2, Amount: MustParseMoney("33.33"), DueDate: time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)},
+		{ID: 3, TransactionID: 10, Number: 3, Amount: MustParseMoney("33.33"), DueDate: time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)},
+	}
+	lastClosingDate := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	schedule := RescheduleInstallments(pending, lastClosingDate, BillingCycle{ClosingDay: 25, DueDay: 5})
+
+	// Assert
+	assert.Equal(t, []time.Time{time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)}, []time.Time{schedule[0].DueDate, schedule[1].DueDate})
+	assert.Equal(t, []int{2, 3}, []int{schedule[0].Number, schedule[1].Number})
+	assert.Equal(t, time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC), pending[0].DueDate)
+}
@@ -0,0 +1,96 @@
+package domain
+
+import "time"
+
+// The minimum payment of an invoice is MinimumPaymentPercent of the total due, but never
+// less than MinimumPaymentFloor unless the total due itself is smaller.
+const MinimumPaymentPercent = 15
+
+var MinimumPaymentFloor = NewMoneyFromCents(1000)
+
+// InvoiceItem is a transaction billed on an invoice. Installment purchases are billed one
+// installment per invoice, with InstallmentNumber set and the installment amount. Amounts
+// are signed like transaction amounts.
+type InvoiceItem struct {
+	TransactionID            int64
+	OperationTypeID          OperationType
+	OperationTypeDescription string
+	InstallmentNumber        *int
+	Amount                   Money
+	EventDate                time.Time
+}
+
+// Invoice (fatura) is the statement of a billing cycle: the transactions with an event date
+// from PeriodStart up to, but not including, ClosingDate. Like AccountBalance, the balances
+// are signed and negative when the account owes money, while TotalDebits and TotalCredits
+// are positive.
+type Invoice struct {
+	ID              int64
+	AccountID       int64
+	PeriodStart     time.Time
+	ClosingDate     time.Time
+	DueDate         time.Time
+	PreviousBalance Money
+	TotalDebits     Money
+	TotalCredits    Money
+	Balance         Money
+	MinimumPayment  Money
+	Items           []InvoiceItem
+	ClosedAt        time.Time
+}
+
+// NewInvoice closes the cycle ending on closingDate. The balance of the previous invoice is
+// carried over, so a debt that was not paid is billed again.
+func NewInvoice(accountID int64, periodStart time.Time, closingDate time.Time, cycle BillingCycle, previousBalance Money, items []InvoiceItem) Invoice {
+	invoice := Invoice{
+		AccountID:       accountID,
+		PeriodStart:     periodStart,
+		ClosingDate:     closingDate,
+		DueDate:         cycle.DueDate(closingDate),
+		PreviousBalance: previousBalance,
+		Balance:         previousBalance,
+		Items:           items,
+	}
+
+	for _, item := range items {
+		if item.Amount.IsNegative() {
+			invoice.TotalDebits = invoice.TotalDebits.Add(item.Amount.Neg())
+		} else {
+			invoice.TotalCredits = invoice.TotalCredits.Add(item.Amount)
+		}
+		invoice.Balance = invoice.Balance.Add(item.Amount)
+	}
+
+	invoice.MinimumPayment = minimumPayment(invoice.TotalDue())
+	return invoice
+}
+
+// TotalDue is the amount the account has to pay, zero when the balance is not negative.
+func (i Invoice) TotalDue() Money {
+	if i.Balance.IsNegative() {
+		return i.Balance.Neg()
+	}
+	return Money{}
+}
+
+func minimumPayment(totalDue Money) Money {
+	cents := (totalDue.Cents()*MinimumPaymentPercent + 99) / 100
+	minimum := NewMoneyFromCents(cents)
+	if minimum.Sub(MinimumPaymentFloor).IsNegative() {
+		minimum = MinimumPaymentFloor
+	}
+	if totalDue.Sub(minimum).IsNegative() {
+		minimum = totalDue
+	}
+	return minimum
+}
+
+// BillingState is where the next invoice of an account starts: at the closing date of its
+// last invoice, carrying that invoice's balance, or at the account creation when no invoice
+// was closed yet.
+type BillingState struct {
+	AccountID       int64
+	Cycle           BillingCycle
+	PeriodStart     time.Time
+	PreviousBalance Money
+}
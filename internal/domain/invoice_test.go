@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewInvoice_WhenItemsBilled_ShouldSumTotalsAndCarryPreviousBalance(t *testing.T) {
+	// Arrange
+	cycle := BillingCycle{ClosingDay: 3, DueDay: 10}
+	periodStart := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+	closingDate := time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)
+	items := []InvoiceItem{
+		{TransactionID: 1, OperationTypeID: CompraAVista, Amount: MustParseMoney("-300")},
+		{TransactionID: 2, OperationTypeID: Pagamento, Amount: MustParseMoney("100")},
+		{TransactionID: 3, OperationTypeID: Saque, Amount: MustParseMoney("-50.50")},
+	}
+
+	// Act
+	invoice := NewInvoice(1, periodStart, closingDate, cycle, MustParseMoney("-20"), items)
+
+	// Assert
+	assert.Equal(t, time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC), invoice.DueDate)
+	assert.Equal(t, MustParseMoney("350.50"), invoice.TotalDebits)
+	assert.Equal(t, MustParseMoney("100"), invoice.TotalCredits)
+	assert.Equal(t, MustParseMoney("-270.50"), invoice.Balance)
+	assert.Equal(t, MustParseMoney("270.50"), invoice.TotalDue())
+	assert.Equal(t, MustParseMoney("40.58"), invoice.MinimumPayment)
+}
+
+func TestNewInvoice_MinimumPayment(t *testing.T) {
+	tests := map[string]struct {
+		balance  string
+		expected string
+	}{
+		"PercentOfTotalDue":     {"-1000", "150"},
+		"FloorWhenPercentSmall": {"-40", "10"},
+		"TotalDueBelowFloor":    {"-7.30", "7.30"},
+		"NothingDue":            {"25", "0"},
+	}
+
+	for name, tt := range tests {
+		// Act
+		invoice := NewInvoice(1, time.Now(), time.Now(), BillingCycle{ClosingDay: 3, DueDay: 10}, MustParseMoney(tt.balance), nil)
+
+		// Assert
+		assert.Equal(t, MustParseMoney(tt.expected), invoice.MinimumPayment, name)
+	}
+}
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrUnbalancedJournalEntry = errors.New("journal entry does not balance")
+
+// LedgerAccount is an account of the general ledger the transactions are posted to.
+type LedgerAccount string
+
+const (
+	// LedgerReceivables is what the customers owe. Its postings carry the customer account,
+	// so it is also the sub-ledger of each account.
+	LedgerReceivables LedgerAccount = "RECEIVABLES"
+	// LedgerCash is the money paid out for purchases and withdrawals and received from
+	// payments.
+	LedgerCash LedgerAccount = "CASH"
+	// LedgerFeeIncome is the revenue from the interest and late fees charged on overdue
+	// invoices.
+	LedgerFeeIncome LedgerAccount = "FEE_INCOME"
+	// LedgerTransferClearing takes the opposite side of each transfer leg and nets to zero
+	// once both legs are posted.
+	LedgerTransferClearing LedgerAccount = "TRANSFER_CLEARING"
+	// LedgerDisputes takes the other side of provisional credits. Lost disputes take the
+	// credit back and net to zero; what won disputes leave is due from the merchants.
+	LedgerDisputes LedgerAccount = "DISPUTES"
+)
+
+// Posting is one line of a journal entry. Debits are positive and credits negative, so the
+// postings of a balanced entry sum to zero. AccountID is the customer account of
+// receivables postings and nil for the other ledger accounts.
+type Posting struct {
+	LedgerAccount LedgerAccount
+	AccountID     *int64
+	Amount        Money
+}
+
+// JournalEntry records a transaction in the general ledger.
+type JournalEntry struct {
+	ID            int64
+	TransactionID int64
+	EntryDate     time.Time
+	Postings      []Posting
+}
+
+// NewJournalEntry rejects entries with fewer than two postings or whose postings do not
+// sum to zero.
+func NewJournalEntry(transactionID int64, entryDate time.Time, postings []Posting) (JournalEntry, error) {
+	if len(postings) < 2 {
+		return JournalEntry{}, fmt.Errorf("%w: %d posting(s)", ErrUnbalancedJournalEntry, len(postings))
+	}
+
+	var sum Money
+	for _, posting := range postings {
+		sum = sum.Add(posting.Amount)
+	}
+	if !sum.IsZero() {
+		return JournalEntry{}, fmt.Errorf("%w: postings sum to %s", ErrUnbalancedJournalEntry, sum)
+	}
+
+	return JournalEntry{TransactionID: transactionID, EntryDate: entryDate, Postings: postings}, nil
+}
+
+// NewTransactionJournalEntry posts a stored transaction: what the customer spends is
+// debited to their receivables and what they pay is credited to them, against the
+// ledger account that takes the other side of the operation.
+func NewTransactionJournalEntry(transaction Transaction) (JournalEntry, error) {
+	accountID := transaction.AccountID()
+	return NewJournalEntry(transaction.ID(), transaction.EventDate(), []Posting{
+		{LedgerAccount: LedgerReceivables, AccountID: &accountID, Amount: transaction.Amount().Neg()},
+		{LedgerAccount: counterLedgerAccount(transaction.OperationTypeID()), Amount: transaction.Amount()},
+	})
+}
+
+func counterLedgerAccount(operationType OperationType) LedgerAccount {
+	switch {
+	case operationType.IsCharge():
+		return LedgerFeeIncome
+	case operationType.IsTransfer():
+		return LedgerTransferClearing
+	case operationType.IsDispute():
+		return LedgerDisputes
+	default:
+		return LedgerCash
+	}
+}
+
+// TrialBalanceLine totals the postings of a ledger account. Debits and Credits are the
+// totals of each side as positive amounts and Balance is their signed difference.
+type TrialBalanceLine struct {
+	LedgerAccount LedgerAccount
+	Debits        Money
+	Credits       Money
+	Balance       Money
+}
+
+// TrialBalance lists the totals of every ledger account. AsOf is set when only entries up
+// to that date were considered.
+type TrialBalance struct {
+	Lines        []TrialBalanceLine
+	TotalDebits  Money
+	TotalCredits Money
+	AsOf         *time.Time
+}
+
+func NewTrialBalance(lines []TrialBalanceLine, asOf *time.Time) TrialBalance {
+	trialBalance := TrialBalance{Lines: lines, AsOf: asOf}
+	for _, line := range lines {
+		trialBalance.TotalDebits = trialBalance.TotalDebits.Add(line.Debits)
+		trialBalance.TotalCredits = trialBalance.TotalCredits.Add(line.Credits)
+	}
+	return trialBalance
+}
+
+// Balanced reports whether the debits of the ledger equal its credits.
+func (t TrialBalance) Balanced() bool {
+	return t.TotalDebits == t.TotalCredits
+}
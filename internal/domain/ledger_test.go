@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJournalEntry_WhenPostingsDoNotBalance_ShouldReturnErrUnbalancedJournalEntry(t *testing.T) {
+	tests := map[string]struct {
+		postings []Posting
+	}{
+		"NoPostings":      {postings: nil},
+		"SinglePosting":   {postings: []Posting{{LedgerAccount: LedgerCash, Amount: MustParseMoney("10")}}},
+		"NonZeroPostings": {postings: []Posting{{LedgerAccount: LedgerReceivables, Amount: MustParseMoney("10")}, {LedgerAccount: LedgerCash, Amount: MustParseMoney("-9.99")}}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			_, err := NewJournalEntry(1, time.Now(), tt.postings)
+
+			// Assert
+			assert.ErrorIs(t, err, ErrUnbalancedJournalEntry)
+		})
+	}
+}
+
+func TestNewTransactionJournalEntry_ShouldPostAgainstTheLedgerAccountOfTheOperation(t *testing.T) {
+	tests := map[string]struct {
+		operationType       OperationType
+		amount              Money
+		expectedReceivables Money
+		expectedCounterpart LedgerAccount
+	}{
+		"Purchase":                  {operationType: CompraAVista, amount: MustParseMoney("-50"), expectedReceivables: MustParseMoney("50"), expectedCounterpart: LedgerCash},
+		"Payment":                   {operationType: Pagamento, amount: MustParseMoney("30"), expectedReceivables: MustParseMoney("-30"), expectedCounterpart: LedgerCash},
+		"Reversal":                  {operationType: Estorno, amount: MustParseMoney("50"), expectedReceivables: MustParseMoney("-50"), expectedCounterpart: LedgerCash},
+		"Interest":                  {operationType: JurosRotativos, amount: MustParseMoney("-0.40"), expectedReceivables: MustParseMoney("0.40"), expectedCounterpart: LedgerFeeIncome},
+		"LateFee":                   {operationType: MultaPorAtraso, amount: MustParseMoney("-10"), expectedReceivables: MustParseMoney("10"), expectedCounterpart: LedgerFeeIncome},
+		"TransferReceived":          {operationType: TransferenciaRecebida, amount: MustParseMoney("25"), expectedReceivables: MustParseMoney("-25"), expectedCounterpart: LedgerTransferClearing},
+		"ProvisionalCredit":         {operationType: CreditoProvisorio, amount: MustParseMoney("40"), expectedReceivables: MustParseMoney("-40"), expectedCounterpart: LedgerDisputes},
+		"ProvisionalCreditReversal": {operationType: EstornoCreditoProvisorio, amount: MustParseMoney("-40"), expectedReceivables: MustParseMoney("40"), expectedCounterpart: LedgerDisputes},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			eventDate := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+			transaction := NewTransaction(3, tt.operationType, tt.amount, eventDate)
+			transaction.SetID(7)
+
+			// Act
+			entry, err := NewTransactionJournalEntry(transaction)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, int64(7), entry.TransactionID)
+			assert.Equal(t, eventDate, entry.EntryDate)
+			assert.Len(t, entry.Postings, 2)
+			assert.Equal(t, LedgerReceivables, entry.Postings[0].LedgerAccount)
+			assert.Equal(t, int64(3), *entry.Postings[0].AccountID)
+			assert.Equal(t, tt.expectedReceivables, entry.Postings[0].Amount)
+			assert.Equal(t, tt.expectedCounterpart, entry.Postings[1].LedgerAccount)
+			assert.Nil(t, entry.Postings[1].AccountID)
+			assert.Equal(t, tt.amount, entry.Postings[1].Amount)
+		})
+	}
+}
+
+func TestNewTrialBalance_ShouldTotalBothSides(t *testing.T) {
+	// Arrange
+	lines := []TrialBalanceLine{
+		{LedgerAccount: LedgerCash, Debits: MustParseMoney("30"), Credits: MustParseMoney("50"), Balance: MustParseMoney("-20")},
+		{LedgerAccount: LedgerReceivables, Debits: MustParseMoney("50"), Credits: MustParseMoney("30"), Balance: MustParseMoney("20")},
+	}
+
+	// Act
+	trialBalance := NewTrialBalance(lines, nil)
+
+	// Assert
+	assert.Equal(t, MustParseMoney("80"), trialBalance.TotalDebits)
+	assert.Equal(t, MustParseMoney("80"), trialBalance.TotalCredits)
+	assert.True(t, trialBalance.Balanced())
+}
@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidMoney = errors.New("amount must be a decimal number with at most two fractional digits")
+
+// Money is an exact amount of money stored as integer cents, matching the
+// NUMERIC(15, 2) columns it is persisted to.
+type Money struct {
+	cents int64
+}
+
+func NewMoneyFromCents(cents int64) Money {
+	return Money{cents: cents}
+}
+
+// ParseMoney parses a decimal string such as "-123.45" without going through float64.
+func ParseMoney(value string) (Money, error) {
+	digits := strings.TrimSpace(value)
+	negative := strings.HasPrefix(digits, "-")
+	digits = strings.TrimPrefix(digits, "-")
+
+	integerPart, fractionalPart, hasPoint := strings.Cut(digits, ".")
+	if integerPart == "" || (hasPoint && fractionalPart == "") || len(fractionalPart) > 2 || !isDigits(integerPart) || !isDigits(fractionalPart) {
+		return Money{}, ErrInvalidMoney
+	}
+
+	units, err := strconv.ParseInt(integerPart, 10, 64)
+	if err != nil || units > math.MaxInt64/100-1 {
+		return Money{}, ErrInvalidMoney
+	}
+
+	fractionalPart += strings.Repeat("0", 2-len(fractionalPart))
+	cents, _ := strconv.ParseInt(fractionalPart, 10, 64)
+
+	total := units*100 + cents
+	if negative {
+		total = -total
+	}
+
+	return Money{cents: total}, nil
+}
+
+// MustParseMoney is like ParseMoney but panics on invalid input. It is meant for
+// constants and tests.
+func MustParseMoney(value string) Money {
+	money, err := ParseMoney(value)
+	if err != nil {
+		panic(fmt.Sprintf("invalid money %q: %v", value, err))
+	}
+	return money
+}
+
+func (m Money) Cents() int64 {
+	return m.cents
+}
+
+func (m Money) IsZero() bool {
+	return m.cents == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.cents < 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.cents > 0
+}
+
+func (m Money) Neg() Money {
+	return Money{cents: -m.cents}
+}
+
+func (m Money) Abs() Money {
+	if m.cents < 0 {
+		return m.Neg()
+	}
+	return m
+}
+
+func (m Money) Add(other Money) Money {
+	return Money{cents: m.cents + other.cents}
+}
+
+func (m Money) Sub(other Money) Money {
+	return Money{cents: m.cents - other.cents}
+}
+
+// String formats the amount with exactly two fractional digits, e.g. "-0.50".
+func (m Money) String() string {
+	sign := ""
+	cents := m.cents
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// MarshalJSON encodes the amount as an exact JSON number.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a numeric string and rejects amounts with
+// more than two fractional digits instead of rounding them.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	value := string(data)
+	if value == "null" {
+		return nil
+	}
+
+	value = strings.TrimSuffix(strings.TrimPrefix(value, `"`), `"`)
+	money, err := ParseMoney(value)
+	if err != nil {
+		return err
+	}
+
+	*m = money
+	return nil
+}
+
+// Scan reads a NUMERIC column, which the driver hands over as text.
+func (m *Money) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*m = Money{}
+		return nil
+	case []byte:
+		return m.scanString(string(value))
+	case string:
+		return m.scanString(value)
+	case int64:
+		*m = Money{cents: value * 100}
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into Money", src)
+	}
+}
+
+func (m *Money) scanString(value string) error {
+	money, err := ParseMoney(value)
+	if err != nil {
+		return fmt.Errorf("cannot scan %q into Money: %w", value, err)
+	}
+	*m = money
+	return nil
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMoney_WhenValidDecimal_ShouldReturnCents(t *testing.T) {
+	tests := map[string]int64{
+		"0":       0,
+		"10":      1000,
+		"10.5":    1050,
+		"10.05":   1005,
+		"-123.45": -12345,
+		"0.1":     10,
+	}
+
+	for input, expected := range tests {
+		// Act
+		money, err := ParseMoney(input)
+
+		// Assert
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, money.Cents(), input)
+	}
+}
+
+func TestParseMoney_WhenInvalidDecimal_ShouldReturnError(t *testing.T) {
+	for _, input := range []string{"", "-", "10.005", "1e3", "abc", "1.2.3", ".5", "10."} {
+		// Act
+		_, err := ParseMoney(input)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidMoney, input)
+	}
+}
+
+func TestMoney_String_ShouldFormatTwoDecimals(t *testing.T) {
+	assert.Equal(t, "0.00", Money{}.String())
+	assert.Equal(t, "-0.50", NewMoneyFromCents(-50).String())
+	assert.Equal(t, "1234.05", NewMoneyFromCents(123405).String())
+}
+
+func TestMoney_Arithmetic_ShouldBeExact(t *testing.T) {
+	// Arrange
+	total := Money{}
+
+	// Act
+	for i := 0; i < 10; i++ {
+		total = total.Add(MustParseMoney("0.10"))
+	}
+
+	// Assert
+	assert.Equal(t, MustParseMoney("1.00"), total)
+	assert.Equal(t, MustParseMoney("-0.70"), MustParseMoney("0.30").Sub(MustParseMoney("1.00")))
+	assert.Equal(t, MustParseMoney("0.70"), MustParseMoney("-0.70").Abs())
+}
+
+func TestMoney_JSON_ShouldRoundTrip(t *testing.T) {
+	// Arrange
+	var payload struct {
+		Amount Money `json:"amount"`
+	}
+
+	// Act
+	err := json.Unmarshal([]byte(`{"amount": 19.99}`), &payload)
+	encoded, marshalErr := json.Marshal(payload)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, marshalErr)
+	assert.Equal(t, int64(1999), payload.Amount.Cents())
+	assert.JSONEq(t, `{"amount": 19.99}`, string(encoded))
+}
+
+func TestMoney_UnmarshalJSON_WhenQuotedString_ShouldParse(t *testing.T) {
+	// Arrange
+	var money Money
+
+	// Act
+	err := json.Unmarshal([]byte(`"-5.10"`), &money)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-510), money.Cents())
+}
+
+func TestMoney_UnmarshalJSON_WhenMoreThanTwoDecimals_ShouldReturnError(t *testing.T) {
+	// Arrange
+	var money Money
+
+	// Act
+	err := json.Unmarshal([]byte(`0.001`), &money)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidMoney)
+}
+
+func TestMoney_Scan_ShouldReadNumericText(t *testing.T) {
+	// Arrange
+	var money Money
+
+	// Act
+	err := money.Scan([]byte("150.75"))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(15075), money.Cents())
+	assert.Error(t, money.Scan(1.5))
+}
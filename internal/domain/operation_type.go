@@ -0,0 +1,79 @@
+package domain
+
+import "time"
+
+// OperationDirection tells whether transactions of an operation type take money from the
+// account (debit) or give it back (credit).
+type OperationDirection string
+
+const (
+	DirectionDebit  OperationDirection = "DEBIT"
+	DirectionCredit OperationDirection = "CREDIT"
+)
+
+func (d OperationDirection) IsValid() bool {
+	return d == DirectionDebit || d == DirectionCredit
+}
+
+// OperationTypeDefinition is an entry of the operation types catalog. Only active types
+// accept new transactions.
+type OperationTypeDefinition struct {
+	id          OperationType
+	description string
+	direction   OperationDirection
+	active      bool
+	createdAt   time.Time
+}
+
+func NewOperationTypeDefinition(description string, direction OperationDirection) *OperationTypeDefinition {
+	return &OperationTypeDefinition{
+		description: description,
+		direction:   direction,
+		active:      true,
+	}
+}
+
+func (o *OperationTypeDefinition) ID() OperationType {
+	return o.id
+}
+
+func (o *OperationTypeDefinition) Description() string {
+	return o.description
+}
+
+func (o *OperationTypeDefinition) Direction() OperationDirection {
+	return o.direction
+}
+
+func (o *OperationTypeDefinition) Active() bool {
+	return o.active
+}
+
+func (o *OperationTypeDefinition) CreatedAt() time.Time {
+	return o.createdAt
+}
+
+// ApplySign returns the amount with the sign of the operation direction, regardless of
+// the sign it was informed with: negative for debits and positive for credits.
+func (o *OperationTypeDefinition) ApplySign(amount Money) Money {
+	if o.direction == DirectionCredit {
+		return amount.Abs()
+	}
+	return amount.Abs().Neg()
+}
+
+func (o *OperationTypeDefinition) SetID(id OperationType) {
+	o.id = id
+}
+
+func (o *OperationTypeDefinition) SetDescription(description string) {
+	o.description = description
+}
+
+func (o *OperationTypeDefinition) SetActive(active bool) {
+	o.active = active
+}
+
+func (o *OperationTypeDefinition) SetCreatedAt(createdAt time.Time) {
+	o.createdAt = createdAt
+}
@@ -3,11 +3,18 @@ package domain
 import "time"
 
 type Transaction struct {
-	id              int64
-	accountID       int64
-	operationTypeID OperationType
-	amount          float64
-	eventDate       time.Time
+	id                       int64
+	accountID                int64
+	operationTypeID          OperationType
+	operationTypeDescription string
+	amount                   Money
+	balance                  Money
+	reversesTransactionID    *int64
+	invoiceID                *int64
+	transferID               *int64
+	explanation              string
+	eventDate                time.Time
+	createdAt                time.Time
 }
 
 type OperationType int
@@ -17,9 +24,27 @@ const (
 	CompraParcelada OperationType = 2
 	Saque           OperationType = 3
 	Pagamento       OperationType = 4
+	// Estorno and EstornoPagamento are only created by reversals: the first compensates
+	// debits and the second compensates payments.
+	Estorno          OperationType = 5
+	EstornoPagamento OperationType = 6
+	// JurosRotativos and MultaPorAtraso are only created by the charge accrual on overdue
+	// invoices.
+	JurosRotativos OperationType = 7
+	MultaPorAtraso OperationType = 8
+	// TransferenciaEnviada and TransferenciaRecebida are only created by transfers: the
+	// first debits the account that sends the amount and the second credits the one that
+	// receives it.
+	TransferenciaEnviada  OperationType = 9
+	TransferenciaRecebida OperationType = 10
+	// CreditoProvisorio and EstornoCreditoProvisorio are only created by disputes: the
+	// first credits the disputed amount while the dispute is open and the second reverses
+	// that credit when the dispute is lost.
+	CreditoProvisorio        OperationType = 11
+	EstornoCreditoProvisorio OperationType = 12
 )
 
-func NewTransaction(accountID int64, operationType OperationType, amount float64, eventDate ...time.Time) Transaction {
+func NewTransaction(accountID int64, operationType OperationType, amount Money, eventDate ...time.Time) Transaction {
 	eDate := time.Now()
 	if len(eventDate) > 0 {
 		eDate = eventDate[0]
@@ -28,10 +53,48 @@ func NewTransaction(accountID int64, operationType OperationType, amount float64
 		accountID:       accountID,
 		operationTypeID: operationType,
 		amount:          amount,
+		balance:         amount,
 		eventDate:       eDate,
 	}
 }
 
+// NewReversal builds the transaction that compensates amount of the original transaction:
+// a credit of the Estorno type for a debit and a debit of the EstornoPagamento type for a
+// payment.
+func NewReversal(original Transaction, amount Money, eventDate time.Time) Transaction {
+	operationType, signed := Estorno, amount.Abs()
+	if original.Amount().IsPositive() {
+		operationType, signed = EstornoPagamento, amount.Abs().Neg()
+	}
+
+	reversal := NewTransaction(original.AccountID(), operationType, signed, eventDate)
+	originalID := original.ID()
+	reversal.reversesTransactionID = &originalID
+	return reversal
+}
+
+func (o OperationType) IsReversal() bool {
+	return o == Estorno || o == EstornoPagamento
+}
+
+func (o OperationType) IsCharge() bool {
+	return o == JurosRotativos || o == MultaPorAtraso
+}
+
+func (o OperationType) IsTransfer() bool {
+	return o == TransferenciaEnviada || o == TransferenciaRecebida
+}
+
+func (o OperationType) IsDispute() bool {
+	return o == CreditoProvisorio || o == EstornoCreditoProvisorio
+}
+
+// IsSystem reports whether the type is posted by the application itself, as opposed to
+// the ones informed on new transactions.
+func (o OperationType) IsSystem() bool {
+	return o.IsReversal() || o.IsCharge() || o.IsTransfer() || o.IsDispute()
+}
+
 func (t *Transaction) ID() int64 {
 	return t.id
 }
@@ -44,22 +107,90 @@ func (t *Transaction) OperationTypeID() OperationType {
 	return t.operationTypeID
 }
 
-func (t *Transaction) Amount() float64 {
+func (t *Transaction) OperationTypeDescription() string {
+	return t.operationTypeDescription
+}
+
+func (t *Transaction) Amount() Money {
 	return t.amount
 }
 
+// Balance is the part of the amount not yet settled: the unpaid amount of a debit
+// or the unallocated amount of a payment.
+func (t *Transaction) Balance() Money {
+	return t.balance
+}
+
+// ReversesTransactionID is the transaction compensated by a reversal, nil for any other
+// transaction.
+func (t *Transaction) ReversesTransactionID() *int64 {
+	return t.reversesTransactionID
+}
+
+// SettleReversal applies the reversal to the open balance of the original transaction:
+// the unpaid part of a debit or the unallocated part of a payment is cancelled first and
+// only what exceeds it stays on the reversal's own balance.
+func (t *Transaction) SettleReversal(reversal *Transaction) {
+	settled := reversal.Amount()
+	if settled.Abs().Sub(t.balance.Abs()).IsPositive() {
+		settled = t.balance.Neg()
+	}
+
+	t.balance = t.balance.Add(settled)
+	reversal.balance = reversal.Amount().Sub(settled)
+}
+
+// InvoiceID is the invoice a charge was accrued on, nil for any other transaction.
+func (t *Transaction) InvoiceID() *int64 {
+	return t.invoiceID
+}
+
+// TransferID is the transfer a transaction is a leg of, nil for any other transaction.
+func (t *Transaction) TransferID() *int64 {
+	return t.transferID
+}
+
+// Explanation tells how a system-generated transaction was computed.
+func (t *Transaction) Explanation() string {
+	return t.explanation
+}
+
 func (t *Transaction) EventDate() time.Time {
 	return t.eventDate
 }
 
-func (o OperationType) IsValid() bool {
-	return o == CompraAVista || o == CompraParcelada || o == Saque || o == Pagamento
+func (t *Transaction) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+func (t *Transaction) SetID(id int64) {
+	t.id = id
+}
+
+func (t *Transaction) SetOperationTypeDescription(description string) {
+	t.operationTypeDescription = description
+}
+
+func (t *Transaction) SetBalance(balance Money) {
+	t.balance = balance
+}
+
+func (t *Transaction) SetReversesTransactionID(transactionID *int64) {
+	t.reversesTransactionID = transactionID
+}
+
+func (t *Transaction) SetInvoiceID(invoiceID *int64) {
+	t.invoiceID = invoiceID
+}
+
+func (t *Transaction) SetTransferID(transferID *int64) {
+	t.transferID = transferID
 }
 
-func (o OperationType) IsPayment() bool {
-	return o == Pagamento
+func (t *Transaction) SetExplanation(explanation string) {
+	t.explanation = explanation
 }
 
-func (o OperationType) IsPurchaseOrWithdraw() bool {
-	return o == CompraAVista || o == CompraParcelada || o == Saque
+func (t *Transaction) SetCreatedAt(createdAt time.Time) {
+	t.createdAt = createdAt
 }
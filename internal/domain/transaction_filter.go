@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+const (
+	DefaultTransactionPageSize = 20
+	MaxTransactionPageSize     = 100
+)
+
+// TransactionCursor points at the last transaction of a page. Transactions are listed
+// newest first, so the next page starts right after this (event_date, id) pair.
+type TransactionCursor struct {
+	EventDate time.Time
+	ID        int64
+}
+
+type TransactionFilter struct {
+	AccountID       int64
+	OperationTypeID *OperationType
+	From            *time.Time
+	To              *time.Time
+	After           *TransactionCursor
+	Limit           int
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReversal_WhenOriginalIsDebit_ShouldCreateCreditEstorno(t *testing.T) {
+	// Arrange
+	original := NewTransaction(1, CompraAVista, MustParseMoney("-50"))
+	original.SetID(7)
+
+	// Act
+	reversal := NewReversal(original, MustParseMoney("20"), time.Now())
+
+	// Assert
+	assert.Equal(t, Estorno, reversal.OperationTypeID())
+	assert.Equal(t, MustParseMoney("20"), reversal.Amount())
+	assert.Equal(t, int64(1), reversal.AccountID())
+	assert.Equal(t, int64(7), *reversal.ReversesTransactionID())
+}
+
+func TestNewReversal_WhenOriginalIsPayment_ShouldCreateDebitEstornoPagamento(t *testing.T) {
+	// Arrange
+	original := NewTransaction(1, Pagamento, MustParseMoney("50"))
+	original.SetID(8)
+
+	// Act
+	reversal := NewReversal(original, MustParseMoney("50"), time.Now())
+
+	// Assert
+	assert.Equal(t, EstornoPagamento, reversal.OperationTypeID())
+	assert.Equal(t, MustParseMoney("-50"), reversal.Amount())
+	assert.Equal(t, int64(8), *reversal.ReversesTransactionID())
+}
+
+func TestTransaction_SettleReversal_ShouldCancelOpenBalanceFirst(t *testing.T) {
+	tests := []struct {
+		name            string
+		amount          string
+		balance         string
+		reversed        string
+		originalBalance string
+		reversalBalance string
+	}{
+		{"UnpaidDebit", "-50", "-50", "20", "-30", "0"},
+		{"PartiallyPaidDebit", "-50", "-10", "30", "0", "20"},
+		{"PaidDebit", "-50", "0", "50", "0", "50"},
+		{"UnallocatedPayment", "50", "50", "50", "0", "0"},
+		{"PartiallyAllocatedPayment", "50", "20", "50", "0", "-30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			original := NewTransaction(1, CompraAVista, MustParseMoney(tt.amount))
+			if original.Amount().IsPositive() {
+				original = NewTransaction(1, Pagamento, MustParseMoney(tt.amount))
+			}
+			original.SetBalance(MustParseMoney(tt.balance))
+			reversal := NewReversal(original, MustParseMoney(tt.reversed), time.Now())
+
+			// Act
+			original.SettleReversal(&reversal)
+
+			// Assert
+			assert.Equal(t, MustParseMoney(tt.originalBalance), original.Balance())
+			assert.Equal(t, MustParseMoney(tt.reversalBalance), reversal.Balance())
+		})
+	}
+}
+
+func TestOperationType_IsSystem(t *testing.T) {
+	tests := map[string]struct {
+		operationType OperationType
+		expected      bool
+	}{
+		"purchase":      {operationType: CompraAVista, expected: false},
+		"payment":       {operationType: Pagamento, expected: false},
+		"reversal":      {operationType: EstornoPagamento, expected: true},
+		"charge":        {operationType: JurosRotativos, expected: true},
+		"transfer":      {operationType: TransferenciaEnviada, expected: true},
+		"dispute":       {operationType: CreditoProvisorio, expected: true},
+		"admin-created": {operationType: OperationType(13), expected: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.operationType.IsSystem())
+		})
+	}
+}
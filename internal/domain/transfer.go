@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidTransfer = errors.New("invalid transfer")
+
+// Transfer moves an amount from one account to another. It is stored as two transactions,
+// a debit on the sending account and a credit on the receiving one, that reference it.
+type Transfer struct {
+	ID                  int64
+	FromAccountID       int64
+	ToAccountID         int64
+	Amount              Money
+	DebitTransactionID  int64
+	CreditTransactionID int64
+	CreatedAt           time.Time
+}
+
+// NewTransfer validates a transfer of amount, which must be positive, between two
+// different accounts.
+func NewTransfer(fromAccountID int64, toAccountID int64, amount Money, createdAt time.Time) (Transfer, error) {
+	if fromAccountID == toAccountID {
+		return Transfer{}, fmt.Errorf("%w: cannot transfer to the same account %d", ErrInvalidTransfer, fromAccountID)
+	}
+	if !amount.IsPositive() {
+		return Transfer{}, fmt.Errorf("%w: amount must be positive", ErrInvalidTransfer)
+	}
+
+	return Transfer{
+		FromAccountID: fromAccountID,
+		ToAccountID:   toAccountID,
+		Amount:        amount,
+		CreatedAt:     createdAt,
+	}, nil
+}
+
+// Legs builds the debit and the credit of the transfer, both referencing its ID.
+func (t Transfer) Legs() (Transaction, Transaction) {
+	transferID := t.ID
+
+	debit := NewTransaction(t.FromAccountID, TransferenciaEnviada, t.Amount.Neg(), t.CreatedAt)
+	debit.transferID = &transferID
+
+	credit := NewTransaction(t.ToAccountID, TransferenciaRecebida, t.Amount, t.CreatedAt)
+	credit.transferID = &transferID
+
+	return debit, credit
+}
+
+// LockOrder returns the accounts of the transfer by ascending id, the order in which they
+// must be locked so opposite transfers between the same accounts cannot deadlock.
+func (t Transfer) LockOrder() []int64 {
+	if t.ToAccountID < t.FromAccountID {
+		return []int64{t.ToAccountID, t.FromAccountID}
+	}
+	return []int64{t.FromAccountID, t.ToAccountID}
+}
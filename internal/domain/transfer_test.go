@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTransfer_WhenInvalid_ShouldReturnErrInvalidTransfer(t *testing.T) {
+	tests := map[string]struct {
+		fromAccountID int64
+		toAccountID   int64
+		amount        Money
+	}{
+		"SameAccount":    {fromAccountID: 1, toAccountID: 1, amount: MustParseMoney("10")},
+		"ZeroAmount":     {fromAccountID: 1, toAccountID: 2, amount: MustParseMoney("0")},
+		"NegativeAmount": {fromAccountID: 1, toAccountID: 2, amount: MustParseMoney("-10")},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			_, err := NewTransfer(tt.fromAccountID, tt.toAccountID, tt.amount, time.Now())
+
+			// Assert
+			assert.ErrorIs(t, err, ErrInvalidTransfer)
+		})
+	}
+}
+
+func TestTransfer_Legs_ShouldDebitSenderAndCreditReceiver(t *testing.T) {
+	// Arrange
+	createdAt := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	transfer, _ := NewTransfer(1, 2, MustParseMoney("25.50"), createdAt)
+	transfer.ID = 7
+
+	// Act
+	debit, credit := transfer.Legs()
+
+	// Assert
+	assert.Equal(t, int64(1), debit.AccountID())
+	assert.Equal(t, TransferenciaEnviada, debit.OperationTypeID())
+	assert.Equal(t, MustParseMoney("-25.50"), debit.Amount())
+	assert.Equal(t, int64(7), *debit.TransferID())
+	assert.Equal(t, createdAt, debit.EventDate())
+	assert.Equal(t, int64(2), credit.AccountID())
+	assert.Equal(t, TransferenciaRecebida, credit.OperationTypeID())
+	assert.Equal(t, MustParseMoney("25.50"), credit.Amount())
+	assert.Equal(t, MustParseMoney("25.50"), credit.Balance())
+	assert.Equal(t, int64(7), *credit.TransferID())
+}
+
+func TestTransfer_LockOrder_ShouldSortAccountsByID(t *testing.T) {
+	tests := map[string]struct {
+		fromAccountID int64
+		toAccountID   int64
+		expected      []int64
+	}{
+		"FromLowerID":  {fromAccountID: 1, toAccountID: 2, expected: []int64{1, 2}},
+		"FromHigherID": {fromAccountID: 2, toAccountID: 1, expected: []int64{1, 2}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			transfer, _ := NewTransfer(tt.fromAccountID, tt.toAccountID, MustParseMoney("10"), time.Now())
+
+			// Act
+			order := transfer.LockOrder()
+
+			// Assert
+			assert.Equal(t, tt.expected, order)
+		})
+	}
+}
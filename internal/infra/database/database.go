@@ -7,7 +7,9 @@ import (
 	"time"
 
 	"github.com/VieiraVitor/transaction-flow/config"
+	"github.com/XSAM/otelsql"
 	_ "github.com/lib/pq"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
 func ConnectDB(cfg *config.Config) (*sql.DB, error) {
@@ -20,7 +22,11 @@ func ConnectDB(cfg *config.Config) (*sql.DB, error) {
 	var err error
 
 	for i := 1; i <= 5; i++ {
-		db, err = sql.Open("postgres", dsn)
+		// Every statement gets a span, a child of the span of the request or job issuing it.
+		db, err = otelsql.Open("postgres", dsn,
+			otelsql.WithAttributes(semconv.DBSystemPostgreSQL, semconv.DBNamespace(cfg.DBName)),
+			otelsql.WithSpanOptions(otelsql.SpanOptions{OmitConnResetSession: true, OmitRows: true}),
+		)
 		if err == nil {
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 			defer cancel()
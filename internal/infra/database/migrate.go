@@ -0,0 +1,176 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+	"github.com/VieiraVitor/transaction-flow/migrations"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// MigrationStatus tells whether the migration of Version was applied to the database.
+type MigrationStatus struct {
+	Version uint
+	Name    string
+	Applied bool
+}
+
+// Migrator applies the migrations embedded in the binary. While migrating it holds a Postgres
+// advisory lock, so when several replicas start together one applies the migrations and the
+// others wait for it, then find nothing left to apply.
+type Migrator struct {
+	migrate *migrate.Migrate
+	source  source.Driver
+}
+
+// NewMigrator migrates the database of db through one of its connections, waiting up to
+// lockTimeout for other instances that are migrating it.
+func NewMigrator(ctx context.Context, db *sql.DB, lockTimeout time.Duration) (*Migrator, error) {
+	sourceDriver, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded migrations: %w", err)
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a connection to migrate: %w", err)
+	}
+
+	databaseDriver, err := postgres.WithConnection(ctx, conn, &postgres.Config{})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to prepare database for migrations: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", sourceDriver, "postgres", databaseDriver)
+	if err != nil {
+		databaseDriver.Close()
+		return nil, fmt.Errorf("failed to create migrator: %w", err)
+	}
+	m.LockTimeout = lockTimeout
+	m.Log = migrateLogger{}
+
+	return &Migrator{migrate: m, source: sourceDriver}, nil
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up() error {
+	if err := m.migrate.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+	return nil
+}
+
+// Down reverts the last steps migrations applied.
+func (m *Migrator) Down(steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("steps must be positive, got %d", steps)
+	}
+	if err := m.migrate.Steps(-steps); err != nil {
+		return fmt.Errorf("failed to revert migrations: %w", err)
+	}
+	return nil
+}
+
+// Version returns the version of the last migration applied, 0 when none was, and whether it
+// failed halfway, leaving the schema dirty.
+func (m *Migrator) Version() (uint, bool, error) {
+	version, dirty, err := m.migrate.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get migration version: %w", err)
+	}
+	return version, dirty, nil
+}
+
+// Status lists the embedded migrations in order, telling which were applied.
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	current, _, err := m.Version()
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := embeddedVersions(m.source)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(versions))
+	for _, version := range versions {
+		name, err := migrationName(m.source, version)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, MigrationStatus{Version: version, Name: name, Applied: version <= current})
+	}
+	return statuses, nil
+}
+
+// Close releases the connection used to migrate, not the pool it came from.
+func (m *Migrator) Close() error {
+	sourceErr, databaseErr := m.migrate.Close()
+	return errors.Join(sourceErr, databaseErr)
+}
+
+// LatestSchemaVersion returns the version of the last embedded migration, the one the
+// application expects the database to be at.
+func LatestSchemaVersion() (uint, error) {
+	sourceDriver, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read embedded migrations: %w", err)
+	}
+	defer sourceDriver.Close()
+
+	versions, err := embeddedVersions(sourceDriver)
+	if err != nil {
+		return 0, err
+	}
+	if len(versions) == 0 {
+		return 0, errors.New("no embedded migrations")
+	}
+	return versions[len(versions)-1], nil
+}
+
+func embeddedVersions(sourceDriver source.Driver) ([]uint, error) {
+	var versions []uint
+	version, err := sourceDriver.First()
+	for err == nil {
+		versions = append(versions, version)
+		version, err = sourceDriver.Next(version)
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to list embedded migrations: %w", err)
+	}
+	return versions, nil
+}
+
+func migrationName(sourceDriver source.Driver, version uint) (string, error) {
+	reader, identifier, err := sourceDriver.ReadUp(version)
+	if err != nil {
+		return "", fmt.Errorf("failed to read migration %d: %w", version, err)
+	}
+	reader.Close()
+	return identifier, nil
+}
+
+// migrateLogger reports each migration applied or reverted.
+type migrateLogger struct{}
+
+func (migrateLogger) Printf(format string, v ...interface{}) {
+	logger.Logger.Info(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (migrateLogger) Verbose() bool {
+	return false
+}
@@ -0,0 +1,45 @@
+package database
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/VieiraVitor/transaction-flow/migrations"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatestSchemaVersion_ShouldReturnVersionOfLastEmbeddedMigration(t *testing.T) {
+	// Arrange
+	files, err := fs.Glob(migrations.FS, "*.up.sql")
+	assert.NoError(t, err)
+
+	// Act
+	version, err := LatestSchemaVersion()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, uint(len(files)), version)
+}
+
+func TestEmbeddedVersions_ShouldListEveryMigrationInOrderWithItsName(t *testing.T) {
+	// Arrange
+	sourceDriver, err := iofs.New(migrations.FS, ".")
+	assert.NoError(t, err)
+	defer sourceDriver.Close()
+
+	// Act
+	versions, err := embeddedVersions(sourceDriver)
+
+	// Assert
+	assert.NoError(t, err)
+	for i, version := range versions {
+		assert.Equal(t, uint(i+1), version)
+
+		name, err := migrationName(sourceDriver, version)
+		assert.NoError(t, err)
+		matches, err := fs.Glob(migrations.FS, "*_"+name+".up.sql")
+		assert.NoError(t, err)
+		assert.Len(t, matches, 1)
+	}
+}
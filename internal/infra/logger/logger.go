@@ -1,15 +1,58 @@
 package logger
 
 import (
+	"context"
 	"log/slog"
 	"os"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 var Logger *slog.Logger
 
+type traceIDKey struct{}
+
 func InitLogger() {
-	Logger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+	Logger = slog.New(NewContextHandler(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level:     slog.LevelInfo,
 		AddSource: true,
-	}))
+	})))
+}
+
+// WithTraceID returns a copy of ctx carrying the traceID of the request it belongs to.
+func WithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey{}, traceID)
+}
+
+// TraceID returns the traceID stored in ctx, or an empty string when there is none.
+func TraceID(ctx context.Context) string {
+	traceID, _ := ctx.Value(traceIDKey{}).(string)
+	return traceID
+}
+
+// contextHandler adds the traceID and spanID of the span in the context to every record
+// logged with it, or the traceID stored by WithTraceID when there is no span.
+type contextHandler struct {
+	slog.Handler
+}
+
+func NewContextHandler(handler slog.Handler) slog.Handler {
+	return contextHandler{Handler: handler}
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(slog.String("traceID", spanContext.TraceID().String()), slog.String("spanID", spanContext.SpanID().String()))
+	} else if traceID := TraceID(ctx); traceID != "" {
+		record.AddAttrs(slog.String("traceID", traceID))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithGroup(name)}
 }
@@ -6,12 +6,16 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/VieiraVitor/transaction-flow/internal/domain"
 	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
 )
 
-var ErrAccountNotFound = errors.New("account not found")
+var (
+	ErrAccountNotFound      = errors.New("account not found")
+	ErrAccountStatusChanged = errors.New("account status was changed concurrently")
+)
 
 type accountRepository struct {
 	db *sql.DB
@@ -22,20 +26,37 @@ func NewAccountRepository(db *sql.DB) *accountRepository {
 }
 
 func (r *accountRepository) CreateAccount(ctx context.Context, account *domain.Account) (int64, error) {
-	query := "INSERT INTO accounts (document_number) VALUES ($1) RETURNING id"
+	query := "INSERT INTO accounts (document_number, document_type, credit_limit, available_credit_limit) VALUES ($1, $2, $3, $4) RETURNING id"
 	var id int64
-	row := r.db.QueryRow(query, account.DocumentNumber())
+	row := r.db.QueryRowContext(ctx, query, account.DocumentNumber(), account.DocumentType(), account.CreditLimit(), account.AvailableCreditLimit())
 	err := row.Scan(&id)
 	if err != nil {
+		if isUniqueViolation(err, accountsDocumentNumberKey) {
+			return 0, r.accountAlreadyExists(ctx, account.DocumentNumber(), err)
+		}
 		logger.Logger.ErrorContext(ctx, "error creating account", slog.String("document_number", account.DocumentNumber()), slog.String("error", err.Error()))
-		return 0, fmt.Errorf("failed to create account: %w", err)
+		return 0, fmt.Errorf("failed to create account: %w", translatePostgresError(err))
 	}
 	return id, err
 }
 
+// accountAlreadyExists looks up the account that already holds the document number so
+// the caller can point the client to it.
+func (r *accountRepository) accountAlreadyExists(ctx context.Context, documentNumber string, uniqueErr error) error {
+	query := "SELECT id FROM accounts WHERE document_number = $1"
+	var id int64
+	if err := r.db.QueryRowContext(ctx, query, documentNumber).Scan(&id); err != nil {
+		logger.Logger.ErrorContext(ctx, "error getting existing account", slog.String("document_number", documentNumber), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to create account: %w", translatePostgresError(uniqueErr))
+	}
+
+	logger.Logger.ErrorContext(ctx, "account already exists", slog.String("document_number", documentNumber), slog.Int64("account_id", id))
+	return &domain.AccountAlreadyExistsError{AccountID: id, DocumentNumber: documentNumber}
+}
+
 func (r *accountRepository) GetAccount(ctx context.Context, accountID int64) (*domain.Account, error) {
-	query := "SELECT id, document_number, created_at FROM accounts WHERE id = $1"
-	row := r.db.QueryRow(query, accountID)
+	query := "SELECT id, document_number, document_type, credit_limit, available_credit_limit, credit_balance, closing_day, due_day, status, created_at FROM accounts WHERE id = $1"
+	row := r.db.QueryRowContext(ctx, query, accountID)
 
 	account, err := r.scanAccount(row)
 	if err != nil {
@@ -50,16 +71,247 @@ func (r *accountRepository) GetAccount(ctx context.Context, accountID int64) (*d
 	return account, nil
 }
 
+// UpdateCreditLimit sets the total credit limit and moves the available limit by the same
+// difference, so what open debits and pending authorizations already use stays used. The
+// update locks the account row like the transactions that change the available limit.
+func (r *accountRepository) UpdateCreditLimit(ctx context.Context, accountID int64, creditLimit domain.Money) error {
+	query := "UPDATE accounts SET available_credit_limit = available_credit_limit + $1 - credit_limit, credit_limit = $1, updated_at = NOW() WHERE id = $2"
+	result, err := r.db.ExecContext(ctx, query, creditLimit, accountID)
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error updating credit limit", slog.Int64("account_id", accountID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to update credit limit: %w", translatePostgresError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error updating credit limit", slog.Int64("account_id", accountID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to update credit limit: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		logger.Logger.ErrorContext(ctx, "account not found", slog.Int64("account_id", accountID))
+		return ErrAccountNotFound
+	}
+
+	return nil
+}
+
+// UpdateBillingCycle changes the billing cycle and, in the same database transaction, moves
+// the installments that no invoice billed yet to the invoices of the new cycle.
+func (r *accountRepository) UpdateBillingCycle(ctx context.Context, accountID int64, billingCycle domain.BillingCycle) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error updating billing cycle", slog.Int64("account_id", accountID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to update billing cycle: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := "UPDATE accounts SET closing_day = $1, due_day = $2, updated_at = NOW() WHERE id = $3"
+	result, err := tx.ExecContext(ctx, query, billingCycle.ClosingDay, billingCycle.DueDay, accountID)
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error updating billing cycle", slog.Int64("account_id", accountID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to update billing cycle: %w", translatePostgresError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error updating billing cycle", slog.Int64("account_id", accountID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to update billing cycle: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		logger.Logger.ErrorContext(ctx, "account not found", slog.Int64("account_id", accountID))
+		return ErrAccountNotFound
+	}
+
+	if err := r.rescheduleInstallments(ctx, tx, accountID, billingCycle); err != nil {
+		logger.Logger.ErrorContext(ctx, "error updating billing cycle", slog.Int64("account_id", accountID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to update billing cycle: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Logger.ErrorContext(ctx, "error updating billing cycle", slog.Int64("account_id", accountID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to update billing cycle: %w", err)
+	}
+
+	return nil
+}
+
+// rescheduleInstallments gives the installments that were not billed yet the due dates of
+// billingCycle, one installment per invoice as when the purchase was made.
+func (r *accountRepository) rescheduleInstallments(ctx context.Context, tx *sql.Tx, accountID int64, billingCycle domain.BillingCycle) error {
+	pending, billedFrom, err := r.listUnbilledInstallments(ctx, tx, accountID)
+	if err != nil {
+		return err
+	}
+
+	query := "UPDATE installments SET due_date = $1 WHERE id = $2"
+	for start := 0; start < len(pending); {
+		end := start
+		for end < len(pending) && pending[end].TransactionID == pending[start].TransactionID {
+			end++
+		}
+
+		for _, installment := range domain.RescheduleInstallments(pending[start:end], billedFrom[pending[start].TransactionID], billingCycle) {
+			if _, err := tx.ExecContext(ctx, query, installment.DueDate, installment.ID); err != nil {
+				return fmt.Errorf("unable to reschedule installment: %w", translatePostgresError(err))
+			}
+		}
+		start = end
+	}
+
+	return nil
+}
+
+// listUnbilledInstallments returns the installments of the account that no invoice billed
+// yet, ordered by purchase and number, and for every purchase when its next installment
+// starts to be billed: the purchase date or, if later, the closing date of the last invoice.
+func (r *accountRepository) listUnbilledInstallments(ctx context.Context, tx *sql.Tx, accountID int64) ([]domain.Installment, map[int64]time.Time, error) {
+	query := `
+		SELECT i.id, i.transaction_id, i.number, i.amount, i.due_date, GREATEST(t.event_date, l.closing_date)
+		FROM installments i
+		JOIN transactions t ON t.id = i.transaction_id
+		LEFT JOIN LATERAL (
+			SELECT closing_date FROM invoices WHERE account_id = t.account_id ORDER BY closing_date DESC LIMIT 1
+		) l ON TRUE
+		WHERE t.account_id = $1
+			AND NOT EXISTS (SELECT 1 FROM invoice_items ii WHERE ii.transaction_id = i.transaction_id AND ii.installment_number = i.number)
+		ORDER BY i.transaction_id, i.number`
+
+	rows, err := tx.QueryContext(ctx, query, accountID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to list unbilled installments: %w", err)
+	}
+	defer rows.Close()
+
+	installments := []domain.Installment{}
+	billedFrom := map[int64]time.Time{}
+	for rows.Next() {
+		var (
+			installment domain.Installment
+			from        time.Time
+		)
+		err := rows.Scan(&installment.ID, &installment.TransactionID, &installment.Number, &installment.Amount, &installment.DueDate, &from)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to scan installment: %w", err)
+		}
+		installments = append(installments, installment)
+		billedFrom[installment.TransactionID] = from
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("unable to list unbilled installments: %w", err)
+	}
+
+	return installments, billedFrom, nil
+}
+
+// UpdateStatus applies the status change only if the account still has the status the
+// change starts from, and records it in the account's status history.
+func (r *accountRepository) UpdateStatus(ctx context.Context, change domain.AccountStatusChange) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logUpdateStatusError(ctx, change, err)
+		return fmt.Errorf("failed to update account status: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := "UPDATE accounts SET status = $1, updated_at = NOW() WHERE id = $2 AND status = $3"
+	result, err := tx.ExecContext(ctx, query, change.ToStatus, change.AccountID, change.FromStatus)
+	if err != nil {
+		r.logUpdateStatusError(ctx, change, err)
+		return fmt.Errorf("failed to update account status: %w", translatePostgresError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logUpdateStatusError(ctx, change, err)
+		return fmt.Errorf("failed to update account status: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		logger.Logger.ErrorContext(ctx, "account status changed concurrently", slog.Int64("account_id", change.AccountID), slog.String("from_status", string(change.FromStatus)))
+		return ErrAccountStatusChanged
+	}
+
+	query = "INSERT INTO account_status_changes (account_id, from_status, to_status, reason, actor, changed_at) VALUES ($1, $2, $3, $4, $5, $6)"
+	_, err = tx.ExecContext(ctx, query, change.AccountID, change.FromStatus, change.ToStatus, change.Reason, change.Actor, change.ChangedAt)
+	if err != nil {
+		r.logUpdateStatusError(ctx, change, err)
+		return fmt.Errorf("failed to update account status: %w", translatePostgresError(err))
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logUpdateStatusError(ctx, change, err)
+		return fmt.Errorf("failed to update account status: %w", err)
+	}
+
+	return nil
+}
+
+// ListStatusChanges returns the status history of the account, oldest change first.
+func (r *accountRepository) ListStatusChanges(ctx context.Context, accountID int64) ([]domain.AccountStatusChange, error) {
+	query := "SELECT id, account_id, from_status, to_status, reason, actor, changed_at FROM account_status_changes WHERE account_id = $1 ORDER BY changed_at, id"
+	rows, err := r.db.QueryContext(ctx, query, accountID)
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error listing account status changes", slog.Int64("account_id", accountID), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list account status changes: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []domain.AccountStatusChange{}
+	for rows.Next() {
+		var change domain.AccountStatusChange
+		err := rows.Scan(&change.ID, &change.AccountID, &change.FromStatus, &change.ToStatus, &change.Reason, &change.Actor, &change.ChangedAt)
+		if err != nil {
+			logger.Logger.ErrorContext(ctx, "error listing account status changes", slog.Int64("account_id", accountID), slog.String("error", err.Error()))
+			return nil, fmt.Errorf("unable to scan account status change: %w", err)
+		}
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Logger.ErrorContext(ctx, "error listing account status changes", slog.Int64("account_id", accountID), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list account status changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+func (r *accountRepository) logUpdateStatusError(ctx context.Context, change domain.AccountStatusChange, err error) {
+	logger.Logger.ErrorContext(
+		ctx,
+		"error updating account status",
+		slog.Int64("account_id", change.AccountID),
+		slog.String("to_status", string(change.ToStatus)),
+		slog.String("error", err.Error()),
+	)
+}
+
 func (r *accountRepository) scanAccount(row *sql.Row) (*domain.Account, error) {
 	var (
-		id             sql.NullInt64
-		documentNumber sql.NullString
-		createdAt      sql.NullTime
+		id                   sql.NullInt64
+		documentNumber       sql.NullString
+		documentType         sql.NullString
+		creditLimit          domain.Money
+		availableCreditLimit domain.Money
+		creditBalance        domain.Money
+		closingDay           sql.NullInt64
+		dueDay               sql.NullInt64
+		status               sql.NullString
+		createdAt            sql.NullTime
 	)
 
 	err := row.Scan(
 		&id,
 		&documentNumber,
+		&documentType,
+		&creditLimit,
+		&availableCreditLimit,
+		&creditBalance,
+		&closingDay,
+		&dueDay,
+		&status,
 		&createdAt,
 	)
 
@@ -67,8 +319,13 @@ func (r *accountRepository) scanAccount(row *sql.Row) (*domain.Account, error) {
 		return nil, fmt.Errorf("unable to scan account: %w", err)
 	}
 
-	account := domain.NewAccount(documentNumber.String)
+	account := domain.NewAccount(documentNumber.String, creditLimit)
 	account.SetID(id.Int64)
+	account.SetAvailableCreditLimit(availableCreditLimit)
+	account.SetCreditBalance(creditBalance)
+	account.SetDocumentType(domain.DocumentType(documentType.String))
+	account.SetBillingCycle(domain.BillingCycle{ClosingDay: int(closingDay.Int64), DueDay: int(dueDay.Int64)})
+	account.SetStatus(domain.AccountStatus(status.String))
 	account.SetCreatedAt(createdAt.Time)
 	return account, nil
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/VieiraVitor/transaction-flow/internal/domain"
 	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
@@ -43,10 +44,11 @@ func TestAccountRepositorySuite(t *testing.T) {
 func (s *AccountRepositoryTestSuite) TestAccountRepository_CreateAccount_WhenValidInput_ShouldReturnID() {
 	// Arrange
 	ctx := context.Background()
-	account := domain.NewAccount("12345678900")
+	account := domain.NewAccount("12345678909", domain.MustParseMoney("1000"))
+	account.SetDocumentType(domain.DocumentTypeCPF)
 
 	s.mock.ExpectQuery("INSERT INTO accounts").
-		WithArgs(account.DocumentNumber()).
+		WithArgs(account.DocumentNumber(), account.DocumentType(), account.CreditLimit(), account.AvailableCreditLimit()).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
 	// Act
@@ -61,11 +63,12 @@ func (s *AccountRepositoryTestSuite) TestAccountRepository_CreateAccount_WhenVal
 func (s *AccountRepositoryTestSuite) TestAccountRepository_CreateAccount_WhenFailedToCreateAccount_ShouldReturnError() {
 	// Arrange
 	ctx := context.Background()
-	account := domain.NewAccount("12345678900")
+	account := domain.NewAccount("12345678909", domain.MustParseMoney("1000"))
+	account.SetDocumentType(domain.DocumentTypeCPF)
 	expectedError := errors.New("failed to create account")
 
 	s.mock.ExpectQuery("INSERT INTO accounts").
-		WithArgs(account.DocumentNumber()).
+		WithArgs(account.DocumentNumber(), account.DocumentType(), account.CreditLimit(), account.AvailableCreditLimit()).
 		WillReturnError(expectedError)
 
 	// Act
@@ -78,14 +81,54 @@ func (s *AccountRepositoryTestSuite) TestAccountRepository_CreateAccount_WhenFai
 	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
 }
 
+func (s *AccountRepositoryTestSuite) TestAccountRepository_CreateAccount_WhenDocumentNumberExists_ShouldReturnAccountAlreadyExistsError() {
+	// Arrange
+	ctx := context.Background()
+	account := domain.NewAccount("12345678909", domain.MustParseMoney("1000"))
+	account.SetDocumentType(domain.DocumentTypeCPF)
+
+	s.mock.ExpectQuery("INSERT INTO accounts").
+		WithArgs(account.DocumentNumber(), account.DocumentType(), account.CreditLimit(), account.AvailableCreditLimit()).
+		WillReturnError(&pq.Error{Code: pgUniqueViolation, Constraint: accountsDocumentNumberKey})
+	s.mock.ExpectQuery("SELECT id FROM accounts WHERE document_number").
+		WithArgs(account.DocumentNumber()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(42))
+
+	// Act
+	id, err := s.repo.CreateAccount(ctx, account)
+
+	// Assert
+	var alreadyExists *domain.AccountAlreadyExistsError
+	assert.ErrorAs(s.T(), err, &alreadyExists)
+	assert.Equal(s.T(), int64(42), alreadyExists.AccountID)
+	assert.ErrorIs(s.T(), err, domain.ErrAlreadyExists)
+	assert.Equal(s.T(), int64(0), id)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *AccountRepositoryTestSuite) TestAccountRepository_UpdateCreditLimit_WhenCheckViolated_ShouldReturnErrConstraintViolation() {
+	// Arrange
+	ctx := context.Background()
+
+	s.mock.ExpectExec("UPDATE accounts SET available_credit_limit").
+		WillReturnError(&pq.Error{Code: pgCheckViolation, Message: "violates check constraint"})
+
+	// Act
+	err := s.repo.UpdateCreditLimit(ctx, 1, domain.MustParseMoney("10"))
+
+	// Assert
+	assert.ErrorIs(s.T(), err, domain.ErrConstraintViolation)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
 func (s *AccountRepositoryTestSuite) TestAccountRepository_GetAccount_WhenAccountExists_ShouldReturnAccount() {
 	// Arrange
 	ctx := context.Background()
 
-	s.mock.ExpectQuery("SELECT id, document_number, created_at FROM accounts WHERE id = ?").
+	s.mock.ExpectQuery("SELECT id, document_number, document_type, credit_limit, available_credit_limit, credit_balance, closing_day, due_day, status, created_at FROM accounts WHERE id = ?").
 		WithArgs(1).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "document_number", "created_at"}).
-			AddRow(1, "12345678900", time.Now()))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "document_number", "document_type", "credit_limit", "available_credit_limit", "credit_balance", "closing_day", "due_day", "status", "created_at"}).
+			AddRow(1, "12345678909", "CPF", "1000.00", "850.00", "0.00", 8, 15, "BLOCKED", time.Now()))
 
 	// Act
 	account, err := s.repo.GetAccount(ctx, 1)
@@ -94,14 +137,20 @@ func (s *AccountRepositoryTestSuite) TestAccountRepository_GetAccount_WhenAccoun
 	assert.NoError(s.T(), err)
 	assert.NotNil(s.T(), account)
 	assert.Equal(s.T(), int64(1), account.ID())
-	assert.Equal(s.T(), "12345678900", account.DocumentNumber())
+	assert.Equal(s.T(), "12345678909", account.DocumentNumber())
+	assert.Equal(s.T(), domain.DocumentTypeCPF, account.DocumentType())
+	assert.Equal(s.T(), domain.MustParseMoney("1000"), account.CreditLimit())
+	assert.Equal(s.T(), domain.MustParseMoney("850"), account.AvailableCreditLimit())
+	assert.Equal(s.T(), domain.MustParseMoney("0"), account.CreditBalance())
+	assert.Equal(s.T(), domain.BillingCycle{ClosingDay: 8, DueDay: 15}, account.BillingCycle())
+	assert.Equal(s.T(), domain.AccountStatusBlocked, account.Status())
 }
 
 func (s *AccountRepositoryTestSuite) TestAccountRepository_GetAccount_WhenAccountNotFound_ShouldReturnError() {
 	// Arrange
 	ctx := context.Background()
 
-	s.mock.ExpectQuery("SELECT id, document_number, created_at FROM accounts WHERE id = ?").
+	s.mock.ExpectQuery("SELECT id, document_number, document_type, credit_limit, available_credit_limit, credit_balance, closing_day, due_day, status, created_at FROM accounts WHERE id = ?").
 		WithArgs(1).
 		WillReturnError(sql.ErrNoRows)
 
@@ -119,7 +168,7 @@ func (s *AccountRepositoryTestSuite) TestAccountRepository_GetAccount_WhenFailTo
 	ctx := context.Background()
 	expectedError := errors.New("failed to get account")
 
-	s.mock.ExpectQuery("SELECT id, document_number, created_at FROM accounts WHERE id = ?").
+	s.mock.ExpectQuery("SELECT id, document_number, document_type, credit_limit, available_credit_limit, credit_balance, closing_day, due_day, status, created_at FROM accounts WHERE id = ?").
 		WithArgs(1).
 		WillReturnError(expectedError)
 
@@ -131,3 +180,188 @@ func (s *AccountRepositoryTestSuite) TestAccountRepository_GetAccount_WhenFailTo
 	assert.Nil(s.T(), account)
 	assert.ErrorContains(s.T(), err, expectedError.Error())
 }
+
+func (s *AccountRepositoryTestSuite) TestAccountRepository_UpdateCreditLimit_WhenAccountExists_ShouldReturnNil() {
+	// Arrange
+	ctx := context.Background()
+
+	s.mock.ExpectExec("UPDATE accounts SET available_credit_limit = available_credit_limit \\+ \\$1 - credit_limit, credit_limit = \\$1").
+		WithArgs(domain.MustParseMoney("1500"), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Act
+	err := s.repo.UpdateCreditLimit(ctx, 1, domain.MustParseMoney("1500"))
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *AccountRepositoryTestSuite) TestAccountRepository_UpdateCreditLimit_WhenAccountNotFound_ShouldReturnError() {
+	// Arrange
+	ctx := context.Background()
+
+	s.mock.ExpectExec("UPDATE accounts SET available_credit_limit").
+		WithArgs(domain.MustParseMoney("1500"), 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	// Act
+	err := s.repo.UpdateCreditLimit(ctx, 1, domain.MustParseMoney("1500"))
+
+	// Assert
+	assert.ErrorIs(s.T(), err, ErrAccountNotFound)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *AccountRepositoryTestSuite) TestAccountRepository_UpdateBillingCycle_WhenAccountExists_ShouldReturnNil() {
+	// Arrange
+	ctx := context.Background()
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec("UPDATE accounts SET closing_day = \\$1, due_day = \\$2").
+		WithArgs(20, 27, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectQuery("SELECT i.id, i.transaction_id, i.number, i.amount, i.due_date").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "transaction_id", "number", "amount", "due_date", "billed_from"}))
+	s.mock.ExpectCommit()
+
+	// Act
+	err := s.repo.UpdateBillingCycle(ctx, 1, domain.BillingCycle{ClosingDay: 20, DueDay: 27})
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *AccountRepositoryTestSuite) TestAccountRepository_UpdateBillingCycle_WhenInstallmentsAreUnbilled_ShouldMoveThemToNewCycle() {
+	// Arrange
+	ctx := context.Background()
+	lastClosingDate := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec("UPDATE accounts SET closing_day = \\$1, due_day = \\$2").
+		WithArgs(25, 5, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectQuery("SELECT i.id, i.transaction_id, i.number, i.amount, i.due_date").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "transaction_id", "number", "amount", "due_date", "billed_from"}).
+			AddRow(2, 10, 2, "33.33", time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC), lastClosingDate).
+			AddRow(3, 10, 3, "33.33", time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), lastClosingDate).
+			AddRow(5, 11, 1, "50.00", time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 28, 12, 0, 0, 0, time.UTC)))
+	s.mock.ExpectExec("UPDATE installments SET due_date = \\$1 WHERE id = \\$2").
+		WithArgs(time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC), 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectExec("UPDATE installments SET due_date = \\$1 WHERE id = \\$2").
+		WithArgs(time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC), 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectExec("UPDATE installments SET due_date = \\$1 WHERE id = \\$2").
+		WithArgs(time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC), 5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectCommit()
+
+	// Act
+	err := s.repo.UpdateBillingCycle(ctx, 1, domain.BillingCycle{ClosingDay: 25, DueDay: 5})
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *AccountRepositoryTestSuite) TestAccountRepository_UpdateBillingCycle_WhenAccountNotFound_ShouldReturnError() {
+	// Arrange
+	ctx := context.Background()
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec("UPDATE accounts SET closing_day = \\$1, due_day = \\$2").
+		WithArgs(20, 27, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mock.ExpectRollback()
+
+	// Act
+	err := s.repo.UpdateBillingCycle(ctx, 1, domain.BillingCycle{ClosingDay: 20, DueDay: 27})
+
+	// Assert
+	assert.ErrorIs(s.T(), err, ErrAccountNotFound)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *AccountRepositoryTestSuite) TestAccountRepository_UpdateStatus_WhenAccountHasFromStatus_ShouldRecordChange() {
+	// Arrange
+	ctx := context.Background()
+	changedAt := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	change := domain.AccountStatusChange{
+		AccountID:  1,
+		FromStatus: domain.AccountStatusActive,
+		ToStatus:   domain.AccountStatusBlocked,
+		Reason:     "fraud suspicion",
+		Actor:      "analyst@bank",
+		ChangedAt:  changedAt,
+	}
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec("UPDATE accounts SET status = \\$1, updated_at = NOW\\(\\) WHERE id = \\$2 AND status = \\$3").
+		WithArgs(domain.AccountStatusBlocked, 1, domain.AccountStatusActive).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectExec("INSERT INTO account_status_changes").
+		WithArgs(1, domain.AccountStatusActive, domain.AccountStatusBlocked, "fraud suspicion", "analyst@bank", changedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	s.mock.ExpectCommit()
+
+	// Act
+	err := s.repo.UpdateStatus(ctx, change)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *AccountRepositoryTestSuite) TestAccountRepository_UpdateStatus_WhenStatusChangedConcurrently_ShouldReturnError() {
+	// Arrange
+	ctx := context.Background()
+	change := domain.AccountStatusChange{
+		AccountID:  1,
+		FromStatus: domain.AccountStatusActive,
+		ToStatus:   domain.AccountStatusBlocked,
+		Reason:     "fraud suspicion",
+		Actor:      "analyst@bank",
+		ChangedAt:  time.Now(),
+	}
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec("UPDATE accounts SET status").
+		WithArgs(domain.AccountStatusBlocked, 1, domain.AccountStatusActive).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mock.ExpectRollback()
+
+	// Act
+	err := s.repo.UpdateStatus(ctx, change)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, ErrAccountStatusChanged)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *AccountRepositoryTestSuite) TestAccountRepository_ListStatusChanges_ShouldReturnHistory() {
+	// Arrange
+	ctx := context.Background()
+	blockedAt := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	unblockedAt := time.Date(2025, 1, 12, 12, 0, 0, 0, time.UTC)
+
+	s.mock.ExpectQuery("SELECT id, account_id, from_status, to_status, reason, actor, changed_at FROM account_status_changes WHERE account_id = \\$1").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "from_status", "to_status", "reason", "actor", "changed_at"}).
+			AddRow(1, 1, "ACTIVE", "BLOCKED", "fraud suspicion", "analyst@bank", blockedAt).
+			AddRow(2, 1, "BLOCKED", "ACTIVE", "customer confirmed purchases", "analyst@bank", unblockedAt))
+
+	// Act
+	changes, err := s.repo.ListStatusChanges(ctx, 1)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []domain.AccountStatusChange{
+		{ID: 1, AccountID: 1, FromStatus: domain.AccountStatusActive, ToStatus: domain.AccountStatusBlocked, Reason: "fraud suspicion", Actor: "analyst@bank", ChangedAt: blockedAt},
+		{ID: 2, AccountID: 1, FromStatus: domain.AccountStatusBlocked, ToStatus: domain.AccountStatusActive, Reason: "customer confirmed purchases", Actor: "analyst@bank", ChangedAt: unblockedAt},
+	}, changes)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
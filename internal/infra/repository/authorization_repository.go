@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+)
+
+var ErrAuthorizationNotFound = errors.New("authorization not found")
+
+const authorizationColumns = "id, account_id, amount, captured_amount, status, transaction_id, expires_at, created_at"
+
+type authorizationRepository struct {
+	db           *sql.DB
+	transactions *transactionRepository
+}
+
+func NewAuthorizationRepository(db *sql.DB) *authorizationRepository {
+	return &authorizationRepository{db: db, transactions: NewTransactionRepository(db)}
+}
+
+// CreateAuthorization holds the authorized amount against the account's available credit
+// limit and stores the authorization in a single database transaction. Blocked and closed
+// accounts are rejected under the account lock.
+func (r *authorizationRepository) CreateAuthorization(ctx context.Context, authorization domain.Authorization) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logAuthorizationError(ctx, "error creating authorization", authorization, err)
+		return 0, fmt.Errorf("failed to create authorization: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := "SELECT status, available_credit_limit + credit_balance - $1 >= 0 FROM accounts WHERE id = $2 FOR UPDATE"
+	var (
+		status         domain.AccountStatus
+		hasCreditLimit bool
+	)
+	if err := tx.QueryRowContext(ctx, query, authorization.Amount, authorization.AccountID).Scan(&status, &hasCreditLimit); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Logger.ErrorContext(ctx, "account not found", slog.Int64("accountID", authorization.AccountID))
+			return 0, ErrAccountNotFound
+		}
+		r.logAuthorizationError(ctx, "error creating authorization", authorization, err)
+		return 0, fmt.Errorf("failed to create authorization: %w", err)
+	}
+
+	if err := status.AcceptsTransactions(authorization.AccountID, domain.DirectionDebit); err != nil {
+		logger.Logger.ErrorContext(ctx, "account does not accept authorization", slog.Int64("accountID", authorization.AccountID), slog.String("status", string(status)))
+		return 0, err
+	}
+
+	if !hasCreditLimit {
+		logger.Logger.ErrorContext(
+			ctx,
+			"insufficient credit limit",
+			slog.Int64("accountID", authorization.AccountID),
+			slog.String("amount", authorization.Amount.String()),
+		)
+		return 0, ErrInsufficientCreditLimit
+	}
+
+	if err := r.adjustCreditLimit(ctx, tx, authorization, authorization.Amount.Neg()); err != nil {
+		return 0, err
+	}
+
+	query = "INSERT INTO authorizations (account_id, amount, status, expires_at, created_at) VALUES ($1, $2, $3, $4, $5) RETURNING id"
+	var id int64
+	err = tx.QueryRowContext(ctx, query, authorization.AccountID, authorization.Amount, authorization.Status, authorization.ExpiresAt, authorization.CreatedAt).Scan(&id)
+	if err != nil {
+		r.logAuthorizationError(ctx, "error creating authorization", authorization, err)
+		return 0, fmt.Errorf("failed to create authorization: %w", translatePostgresError(err))
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logAuthorizationError(ctx, "error creating authorization", authorization, err)
+		return 0, fmt.Errorf("failed to create authorization: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *authorizationRepository) GetAuthorization(ctx context.Context, authorizationID int64) (*domain.Authorization, error) {
+	query := fmt.Sprintf("SELECT %s FROM authorizations WHERE id = $1", authorizationColumns)
+	authorization, err := r.scanAuthorization(r.db.QueryRowContext(ctx, query, authorizationID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Logger.ErrorContext(ctx, "authorization not found", slog.Int64("authorizationID", authorizationID))
+			return nil, ErrAuthorizationNotFound
+		}
+		logger.Logger.ErrorContext(ctx, "error getting authorization", slog.Int64("authorizationID", authorizationID), slog.String("error", err.Error()))
+		return nil, err
+	}
+	return authorization, nil
+}
+
+// ListExpiredAuthorizations returns the pending authorizations whose expiry is not after now.
+func (r *authorizationRepository) ListExpiredAuthorizations(ctx context.Context, now time.Time) ([]domain.Authorization, error) {
+	query := fmt.Sprintf("SELECT %s FROM authorizations WHERE status = $1 AND expires_at <= $2 ORDER BY expires_at, id", authorizationColumns)
+	rows, err := r.db.QueryContext(ctx, query, domain.AuthorizationPending, now)
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error listing expired authorizations", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list expired authorizations: %w", err)
+	}
+	defer rows.Close()
+
+	authorizations := []domain.Authorization{}
+	for rows.Next() {
+		authorization, err := r.scanAuthorization(rows)
+		if err != nil {
+			logger.Logger.ErrorContext(ctx, "error listing expired authorizations", slog.String("error", err.Error()))
+			return nil, err
+		}
+		authorizations = append(authorizations, *authorization)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Logger.ErrorContext(ctx, "error listing expired authorizations", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list expired authorizations: %w", err)
+	}
+
+	return authorizations, nil
+}
+
+// CaptureAuthorization releases the hold, posts the purchase against the account's credit
+// limit and marks the authorization as captured, all in a single database transaction.
+// It fails with ErrAuthorizationNotPending if the authorization was captured, voided or
+// expired meanwhile.
+func (r *authorizationRepository) CaptureAuthorization(ctx context.Context, authorization domain.Authorization, purchase domain.Transaction) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logAuthorizationError(ctx, "error capturing authorization", authorization, err)
+		return 0, fmt.Errorf("failed to capture authorization: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.transactions.lockAccount(ctx, tx, authorization.AccountID); err != nil {
+		return 0, err
+	}
+
+	if err := r.finishAuthorization(ctx, tx, authorization); err != nil {
+		return 0, err
+	}
+
+	if err := r.transactions.applyCreditLimit(ctx, tx, purchase); err != nil {
+		return 0, err
+	}
+
+	transactionID, err := r.transactions.insertTransaction(ctx, tx, purchase)
+	if err != nil {
+		return 0, err
+	}
+
+	query := "UPDATE authorizations SET captured_amount = $1, transaction_id = $2 WHERE id = $3"
+	if _, err := tx.ExecContext(ctx, query, authorization.CapturedAmount, transactionID, authorization.ID); err != nil {
+		r.logAuthorizationError(ctx, "error capturing authorization", authorization, err)
+		return 0, fmt.Errorf("failed to capture authorization: %w", translatePostgresError(err))
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logAuthorizationError(ctx, "error capturing authorization", authorization, err)
+		return 0, fmt.Errorf("failed to capture authorization: %w", err)
+	}
+
+	return transactionID, nil
+}
+
+// ReleaseAuthorization gives the held amount back to the account's available credit limit
+// and stores the final status of a voided or expired authorization.
+func (r *authorizationRepository) ReleaseAuthorization(ctx context.Context, authorization domain.Authorization) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logAuthorizationError(ctx, "error releasing authorization", authorization, err)
+		return fmt.Errorf("failed to release authorization: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.transactions.lockAccount(ctx, tx, authorization.AccountID); err != nil {
+		return err
+	}
+
+	if err := r.finishAuthorization(ctx, tx, authorization); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logAuthorizationError(ctx, "error releasing authorization", authorization, err)
+		return fmt.Errorf("failed to release authorization: %w", err)
+	}
+
+	return nil
+}
+
+// finishAuthorization moves a pending authorization to its final status and releases its
+// hold. The account row must already be locked by the caller.
+func (r *authorizationRepository) finishAuthorization(ctx context.Context, tx *sql.Tx, authorization domain.Authorization) error {
+	query := "UPDATE authorizations SET status = $1, updated_at = NOW() WHERE id = $2 AND status = $3"
+	result, err := tx.ExecContext(ctx, query, authorization.Status, authorization.ID, domain.AuthorizationPending)
+	if err != nil {
+		r.logAuthorizationError(ctx, "error updating authorization", authorization, err)
+		return fmt.Errorf("failed to update authorization: %w", translatePostgresError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logAuthorizationError(ctx, "error updating authorization", authorization, err)
+		return fmt.Errorf("failed to update authorization: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		logger.Logger.ErrorContext(ctx, "authorization is not pending", slog.Int64("authorizationID", authorization.ID))
+		return fmt.Errorf("%w: authorization %d", domain.ErrAuthorizationNotPending, authorization.ID)
+	}
+
+	return r.adjustCreditLimit(ctx, tx, authorization, authorization.Amount)
+}
+
+func (r *authorizationRepository) adjustCreditLimit(ctx context.Context, tx *sql.Tx, authorization domain.Authorization, amount domain.Money) error {
+	if _, err := tx.ExecContext(ctx, adjustAvailableCreditQuery, amount, authorization.AccountID); err != nil {
+		r.logAuthorizationError(ctx, "error updating available credit limit", authorization, err)
+		return fmt.Errorf("failed to update available credit limit: %w", translatePostgresError(err))
+	}
+	return nil
+}
+
+func (r *authorizationRepository) scanAuthorization(row rowScanner) (*domain.Authorization, error) {
+	var (
+		authorization domain.Authorization
+		transactionID sql.NullInt64
+	)
+
+	err := row.Scan(
+		&authorization.ID,
+		&authorization.AccountID,
+		&authorization.Amount,
+		&authorization.CapturedAmount,
+		&authorization.Status,
+		&transactionID,
+		&authorization.ExpiresAt,
+		&authorization.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to scan authorization: %w", err)
+	}
+
+	if transactionID.Valid {
+		authorization.TransactionID = &transactionID.Int64
+	}
+	return &authorization, nil
+}
+
+func (r *authorizationRepository) logAuthorizationError(ctx context.Context, msg string, authorization domain.Authorization, err error) {
+	logger.Logger.ErrorContext(
+		ctx,
+		msg,
+		slog.Int64("authorizationID", authorization.ID),
+		slog.Int64("accountID", authorization.AccountID),
+		slog.String("amount", authorization.Amount.String()),
+		slog.String("error", err.Error()),
+	)
+}
@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuthorizationRepositoryTestSuite struct {
+	suite.Suite
+	repo *authorizationRepository
+	mock sqlmock.Sqlmock
+	db   *sql.DB
+}
+
+func (s *AuthorizationRepositoryTestSuite) SetupTest() {
+	logger.InitLogger()
+	var err error
+	s.db, s.mock, err = sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	s.repo = NewAuthorizationRepository(s.db)
+}
+
+func (s *AuthorizationRepositoryTestSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func TestAuthorizationRepositorySuite(t *testing.T) {
+	suite.Run(t, new(AuthorizationRepositoryTestSuite))
+}
+
+func (s *AuthorizationRepositoryTestSuite) TestAuthorizationRepository_CreateAuthorization_WhenHasCreditLimit_ShouldHoldAmount() {
+	// Arrange
+	createdAt := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	authorization, _ := domain.NewAuthorization(1, domain.MustParseMoney("150"), createdAt, time.Hour)
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT status, available_credit_limit \\+ credit_balance - \\$1 >= 0 FROM accounts WHERE id = \\$2 FOR UPDATE").
+		WithArgs(authorization.Amount, int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "has_credit_limit"}).AddRow("ACTIVE", true))
+	s.mock.ExpectExec("UPDATE accounts SET available_credit_limit = CASE").
+		WithArgs(domain.MustParseMoney("-150"), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectQuery("INSERT INTO authorizations").
+		WithArgs(int64(1), authorization.Amount, domain.AuthorizationPending, createdAt.Add(time.Hour), createdAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	s.mock.ExpectCommit()
+
+	// Act
+	id, err := s.repo.CreateAuthorization(context.Background(), authorization)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(3), id)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *AuthorizationRepositoryTestSuite) TestAuthorizationRepository_CreateAuthorization_WhenInsufficientCreditLimit_ShouldReturnError() {
+	// Arrange
+	authorization, _ := domain.NewAuthorization(1, domain.MustParseMoney("150"), time.Now(), time.Hour)
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT status, available_credit_limit").
+		WithArgs(authorization.Amount, int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "has_credit_limit"}).AddRow("ACTIVE", false))
+	s.mock.ExpectRollback()
+
+	// Act
+	id, err := s.repo.CreateAuthorization(context.Background(), authorization)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, ErrInsufficientCreditLimit)
+	assert.Equal(s.T(), int64(0), id)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *AuthorizationRepositoryTestSuite) TestAuthorizationRepository_CreateAuthorization_WhenAccountIsBlocked_ShouldReturnErrAccountBlocked() {
+	// Arrange
+	authorization, _ := domain.NewAuthorization(1, domain.MustParseMoney("150"), time.Now(), time.Hour)
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT status, available_credit_limit").
+		WithArgs(authorization.Amount, int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "has_credit_limit"}).AddRow("BLOCKED", true))
+	s.mock.ExpectRollback()
+
+	// Act
+	id, err := s.repo.CreateAuthorization(context.Background(), authorization)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, domain.ErrAccountBlocked)
+	assert.Equal(s.T(), int64(0), id)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *AuthorizationRepositoryTestSuite) TestAuthorizationRepository_GetAuthorization_WhenNotFound_ShouldReturnErrAuthorizationNotFound() {
+	// Arrange
+	s.mock.ExpectQuery("SELECT (.+) FROM authorizations WHERE id = \\$1").
+		WithArgs(int64(3)).
+		WillReturnError(sql.ErrNoRows)
+
+	// Act
+	authorization, err := s.repo.GetAuthorization(context.Background(), 3)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, ErrAuthorizationNotFound)
+	assert.Nil(s.T(), authorization)
+}
+
+func (s *AuthorizationRepositoryTestSuite) TestAuthorizationRepository_GetAuthorization_WhenCaptured_ShouldReturnTransactionID() {
+	// Arrange
+	createdAt := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	s.mock.ExpectQuery("SELECT id, account_id, amount, captured_amount, status, transaction_id, expires_at, created_at FROM authorizations WHERE id = \\$1").
+		WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "amount", "captured_amount", "status", "transaction_id", "expires_at", "created_at"}).
+			AddRow(3, 1, "150.00", "120.50", "CAPTURED", 9, createdAt.Add(time.Hour), createdAt))
+
+	// Act
+	authorization, err := s.repo.GetAuthorization(context.Background(), 3)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), domain.MustParseMoney("150"), authorization.Amount)
+	assert.Equal(s.T(), domain.MustParseMoney("120.50"), authorization.CapturedAmount)
+	assert.Equal(s.T(), domain.AuthorizationCaptured, authorization.Status)
+	assert.Equal(s.T(), int64(9), *authorization.TransactionID)
+}
+
+func (s *AuthorizationRepositoryTestSuite) TestAuthorizationRepository_CaptureAuthorization_ShouldReleaseHoldAndPostPurchase() {
+	// Arrange
+	authorization, _ := domain.NewAuthorization(1, domain.MustParseMoney("150"), time.Now(), time.Hour)
+	authorization.ID = 3
+	amount := domain.MustParseMoney("120.50")
+	purchase, _ := authorization.Capture(&amount, time.Now())
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT id FROM accounts WHERE id = \\$1 FOR UPDATE").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	s.mock.ExpectExec("UPDATE authorizations SET status = \\$1, updated_at = NOW\\(\\) WHERE id = \\$2 AND status = \\$3").
+		WithArgs(domain.AuthorizationCaptured, int64(3), domain.AuthorizationPending).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectExec("UPDATE accounts SET available_credit_limit").
+		WithArgs(domain.MustParseMoney("150"), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectQuery("SELECT status, available_credit_limit").
+		WithArgs(purchase.Amount(), int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "has_credit_limit"}).AddRow("ACTIVE", true))
+	s.mock.ExpectExec("UPDATE accounts SET available_credit_limit").
+		WithArgs(domain.MustParseMoney("-120.50"), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectQuery("INSERT INTO transactions").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+	s.mock.ExpectQuery("INSERT INTO journal_entries").
+		WithArgs(int64(9), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+	s.mock.ExpectExec("INSERT INTO postings").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	s.mock.ExpectExec("UPDATE authorizations SET captured_amount = \\$1, transaction_id = \\$2 WHERE id = \\$3").
+		WithArgs(amount, int64(9), int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectCommit()
+
+	// Act
+	transactionID, err := s.repo.CaptureAuthorization(context.Background(), authorization, purchase)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(9), transactionID)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *AuthorizationRepositoryTestSuite) TestAuthorizationRepository_ReleaseAuthorization_WhenNoLongerPending_ShouldReturnErrAuthorizationNotPending() {
+	// Arrange
+	authorization, _ := domain.NewAuthorization(1, domain.MustParseMoney("150"), time.Now(), time.Hour)
+	authorization.ID = 3
+	_ = authorization.Void(time.Now())
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT id FROM accounts WHERE id = \\$1 FOR UPDATE").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	s.mock.ExpectExec("UPDATE authorizations SET status").
+		WithArgs(domain.AuthorizationVoided, int64(3), domain.AuthorizationPending).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mock.ExpectRollback()
+
+	// Act
+	err := s.repo.ReleaseAuthorization(context.Background(), authorization)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, domain.ErrAuthorizationNotPending)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *AuthorizationRepositoryTestSuite) TestAuthorizationRepository_ListExpiredAuthorizations_ShouldReturnPendingAuthorizationsPastExpiry() {
+	// Arrange
+	now := time.Date(2025, 2, 7, 12, 0, 0, 0, time.UTC)
+	createdAt := now.Add(-8 * 24 * time.Hour)
+	s.mock.ExpectQuery("SELECT (.+) FROM authorizations WHERE status = \\$1 AND expires_at <= \\$2").
+		WithArgs(domain.AuthorizationPending, now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "amount", "captured_amount", "status", "transaction_id", "expires_at", "created_at"}).
+			AddRow(3, 1, "150.00", "0.00", "PENDING", nil, createdAt.Add(7*24*time.Hour), createdAt))
+
+	// Act
+	authorizations, err := s.repo.ListExpiredAuthorizations(context.Background(), now)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), authorizations, 1)
+	assert.Equal(s.T(), int64(3), authorizations[0].ID)
+	assert.Nil(s.T(), authorizations[0].TransactionID)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
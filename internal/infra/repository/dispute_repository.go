@@ -0,0 +1,394 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+)
+
+var (
+	ErrDisputeNotFound      = errors.New("dispute not found")
+	ErrDisputeAlreadyOpen   = errors.New("transaction already has a dispute")
+	ErrDisputeExceedsAmount = errors.New("dispute exceeds the amount not yet reversed")
+)
+
+const (
+	disputeColumns = "id, transaction_id, account_id, amount, reason, status, provisional_credit_transaction_id, reversal_transaction_id, opened_at, resolved_at"
+
+	disputesTransactionIDActiveKey = "disputes_transaction_id_active_key"
+)
+
+type disputeRepository struct {
+	db           *sql.DB
+	transactions *transactionRepository
+}
+
+func NewDisputeRepository(db *sql.DB) *disputeRepository {
+	return &disputeRepository{db: db, transactions: NewTransactionRepository(db)}
+}
+
+// OpenDispute stores the dispute, posts its provisional credit and records both status
+// changes in a single database transaction. The dispute must already be in
+// PROVISIONAL_CREDIT with credit being the transaction that granted it. The disputed
+// transaction is locked, as in transactionRepository.CreateReversal, and the dispute cannot
+// exceed what is left of it after its reversals, so the same amount is never credited back
+// twice.
+func (r *disputeRepository) OpenDispute(ctx context.Context, dispute domain.Dispute, credit domain.Transaction) (*domain.Dispute, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logDisputeError(ctx, "error opening dispute", dispute, err)
+		return nil, fmt.Errorf("failed to open dispute: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.transactions.lockAccount(ctx, tx, dispute.AccountID); err != nil {
+		return nil, err
+	}
+	if err := r.ensureNotReversed(ctx, tx, dispute); err != nil {
+		return nil, err
+	}
+
+	query := "INSERT INTO disputes (transaction_id, account_id, amount, reason, status, opened_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id"
+	err = tx.QueryRowContext(ctx, query, dispute.TransactionID, dispute.AccountID, dispute.Amount, dispute.Reason, domain.DisputeOpened, dispute.OpenedAt).Scan(&dispute.ID)
+	if err != nil {
+		if isUniqueViolation(err, disputesTransactionIDActiveKey) {
+			logger.Logger.ErrorContext(ctx, "transaction already has a dispute", slog.Int64("transactionID", dispute.TransactionID))
+			return nil, fmt.Errorf("%w: transaction %d", ErrDisputeAlreadyOpen, dispute.TransactionID)
+		}
+		r.logDisputeError(ctx, "error opening dispute", dispute, err)
+		return nil, fmt.Errorf("failed to open dispute: %w", translatePostgresError(err))
+	}
+
+	opened := domain.DisputeEvent{ToStatus: domain.DisputeOpened, Note: dispute.Reason, CreatedAt: dispute.OpenedAt}
+	if err := r.insertEvent(ctx, tx, dispute, &opened); err != nil {
+		return nil, err
+	}
+
+	if err := r.transactions.applyCreditLimit(ctx, tx, credit); err != nil {
+		return nil, err
+	}
+	creditID, err := r.transactions.insertTransaction(ctx, tx, credit)
+	if err != nil {
+		return nil, err
+	}
+	dispute.ProvisionalCreditTransactionID = &creditID
+
+	query = "UPDATE disputes SET status = $1, provisional_credit_transaction_id = $2, updated_at = NOW() WHERE id = $3"
+	if _, err := tx.ExecContext(ctx, query, dispute.Status, creditID, dispute.ID); err != nil {
+		r.logDisputeError(ctx, "error opening dispute", dispute, err)
+		return nil, fmt.Errorf("failed to open dispute: %w", translatePostgresError(err))
+	}
+
+	credited := domain.DisputeEvent{FromStatus: domain.DisputeOpened, ToStatus: dispute.Status, TransactionID: &creditID, CreatedAt: dispute.OpenedAt}
+	if err := r.insertEvent(ctx, tx, dispute, &credited); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logDisputeError(ctx, "error opening dispute", dispute, err)
+		return nil, fmt.Errorf("failed to open dispute: %w", err)
+	}
+
+	dispute.Events = []domain.DisputeEvent{opened, credited}
+	return &dispute, nil
+}
+
+// ensureNotReversed locks the disputed transaction and checks that the dispute does not
+// exceed what its reversals left of it. Disputes that are open or won are not subtracted:
+// while there is one, the unique index on the active disputes rejects another.
+func (r *disputeRepository) ensureNotReversed(ctx context.Context, tx *sql.Tx, dispute domain.Dispute) error {
+	query := `
+		SELECT amount, (SELECT COALESCE(SUM(r.amount), 0) FROM transactions r WHERE r.reverses_transaction_id = transactions.id)
+		FROM transactions
+		WHERE id = $1
+		FOR UPDATE`
+
+	var amount, reversed domain.Money
+	if err := tx.QueryRowContext(ctx, query, dispute.TransactionID).Scan(&amount, &reversed); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Logger.ErrorContext(ctx, "transaction not found", slog.Int64("transactionID", dispute.TransactionID))
+			return ErrTransactionNotFound
+		}
+		r.logDisputeError(ctx, "error opening dispute", dispute, err)
+		return fmt.Errorf("failed to open dispute: %w", err)
+	}
+
+	remaining := amount.Add(reversed).Abs()
+	if dispute.Amount.Sub(remaining).IsPositive() {
+		logger.Logger.ErrorContext(ctx, "dispute exceeds the amount not yet reversed", slog.Int64("transactionID", dispute.TransactionID), slog.String("amount", dispute.Amount.String()))
+		return fmt.Errorf("%w: %s disputed, %s left", ErrDisputeExceedsAmount, dispute.Amount, remaining)
+	}
+	return nil
+}
+
+// ResolveDispute stores the outcome of a dispute that still holds its provisional credit
+// and, for a lost dispute, posts the reversal of that credit. The reversal cancels what is
+// left of the credit's balance first and is posted even if it takes the available credit
+// limit below zero, as charges are. It fails with
+// domain.ErrDisputeNotResolvable if the dispute was resolved meanwhile.
+func (r *disputeRepository) ResolveDispute(ctx context.Context, dispute domain.Dispute, reversal *domain.Transaction) (*domain.Dispute, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logDisputeError(ctx, "error resolving dispute", dispute, err)
+		return nil, fmt.Errorf("failed to resolve dispute: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.transactions.lockAccount(ctx, tx, dispute.AccountID); err != nil {
+		return nil, err
+	}
+
+	query := "UPDATE disputes SET status = $1, resolved_at = $2, updated_at = NOW() WHERE id = $3 AND status = $4"
+	result, err := tx.ExecContext(ctx, query, dispute.Status, dispute.ResolvedAt, dispute.ID, domain.DisputeProvisionalCredit)
+	if err != nil {
+		r.logDisputeError(ctx, "error resolving dispute", dispute, err)
+		return nil, fmt.Errorf("failed to resolve dispute: %w", translatePostgresError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logDisputeError(ctx, "error resolving dispute", dispute, err)
+		return nil, fmt.Errorf("failed to resolve dispute: %w", err)
+	}
+	if rowsAffected == 0 {
+		logger.Logger.ErrorContext(ctx, "dispute is already resolved", slog.Int64("disputeID", dispute.ID))
+		return nil, fmt.Errorf("%w: dispute %d", domain.ErrDisputeNotResolvable, dispute.ID)
+	}
+
+	resolved := domain.DisputeEvent{FromStatus: domain.DisputeProvisionalCredit, ToStatus: dispute.Status, CreatedAt: *dispute.ResolvedAt}
+	if reversal != nil {
+		reversalID, err := r.reverseProvisionalCredit(ctx, tx, dispute, *reversal)
+		if err != nil {
+			return nil, err
+		}
+		dispute.ReversalTransactionID = &reversalID
+		resolved.TransactionID = &reversalID
+
+		query = "UPDATE disputes SET reversal_transaction_id = $1 WHERE id = $2"
+		if _, err := tx.ExecContext(ctx, query, reversalID, dispute.ID); err != nil {
+			r.logDisputeError(ctx, "error resolving dispute", dispute, err)
+			return nil, fmt.Errorf("failed to resolve dispute: %w", translatePostgresError(err))
+		}
+	}
+
+	if err := r.insertEvent(ctx, tx, dispute, &resolved); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logDisputeError(ctx, "error resolving dispute", dispute, err)
+		return nil, fmt.Errorf("failed to resolve dispute: %w", err)
+	}
+
+	dispute.Events = append(dispute.Events, resolved)
+	return &dispute, nil
+}
+
+// reverseProvisionalCredit settles the reversal against the balance of the provisional
+// credit and posts it. The account row must already be locked by the caller.
+func (r *disputeRepository) reverseProvisionalCredit(ctx context.Context, tx *sql.Tx, dispute domain.Dispute, reversal domain.Transaction) (int64, error) {
+	credit := domain.NewTransaction(dispute.AccountID, domain.CreditoProvisorio, dispute.Amount)
+	credit.SetID(*dispute.ProvisionalCreditTransactionID)
+
+	var balance domain.Money
+	if err := tx.QueryRowContext(ctx, "SELECT balance FROM transactions WHERE id = $1", credit.ID()).Scan(&balance); err != nil {
+		r.logDisputeError(ctx, "error reversing provisional credit", dispute, err)
+		return 0, fmt.Errorf("failed to reverse provisional credit: %w", err)
+	}
+	credit.SetBalance(balance)
+	credit.SettleReversal(&reversal)
+
+	query := "UPDATE transactions SET balance = $1, updated_at = NOW() WHERE id = $2"
+	if _, err := tx.ExecContext(ctx, query, credit.Balance(), credit.ID()); err != nil {
+		r.logDisputeError(ctx, "error reversing provisional credit", dispute, err)
+		return 0, fmt.Errorf("failed to reverse provisional credit: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, adjustAvailableCreditQuery, reversal.Amount(), reversal.AccountID()); err != nil {
+		r.logDisputeError(ctx, "error reversing provisional credit", dispute, err)
+		return 0, fmt.Errorf("failed to reverse provisional credit: %w", err)
+	}
+
+	return r.transactions.insertTransaction(ctx, tx, reversal)
+}
+
+// insertEvent records the status change in the dispute's history and sets its ID.
+func (r *disputeRepository) insertEvent(ctx context.Context, tx *sql.Tx, dispute domain.Dispute, event *domain.DisputeEvent) error {
+	query := "INSERT INTO dispute_events (dispute_id, from_status, to_status, transaction_id, note, created_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id"
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		dispute.ID,
+		sql.NullString{String: string(event.FromStatus), Valid: event.FromStatus != ""},
+		event.ToStatus,
+		event.TransactionID,
+		sql.NullString{String: event.Note, Valid: event.Note != ""},
+		event.CreatedAt,
+	).Scan(&event.ID)
+	if err != nil {
+		r.logDisputeError(ctx, "error recording dispute event", dispute, err)
+		return fmt.Errorf("failed to record dispute event: %w", translatePostgresError(err))
+	}
+	event.DisputeID = dispute.ID
+	return nil
+}
+
+// GetDispute returns the dispute with its events.
+func (r *disputeRepository) GetDispute(ctx context.Context, disputeID int64) (*domain.Dispute, error) {
+	query := fmt.Sprintf("SELECT %s FROM disputes WHERE id = $1", disputeColumns)
+	dispute, err := r.scanDispute(r.db.QueryRowContext(ctx, query, disputeID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Logger.ErrorContext(ctx, "dispute not found", slog.Int64("disputeID", disputeID))
+			return nil, ErrDisputeNotFound
+		}
+		logger.Logger.ErrorContext(ctx, "error getting dispute", slog.Int64("disputeID", disputeID), slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	query = "SELECT id, dispute_id, from_status, to_status, transaction_id, note, created_at FROM dispute_events WHERE dispute_id = $1 ORDER BY id"
+	events, err := r.listEvents(ctx, query, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	dispute.Events = events[dispute.ID]
+	return dispute, nil
+}
+
+// ListDisputes returns every dispute of the transaction with its events, oldest first.
+func (r *disputeRepository) ListDisputes(ctx context.Context, transactionID int64) ([]domain.Dispute, error) {
+	query := fmt.Sprintf("SELECT %s FROM disputes WHERE transaction_id = $1 ORDER BY id", disputeColumns)
+	rows, err := r.db.QueryContext(ctx, query, transactionID)
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error listing disputes", slog.Int64("transactionID", transactionID), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list disputes: %w", err)
+	}
+	defer rows.Close()
+
+	disputes := []domain.Dispute{}
+	for rows.Next() {
+		dispute, err := r.scanDispute(rows)
+		if err != nil {
+			logger.Logger.ErrorContext(ctx, "error listing disputes", slog.Int64("transactionID", transactionID), slog.String("error", err.Error()))
+			return nil, err
+		}
+		disputes = append(disputes, *dispute)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Logger.ErrorContext(ctx, "error listing disputes", slog.Int64("transactionID", transactionID), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list disputes: %w", err)
+	}
+
+	if len(disputes) == 0 {
+		return disputes, nil
+	}
+
+	query = `
+		SELECT e.id, e.dispute_id, e.from_status, e.to_status, e.transaction_id, e.note, e.created_at
+		FROM dispute_events e
+		JOIN disputes d ON d.id = e.dispute_id
+		WHERE d.transaction_id = $1
+		ORDER BY e.id`
+	events, err := r.listEvents(ctx, query, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range disputes {
+		disputes[i].Events = events[disputes[i].ID]
+	}
+
+	return disputes, nil
+}
+
+// listEvents runs a query over dispute_events and groups the events by dispute.
+func (r *disputeRepository) listEvents(ctx context.Context, query string, arg int64) (map[int64][]domain.DisputeEvent, error) {
+	rows, err := r.db.QueryContext(ctx, query, arg)
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error listing dispute events", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list dispute events: %w", err)
+	}
+	defer rows.Close()
+
+	events := map[int64][]domain.DisputeEvent{}
+	for rows.Next() {
+		var (
+			event         domain.DisputeEvent
+			fromStatus    sql.NullString
+			transactionID sql.NullInt64
+			note          sql.NullString
+		)
+		err := rows.Scan(&event.ID, &event.DisputeID, &fromStatus, &event.ToStatus, &transactionID, &note, &event.CreatedAt)
+		if err != nil {
+			logger.Logger.ErrorContext(ctx, "error listing dispute events", slog.String("error", err.Error()))
+			return nil, fmt.Errorf("unable to scan dispute event: %w", err)
+		}
+
+		event.FromStatus = domain.DisputeStatus(fromStatus.String)
+		event.Note = note.String
+		if transactionID.Valid {
+			event.TransactionID = &transactionID.Int64
+		}
+		events[event.DisputeID] = append(events[event.DisputeID], event)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Logger.ErrorContext(ctx, "error listing dispute events", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list dispute events: %w", err)
+	}
+
+	return events, nil
+}
+
+func (r *disputeRepository) scanDispute(row rowScanner) (*domain.Dispute, error) {
+	var (
+		dispute                        domain.Dispute
+		provisionalCreditTransactionID sql.NullInt64
+		reversalTransactionID          sql.NullInt64
+		resolvedAt                     sql.NullTime
+	)
+
+	err := row.Scan(
+		&dispute.ID,
+		&dispute.TransactionID,
+		&dispute.AccountID,
+		&dispute.Amount,
+		&dispute.Reason,
+		&dispute.Status,
+		&provisionalCreditTransactionID,
+		&reversalTransactionID,
+		&dispute.OpenedAt,
+		&resolvedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to scan dispute: %w", err)
+	}
+
+	if provisionalCreditTransactionID.Valid {
+		dispute.ProvisionalCreditTransactionID = &provisionalCreditTransactionID.Int64
+	}
+	if reversalTransactionID.Valid {
+		dispute.ReversalTransactionID = &reversalTransactionID.Int64
+	}
+	if resolvedAt.Valid {
+		dispute.ResolvedAt = &resolvedAt.Time
+	}
+	return &dispute, nil
+}
+
+func (r *disputeRepository) logDisputeError(ctx context.Context, msg string, dispute domain.Dispute, err error) {
+	logger.Logger.ErrorContext(
+		ctx,
+		msg,
+		slog.Int64("disputeID", dispute.ID),
+		slog.Int64("transactionID", dispute.TransactionID),
+		slog.Int64("accountID", dispute.AccountID),
+		slog.String("amount", dispute.Amount.String()),
+		slog.String("error", err.Error()),
+	)
+}
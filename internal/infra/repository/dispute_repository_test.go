@@ -0,0 +1,271 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type DisputeRepositoryTestSuite struct {
+	suite.Suite
+	repo *disputeRepository
+	mock sqlmock.Sqlmock
+	db   *sql.DB
+}
+
+func (s *DisputeRepositoryTestSuite) SetupTest() {
+	logger.InitLogger()
+	var err error
+	s.db, s.mock, err = sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	s.repo = NewDisputeRepository(s.db)
+}
+
+func (s *DisputeRepositoryTestSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func TestDisputeRepositorySuite(t *testing.T) {
+	suite.Run(t, new(DisputeRepositoryTestSuite))
+}
+
+func (s *DisputeRepositoryTestSuite) newDispute(openedAt time.Time) (domain.Dispute, domain.Transaction) {
+	purchase := domain.NewTransaction(1, domain.CompraAVista, domain.MustParseMoney("-100"), openedAt)
+	purchase.SetID(7)
+	dispute, _ := domain.NewDispute(purchase, nil, "not recognized", openedAt)
+	credit, _ := dispute.GrantProvisionalCredit()
+	return dispute, credit
+}
+
+func (s *DisputeRepositoryTestSuite) TestDisputeRepository_OpenDispute_ShouldPostProvisionalCreditAndRecordEvents() {
+	// Arrange
+	openedAt := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	dispute, credit := s.newDispute(openedAt)
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT id FROM accounts WHERE id = \\$1 FOR UPDATE").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	s.mock.ExpectQuery("SELECT amount, (.|\\n)+FOR UPDATE").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"amount", "reversed"}).AddRow("-100.00", "0"))
+	s.mock.ExpectQuery("INSERT INTO disputes").
+		WithArgs(int64(7), int64(1), domain.MustParseMoney("100"), "not recognized", domain.DisputeOpened, openedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+	s.mock.ExpectQuery("INSERT INTO dispute_events").
+		WithArgs(int64(4), sql.NullString{}, domain.DisputeOpened, nil, sql.NullString{String: "not recognized", Valid: true}, openedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	s.mock.ExpectQuery("SELECT status, available_credit_limit").
+		WithArgs(domain.MustParseMoney("100"), int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "has_credit_limit"}).AddRow("ACTIVE", true))
+	s.mock.ExpectExec("UPDATE accounts SET available_credit_limit").
+		WithArgs(domain.MustParseMoney("100"), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectQuery("INSERT INTO transactions").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(8))
+	s.mock.ExpectQuery("INSERT INTO journal_entries").
+		WithArgs(int64(8), openedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(8))
+	s.mock.ExpectExec("INSERT INTO postings").
+		WithArgs(int64(8), domain.LedgerReceivables, int64(1), domain.MustParseMoney("-100"), domain.LedgerDisputes, nil, domain.MustParseMoney("100")).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	s.mock.ExpectExec("WITH credit AS").
+		WithArgs(int64(1), int64(8)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	s.mock.ExpectExec("UPDATE disputes SET status = \\$1, provisional_credit_transaction_id = \\$2").
+		WithArgs(domain.DisputeProvisionalCredit, int64(8), int64(4)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectQuery("INSERT INTO dispute_events").
+		WithArgs(int64(4), sql.NullString{String: "OPENED", Valid: true}, domain.DisputeProvisionalCredit, int64(8), sql.NullString{}, openedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	s.mock.ExpectCommit()
+
+	// Act
+	opened, err := s.repo.OpenDispute(context.Background(), dispute, credit)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(4), opened.ID)
+	assert.Equal(s.T(), domain.DisputeProvisionalCredit, opened.Status)
+	assert.Equal(s.T(), int64(8), *opened.ProvisionalCreditTransactionID)
+	assert.Len(s.T(), opened.Events, 2)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *DisputeRepositoryTestSuite) TestDisputeRepository_OpenDispute_WhenTransactionAlreadyDisputed_ShouldReturnErrDisputeAlreadyOpen() {
+	// Arrange
+	dispute, credit := s.newDispute(time.Now())
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT id FROM accounts WHERE id = \\$1 FOR UPDATE").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	s.mock.ExpectQuery("SELECT amount, (.|\\n)+FOR UPDATE").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"amount", "reversed"}).AddRow("-100.00", "0"))
+	s.mock.ExpectQuery("INSERT INTO disputes").
+		WillReturnError(&pq.Error{Code: pgUniqueViolation, Constraint: disputesTransactionIDActiveKey})
+	s.mock.ExpectRollback()
+
+	// Act
+	opened, err := s.repo.OpenDispute(context.Background(), dispute, credit)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, ErrDisputeAlreadyOpen)
+	assert.Nil(s.T(), opened)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *DisputeRepositoryTestSuite) TestDisputeRepository_OpenDispute_WhenTransactionWasReversed_ShouldReturnErrDisputeExceedsAmount() {
+	// Arrange
+	dispute, credit := s.newDispute(time.Now())
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT id FROM accounts WHERE id = \\$1 FOR UPDATE").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	s.mock.ExpectQuery("SELECT amount, (.|\\n)+FOR UPDATE").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"amount", "reversed"}).AddRow("-100.00", "40.00"))
+	s.mock.ExpectRollback()
+
+	// Act
+	opened, err := s.repo.OpenDispute(context.Background(), dispute, credit)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, ErrDisputeExceedsAmount)
+	assert.EqualError(s.T(), err, "dispute exceeds the amount not yet reversed: 100.00 disputed, 60.00 left")
+	assert.Nil(s.T(), opened)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *DisputeRepositoryTestSuite) TestDisputeRepository_ResolveDispute_WhenLost_ShouldReverseProvisionalCredit() {
+	// Arrange
+	creditID := int64(8)
+	resolvedAt := time.Date(2025, 2, 15, 12, 0, 0, 0, time.UTC)
+	dispute := domain.Dispute{ID: 4, TransactionID: 7, AccountID: 1, Amount: domain.MustParseMoney("100"), Status: domain.DisputeProvisionalCredit, ProvisionalCreditTransactionID: &creditID}
+	reversal, _ := dispute.Resolve(domain.DisputeLost, resolvedAt)
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT id FROM accounts WHERE id = \\$1 FOR UPDATE").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	s.mock.ExpectExec("UPDATE disputes SET status = \\$1, resolved_at = \\$2, updated_at = NOW\\(\\) WHERE id = \\$3 AND status = \\$4").
+		WithArgs(domain.DisputeLost, resolvedAt, int64(4), domain.DisputeProvisionalCredit).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectQuery("SELECT balance FROM transactions WHERE id = \\$1").
+		WithArgs(int64(8)).
+		WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow("30.00"))
+	s.mock.ExpectExec("UPDATE transactions SET balance = \\$1").
+		WithArgs(domain.MustParseMoney("0"), int64(8)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectExec("UPDATE accounts SET available_credit_limit = CASE").
+		WithArgs(domain.MustParseMoney("-100"), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectQuery("INSERT INTO transactions").
+		WithArgs(int64(1), domain.EstornoCreditoProvisorio, domain.MustParseMoney("-100"), domain.MustParseMoney("-70"), &creditID, nil, nil, sql.NullString{}, resolvedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+	s.mock.ExpectQuery("INSERT INTO journal_entries").
+		WithArgs(int64(9), resolvedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+	s.mock.ExpectExec("INSERT INTO postings").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	s.mock.ExpectExec("UPDATE disputes SET reversal_transaction_id = \\$1 WHERE id = \\$2").
+		WithArgs(int64(9), int64(4)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectQuery("INSERT INTO dispute_events").
+		WithArgs(int64(4), sql.NullString{String: "PROVISIONAL_CREDIT", Valid: true}, domain.DisputeLost, int64(9), sql.NullString{}, resolvedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	s.mock.ExpectCommit()
+
+	// Act
+	resolved, err := s.repo.ResolveDispute(context.Background(), dispute, reversal)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), domain.DisputeLost, resolved.Status)
+	assert.Equal(s.T(), int64(9), *resolved.ReversalTransactionID)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *DisputeRepositoryTestSuite) TestDisputeRepository_ResolveDispute_WhenAlreadyResolved_ShouldReturnErrDisputeNotResolvable() {
+	// Arrange
+	creditID := int64(8)
+	dispute := domain.Dispute{ID: 4, TransactionID: 7, AccountID: 1, Amount: domain.MustParseMoney("100"), Status: domain.DisputeProvisionalCredit, ProvisionalCreditTransactionID: &creditID}
+	_, _ = dispute.Resolve(domain.DisputeWon, time.Now())
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT id FROM accounts WHERE id = \\$1 FOR UPDATE").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	s.mock.ExpectExec("UPDATE disputes SET status").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mock.ExpectRollback()
+
+	// Act
+	resolved, err := s.repo.ResolveDispute(context.Background(), dispute, nil)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, domain.ErrDisputeNotResolvable)
+	assert.Nil(s.T(), resolved)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *DisputeRepositoryTestSuite) TestDisputeRepository_GetDispute_WhenNotFound_ShouldReturnErrDisputeNotFound() {
+	// Arrange
+	s.mock.ExpectQuery("SELECT (.+) FROM disputes WHERE id = \\$1").
+		WithArgs(int64(4)).
+		WillReturnError(sql.ErrNoRows)
+
+	// Act
+	dispute, err := s.repo.GetDispute(context.Background(), 4)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, ErrDisputeNotFound)
+	assert.Nil(s.T(), dispute)
+}
+
+func (s *DisputeRepositoryTestSuite) TestDisputeRepository_ListDisputes_ShouldReturnDisputesWithTheirEvents() {
+	// Arrange
+	openedAt := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	resolvedAt := openedAt.Add(15 * 24 * time.Hour)
+	s.mock.ExpectQuery("SELECT (.+) FROM disputes WHERE transaction_id = \\$1 ORDER BY id").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "transaction_id", "account_id", "amount", "reason", "status", "provisional_credit_transaction_id", "reversal_transaction_id", "opened_at", "resolved_at"}).
+			AddRow(4, 7, 1, "100.00", "not recognized", "LOST", 8, 9, openedAt, resolvedAt).
+			AddRow(5, 7, 1, "50.00", "charged twice", "PROVISIONAL_CREDIT", 10, nil, resolvedAt, nil))
+	s.mock.ExpectQuery("SELECT (.+) FROM dispute_events e JOIN disputes d ON d.id = e.dispute_id WHERE d.transaction_id = \\$1").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "dispute_id", "from_status", "to_status", "transaction_id", "note", "created_at"}).
+			AddRow(1, 4, nil, "OPENED", nil, "not recognized", openedAt).
+			AddRow(2, 4, "OPENED", "PROVISIONAL_CREDIT", 8, nil, openedAt).
+			AddRow(3, 4, "PROVISIONAL_CREDIT", "LOST", 9, nil, resolvedAt).
+			AddRow(4, 5, nil, "OPENED", nil, "charged twice", resolvedAt).
+			AddRow(5, 5, "OPENED", "PROVISIONAL_CREDIT", 10, nil, resolvedAt))
+
+	// Act
+	disputes, err := s.repo.ListDisputes(context.Background(), 7)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), disputes, 2)
+	assert.Equal(s.T(), domain.DisputeLost, disputes[0].Status)
+	assert.Equal(s.T(), &resolvedAt, disputes[0].ResolvedAt)
+	assert.Len(s.T(), disputes[0].Events, 3)
+	assert.Equal(s.T(), domain.DisputeStatus(""), disputes[0].Events[0].FromStatus)
+	assert.Equal(s.T(), "not recognized", disputes[0].Events[0].Note)
+	assert.Equal(s.T(), int64(9), *disputes[0].Events[2].TransactionID)
+	assert.Nil(s.T(), disputes[1].ReversalTransactionID)
+	assert.Len(s.T(), disputes[1].Events, 2)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
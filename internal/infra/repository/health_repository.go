@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+)
+
+type healthRepository struct {
+	db *sql.DB
+}
+
+func NewHealthRepository(db *sql.DB) *healthRepository {
+	return &healthRepository{db: db}
+}
+
+func (r *healthRepository) Ping(ctx context.Context) error {
+	if err := r.db.PingContext(ctx); err != nil {
+		logger.Logger.ErrorContext(ctx, "error pinging database", slog.String("error", err.Error()))
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+	return nil
+}
+
+// GetMigrationVersion returns the schema version recorded by the migrations and whether the
+// last migration failed halfway, leaving the schema dirty.
+func (r *healthRepository) GetMigrationVersion(ctx context.Context) (uint, bool, error) {
+	query := "SELECT version, dirty FROM schema_migrations LIMIT 1"
+	var (
+		version uint
+		dirty   bool
+	)
+	if err := r.db.QueryRowContext(ctx, query).Scan(&version, &dirty); err != nil {
+		logger.Logger.ErrorContext(ctx, "error getting migration version", slog.String("error", err.Error()))
+		return 0, false, fmt.Errorf("failed to get migration version: %w", err)
+	}
+	return version, dirty, nil
+}
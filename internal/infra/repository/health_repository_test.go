@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type HealthRepositoryTestSuite struct {
+	suite.Suite
+	repo *healthRepository
+	mock sqlmock.Sqlmock
+	db   *sql.DB
+}
+
+func (s *HealthRepositoryTestSuite) SetupTest() {
+	logger.InitLogger()
+	var err error
+	s.db, s.mock, err = sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		s.T().Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	s.repo = NewHealthRepository(s.db)
+}
+
+func (s *HealthRepositoryTestSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func TestHealthRepositorySuite(t *testing.T) {
+	suite.Run(t, new(HealthRepositoryTestSuite))
+}
+
+func (s *HealthRepositoryTestSuite) TestHealthRepository_Ping_WhenDatabaseIsDown_ShouldReturnError() {
+	// Arrange
+	s.mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+
+	ctx := context.Background()
+	// Act
+	err := s.repo.Ping(ctx)
+
+	// Assert
+	assert.ErrorContains(s.T(), err, "connection refused")
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *HealthRepositoryTestSuite) TestHealthRepository_GetMigrationVersion_ShouldReturnVersionAndDirtyFlag() {
+	// Arrange
+	s.mock.ExpectQuery("SELECT version, dirty FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(20, true))
+
+	ctx := context.Background()
+	// Act
+	version, dirty, err := s.repo.GetMigrationVersion(ctx)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), uint(20), version)
+	assert.True(s.T(), dirty)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *HealthRepositoryTestSuite) TestHealthRepository_GetMigrationVersion_WhenNoMigrationWasApplied_ShouldReturnError() {
+	// Arrange
+	s.mock.ExpectQuery("SELECT version, dirty FROM schema_migrations").
+		WillReturnError(errors.New(`relation "schema_migrations" does not exist`))
+
+	ctx := context.Background()
+	// Act
+	_, _, err := s.repo.GetMigrationVersion(ctx)
+
+	// Assert
+	assert.Error(s.T(), err)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+)
+
+var (
+	ErrIdempotencyKeyReused     = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is still being processed")
+	ErrIdempotencyClaimLost     = errors.New("idempotency key was taken over by another request")
+)
+
+type idempotencyRepository struct {
+	db *sql.DB
+}
+
+func NewIdempotencyRepository(db *sql.DB) *idempotencyRepository {
+	return &idempotencyRepository{db: db}
+}
+
+// maxReserveAttempts bounds how many times ReserveKey tries to claim a key that keeps
+// disappearing between the claim and the lookup of the recorded response.
+const maxReserveAttempts = 3
+
+// ReserveKey claims the key for the request identified by requestHash. It returns nil when
+// the caller owns the key and must process the request, or the recorded response when the
+// request is a replay. The primary key guarantees that only one concurrent request can claim
+// a key; expired keys, and reservations whose lease ran out without a response because their
+// request died, are claimed again as if they were new. claimToken identifies the claim and
+// must be passed to SaveResponse or ReleaseKey.
+func (r *idempotencyRepository) ReserveKey(ctx context.Context, scope string, key string, requestHash string, claimToken string, ttl time.Duration, lease time.Duration) (*domain.IdempotentResponse, error) {
+	for attempt := 0; attempt < maxReserveAttempts; attempt++ {
+		claimed, err := r.claimKey(ctx, scope, key, requestHash, claimToken, ttl, lease)
+		if err != nil {
+			return nil, err
+		}
+		if claimed {
+			return nil, nil
+		}
+
+		response, err := r.getReservation(ctx, scope, key, requestHash)
+		if errors.Is(err, sql.ErrNoRows) {
+			// The key was released or purged after the claim failed, so it is free again.
+			continue
+		}
+		return response, err
+	}
+
+	return nil, ErrIdempotencyKeyInProgress
+}
+
+func (r *idempotencyRepository) claimKey(ctx context.Context, scope string, key string, requestHash string, claimToken string, ttl time.Duration, lease time.Duration) (bool, error) {
+	query := `
+		INSERT INTO idempotency_keys (scope, key, request_hash, claim_token, expires_at, locked_until)
+		VALUES ($1, $2, $3, $4, NOW() + $5 * INTERVAL '1 second', NOW() + $6 * INTERVAL '1 second')
+		ON CONFLICT (scope, key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash, claim_token = EXCLUDED.claim_token, status_code = NULL, response_body = NULL, created_at = NOW(), expires_at = EXCLUDED.expires_at, locked_until = EXCLUDED.locked_until
+		WHERE idempotency_keys.expires_at <= NOW()
+		OR (idempotency_keys.status_code IS NULL AND idempotency_keys.locked_until <= NOW())
+		RETURNING key`
+
+	var claimedKey string
+	err := r.db.QueryRowContext(ctx, query, scope, key, requestHash, claimToken, ttl.Seconds(), lease.Seconds()).Scan(&claimedKey)
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	logger.Logger.ErrorContext(ctx, "error reserving idempotency key", slog.String("scope", scope), slog.String("key", key), slog.String("error", err.Error()))
+	return false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+}
+
+// getReservation returns the response recorded for a key owned by another request. It
+// returns sql.ErrNoRows when the key no longer exists.
+func (r *idempotencyRepository) getReservation(ctx context.Context, scope string, key string, requestHash string) (*domain.IdempotentResponse, error) {
+	query := "SELECT request_hash, status_code, response_body FROM idempotency_keys WHERE scope = $1 AND key = $2"
+	var (
+		storedHash   sql.NullString
+		statusCode   sql.NullInt64
+		responseBody []byte
+	)
+	err := r.db.QueryRowContext(ctx, query, scope, key).Scan(&storedHash, &statusCode, &responseBody)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error getting idempotency key", slog.String("scope", scope), slog.String("key", key), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+
+	if storedHash.String != requestHash {
+		return nil, ErrIdempotencyKeyReused
+	}
+	if !statusCode.Valid {
+		return nil, ErrIdempotencyKeyInProgress
+	}
+
+	return &domain.IdempotentResponse{StatusCode: int(statusCode.Int64), Body: responseBody}, nil
+}
+
+// SaveResponse records the response of the request that claimed the key with claimToken.
+// It returns ErrIdempotencyClaimLost, recording nothing, if a retry took the key over after
+// the lease of that request ran out.
+func (r *idempotencyRepository) SaveResponse(ctx context.Context, scope string, key string, claimToken string, response domain.IdempotentResponse) error {
+	query := "UPDATE idempotency_keys SET status_code = $1, response_body = $2 WHERE scope = $3 AND key = $4 AND claim_token = $5 AND status_code IS NULL"
+	result, err := r.db.ExecContext(ctx, query, response.StatusCode, response.Body, scope, key, claimToken)
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error saving idempotent response", slog.String("scope", scope), slog.String("key", key), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to save idempotent response: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error saving idempotent response", slog.String("scope", scope), slog.String("key", key), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to save idempotent response: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		logger.Logger.ErrorContext(ctx, "idempotency key was taken over by another request", slog.String("scope", scope), slog.String("key", key))
+		return ErrIdempotencyClaimLost
+	}
+
+	return nil
+}
+
+// ReleaseKey drops a reservation whose request did not produce a response worth replaying,
+// so the client can retry it with the same key. Only the claim identified by claimToken is
+// dropped, never one that a retry took over meanwhile.
+func (r *idempotencyRepository) ReleaseKey(ctx context.Context, scope string, key string, claimToken string) error {
+	query := "DELETE FROM idempotency_keys WHERE scope = $1 AND key = $2 AND claim_token = $3 AND status_code IS NULL"
+	if _, err := r.db.ExecContext(ctx, query, scope, key, claimToken); err != nil {
+		logger.Logger.ErrorContext(ctx, "error releasing idempotency key", slog.String("scope", scope), slog.String("key", key), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (r *idempotencyRepository) DeleteExpiredKeys(ctx context.Context) (int64, error) {
+	query := "DELETE FROM idempotency_keys WHERE expires_at <= NOW()"
+	result, err := r.db.ExecContext(ctx, query)
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error deleting expired idempotency keys", slog.String("error", err.Error()))
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error deleting expired idempotency keys", slog.String("error", err.Error()))
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	return rowsAffected, nil
+}
@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type IdempotencyRepositoryTestSuite struct {
+	suite.Suite
+	repo *idempotencyRepository
+	mock sqlmock.Sqlmock
+	db   *sql.DB
+}
+
+func (s *IdempotencyRepositoryTestSuite) SetupTest() {
+	logger.InitLogger()
+	var err error
+	s.db, s.mock, err = sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	s.repo = NewIdempotencyRepository(s.db)
+}
+
+func (s *IdempotencyRepositoryTestSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func TestIdempotencyRepositorySuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyRepositoryTestSuite))
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestIdempotencyRepository_ReserveKey_WhenKeyIsNew_ShouldClaimIt() {
+	// Arrange
+	ctx := context.Background()
+
+	s.mock.ExpectQuery("INSERT INTO idempotency_keys").
+		WithArgs("POST /transactions", "key-1", "hash", "claim-1", float64(3600), float64(60)).
+		WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow("key-1"))
+
+	// Act
+	recorded, err := s.repo.ReserveKey(ctx, "POST /transactions", "key-1", "hash", "claim-1", time.Hour, time.Minute)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), recorded)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestIdempotencyRepository_ReserveKey_WhenResponseRecorded_ShouldReturnIt() {
+	// Arrange
+	ctx := context.Background()
+
+	s.mock.ExpectQuery("INSERT INTO idempotency_keys").
+		WillReturnError(sql.ErrNoRows)
+	s.mock.ExpectQuery("SELECT request_hash, status_code, response_body FROM idempotency_keys").
+		WithArgs("POST /transactions", "key-1").
+		WillReturnRows(sqlmock.NewRows([]string{"request_hash", "status_code", "response_body"}).AddRow("hash", 201, []byte(`{"id":1}`)))
+
+	// Act
+	recorded, err := s.repo.ReserveKey(ctx, "POST /transactions", "key-1", "hash", "claim-1", time.Hour, time.Minute)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), &domain.IdempotentResponse{StatusCode: 201, Body: []byte(`{"id":1}`)}, recorded)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestIdempotencyRepository_ReserveKey_WhenPayloadDiffers_ShouldReturnErrIdempotencyKeyReused() {
+	// Arrange
+	ctx := context.Background()
+
+	s.mock.ExpectQuery("INSERT INTO idempotency_keys").
+		WillReturnError(sql.ErrNoRows)
+	s.mock.ExpectQuery("SELECT request_hash, status_code, response_body FROM idempotency_keys").
+		WillReturnRows(sqlmock.NewRows([]string{"request_hash", "status_code", "response_body"}).AddRow("other-hash", 201, []byte(`{"id":1}`)))
+
+	// Act
+	recorded, err := s.repo.ReserveKey(ctx, "POST /transactions", "key-1", "hash", "claim-1", time.Hour, time.Minute)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, ErrIdempotencyKeyReused)
+	assert.Nil(s.T(), recorded)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestIdempotencyRepository_ReserveKey_WhenRequestInFlight_ShouldReturnErrIdempotencyKeyInProgress() {
+	// Arrange
+	ctx := context.Background()
+
+	s.mock.ExpectQuery("INSERT INTO idempotency_keys").
+		WillReturnError(sql.ErrNoRows)
+	s.mock.ExpectQuery("SELECT request_hash, status_code, response_body FROM idempotency_keys").
+		WillReturnRows(sqlmock.NewRows([]string{"request_hash", "status_code", "response_body"}).AddRow("hash", nil, nil))
+
+	// Act
+	recorded, err := s.repo.ReserveKey(ctx, "POST /transactions", "key-1", "hash", "claim-1", time.Hour, time.Minute)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, ErrIdempotencyKeyInProgress)
+	assert.Nil(s.T(), recorded)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestIdempotencyRepository_ReserveKey_WhenKeyVanishesAfterFailedClaim_ShouldRetryClaim() {
+	// Arrange
+	ctx := context.Background()
+
+	s.mock.ExpectQuery("INSERT INTO idempotency_keys").
+		WillReturnError(sql.ErrNoRows)
+	s.mock.ExpectQuery("SELECT request_hash, status_code, response_body FROM idempotency_keys").
+		WillReturnError(sql.ErrNoRows)
+	s.mock.ExpectQuery("INSERT INTO idempotency_keys").
+		WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow("key-1"))
+
+	// Act
+	recorded, err := s.repo.ReserveKey(ctx, "POST /transactions", "key-1", "hash", "claim-1", time.Hour, time.Minute)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), recorded)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestIdempotencyRepository_ReserveKey_WhenKeyKeepsVanishing_ShouldReturnErrIdempotencyKeyInProgress() {
+	// Arrange
+	ctx := context.Background()
+
+	for attempt := 0; attempt < maxReserveAttempts; attempt++ {
+		s.mock.ExpectQuery("INSERT INTO idempotency_keys").
+			WillReturnError(sql.ErrNoRows)
+		s.mock.ExpectQuery("SELECT request_hash, status_code, response_body FROM idempotency_keys").
+			WillReturnError(sql.ErrNoRows)
+	}
+
+	// Act
+	recorded, err := s.repo.ReserveKey(ctx, "POST /transactions", "key-1", "hash", "claim-1", time.Hour, time.Minute)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, ErrIdempotencyKeyInProgress)
+	assert.Nil(s.T(), recorded)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestIdempotencyRepository_ReserveKey_WhenQueryFails_ShouldReturnError() {
+	// Arrange
+	ctx := context.Background()
+	expectedError := errors.New("connection reset")
+
+	s.mock.ExpectQuery("INSERT INTO idempotency_keys").
+		WillReturnError(expectedError)
+
+	// Act
+	recorded, err := s.repo.ReserveKey(ctx, "POST /transactions", "key-1", "hash", "claim-1", time.Hour, time.Minute)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, expectedError)
+	assert.Nil(s.T(), recorded)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestIdempotencyRepository_SaveResponse_ShouldUpdateKey() {
+	// Arrange
+	ctx := context.Background()
+	response := domain.IdempotentResponse{StatusCode: 201, Body: []byte(`{"id":1}`)}
+
+	s.mock.ExpectExec("UPDATE idempotency_keys SET status_code = \\$1, response_body = \\$2 WHERE scope = \\$3 AND key = \\$4 AND claim_token = \\$5").
+		WithArgs(response.StatusCode, response.Body, "POST /transactions", "key-1", "claim-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Act
+	err := s.repo.SaveResponse(ctx, "POST /transactions", "key-1", "claim-1", response)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestIdempotencyRepository_SaveResponse_WhenKeyWasTakenOver_ShouldReturnErrIdempotencyClaimLost() {
+	// Arrange
+	ctx := context.Background()
+	response := domain.IdempotentResponse{StatusCode: 201, Body: []byte(`{"id":1}`)}
+
+	s.mock.ExpectExec("UPDATE idempotency_keys SET status_code").
+		WithArgs(response.StatusCode, response.Body, "POST /transactions", "key-1", "claim-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	// Act
+	err := s.repo.SaveResponse(ctx, "POST /transactions", "key-1", "claim-1", response)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, ErrIdempotencyClaimLost)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestIdempotencyRepository_ReleaseKey_ShouldDeletePendingKey() {
+	// Arrange
+	ctx := context.Background()
+
+	s.mock.ExpectExec("DELETE FROM idempotency_keys WHERE scope = \\$1 AND key = \\$2 AND claim_token = \\$3 AND status_code IS NULL").
+		WithArgs("POST /transactions", "key-1", "claim-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Act
+	err := s.repo.ReleaseKey(ctx, "POST /transactions", "key-1", "claim-1")
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestIdempotencyRepository_DeleteExpiredKeys_ShouldReturnDeletedCount() {
+	// Arrange
+	ctx := context.Background()
+
+	s.mock.ExpectExec("DELETE FROM idempotency_keys WHERE expires_at").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	// Act
+	deleted, err := s.repo.DeleteExpiredKeys(ctx)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(3), deleted)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
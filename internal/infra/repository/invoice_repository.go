@@ -0,0 +1,332 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+)
+
+var ErrInvoiceNotFound = errors.New("invoice not found")
+
+const invoiceColumns = "id, account_id, period_start, closing_date, due_date, previous_balance, total_debits, total_credits, balance, minimum_payment, closed_at"
+
+type invoiceRepository struct {
+	db *sql.DB
+}
+
+func NewInvoiceRepository(db *sql.DB) *invoiceRepository {
+	return &invoiceRepository{db: db}
+}
+
+// ListBillingStates returns, for every account, where its next invoice starts.
+func (r *invoiceRepository) ListBillingStates(ctx context.Context) ([]domain.BillingState, error) {
+	query := `
+		SELECT a.id, a.closing_day, a.due_day, COALESCE(i.closing_date, a.created_at, NOW()), COALESCE(i.balance, 0)
+		FROM accounts a
+		LEFT JOIN LATERAL (
+			SELECT closing_date, balance FROM invoices WHERE account_id = a.id ORDER BY closing_date DESC LIMIT 1
+		) i ON TRUE
+		ORDER BY a.id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error listing billing states", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list billing states: %w", err)
+	}
+	defer rows.Close()
+
+	states := []domain.BillingState{}
+	for rows.Next() {
+		var state domain.BillingState
+		err := rows.Scan(&state.AccountID, &state.Cycle.ClosingDay, &state.Cycle.DueDay, &state.PeriodStart, &state.PreviousBalance)
+		if err != nil {
+			logger.Logger.ErrorContext(ctx, "error listing billing states", slog.String("error", err.Error()))
+			return nil, fmt.Errorf("unable to scan billing state: %w", err)
+		}
+		states = append(states, state)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Logger.ErrorContext(ctx, "error listing billing states", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list billing states: %w", err)
+	}
+
+	return states, nil
+}
+
+// ListBillableItems returns what an invoice bills: the transactions with an event date in
+// [periodStart, closingDate) and, instead of the installment purchases themselves, their
+// installments due up to installmentsDueUntil that no invoice billed yet. Installments are
+// matched by what was billed rather than by a due date window, so a change of billing cycle
+// can neither bill an installment twice nor skip it. Charges are matched the same way, since
+// an accrual that runs late posts them on days of invoices that are already closed.
+func (r *invoiceRepository) ListBillableItems(ctx context.Context, accountID int64, periodStart time.Time, closingDate time.Time, installmentsDueUntil time.Time) ([]domain.InvoiceItem, error) {
+	query := `
+		SELECT t.id, t.operation_type_id, o.description, NULL::SMALLINT AS installment_number, t.amount, t.event_date
+		FROM transactions t
+		JOIN operation_types o ON o.id = t.operation_type_id
+		WHERE t.account_id = $1 AND t.event_date < $3
+			AND (t.event_date >= $2 OR (t.invoice_id IS NOT NULL AND NOT EXISTS (SELECT 1 FROM invoice_items ii WHERE ii.transaction_id = t.id)))
+			AND NOT EXISTS (SELECT 1 FROM installments i WHERE i.transaction_id = t.id)
+		UNION ALL
+		SELECT t.id, t.operation_type_id, o.description, i.number, -i.amount, t.event_date
+		FROM installments i
+		JOIN transactions t ON t.id = i.transaction_id
+		JOIN operation_types o ON o.id = t.operation_type_id
+		WHERE t.account_id = $1 AND i.due_date <= $4
+			AND NOT EXISTS (SELECT 1 FROM invoice_items ii WHERE ii.transaction_id = i.transaction_id AND ii.installment_number = i.number)
+		ORDER BY event_date, id, installment_number`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID, periodStart, closingDate, installmentsDueUntil)
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error listing billable items", slog.Int64("accountID", accountID), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list billable items: %w", err)
+	}
+	defer rows.Close()
+
+	items, err := r.scanInvoiceItems(rows)
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error listing billable items", slog.Int64("accountID", accountID), slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// CreateInvoice stores the invoice with its items in a single database transaction. Closing
+// the same cycle twice returns domain.ErrAlreadyExists.
+func (r *invoiceRepository) CreateInvoice(ctx context.Context, invoice domain.Invoice) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logCreateInvoiceError(ctx, invoice, err)
+		return 0, fmt.Errorf("failed to create invoice: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO invoices (account_id, period_start, closing_date, due_date, previous_balance, total_debits, total_credits, balance, minimum_payment)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
+	var id int64
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		invoice.AccountID,
+		invoice.PeriodStart,
+		invoice.ClosingDate,
+		invoice.DueDate,
+		invoice.PreviousBalance,
+		invoice.TotalDebits,
+		invoice.TotalCredits,
+		invoice.Balance,
+		invoice.MinimumPayment,
+	).Scan(&id)
+	if err != nil {
+		r.logCreateInvoiceError(ctx, invoice, err)
+		return 0, fmt.Errorf("failed to create invoice: %w", translatePostgresError(err))
+	}
+
+	query = "INSERT INTO invoice_items (invoice_id, transaction_id, installment_number, amount) VALUES ($1, $2, $3, $4)"
+	for _, item := range invoice.Items {
+		if _, err := tx.ExecContext(ctx, query, id, item.TransactionID, item.InstallmentNumber, item.Amount); err != nil {
+			r.logCreateInvoiceError(ctx, invoice, err)
+			return 0, fmt.Errorf("failed to create invoice items: %w", translatePostgresError(err))
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logCreateInvoiceError(ctx, invoice, err)
+		return 0, fmt.Errorf("failed to create invoice: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *invoiceRepository) GetInvoice(ctx context.Context, invoiceID int64) (*domain.Invoice, error) {
+	query := fmt.Sprintf("SELECT %s FROM invoices WHERE id = $1", invoiceColumns)
+	invoice, err := r.scanInvoice(r.db.QueryRowContext(ctx, query, invoiceID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Logger.ErrorContext(ctx, "invoice not found", slog.Int64("invoiceID", invoiceID))
+			return nil, ErrInvoiceNotFound
+		}
+		logger.Logger.ErrorContext(ctx, "error getting invoice", slog.Int64("invoiceID", invoiceID), slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	query = `
+		SELECT ii.transaction_id, t.operation_type_id, o.description, ii.installment_number, ii.amount, t.event_date
+		FROM invoice_items ii
+		JOIN transactions t ON t.id = ii.transaction_id
+		JOIN operation_types o ON o.id = t.operation_type_id
+		WHERE ii.invoice_id = $1
+		ORDER BY ii.id`
+	rows, err := r.db.QueryContext(ctx, query, invoiceID)
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error getting invoice items", slog.Int64("invoiceID", invoiceID), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to get invoice items: %w", err)
+	}
+	defer rows.Close()
+
+	invoice.Items, err = r.scanInvoiceItems(rows)
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error getting invoice items", slog.Int64("invoiceID", invoiceID), slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// ListInvoices returns the invoices of the account newest first, without their items.
+func (r *invoiceRepository) ListInvoices(ctx context.Context, accountID int64) ([]domain.Invoice, error) {
+	query := fmt.Sprintf("SELECT %s FROM invoices WHERE account_id = $1 ORDER BY closing_date DESC", invoiceColumns)
+	rows, err := r.db.QueryContext(ctx, query, accountID)
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error listing invoices", slog.Int64("accountID", accountID), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list invoices: %w", err)
+	}
+	defer rows.Close()
+
+	invoices := []domain.Invoice{}
+	for rows.Next() {
+		invoice, err := r.scanInvoice(rows)
+		if err != nil {
+			logger.Logger.ErrorContext(ctx, "error listing invoices", slog.Int64("accountID", accountID), slog.String("error", err.Error()))
+			return nil, err
+		}
+		invoices = append(invoices, *invoice)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Logger.ErrorContext(ctx, "error listing invoices", slog.Int64("accountID", accountID), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list invoices: %w", err)
+	}
+
+	return invoices, nil
+}
+
+// ListOverdueInvoices returns, for every account, its latest invoice due before day together
+// with the charges already accrued on it.
+func (r *invoiceRepository) ListOverdueInvoices(ctx context.Context, day time.Time) ([]domain.OverdueInvoice, error) {
+	query := fmt.Sprintf(`
+		SELECT DISTINCT ON (account_id) %s,
+			(SELECT MAX(t.event_date) FROM transactions t WHERE t.invoice_id = invoices.id AND t.operation_type_id = $2),
+			EXISTS (SELECT 1 FROM transactions t WHERE t.invoice_id = invoices.id AND t.operation_type_id = $3)
+		FROM invoices
+		WHERE due_date < $1
+		ORDER BY account_id, closing_date DESC`, invoiceColumns)
+
+	rows, err := r.db.QueryContext(ctx, query, day, domain.JurosRotativos, domain.MultaPorAtraso)
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error listing overdue invoices", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list overdue invoices: %w", err)
+	}
+	defer rows.Close()
+
+	overdue := []domain.OverdueInvoice{}
+	for rows.Next() {
+		var (
+			lastInterestDate sql.NullTime
+			lateFeeCharged   bool
+		)
+		invoice, err := r.scanInvoice(rows, &lastInterestDate, &lateFeeCharged)
+		if err != nil {
+			logger.Logger.ErrorContext(ctx, "error listing overdue invoices", slog.String("error", err.Error()))
+			return nil, err
+		}
+
+		overdueInvoice := domain.OverdueInvoice{Invoice: *invoice, LateFeeCharged: lateFeeCharged}
+		if lastInterestDate.Valid {
+			overdueInvoice.LastInterestDate = &lastInterestDate.Time
+		}
+		overdue = append(overdue, overdueInvoice)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Logger.ErrorContext(ctx, "error listing overdue invoices", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list overdue invoices: %w", err)
+	}
+
+	return overdue, nil
+}
+
+// SumPayments returns what was paid with an event date in [from, to): the credits net of
+// the payment reversals.
+func (r *invoiceRepository) SumPayments(ctx context.Context, accountID int64, from time.Time, to time.Time) (domain.Money, error) {
+	query := `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM transactions
+		WHERE account_id = $1 AND event_date >= $2 AND event_date < $3 AND (amount > 0 OR operation_type_id = $4)`
+
+	var paid domain.Money
+	if err := r.db.QueryRowContext(ctx, query, accountID, from, to, domain.EstornoPagamento).Scan(&paid); err != nil {
+		logger.Logger.ErrorContext(ctx, "error summing payments", slog.Int64("accountID", accountID), slog.String("error", err.Error()))
+		return domain.Money{}, fmt.Errorf("failed to sum payments: %w", err)
+	}
+
+	return paid, nil
+}
+
+// scanInvoice reads the invoice columns followed by any extra columns selected by the caller.
+func (r *invoiceRepository) scanInvoice(row rowScanner, extra ...interface{}) (*domain.Invoice, error) {
+	var invoice domain.Invoice
+	dest := []interface{}{
+		&invoice.ID,
+		&invoice.AccountID,
+		&invoice.PeriodStart,
+		&invoice.ClosingDate,
+		&invoice.DueDate,
+		&invoice.PreviousBalance,
+		&invoice.TotalDebits,
+		&invoice.TotalCredits,
+		&invoice.Balance,
+		&invoice.MinimumPayment,
+		&invoice.ClosedAt,
+	}
+
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to scan invoice: %w", err)
+	}
+	return &invoice, nil
+}
+
+func (r *invoiceRepository) scanInvoiceItems(rows *sql.Rows) ([]domain.InvoiceItem, error) {
+	items := []domain.InvoiceItem{}
+	for rows.Next() {
+		var (
+			item              domain.InvoiceItem
+			installmentNumber sql.NullInt64
+		)
+		err := rows.Scan(&item.TransactionID, &item.OperationTypeID, &item.OperationTypeDescription, &installmentNumber, &item.Amount, &item.EventDate)
+		if err != nil {
+			return nil, fmt.Errorf("unable to scan invoice item: %w", err)
+		}
+		if installmentNumber.Valid {
+			number := int(installmentNumber.Int64)
+			item.InstallmentNumber = &number
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list invoice items: %w", err)
+	}
+
+	return items, nil
+}
+
+func (r *invoiceRepository) logCreateInvoiceError(ctx context.Context, invoice domain.Invoice, err error) {
+	logger.Logger.ErrorContext(
+		ctx,
+		"error creating invoice",
+		slog.Int64("accountID", invoice.AccountID),
+		slog.Time("closingDate", invoice.ClosingDate),
+		slog.String("error", err.Error()),
+	)
+}
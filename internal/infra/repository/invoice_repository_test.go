@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type InvoiceRepositoryTestSuite struct {
+	suite.Suite
+	repo *invoiceRepository
+	mock sqlmock.Sqlmock
+	db   *sql.DB
+}
+
+func (s *InvoiceRepositoryTestSuite) SetupTest() {
+	logger.InitLogger()
+	var err error
+	s.db, s.mock, err = sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	s.repo = NewInvoiceRepository(s.db)
+}
+
+func (s *InvoiceRepositoryTestSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func TestInvoiceRepositorySuite(t *testing.T) {
+	suite.Run(t, new(InvoiceRepositoryTestSuite))
+}
+
+func (s *InvoiceRepositoryTestSuite) TestInvoiceRepository_ListBillingStates_WhenAccountsExist_ShouldReturnStates() {
+	// Arrange
+	periodStart := time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)
+	s.mock.ExpectQuery("SELECT a.id, a.closing_day, a.due_day").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "closing_day", "due_day", "period_start", "balance"}).
+			AddRow(1, 3, 10, periodStart, "-120.50"))
+
+	ctx := context.Background()
+	// Act
+	states, err := s.repo.ListBillingStates(ctx)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []domain.BillingState{{
+		AccountID:       1,
+		Cycle:           domain.BillingCycle{ClosingDay: 3, DueDay: 10},
+		PeriodStart:     periodStart,
+		PreviousBalance: domain.MustParseMoney("-120.50"),
+	}}, states)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *InvoiceRepositoryTestSuite) TestInvoiceRepository_ListBillableItems_WhenItemsExist_ShouldReturnThem() {
+	// Arrange
+	periodStart := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+	closingDate := time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)
+	dueUntil := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+	eventDate := time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)
+
+	s.mock.ExpectQuery("SELECT t.id, t.operation_type_id, o.description").
+		WithArgs(int64(1), periodStart, closingDate, dueUntil).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "operation_type_id", "description", "installment_number", "amount", "event_date"}).
+			AddRow(4, 2, "COMPRA PARCELADA", 1, "-33.34", eventDate).
+			AddRow(5, 4, "PAGAMENTO", nil, "10.00", eventDate))
+
+	ctx := context.Background()
+	// Act
+	items, err := s.repo.ListBillableItems(ctx, 1, periodStart, closingDate, dueUntil)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), items, 2)
+	assert.Equal(s.T(), domain.CompraParcelada, items[0].OperationTypeID)
+	assert.Equal(s.T(), 1, *items[0].InstallmentNumber)
+	assert.Equal(s.T(), domain.MustParseMoney("-33.34"), items[0].Amount)
+	assert.Nil(s.T(), items[1].InstallmentNumber)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *InvoiceRepositoryTestSuite) TestInvoiceRepository_CreateInvoice_WhenValidInput_ShouldStoreItems() {
+	// Arrange
+	installmentNumber := 2
+	invoice := domain.NewInvoice(
+		1,
+		time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC),
+		domain.BillingCycle{ClosingDay: 3, DueDay: 10},
+		domain.MustParseMoney("0"),
+		[]domain.InvoiceItem{{TransactionID: 4, InstallmentNumber: &installmentNumber, Amount: domain.MustParseMoney("-33.33")}},
+	)
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("INSERT INTO invoices").
+		WithArgs(int64(1), invoice.PeriodStart, invoice.ClosingDate, invoice.DueDate, invoice.PreviousBalance, invoice.TotalDebits, invoice.TotalCredits, invoice.Balance, invoice.MinimumPayment).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	s.mock.ExpectExec("INSERT INTO invoice_items").
+		WithArgs(int64(7), int64(4), &installmentNumber, domain.MustParseMoney("-33.33")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectCommit()
+
+	ctx := context.Background()
+	// Act
+	id, err := s.repo.CreateInvoice(ctx, invoice)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(7), id)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *InvoiceRepositoryTestSuite) TestInvoiceRepository_CreateInvoice_WhenCycleAlreadyClosed_ShouldReturnErrAlreadyExists() {
+	// Arrange
+	invoice := domain.NewInvoice(1, time.Now(), time.Now(), domain.BillingCycle{ClosingDay: 3, DueDay: 10}, domain.MustParseMoney("0"), nil)
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("INSERT INTO invoices").
+		WillReturnError(&pq.Error{Code: pgUniqueViolation, Message: "duplicate key value violates unique constraint"})
+	s.mock.ExpectRollback()
+
+	ctx := context.Background()
+	// Act
+	id, err := s.repo.CreateInvoice(ctx, invoice)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, domain.ErrAlreadyExists)
+	assert.Equal(s.T(), int64(0), id)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *InvoiceRepositoryTestSuite) TestInvoiceRepository_GetInvoice_WhenInvoiceExists_ShouldReturnInvoiceWithItems() {
+	// Arrange
+	periodStart := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+	closingDate := time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)
+	dueDate := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+
+	s.mock.ExpectQuery("SELECT id, account_id, period_start, closing_date, due_date").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "period_start", "closing_date", "due_date", "previous_balance", "total_debits", "total_credits", "balance", "minimum_payment", "closed_at"}).
+			AddRow(7, 1, periodStart, closingDate, dueDate, "0.00", "100.00", "0.00", "-100.00", "15.00", closingDate))
+	s.mock.ExpectQuery("SELECT ii.transaction_id").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "operation_type_id", "description", "installment_number", "amount", "event_date"}).
+			AddRow(4, 1, "COMPRA A VISTA", nil, "-100.00", periodStart))
+
+	ctx := context.Background()
+	// Act
+	invoice, err := s.repo.GetInvoice(ctx, 7)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), dueDate, invoice.DueDate)
+	assert.Equal(s.T(), domain.MustParseMoney("-100"), invoice.Balance)
+	assert.Equal(s.T(), domain.MustParseMoney("15"), invoice.MinimumPayment)
+	assert.Len(s.T(), invoice.Items, 1)
+	assert.Equal(s.T(), "COMPRA A VISTA", invoice.Items[0].OperationTypeDescription)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *InvoiceRepositoryTestSuite) TestInvoiceRepository_GetInvoice_WhenInvoiceNotFound_ShouldReturnErrInvoiceNotFound() {
+	// Arrange
+	s.mock.ExpectQuery("SELECT id, account_id, period_start, closing_date, due_date").
+		WithArgs(int64(7)).
+		WillReturnError(sql.ErrNoRows)
+
+	ctx := context.Background()
+	// Act
+	invoice, err := s.repo.GetInvoice(ctx, 7)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, ErrInvoiceNotFound)
+	assert.Nil(s.T(), invoice)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *InvoiceRepositoryTestSuite) TestInvoiceRepository_ListOverdueInvoices_WhenInvoicesAreOverdue_ShouldReturnAccruedCharges() {
+	// Arrange
+	day := time.Date(2025, 2, 13, 0, 0, 0, 0, time.UTC)
+	periodStart := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+	closingDate := time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)
+	dueDate := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+	lastInterestDate := time.Date(2025, 2, 12, 0, 0, 0, 0, time.UTC)
+
+	s.mock.ExpectQuery("SELECT DISTINCT ON \\(account_id\\) id, account_id, period_start").
+		WithArgs(day, domain.JurosRotativos, domain.MultaPorAtraso).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "period_start", "closing_date", "due_date", "previous_balance", "total_debits", "total_credits", "balance", "minimum_payment", "closed_at", "last_interest_date", "late_fee_charged"}).
+			AddRow(7, 1, periodStart, closingDate, dueDate, "0.00", "100.00", "0.00", "-100.00", "15.00", closingDate, lastInterestDate, true).
+			AddRow(8, 2, periodStart, closingDate, dueDate, "0.00", "50.00", "0.00", "-50.00", "10.00", closingDate, nil, false))
+
+	ctx := context.Background()
+	// Act
+	overdue, err := s.repo.ListOverdueInvoices(ctx, day)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), overdue, 2)
+	assert.Equal(s.T(), int64(7), overdue[0].Invoice.ID)
+	assert.Equal(s.T(), &lastInterestDate, overdue[0].LastInterestDate)
+	assert.True(s.T(), overdue[0].LateFeeCharged)
+	assert.Nil(s.T(), overdue[1].LastInterestDate)
+	assert.False(s.T(), overdue[1].LateFeeCharged)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *InvoiceRepositoryTestSuite) TestInvoiceRepository_SumPayments_WhenPaymentsExist_ShouldReturnTotal() {
+	// Arrange
+	from := time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 2, 11, 0, 0, 0, 0, time.UTC)
+
+	s.mock.ExpectQuery("SELECT COALESCE\\(SUM\\(amount\\), 0\\)").
+		WithArgs(int64(1), from, to, domain.EstornoPagamento).
+		WillReturnRows(sqlmock.NewRows([]string{"paid"}).AddRow("60.00"))
+
+	ctx := context.Background()
+	// Act
+	paid, err := s.repo.SumPayments(ctx, 1, from, to)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), domain.MustParseMoney("60"), paid)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
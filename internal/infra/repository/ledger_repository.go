@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+)
+
+type ledgerRepository struct {
+	db *sql.DB
+}
+
+func NewLedgerRepository(db *sql.DB) *ledgerRepository {
+	return &ledgerRepository{db: db}
+}
+
+// GetTrialBalance totals the postings of each ledger account, only those of journal entries
+// dated at or before asOf when it is set.
+func (r *ledgerRepository) GetTrialBalance(ctx context.Context, asOf *time.Time) ([]domain.TrialBalanceLine, error) {
+	query := `
+		SELECT
+			p.ledger_account,
+			COALESCE(SUM(p.amount) FILTER (WHERE p.amount > 0), 0),
+			COALESCE(-SUM(p.amount) FILTER (WHERE p.amount < 0), 0),
+			SUM(p.amount)
+		FROM postings p
+		JOIN journal_entries j ON j.id = p.journal_entry_id
+		WHERE $1::TIMESTAMP IS NULL OR j.entry_date <= $1
+		GROUP BY p.ledger_account
+		ORDER BY p.ledger_account`
+
+	rows, err := r.db.QueryContext(ctx, query, asOf)
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error getting trial balance", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to get trial balance: %w", err)
+	}
+	defer rows.Close()
+
+	lines := []domain.TrialBalanceLine{}
+	for rows.Next() {
+		var line domain.TrialBalanceLine
+		if err := rows.Scan(&line.LedgerAccount, &line.Debits, &line.Credits, &line.Balance); err != nil {
+			logger.Logger.ErrorContext(ctx, "error getting trial balance", slog.String("error", err.Error()))
+			return nil, fmt.Errorf("unable to scan trial balance: %w", err)
+		}
+		lines = append(lines, line)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Logger.ErrorContext(ctx, "error getting trial balance", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to get trial balance: %w", err)
+	}
+
+	return lines, nil
+}
+
+// insertJournalEntry stores the entry and its postings within the caller's database
+// transaction. The database checks that the postings balance when tx commits.
+func insertJournalEntry(ctx context.Context, tx *sql.Tx, entry domain.JournalEntry) error {
+	query := "INSERT INTO journal_entries (transaction_id, entry_date) VALUES ($1, $2) RETURNING id"
+	var entryID int64
+	if err := tx.QueryRowContext(ctx, query, entry.TransactionID, entry.EntryDate).Scan(&entryID); err != nil {
+		logger.Logger.ErrorContext(ctx, "error creating journal entry", slog.Int64("transactionID", entry.TransactionID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to create journal entry: %w", translatePostgresError(err))
+	}
+
+	values := make([]string, 0, len(entry.Postings))
+	args := []interface{}{entryID}
+	for _, posting := range entry.Postings {
+		values = append(values, fmt.Sprintf("($1, $%d, $%d, $%d)", len(args)+1, len(args)+2, len(args)+3))
+		args = append(args, posting.LedgerAccount, posting.AccountID, posting.Amount)
+	}
+
+	query = "INSERT INTO postings (journal_entry_id, ledger_account, account_id, amount) VALUES " + strings.Join(values, ", ")
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		logger.Logger.ErrorContext(ctx, "error creating postings", slog.Int64("transactionID", entry.TransactionID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to create postings: %w", translatePostgresError(err))
+	}
+
+	return nil
+}
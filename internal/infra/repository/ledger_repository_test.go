@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type LedgerRepositoryTestSuite struct {
+	suite.Suite
+	repo *ledgerRepository
+	mock sqlmock.Sqlmock
+	db   *sql.DB
+}
+
+func (s *LedgerRepositoryTestSuite) SetupTest() {
+	logger.InitLogger()
+	var err error
+	s.db, s.mock, err = sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	s.repo = NewLedgerRepository(s.db)
+}
+
+func (s *LedgerRepositoryTestSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func TestLedgerRepositorySuite(t *testing.T) {
+	suite.Run(t, new(LedgerRepositoryTestSuite))
+}
+
+func (s *LedgerRepositoryTestSuite) TestLedgerRepository_GetTrialBalance_WhenPostingsExist_ShouldReturnTotalsPerLedgerAccount() {
+	// Arrange
+	asOf := time.Date(2025, 1, 31, 23, 59, 59, 0, time.UTC)
+
+	s.mock.ExpectQuery("SELECT\\s+p.ledger_account").
+		WithArgs(&asOf).
+		WillReturnRows(sqlmock.NewRows([]string{"ledger_account", "debits", "credits", "balance"}).
+			AddRow("CASH", "30.00", "50.00", "-20.00").
+			AddRow("RECEIVABLES", "50.00", "30.00", "20.00"))
+
+	ctx := context.Background()
+	// Act
+	lines, err := s.repo.GetTrialBalance(ctx, &asOf)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), lines, 2)
+	assert.Equal(s.T(), domain.LedgerCash, lines[0].LedgerAccount)
+	assert.Equal(s.T(), domain.MustParseMoney("-20"), lines[0].Balance)
+	assert.Equal(s.T(), domain.LedgerReceivables, lines[1].LedgerAccount)
+	assert.Equal(s.T(), domain.MustParseMoney("50"), lines[1].Debits)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *LedgerRepositoryTestSuite) TestLedgerRepository_GetTrialBalance_WhenQueryFails_ShouldReturnError() {
+	// Arrange
+	s.mock.ExpectQuery("SELECT\\s+p.ledger_account").
+		WillReturnError(errors.New("connection reset"))
+
+	ctx := context.Background()
+	// Act
+	lines, err := s.repo.GetTrialBalance(ctx, nil)
+
+	// Assert
+	assert.EqualError(s.T(), err, "failed to get trial balance: connection reset")
+	assert.Nil(s.T(), lines)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *LedgerRepositoryTestSuite) TestInsertJournalEntry_WhenEntryIsBalanced_ShouldStoreEveryPosting() {
+	// Arrange
+	eventDate := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	transaction := domain.NewTransaction(3, domain.CompraAVista, domain.MustParseMoney("-50"), eventDate)
+	transaction.SetID(7)
+	entry, _ := domain.NewTransactionJournalEntry(transaction)
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("INSERT INTO journal_entries").
+		WithArgs(int64(7), eventDate).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	s.mock.ExpectExec("INSERT INTO postings \\(journal_entry_id, ledger_account, account_id, amount\\) VALUES \\(\\$1, \\$2, \\$3, \\$4\\), \\(\\$1, \\$5, \\$6, \\$7\\)").
+		WithArgs(int64(2), domain.LedgerReceivables, entry.Postings[0].AccountID, domain.MustParseMoney("50"), domain.LedgerCash, (*int64)(nil), domain.MustParseMoney("-50")).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	s.mock.ExpectCommit()
+
+	ctx := context.Background()
+	tx, _ := s.db.BeginTx(ctx, nil)
+
+	// Act
+	err := insertJournalEntry(ctx, tx, entry)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.NoError(s.T(), tx.Commit())
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
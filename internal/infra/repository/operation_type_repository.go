@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+)
+
+var ErrOperationTypeNotFound = errors.New("operation type not found")
+
+type operationTypeRepository struct {
+	db *sql.DB
+}
+
+func NewOperationTypeRepository(db *sql.DB) *operationTypeRepository {
+	return &operationTypeRepository{db: db}
+}
+
+func (r *operationTypeRepository) CreateOperationType(ctx context.Context, operationType *domain.OperationTypeDefinition) (domain.OperationType, error) {
+	query := "INSERT INTO operation_types (description, direction, active) VALUES ($1, $2, $3) RETURNING id"
+	var id int64
+	err := r.db.QueryRowContext(ctx, query, operationType.Description(), operationType.Direction(), operationType.Active()).Scan(&id)
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error creating operation type", slog.String("description", operationType.Description()), slog.String("error", err.Error()))
+		return 0, fmt.Errorf("failed to create operation type: %w", translatePostgresError(err))
+	}
+	return domain.OperationType(id), nil
+}
+
+func (r *operationTypeRepository) GetOperationType(ctx context.Context, id domain.OperationType) (*domain.OperationTypeDefinition, error) {
+	query := "SELECT id, description, direction, active, created_at FROM operation_types WHERE id = $1"
+	operationType, err := r.scanOperationType(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Logger.ErrorContext(ctx, "operation type not found", slog.Any("operationTypeID", id))
+			return nil, ErrOperationTypeNotFound
+		}
+		logger.Logger.ErrorContext(ctx, "error getting operation type", slog.Any("operationTypeID", id), slog.String("error", err.Error()))
+		return nil, err
+	}
+	return operationType, nil
+}
+
+func (r *operationTypeRepository) ListOperationTypes(ctx context.Context) ([]domain.OperationTypeDefinition, error) {
+	query := "SELECT id, description, direction, active, created_at FROM operation_types ORDER BY id"
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error listing operation types", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list operation types: %w", err)
+	}
+	defer rows.Close()
+
+	operationTypes := []domain.OperationTypeDefinition{}
+	for rows.Next() {
+		operationType, err := r.scanOperationType(rows)
+		if err != nil {
+			logger.Logger.ErrorContext(ctx, "error listing operation types", slog.String("error", err.Error()))
+			return nil, err
+		}
+		operationTypes = append(operationTypes, *operationType)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Logger.ErrorContext(ctx, "error listing operation types", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list operation types: %w", err)
+	}
+
+	return operationTypes, nil
+}
+
+// UpdateOperationType saves the description and active flag. The direction is immutable
+// because existing transactions were signed with it.
+func (r *operationTypeRepository) UpdateOperationType(ctx context.Context, operationType *domain.OperationTypeDefinition) error {
+	query := "UPDATE operation_types SET description = $1, active = $2, updated_at = NOW() WHERE id = $3"
+	result, err := r.db.ExecContext(ctx, query, operationType.Description(), operationType.Active(), operationType.ID())
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error updating operation type", slog.Any("operationTypeID", operationType.ID()), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to update operation type: %w", translatePostgresError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error updating operation type", slog.Any("operationTypeID", operationType.ID()), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to update operation type: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		logger.Logger.ErrorContext(ctx, "operation type not found", slog.Any("operationTypeID", operationType.ID()))
+		return ErrOperationTypeNotFound
+	}
+
+	return nil
+}
+
+func (r *operationTypeRepository) scanOperationType(row rowScanner) (*domain.OperationTypeDefinition, error) {
+	var (
+		id          sql.NullInt64
+		description sql.NullString
+		direction   sql.NullString
+		active      sql.NullBool
+		createdAt   sql.NullTime
+	)
+
+	err := row.Scan(&id, &description, &direction, &active, &createdAt)
+	if err != nil {
+		return nil, fmt.Errorf("unable to scan operation type: %w", err)
+	}
+
+	operationType := domain.NewOperationTypeDefinition(description.String, domain.OperationDirection(direction.String))
+	operationType.SetID(domain.OperationType(id.Int64))
+	operationType.SetActive(active.Bool)
+	operationType.SetCreatedAt(createdAt.Time)
+	return operationType, nil
+}
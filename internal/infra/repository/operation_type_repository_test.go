@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type OperationTypeRepositoryTestSuite struct {
+	suite.Suite
+	repo *operationTypeRepository
+	mock sqlmock.Sqlmock
+	db   *sql.DB
+}
+
+func (s *OperationTypeRepositoryTestSuite) SetupTest() {
+	logger.InitLogger()
+	var err error
+	s.db, s.mock, err = sqlmock.New()
+	if err != nil {
+		s.T().Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	s.repo = NewOperationTypeRepository(s.db)
+}
+
+func (s *OperationTypeRepositoryTestSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func TestOperationTypeRepositorySuite(t *testing.T) {
+	suite.Run(t, new(OperationTypeRepositoryTestSuite))
+}
+
+func (s *OperationTypeRepositoryTestSuite) TestOperationTypeRepository_CreateOperationType_WhenValidInput_ShouldReturnID() {
+	// Arrange
+	ctx := context.Background()
+
+	s.mock.ExpectQuery("INSERT INTO operation_types").
+		WithArgs("TARIFA", domain.DirectionDebit, true).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+
+	// Act
+	id, err := s.repo.CreateOperationType(ctx, domain.NewOperationTypeDefinition("TARIFA", domain.DirectionDebit))
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), domain.OperationType(5), id)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *OperationTypeRepositoryTestSuite) TestOperationTypeRepository_CreateOperationType_WhenDescriptionExists_ShouldReturnErrAlreadyExists() {
+	// Arrange
+	ctx := context.Background()
+
+	s.mock.ExpectQuery("INSERT INTO operation_types").
+		WillReturnError(&pq.Error{Code: "23505", Constraint: "operation_types_description_key"})
+
+	// Act
+	id, err := s.repo.CreateOperationType(ctx, domain.NewOperationTypeDefinition("SAQUE", domain.DirectionDebit))
+
+	// Assert
+	assert.ErrorIs(s.T(), err, domain.ErrAlreadyExists)
+	assert.Equal(s.T(), domain.OperationType(0), id)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *OperationTypeRepositoryTestSuite) TestOperationTypeRepository_GetOperationType_WhenNotFound_ShouldReturnErrOperationTypeNotFound() {
+	// Arrange
+	ctx := context.Background()
+
+	s.mock.ExpectQuery("SELECT id, description, direction, active, created_at FROM operation_types WHERE id = \\$1").
+		WithArgs(domain.OperationType(9)).
+		WillReturnError(sql.ErrNoRows)
+
+	// Act
+	operationType, err := s.repo.GetOperationType(ctx, 9)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, ErrOperationTypeNotFound)
+	assert.Nil(s.T(), operationType)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *OperationTypeRepositoryTestSuite) TestOperationTypeRepository_ListOperationTypes_WhenRowsExist_ShouldReturnThem() {
+	// Arrange
+	ctx := context.Background()
+	createdAt := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+
+	s.mock.ExpectQuery("SELECT id, description, direction, active, created_at FROM operation_types ORDER BY id").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "description", "direction", "active", "created_at"}).
+			AddRow(1, "COMPRA A VISTA", "DEBIT", true, createdAt).
+			AddRow(4, "PAGAMENTO", "CREDIT", false, createdAt))
+
+	// Act
+	operationTypes, err := s.repo.ListOperationTypes(ctx)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), operationTypes, 2)
+	assert.Equal(s.T(), domain.CompraAVista, operationTypes[0].ID())
+	assert.Equal(s.T(), domain.DirectionDebit, operationTypes[0].Direction())
+	assert.True(s.T(), operationTypes[0].Active())
+	assert.Equal(s.T(), domain.Pagamento, operationTypes[1].ID())
+	assert.Equal(s.T(), domain.DirectionCredit, operationTypes[1].Direction())
+	assert.False(s.T(), operationTypes[1].Active())
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *OperationTypeRepositoryTestSuite) TestOperationTypeRepository_UpdateOperationType_WhenNotFound_ShouldReturnErrOperationTypeNotFound() {
+	// Arrange
+	ctx := context.Background()
+	operationType := domain.NewOperationTypeDefinition("TARIFA", domain.DirectionDebit)
+	operationType.SetID(9)
+	operationType.SetActive(false)
+
+	s.mock.ExpectExec("UPDATE operation_types SET description = \\$1, active = \\$2").
+		WithArgs("TARIFA", false, domain.OperationType(9)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	// Act
+	err := s.repo.UpdateOperationType(ctx, operationType)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, ErrOperationTypeNotFound)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/lib/pq"
+)
+
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgCheckViolation      = "23514"
+
+	accountsDocumentNumberKey = "accounts_document_number_key"
+)
+
+// translatePostgresError replaces constraint violations reported by Postgres with the matching
+// domain error, keeping the driver message as description. Other errors are returned as is.
+func translatePostgresError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+
+	switch pqErr.Code {
+	case pgUniqueViolation:
+		return fmt.Errorf("%w: %s", domain.ErrAlreadyExists, pqErr.Message)
+	case pgForeignKeyViolation:
+		return fmt.Errorf("%w: %s", domain.ErrReferenceNotFound, pqErr.Message)
+	case pgCheckViolation:
+		return fmt.Errorf("%w: %s", domain.ErrConstraintViolation, pqErr.Message)
+	default:
+		return err
+	}
+}
+
+func isUniqueViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == pgUniqueViolation && pqErr.Constraint == constraint
+}
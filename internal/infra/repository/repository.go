@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/VieiraVitor/transaction-flow/internal/domain"
 )
@@ -9,8 +10,68 @@ import (
 type AccountRepository interface {
 	CreateAccount(ctx context.Context, account *domain.Account) (int64, error)
 	GetAccount(ctx context.Context, accountID int64) (*domain.Account, error)
+	UpdateCreditLimit(ctx context.Context, accountID int64, creditLimit domain.Money) error
+	UpdateBillingCycle(ctx context.Context, accountID int64, billingCycle domain.BillingCycle) error
+	UpdateStatus(ctx context.Context, change domain.AccountStatusChange) error
+	ListStatusChanges(ctx context.Context, accountID int64) ([]domain.AccountStatusChange, error)
 }
 
 type TransactionRepository interface {
 	CreateTransaction(ctx context.Context, transaction domain.Transaction) (int64, error)
+	CreateInstallmentPurchase(ctx context.Context, purchase domain.Transaction, installments []domain.Installment) (int64, error)
+	CreateReversal(ctx context.Context, reversal domain.Transaction) (int64, error)
+	CreateCharge(ctx context.Context, charge domain.Transaction) (int64, error)
+	CreateTransfer(ctx context.Context, transfer domain.Transfer) (*domain.Transfer, error)
+	GetTransaction(ctx context.Context, transactionID int64) (*domain.Transaction, error)
+	ListTransactions(ctx context.Context, filter domain.TransactionFilter) ([]domain.Transaction, error)
+	ListInstallments(ctx context.Context, transactionID int64) ([]domain.Installment, error)
+	GetAccountBalance(ctx context.Context, accountID int64, asOf *time.Time) (*domain.AccountBalance, error)
+}
+
+type InvoiceRepository interface {
+	ListBillingStates(ctx context.Context) ([]domain.BillingState, error)
+	ListBillableItems(ctx context.Context, accountID int64, periodStart time.Time, closingDate time.Time, installmentsDueUntil time.Time) ([]domain.InvoiceItem, error)
+	CreateInvoice(ctx context.Context, invoice domain.Invoice) (int64, error)
+	GetInvoice(ctx context.Context, invoiceID int64) (*domain.Invoice, error)
+	ListInvoices(ctx context.Context, accountID int64) ([]domain.Invoice, error)
+	ListOverdueInvoices(ctx context.Context, day time.Time) ([]domain.OverdueInvoice, error)
+	SumPayments(ctx context.Context, accountID int64, from time.Time, to time.Time) (domain.Money, error)
+}
+
+type AuthorizationRepository interface {
+	CreateAuthorization(ctx context.Context, authorization domain.Authorization) (int64, error)
+	GetAuthorization(ctx context.Context, authorizationID int64) (*domain.Authorization, error)
+	ListExpiredAuthorizations(ctx context.Context, now time.Time) ([]domain.Authorization, error)
+	CaptureAuthorization(ctx context.Context, authorization domain.Authorization, purchase domain.Transaction) (int64, error)
+	ReleaseAuthorization(ctx context.Context, authorization domain.Authorization) error
+}
+
+type DisputeRepository interface {
+	OpenDispute(ctx context.Context, dispute domain.Dispute, credit domain.Transaction) (*domain.Dispute, error)
+	ResolveDispute(ctx context.Context, dispute domain.Dispute, reversal *domain.Transaction) (*domain.Dispute, error)
+	GetDispute(ctx context.Context, disputeID int64) (*domain.Dispute, error)
+	ListDisputes(ctx context.Context, transactionID int64) ([]domain.Dispute, error)
+}
+
+type LedgerRepository interface {
+	GetTrialBalance(ctx context.Context, asOf *time.Time) ([]domain.TrialBalanceLine, error)
+}
+
+type HealthRepository interface {
+	Ping(ctx context.Context) error
+	GetMigrationVersion(ctx context.Context) (uint, bool, error)
+}
+
+type IdempotencyRepository interface {
+	ReserveKey(ctx context.Context, scope string, key string, requestHash string, claimToken string, ttl time.Duration, lease time.Duration) (*domain.IdempotentResponse, error)
+	SaveResponse(ctx context.Context, scope string, key string, claimToken string, response domain.IdempotentResponse) error
+	ReleaseKey(ctx context.Context, scope string, key string, claimToken string) error
+	DeleteExpiredKeys(ctx context.Context) (int64, error)
+}
+
+type OperationTypeRepository interface {
+	CreateOperationType(ctx context.Context, operationType *domain.OperationTypeDefinition) (domain.OperationType, error)
+	GetOperationType(ctx context.Context, id domain.OperationType) (*domain.OperationTypeDefinition, error)
+	ListOperationTypes(ctx context.Context) ([]domain.OperationTypeDefinition, error)
+	UpdateOperationType(ctx context.Context, operationType *domain.OperationTypeDefinition) error
 }
@@ -3,13 +3,28 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/VieiraVitor/transaction-flow/internal/domain"
 	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
 )
 
+var (
+	ErrInsufficientCreditLimit    = errors.New("insufficient credit limit")
+	ErrTransactionNotFound        = errors.New("transaction not found")
+	ErrTransactionAlreadyReversed = errors.New("transaction already reversed")
+	ErrReversalExceedsAmount      = errors.New("reversal exceeds the amount not yet reversed or disputed")
+	ErrTransactionDisputed        = errors.New("transaction is disputed")
+)
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
 type transactionRepository struct {
 	db *sql.DB
 }
@@ -18,23 +33,597 @@ func NewTransactionRepository(db *sql.DB) *transactionRepository {
 	return &transactionRepository{db: db}
 }
 
+// CreateTransaction stores the transaction and applies its amount to the account's
+// available credit limit in a single database transaction. The account row is locked
+// so concurrent debits cannot both consume the same limit. Payments discharge the
+// account's open debits before the transaction is committed.
 func (r *transactionRepository) CreateTransaction(ctx context.Context, transaction domain.Transaction) (int64, error) {
-	query := "INSERT INTO transactions (account_id, operation_type_id, amount, event_date) VALUES($1, $2, $3, $4) RETURNING id"
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logCreateTransactionError(ctx, transaction, err)
+		return 0, fmt.Errorf("failed to create transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.applyCreditLimit(ctx, tx, transaction); err != nil {
+		return 0, err
+	}
+
+	id, err := r.insertTransaction(ctx, tx, transaction)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logCreateTransactionError(ctx, transaction, err)
+		return 0, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	return id, nil
+}
+
+// CreateInstallmentPurchase stores the purchase with its installment schedule. The full
+// amount is taken from the available credit limit right away, as in CreateTransaction.
+func (r *transactionRepository) CreateInstallmentPurchase(ctx context.Context, purchase domain.Transaction, installments []domain.Installment) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logCreateTransactionError(ctx, purchase, err)
+		return 0, fmt.Errorf("failed to create transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.applyCreditLimit(ctx, tx, purchase); err != nil {
+		return 0, err
+	}
+
+	id, err := r.insertTransaction(ctx, tx, purchase)
+	if err != nil {
+		return 0, err
+	}
+
+	query := "INSERT INTO installments (transaction_id, number, amount, due_date) VALUES ($1, $2, $3, $4)"
+	for _, installment := range installments {
+		if _, err := tx.ExecContext(ctx, query, id, installment.Number, installment.Amount, installment.DueDate); err != nil {
+			r.logCreateTransactionError(ctx, purchase, err)
+			return 0, fmt.Errorf("failed to create installments: %w", translatePostgresError(err))
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logCreateTransactionError(ctx, purchase, err)
+		return 0, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	return id, nil
+}
+
+// CreateReversal stores a reversal built by domain.NewReversal in a single database
+// transaction. The account row is locked before the original transaction is read, as in
+// CreateTransaction, and the original row is locked too, so concurrent reversals and
+// disputes cannot together credit more than the original amount. The amount of disputes
+// that are open or won was already credited back and cannot be reversed as well. The
+// reversal cancels the open balance of the original transaction first and whatever it
+// credits beyond that discharges the account's other open debits.
+func (r *transactionRepository) CreateReversal(ctx context.Context, reversal domain.Transaction) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logCreateTransactionError(ctx, reversal, err)
+		return 0, fmt.Errorf("failed to create reversal: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.lockAccount(ctx, tx, reversal.AccountID()); err != nil {
+		return 0, err
+	}
+
+	originalID := *reversal.ReversesTransactionID()
+	query := `
+		SELECT id, account_id, operation_type_id, amount, balance, reverses_transaction_id, invoice_id, transfer_id, explanation, event_date, created_at,
+			(SELECT COALESCE(SUM(r.amount), 0) FROM transactions r WHERE r.reverses_transaction_id = transactions.id),
+			(SELECT COALESCE(SUM(d.amount), 0) FROM disputes d WHERE d.transaction_id = transactions.id AND d.status <> 'LOST')
+		FROM transactions
+		WHERE id = $1
+		FOR UPDATE`
+
+	var reversed, disputed domain.Money
+	original, err := r.scanTransaction(tx.QueryRowContext(ctx, query, originalID), &reversed, &disputed)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Logger.ErrorContext(ctx, "transaction not found", slog.Int64("transactionID", originalID))
+			return 0, ErrTransactionNotFound
+		}
+		r.logCreateTransactionError(ctx, reversal, err)
+		return 0, fmt.Errorf("failed to create reversal: %w", err)
+	}
+
+	notReversed := original.Amount().Add(reversed).Abs()
+	if notReversed.IsZero() {
+		logger.Logger.ErrorContext(ctx, "transaction already reversed", slog.Int64("transactionID", originalID))
+		return 0, ErrTransactionAlreadyReversed
+	}
+	remaining := notReversed.Sub(disputed)
+	if !remaining.IsPositive() {
+		logger.Logger.ErrorContext(ctx, "transaction is disputed", slog.Int64("transactionID", originalID), slog.String("disputed", disputed.String()))
+		return 0, fmt.Errorf("%w: %s of transaction %d is disputed", ErrTransactionDisputed, disputed, originalID)
+	}
+	if reversal.Amount().Abs().Sub(remaining).IsPositive() {
+		logger.Logger.ErrorContext(ctx, "reversal exceeds the amount not yet reversed or disputed", slog.Int64("transactionID", originalID), slog.String("amount", reversal.Amount().String()))
+		return 0, fmt.Errorf("%w: %s requested, %s left", ErrReversalExceedsAmount, reversal.Amount().Abs(), remaining)
+	}
+
+	if err := r.applyCreditLimit(ctx, tx, reversal); err != nil {
+		return 0, err
+	}
+
+	original.SettleReversal(&reversal)
+	query = "UPDATE transactions SET balance = $1, updated_at = NOW() WHERE id = $2"
+	if _, err := tx.ExecContext(ctx, query, original.Balance(), originalID); err != nil {
+		r.logCreateTransactionError(ctx, reversal, err)
+		return 0, fmt.Errorf("failed to create reversal: %w", err)
+	}
+
+	id, err := r.insertTransaction(ctx, tx, reversal)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logCreateTransactionError(ctx, reversal, err)
+		return 0, fmt.Errorf("failed to create reversal: %w", err)
+	}
+
+	return id, nil
+}
+
+// CreateCharge stores a charge accrued on an overdue invoice. Unlike other debits, charges
+// are not rejected for lack of credit limit: the available limit goes negative by the
+// amount charged over it, which later payments restore first.
+// Accruing the same charge twice returns domain.ErrAlreadyExists.
+func (r *transactionRepository) CreateCharge(ctx context.Context, charge domain.Transaction) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logCreateTransactionError(ctx, charge, err)
+		return 0, fmt.Errorf("failed to create charge: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.lockAccount(ctx, tx, charge.AccountID()); err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.ExecContext(ctx, adjustAvailableCreditQuery, charge.Amount(), charge.AccountID()); err != nil {
+		r.logCreateTransactionError(ctx, charge, err)
+		return 0, fmt.Errorf("failed to create charge: %w", err)
+	}
+
+	id, err := r.insertTransaction(ctx, tx, charge)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logCreateTransactionError(ctx, charge, err)
+		return 0, fmt.Errorf("failed to create charge: %w", err)
+	}
+
+	return id, nil
+}
+
+// CreateTransfer stores the transfer with its debit and credit legs in a single database
+// transaction, so either both accounts are updated or neither is. Both account rows are
+// locked up front in ascending id order, so concurrent transfers in opposite directions
+// between the same accounts cannot deadlock. The debit is subject to the sender's credit
+// limit and the credit discharges the receiver's open debits, as a payment would.
+func (r *transactionRepository) CreateTransfer(ctx context.Context, transfer domain.Transfer) (*domain.Transfer, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logCreateTransferError(ctx, transfer, err)
+		return nil, fmt.Errorf("failed to create transfer: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, accountID := range transfer.LockOrder() {
+		if err := r.lockAccount(ctx, tx, accountID); err != nil {
+			return nil, err
+		}
+	}
+
+	query := "INSERT INTO transfers (from_account_id, to_account_id, amount, created_at) VALUES ($1, $2, $3, $4) RETURNING id"
+	err = tx.QueryRowContext(ctx, query, transfer.FromAccountID, transfer.ToAccountID, transfer.Amount, transfer.CreatedAt).Scan(&transfer.ID)
+	if err != nil {
+		r.logCreateTransferError(ctx, transfer, err)
+		return nil, fmt.Errorf("failed to create transfer: %w", translatePostgresError(err))
+	}
+
+	debit, credit := transfer.Legs()
+	if err := r.applyCreditLimit(ctx, tx, debit); err != nil {
+		return nil, err
+	}
+	if transfer.DebitTransactionID, err = r.insertTransaction(ctx, tx, debit); err != nil {
+		return nil, err
+	}
+
+	if err := r.applyCreditLimit(ctx, tx, credit); err != nil {
+		return nil, err
+	}
+	if transfer.CreditTransactionID, err = r.insertTransaction(ctx, tx, credit); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logCreateTransferError(ctx, transfer, err)
+		return nil, fmt.Errorf("failed to create transfer: %w", err)
+	}
+
+	return &transfer, nil
+}
 
+// insertTransaction inserts the transaction with its journal entry and, when it keeps a
+// positive balance, uses it to discharge the account's open debits.
+func (r *transactionRepository) insertTransaction(ctx context.Context, tx *sql.Tx, transaction domain.Transaction) (int64, error) {
+	query := "INSERT INTO transactions (account_id, operation_type_id, amount, balance, reverses_transaction_id, invoice_id, transfer_id, explanation, event_date) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id"
 	var id int64
-	row := r.db.QueryRow(query, transaction.AccountID(), transaction.OperationTypeID(), transaction.Amount(), transaction.EventDate())
+	row := tx.QueryRowContext(
+		ctx,
+		query,
+		transaction.AccountID(),
+		transaction.OperationTypeID(),
+		transaction.Amount(),
+		transaction.Balance(),
+		transaction.ReversesTransactionID(),
+		transaction.InvoiceID(),
+		transaction.TransferID(),
+		sql.NullString{String: transaction.Explanation(), Valid: transaction.Explanation() != ""},
+		transaction.EventDate(),
+	)
 	err := row.Scan((&id))
 	if err != nil {
+		r.logCreateTransactionError(ctx, transaction, err)
+		return 0, fmt.Errorf("failed to create transaction: %w", translatePostgresError(err))
+	}
+
+	transaction.SetID(id)
+	entry, err := domain.NewTransactionJournalEntry(transaction)
+	if err != nil {
+		r.logCreateTransactionError(ctx, transaction, err)
+		return 0, fmt.Errorf("failed to create transaction: %w", err)
+	}
+	if err := insertJournalEntry(ctx, tx, entry); err != nil {
+		return 0, err
+	}
+
+	if transaction.Balance().IsPositive() {
+		if err := r.dischargeDebits(ctx, tx, transaction.AccountID(), id); err != nil {
+			r.logCreateTransactionError(ctx, transaction, err)
+			return 0, fmt.Errorf("failed to create transaction: %w", err)
+		}
+	}
+
+	return id, nil
+}
+
+func (r *transactionRepository) lockAccount(ctx context.Context, tx *sql.Tx, accountID int64) error {
+	var id int64
+	err := tx.QueryRowContext(ctx, "SELECT id FROM accounts WHERE id = $1 FOR UPDATE", accountID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Logger.ErrorContext(ctx, "account not found", slog.Int64("accountID", accountID))
+			return ErrAccountNotFound
+		}
+		logger.Logger.ErrorContext(ctx, "error locking account", slog.Int64("accountID", accountID), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to lock account: %w", err)
+	}
+	return nil
+}
+
+// adjustAvailableCreditQuery applies the signed amount $1 to account $2. Credits restore
+// the available credit limit up to the credit limit and keep what exceeds it as the
+// credit balance; debits are taken from the credit balance first.
+const adjustAvailableCreditQuery = `UPDATE accounts SET available_credit_limit = CASE
+		WHEN $1::NUMERIC >= 0 THEN LEAST(available_credit_limit + $1::NUMERIC, GREATEST(credit_limit, available_credit_limit))
+		ELSE available_credit_limit + LEAST(credit_balance + $1::NUMERIC, 0)
+	END,
+	credit_balance = CASE
+		WHEN $1::NUMERIC >= 0 THEN credit_balance + GREATEST(available_credit_limit + $1::NUMERIC - GREATEST(credit_limit, available_credit_limit), 0)
+		ELSE GREATEST(credit_balance + $1::NUMERIC, 0)
+	END,
+	updated_at = NOW()
+	WHERE id = $2`
+
+// applyCreditLimit locks the account row and adds the signed transaction amount to
+// its available credit limit as adjustAvailableCreditQuery does, rejecting debits that
+// the available limit and the credit balance together cannot cover. The status
+// is checked under the same lock, so an account blocked or closed concurrently cannot
+// take the transaction.
+func (r *transactionRepository) applyCreditLimit(ctx context.Context, tx *sql.Tx, transaction domain.Transaction) error {
+	query := "SELECT status, available_credit_limit + credit_balance + $1 >= 0 FROM accounts WHERE id = $2 FOR UPDATE"
+	var (
+		status         domain.AccountStatus
+		hasCreditLimit bool
+	)
+	err := tx.QueryRowContext(ctx, query, transaction.Amount(), transaction.AccountID()).Scan(&status, &hasCreditLimit)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Logger.ErrorContext(ctx, "account not found", slog.Int64("accountID", transaction.AccountID()))
+			return ErrAccountNotFound
+		}
+		r.logCreateTransactionError(ctx, transaction, err)
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	direction := domain.DirectionCredit
+	if transaction.Amount().IsNegative() {
+		direction = domain.DirectionDebit
+	}
+	if err := status.AcceptsTransactions(transaction.AccountID(), direction); err != nil {
+		logger.Logger.ErrorContext(ctx, "account does not accept transaction", slog.Int64("accountID", transaction.AccountID()), slog.String("status", string(status)))
+		return err
+	}
+
+	if !hasCreditLimit {
 		logger.Logger.ErrorContext(
 			ctx,
-			"error creating transaction",
+			"insufficient credit limit",
 			slog.Int64("accountID", transaction.AccountID()),
-			slog.Any("operationTypeID", transaction.OperationTypeID()),
-			slog.Float64("amount", transaction.Amount()),
-			slog.Time("eventDate", transaction.EventDate()),
-			slog.String("error", err.Error()),
+			slog.String("amount", transaction.Amount().String()),
 		)
-		return 0, fmt.Errorf("failed to create transaction: %w", err)
+		return ErrInsufficientCreditLimit
 	}
-	return id, nil
+
+	if _, err := tx.ExecContext(ctx, adjustAvailableCreditQuery, transaction.Amount(), transaction.AccountID()); err != nil {
+		r.logCreateTransactionError(ctx, transaction, err)
+		return fmt.Errorf("failed to create transaction: %w", translatePostgresError(err))
+	}
+
+	return nil
+}
+
+// dischargeDebits allocates the balance of the credit transaction to the account's open
+// debits, oldest event_date first, and keeps whatever is left over on the credit itself.
+func (r *transactionRepository) dischargeDebits(ctx context.Context, tx *sql.Tx, accountID int64, creditID int64) error {
+	query := `
+		WITH credit AS (
+			SELECT balance AS available FROM transactions WHERE id = $2
+		), open_debits AS (
+			SELECT id, -balance AS open_amount, SUM(-balance) OVER (ORDER BY event_date, id) + balance AS allocated_before
+			FROM transactions
+			WHERE account_id = $1 AND balance < 0
+		), discharged AS (
+			UPDATE transactions t
+			SET balance = t.balance + LEAST(o.open_amount, c.available - o.allocated_before), updated_at = NOW()
+			FROM open_debits o, credit c
+			WHERE t.id = o.id AND o.allocated_before < c.available
+			RETURNING LEAST(o.open_amount, c.available - o.allocated_before) AS paid
+		)
+		UPDATE transactions
+		SET balance = balance - (SELECT COALESCE(SUM(paid), 0) FROM discharged), updated_at = NOW()
+		WHERE id = $2`
+
+	if _, err := tx.ExecContext(ctx, query, accountID, creditID); err != nil {
+		logger.Logger.ErrorContext(ctx, "error discharging debits", slog.Int64("accountID", accountID), slog.Int64("creditID", creditID), slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+func (r *transactionRepository) GetTransaction(ctx context.Context, transactionID int64) (*domain.Transaction, error) {
+	query := `
+		SELECT t.id, t.account_id, t.operation_type_id, t.amount, t.balance, t.reverses_transaction_id, t.invoice_id, t.transfer_id, t.explanation, t.event_date, t.created_at, o.description
+		FROM transactions t
+		JOIN operation_types o ON o.id = t.operation_type_id
+		WHERE t.id = $1`
+
+	var description sql.NullString
+	row := r.db.QueryRowContext(ctx, query, transactionID)
+	transaction, err := r.scanTransaction(row, &description)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Logger.ErrorContext(ctx, "transaction not found", slog.Int64("transactionID", transactionID), slog.String("error", err.Error()))
+			return nil, ErrTransactionNotFound
+		}
+		logger.Logger.ErrorContext(ctx, "error getting transaction", slog.Int64("transactionID", transactionID), slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	transaction.SetOperationTypeDescription(description.String)
+	return &transaction, nil
+}
+
+func (r *transactionRepository) ListInstallments(ctx context.Context, transactionID int64) ([]domain.Installment, error) {
+	query := "SELECT id, transaction_id, number, amount, due_date FROM installments WHERE transaction_id = $1 ORDER BY number"
+	rows, err := r.db.QueryContext(ctx, query, transactionID)
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error listing installments", slog.Int64("transactionID", transactionID), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list installments: %w", err)
+	}
+	defer rows.Close()
+
+	installments := []domain.Installment{}
+	for rows.Next() {
+		var installment domain.Installment
+		err := rows.Scan(&installment.ID, &installment.TransactionID, &installment.Number, &installment.Amount, &installment.DueDate)
+		if err != nil {
+			logger.Logger.ErrorContext(ctx, "error listing installments", slog.Int64("transactionID", transactionID), slog.String("error", err.Error()))
+			return nil, fmt.Errorf("unable to scan installment: %w", err)
+		}
+		installments = append(installments, installment)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Logger.ErrorContext(ctx, "error listing installments", slog.Int64("transactionID", transactionID), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list installments: %w", err)
+	}
+
+	return installments, nil
+}
+
+// ListTransactions returns the account's transactions newest first, ordered by
+// (event_date, id) so pages stay stable while new transactions are created.
+func (r *transactionRepository) ListTransactions(ctx context.Context, filter domain.TransactionFilter) ([]domain.Transaction, error) {
+	conditions := []string{"account_id = $1"}
+	args := []interface{}{filter.AccountID}
+
+	if filter.OperationTypeID != nil {
+		args = append(args, *filter.OperationTypeID)
+		conditions = append(conditions, fmt.Sprintf("operation_type_id = $%d", len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("event_date >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("event_date < $%d", len(args)))
+	}
+	if filter.After != nil {
+		args = append(args, filter.After.EventDate, filter.After.ID)
+		conditions = append(conditions, fmt.Sprintf("(event_date, id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+	args = append(args, filter.Limit)
+
+	query := fmt.Sprintf(
+		"SELECT id, account_id, operation_type_id, amount, balance, reverses_transaction_id, invoice_id, transfer_id, explanation, event_date, created_at FROM transactions WHERE %s ORDER BY event_date DESC, id DESC LIMIT $%d",
+		strings.Join(conditions, " AND "),
+		len(args),
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error listing transactions", slog.Int64("accountID", filter.AccountID), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list transactions: %w", err)
+	}
+	defer rows.Close()
+
+	transactions := []domain.Transaction{}
+	for rows.Next() {
+		transaction, err := r.scanTransaction(rows)
+		if err != nil {
+			logger.Logger.ErrorContext(ctx, "error listing transactions", slog.Int64("accountID", filter.AccountID), slog.String("error", err.Error()))
+			return nil, err
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Logger.ErrorContext(ctx, "error listing transactions", slog.Int64("accountID", filter.AccountID), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list transactions: %w", err)
+	}
+
+	return transactions, nil
+}
+
+// GetAccountBalance sums the account's transactions, optionally only those with an
+// event_date up to asOf, in a single aggregate query.
+func (r *transactionRepository) GetAccountBalance(ctx context.Context, accountID int64, asOf *time.Time) (*domain.AccountBalance, error) {
+	query := `
+		SELECT
+			COALESCE(SUM(amount), 0),
+			COALESCE(-SUM(amount) FILTER (WHERE amount < 0), 0),
+			COALESCE(SUM(amount) FILTER (WHERE amount > 0), 0),
+			MAX(event_date)
+		FROM transactions
+		WHERE account_id = $1 AND ($2::TIMESTAMP IS NULL OR event_date <= $2)`
+
+	var (
+		balance           domain.Money
+		debits            domain.Money
+		credits           domain.Money
+		lastTransactionAt sql.NullTime
+	)
+	err := r.db.QueryRowContext(ctx, query, accountID, asOf).Scan(&balance, &debits, &credits, &lastTransactionAt)
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "error getting account balance", slog.Int64("accountID", accountID), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to get account balance: %w", err)
+	}
+
+	accountBalance := &domain.AccountBalance{
+		AccountID: accountID,
+		Balance:   balance,
+		Debits:    debits,
+		Credits:   credits,
+		AsOf:      asOf,
+	}
+	if lastTransactionAt.Valid {
+		accountBalance.LastTransactionAt = &lastTransactionAt.Time
+	}
+
+	return accountBalance, nil
+}
+
+// scanTransaction reads the transaction columns in their canonical order followed by any
+// extra columns selected by the caller.
+func (r *transactionRepository) scanTransaction(row rowScanner, extra ...interface{}) (domain.Transaction, error) {
+	var (
+		id              sql.NullInt64
+		accountID       sql.NullInt64
+		operationTypeID sql.NullInt64
+		amount          domain.Money
+		balance         domain.Money
+		reversesID      sql.NullInt64
+		invoiceID       sql.NullInt64
+		transferID      sql.NullInt64
+		explanation     sql.NullString
+		eventDate       sql.NullTime
+		createdAt       sql.NullTime
+	)
+
+	dest := []interface{}{
+		&id,
+		&accountID,
+		&operationTypeID,
+		&amount,
+		&balance,
+		&reversesID,
+		&invoiceID,
+		&transferID,
+		&explanation,
+		&eventDate,
+		&createdAt,
+	}
+
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return domain.Transaction{}, fmt.Errorf("unable to scan transaction: %w", err)
+	}
+
+	transaction := domain.NewTransaction(accountID.Int64, domain.OperationType(operationTypeID.Int64), amount, eventDate.Time)
+	transaction.SetID(id.Int64)
+	transaction.SetBalance(balance)
+	transaction.SetCreatedAt(createdAt.Time)
+	if reversesID.Valid {
+		transaction.SetReversesTransactionID(&reversesID.Int64)
+	}
+	if invoiceID.Valid {
+		transaction.SetInvoiceID(&invoiceID.Int64)
+	}
+	if transferID.Valid {
+		transaction.SetTransferID(&transferID.Int64)
+	}
+	transaction.SetExplanation(explanation.String)
+
+	return transaction, nil
+}
+
+func (r *transactionRepository) logCreateTransactionError(ctx context.Context, transaction domain.Transaction, err error) {
+	logger.Logger.ErrorContext(
+		ctx,
+		"error creating transaction",
+		slog.Int64("accountID", transaction.AccountID()),
+		slog.Any("operationTypeID", transaction.OperationTypeID()),
+		slog.String("amount", transaction.Amount().String()),
+		slog.Time("eventDate", transaction.EventDate()),
+		slog.String("error", err.Error()),
+	)
+}
+
+func (r *transactionRepository) logCreateTransferError(ctx context.Context, transfer domain.Transfer, err error) {
+	logger.Logger.ErrorContext(
+		ctx,
+		"error creating transfer",
+		slog.Int64("fromAccountID", transfer.FromAccountID),
+		slog.Int64("toAccountID", transfer.ToAccountID),
+		slog.String("amount", transfer.Amount.String()),
+		slog.String("error", err.Error()),
+	)
 }
@@ -5,10 +5,12 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/VieiraVitor/transaction-flow/internal/domain"
 	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
@@ -40,10 +42,19 @@ func TestTransactionRepositorySuite(t *testing.T) {
 
 func (s *TransactionRepositoryTestSuite) TestTransactionRepository_CreateTransaction_WhenValidInput_ShouldReturnId() {
 	// Arrange
-	transaction := domain.NewTransaction(int64(1), 1, 100)
+	transaction := domain.NewTransaction(int64(1), 1, domain.MustParseMoney("-100"))
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT status, available_credit_limit").
+		WithArgs(transaction.Amount(), transaction.AccountID()).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "has_credit_limit"}).AddRow("ACTIVE", true))
+	s.mock.ExpectExec("UPDATE accounts SET available_credit_limit").
+		WithArgs(transaction.Amount(), transaction.AccountID()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
 	s.mock.ExpectQuery("INSERT INTO transactions").
-		WithArgs(transaction.AccountID(), transaction.OperationTypeID(), transaction.Amount(), transaction.EventDate()).
+		WithArgs(transaction.AccountID(), transaction.OperationTypeID(), transaction.Amount(), transaction.Balance(), transaction.ReversesTransactionID(), transaction.InvoiceID(), transaction.TransferID(), sql.NullString{}, transaction.EventDate()).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	s.expectJournalEntry(1)
+	s.mock.ExpectCommit()
 
 	ctx := context.Background()
 	// Act
@@ -57,12 +68,20 @@ func (s *TransactionRepositoryTestSuite) TestTransactionRepository_CreateTransac
 
 func (s *TransactionRepositoryTestSuite) TestTransactionRepository_CreateTransaction_WhenFailedToCreateAccount_ShouldReturnError() {
 	// Arrange
-	transaction := domain.NewTransaction(int64(1), 1, 100)
+	transaction := domain.NewTransaction(int64(1), 1, domain.MustParseMoney("-100"))
 	expectedError := errors.New("failed to create transaction")
 
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT status, available_credit_limit").
+		WithArgs(transaction.Amount(), transaction.AccountID()).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "has_credit_limit"}).AddRow("ACTIVE", true))
+	s.mock.ExpectExec("UPDATE accounts SET available_credit_limit").
+		WithArgs(transaction.Amount(), transaction.AccountID()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
 	s.mock.ExpectQuery("INSERT INTO transactions").
-		WithArgs(transaction.AccountID(), transaction.OperationTypeID(), transaction.Amount(), transaction.EventDate()).
+		WithArgs(transaction.AccountID(), transaction.OperationTypeID(), transaction.Amount(), transaction.Balance(), transaction.ReversesTransactionID(), transaction.InvoiceID(), transaction.TransferID(), sql.NullString{}, transaction.EventDate()).
 		WillReturnError(expectedError)
+	s.mock.ExpectRollback()
 
 	ctx := context.Background()
 	// Act
@@ -74,3 +93,733 @@ func (s *TransactionRepositoryTestSuite) TestTransactionRepository_CreateTransac
 	assert.Error(s.T(), expectedError, err)
 	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
 }
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_CreateTransaction_WhenForeignKeyViolated_ShouldReturnErrReferenceNotFound() {
+	// Arrange
+	transaction := domain.NewTransaction(int64(1), 9, domain.MustParseMoney("-100"))
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT status, available_credit_limit").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "has_credit_limit"}).AddRow("ACTIVE", true))
+	s.mock.ExpectExec("UPDATE accounts SET available_credit_limit").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectQuery("INSERT INTO transactions").
+		WillReturnError(&pq.Error{Code: pgForeignKeyViolation, Message: "violates foreign key constraint"})
+	s.mock.ExpectRollback()
+
+	ctx := context.Background()
+	// Act
+	id, err := s.repo.CreateTransaction(ctx, transaction)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, domain.ErrReferenceNotFound)
+	assert.Equal(s.T(), int64(0), id)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_CreateTransaction_WhenInsufficientCreditLimit_ShouldReturnError() {
+	// Arrange
+	transaction := domain.NewTransaction(int64(1), 1, domain.MustParseMoney("-100"))
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT status, available_credit_limit").
+		WithArgs(transaction.Amount(), transaction.AccountID()).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "has_credit_limit"}).AddRow("ACTIVE", false))
+	s.mock.ExpectRollback()
+
+	ctx := context.Background()
+	// Act
+	id, err := s.repo.CreateTransaction(ctx, transaction)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, ErrInsufficientCreditLimit)
+	assert.Equal(s.T(), int64(0), id)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_CreateTransaction_WhenDebitOnBlockedAccount_ShouldReturnErrAccountBlocked() {
+	// Arrange
+	transaction := domain.NewTransaction(int64(1), 1, domain.MustParseMoney("-100"))
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT status, available_credit_limit \\+ credit_balance \\+ \\$1 >= 0 FROM accounts WHERE id = \\$2 FOR UPDATE").
+		WithArgs(transaction.Amount(), transaction.AccountID()).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "has_credit_limit"}).AddRow("BLOCKED", true))
+	s.mock.ExpectRollback()
+
+	ctx := context.Background()
+	// Act
+	id, err := s.repo.CreateTransaction(ctx, transaction)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, domain.ErrAccountBlocked)
+	assert.Equal(s.T(), int64(0), id)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_CreateTransaction_WhenPaymentOnClosedAccount_ShouldReturnErrAccountClosed() {
+	// Arrange
+	transaction := domain.NewTransaction(int64(1), 4, domain.MustParseMoney("100"))
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT status, available_credit_limit \\+ credit_balance \\+ \\$1 >= 0 FROM accounts WHERE id = \\$2 FOR UPDATE").
+		WithArgs(transaction.Amount(), transaction.AccountID()).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "has_credit_limit"}).AddRow("CLOSED", true))
+	s.mock.ExpectRollback()
+
+	ctx := context.Background()
+	// Act
+	id, err := s.repo.CreateTransaction(ctx, transaction)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, domain.ErrAccountClosed)
+	assert.Equal(s.T(), int64(0), id)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_CreateTransaction_WhenAccountNotFound_ShouldReturnError() {
+	// Arrange
+	transaction := domain.NewTransaction(int64(1), 4, domain.MustParseMoney("100"))
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT status, available_credit_limit").
+		WithArgs(transaction.Amount(), transaction.AccountID()).
+		WillReturnError(sql.ErrNoRows)
+	s.mock.ExpectRollback()
+
+	ctx := context.Background()
+	// Act
+	id, err := s.repo.CreateTransaction(ctx, transaction)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, ErrAccountNotFound)
+	assert.Equal(s.T(), int64(0), id)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_CreateTransaction_WhenPayment_ShouldDischargeDebits() {
+	// Arrange
+	transaction := domain.NewTransaction(int64(1), 4, domain.MustParseMoney("100"))
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT status, available_credit_limit").
+		WithArgs(transaction.Amount(), transaction.AccountID()).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "has_credit_limit"}).AddRow("ACTIVE", true))
+	s.mock.ExpectExec("UPDATE accounts SET available_credit_limit = CASE WHEN \\$1::NUMERIC >= 0 THEN LEAST\\(available_credit_limit \\+ \\$1::NUMERIC, GREATEST\\(credit_limit, available_credit_limit\\)\\)").
+		WithArgs(transaction.Amount(), transaction.AccountID()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectQuery("INSERT INTO transactions").
+		WithArgs(transaction.AccountID(), transaction.OperationTypeID(), transaction.Amount(), transaction.Balance(), transaction.ReversesTransactionID(), transaction.InvoiceID(), transaction.TransferID(), sql.NullString{}, transaction.EventDate()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	s.expectJournalEntry(7)
+	s.mock.ExpectExec("WITH credit AS").
+		WithArgs(transaction.AccountID(), int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectCommit()
+
+	ctx := context.Background()
+	// Act
+	id, err := s.repo.CreateTransaction(ctx, transaction)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(7), id)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_CreateTransaction_WhenDischargeFails_ShouldRollback() {
+	// Arrange
+	transaction := domain.NewTransaction(int64(1), 4, domain.MustParseMoney("100"))
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT status, available_credit_limit").
+		WithArgs(transaction.Amount(), transaction.AccountID()).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "has_credit_limit"}).AddRow("ACTIVE", true))
+	s.mock.ExpectExec("UPDATE accounts SET available_credit_limit").
+		WithArgs(transaction.Amount(), transaction.AccountID()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectQuery("INSERT INTO transactions").
+		WithArgs(transaction.AccountID(), transaction.OperationTypeID(), transaction.Amount(), transaction.Balance(), transaction.ReversesTransactionID(), transaction.InvoiceID(), transaction.TransferID(), sql.NullString{}, transaction.EventDate()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	s.expectJournalEntry(7)
+	s.mock.ExpectExec("WITH credit AS").
+		WithArgs(transaction.AccountID(), int64(7)).
+		WillReturnError(errors.New("deadlock detected"))
+	s.mock.ExpectRollback()
+
+	ctx := context.Background()
+	// Act
+	id, err := s.repo.CreateTransaction(ctx, transaction)
+
+	// Assert
+	assert.ErrorContains(s.T(), err, "deadlock detected")
+	assert.Equal(s.T(), int64(0), id)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_ListTransactions_WhenNoFilters_ShouldReturnTransactions() {
+	// Arrange
+	eventDate := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	filter := domain.TransactionFilter{AccountID: 1, Limit: 2}
+
+	s.mock.ExpectQuery(`SELECT id, account_id, operation_type_id, amount, balance, reverses_transaction_id, invoice_id, transfer_id, explanation, event_date, created_at FROM transactions WHERE account_id = \$1 ORDER BY event_date DESC, id DESC LIMIT \$2`).
+		WithArgs(int64(1), 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "balance", "reverses_transaction_id", "invoice_id", "transfer_id", "explanation", "event_date", "created_at"}).
+			AddRow(2, 1, 4, "100.00", "40.00", nil, nil, nil, nil, eventDate, eventDate).
+			AddRow(1, 1, 1, "-60.00", "0.00", nil, nil, nil, nil, eventDate.Add(-time.Hour), eventDate))
+
+	ctx := context.Background()
+	// Act
+	transactions, err := s.repo.ListTransactions(ctx, filter)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), transactions, 2)
+	assert.Equal(s.T(), int64(2), transactions[0].ID())
+	assert.Equal(s.T(), domain.Pagamento, transactions[0].OperationTypeID())
+	assert.Equal(s.T(), domain.MustParseMoney("100"), transactions[0].Amount())
+	assert.Equal(s.T(), domain.MustParseMoney("40"), transactions[0].Balance())
+	assert.Equal(s.T(), eventDate, transactions[0].EventDate())
+	assert.Equal(s.T(), int64(1), transactions[1].ID())
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_ListTransactions_WhenAllFilters_ShouldApplyThem() {
+	// Arrange
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	operationType := domain.CompraAVista
+	after := domain.TransactionCursor{EventDate: time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC), ID: 10}
+	filter := domain.TransactionFilter{
+		AccountID:       1,
+		OperationTypeID: &operationType,
+		From:            &from,
+		To:              &to,
+		After:           &after,
+		Limit:           5,
+	}
+
+	s.mock.ExpectQuery(`WHERE account_id = \$1 AND operation_type_id = \$2 AND event_date >= \$3 AND event_date < \$4 AND \(event_date, id\) < \(\$5, \$6\) ORDER BY event_date DESC, id DESC LIMIT \$7`).
+		WithArgs(int64(1), operationType, from, to, after.EventDate, after.ID, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "balance", "reverses_transaction_id", "invoice_id", "transfer_id", "explanation", "event_date", "created_at"}))
+
+	ctx := context.Background()
+	// Act
+	transactions, err := s.repo.ListTransactions(ctx, filter)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), transactions)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_ListTransactions_WhenQueryFails_ShouldReturnError() {
+	// Arrange
+	expectedError := errors.New("connection reset")
+	s.mock.ExpectQuery("SELECT id, account_id, operation_type_id").
+		WillReturnError(expectedError)
+
+	ctx := context.Background()
+	// Act
+	transactions, err := s.repo.ListTransactions(ctx, domain.TransactionFilter{AccountID: 1, Limit: 2})
+
+	// Assert
+	assert.ErrorIs(s.T(), err, expectedError)
+	assert.Nil(s.T(), transactions)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_GetTransaction_WhenTransactionExists_ShouldReturnTransaction() {
+	// Arrange
+	eventDate := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	createdAt := eventDate.Add(time.Second)
+
+	s.mock.ExpectQuery("SELECT t.id, t.account_id, t.operation_type_id, t.amount, t.balance, t.reverses_transaction_id, t.invoice_id, t.transfer_id, t.explanation, t.event_date, t.created_at, o.description").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "balance", "reverses_transaction_id", "invoice_id", "transfer_id", "explanation", "event_date", "created_at", "description"}).
+			AddRow(7, 1, 1, "-50.00", "-20.00", nil, nil, nil, nil, eventDate, createdAt, "COMPRA A VISTA"))
+
+	ctx := context.Background()
+	// Act
+	transaction, err := s.repo.GetTransaction(ctx, 7)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(7), transaction.ID())
+	assert.Equal(s.T(), int64(1), transaction.AccountID())
+	assert.Equal(s.T(), domain.CompraAVista, transaction.OperationTypeID())
+	assert.Equal(s.T(), "COMPRA A VISTA", transaction.OperationTypeDescription())
+	assert.Equal(s.T(), domain.MustParseMoney("-50"), transaction.Amount())
+	assert.Equal(s.T(), domain.MustParseMoney("-20"), transaction.Balance())
+	assert.Equal(s.T(), eventDate, transaction.EventDate())
+	assert.Equal(s.T(), createdAt, transaction.CreatedAt())
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_GetTransaction_WhenTransactionNotFound_ShouldReturnError() {
+	// Arrange
+	s.mock.ExpectQuery("SELECT t.id, t.account_id").
+		WithArgs(int64(7)).
+		WillReturnError(sql.ErrNoRows)
+
+	ctx := context.Background()
+	// Act
+	transaction, err := s.repo.GetTransaction(ctx, 7)
+
+	// Assert
+	assert.Nil(s.T(), transaction)
+	assert.Equal(s.T(), "transaction not found", err.Error())
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_GetTransaction_WhenFailToGetTransaction_ShouldReturnError() {
+	// Arrange
+	expectedError := errors.New("failed to get transaction")
+	s.mock.ExpectQuery("SELECT t.id, t.account_id").
+		WithArgs(int64(7)).
+		WillReturnError(expectedError)
+
+	ctx := context.Background()
+	// Act
+	transaction, err := s.repo.GetTransaction(ctx, 7)
+
+	// Assert
+	assert.Nil(s.T(), transaction)
+	assert.ErrorContains(s.T(), err, expectedError.Error())
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_GetAccountBalance_WhenTransactionsExist_ShouldReturnTotals() {
+	// Arrange
+	asOf := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	lastTransactionAt := time.Date(2025, 1, 30, 10, 0, 0, 0, time.UTC)
+
+	s.mock.ExpectQuery("SELECT(.+)FROM transactions(.+)WHERE account_id = \\$1 AND \\(\\$2::TIMESTAMP IS NULL OR event_date <= \\$2\\)").
+		WithArgs(int64(1), &asOf).
+		WillReturnRows(sqlmock.NewRows([]string{"balance", "debits", "credits", "last_transaction_at"}).
+			AddRow("-70.00", "120.00", "50.00", lastTransactionAt))
+
+	ctx := context.Background()
+	// Act
+	balance, err := s.repo.GetAccountBalance(ctx, 1, &asOf)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), &domain.AccountBalance{
+		AccountID:         1,
+		Balance:           domain.MustParseMoney("-70"),
+		Debits:            domain.MustParseMoney("120"),
+		Credits:           domain.MustParseMoney("50"),
+		LastTransactionAt: &lastTransactionAt,
+		AsOf:              &asOf,
+	}, balance)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_GetAccountBalance_WhenNoTransactions_ShouldReturnZeroBalance() {
+	// Arrange
+	s.mock.ExpectQuery("FROM transactions").
+		WithArgs(int64(1), nil).
+		WillReturnRows(sqlmock.NewRows([]string{"balance", "debits", "credits", "last_transaction_at"}).
+			AddRow("0", "0", "0", nil))
+
+	ctx := context.Background()
+	// Act
+	balance, err := s.repo.GetAccountBalance(ctx, 1, nil)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), balance.Balance.IsZero())
+	assert.Nil(s.T(), balance.LastTransactionAt)
+	assert.Nil(s.T(), balance.AsOf)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_CreateInstallmentPurchase_WhenValidInput_ShouldStoreInstallments() {
+	// Arrange
+	purchase := domain.NewTransaction(int64(1), domain.CompraParcelada, domain.MustParseMoney("-100"))
+	installments, _ := domain.NewInstallmentSchedule(purchase.Amount(), 2, time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC), domain.BillingCycle{ClosingDay: 3, DueDay: 10})
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT status, available_credit_limit").
+		WithArgs(purchase.Amount(), purchase.AccountID()).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "has_credit_limit"}).AddRow("ACTIVE", true))
+	s.mock.ExpectExec("UPDATE accounts SET available_credit_limit").
+		WithArgs(purchase.Amount(), purchase.AccountID()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectQuery("INSERT INTO transactions").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	s.expectJournalEntry(3)
+	for _, installment := range installments {
+		s.mock.ExpectExec("INSERT INTO installments").
+			WithArgs(int64(3), installment.Number, installment.Amount, installment.DueDate).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	s.mock.ExpectCommit()
+
+	ctx := context.Background()
+	// Act
+	id, err := s.repo.CreateInstallmentPurchase(ctx, purchase, installments)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(3), id)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_CreateInstallmentPurchase_WhenInsufficientCreditLimit_ShouldReturnError() {
+	// Arrange
+	purchase := domain.NewTransaction(int64(1), domain.CompraParcelada, domain.MustParseMoney("-100"))
+	installments, _ := domain.NewInstallmentSchedule(purchase.Amount(), 2, time.Now(), domain.BillingCycle{ClosingDay: 3, DueDay: 10})
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT status, available_credit_limit").
+		WithArgs(purchase.Amount(), purchase.AccountID()).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "has_credit_limit"}).AddRow("ACTIVE", false))
+	s.mock.ExpectRollback()
+
+	ctx := context.Background()
+	// Act
+	id, err := s.repo.CreateInstallmentPurchase(ctx, purchase, installments)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, ErrInsufficientCreditLimit)
+	assert.Equal(s.T(), int64(0), id)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_ListInstallments_WhenInstallmentsExist_ShouldReturnThemInOrder() {
+	// Arrange
+	firstDue := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+	secondDue := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	s.mock.ExpectQuery("SELECT id, transaction_id, number, amount, due_date FROM installments").
+		WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "transaction_id", "number", "amount", "due_date"}).
+			AddRow(1, 3, 1, "50.00", firstDue).
+			AddRow(2, 3, 2, "50.00", secondDue))
+
+	ctx := context.Background()
+	// Act
+	installments, err := s.repo.ListInstallments(ctx, 3)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), installments, 2)
+	assert.Equal(s.T(), 2, installments[1].Number)
+	assert.Equal(s.T(), domain.MustParseMoney("50"), installments[1].Amount)
+	assert.Equal(s.T(), secondDue, installments[1].DueDate)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_CreateReversal_WhenDebitPartiallyPaid_ShouldSettleAndDischargeRemainder() {
+	// Arrange
+	eventDate := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	original := domain.NewTransaction(1, domain.CompraAVista, domain.MustParseMoney("-50"))
+	original.SetID(7)
+	reversal := domain.NewReversal(original, domain.MustParseMoney("50"), eventDate)
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT id FROM accounts WHERE id = \\$1 FOR UPDATE").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	s.mock.ExpectQuery("SELECT id, account_id, operation_type_id, amount, balance, reverses_transaction_id, invoice_id, transfer_id, explanation, event_date, created_at").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "balance", "reverses_transaction_id", "invoice_id", "transfer_id", "explanation", "event_date", "created_at", "reversed", "disputed"}).
+			AddRow(7, 1, 1, "-50.00", "-10.00", nil, nil, nil, nil, eventDate, eventDate, "0", "0"))
+	s.mock.ExpectQuery("SELECT status, available_credit_limit").
+		WithArgs(reversal.Amount(), int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "has_credit_limit"}).AddRow("ACTIVE", true))
+	s.mock.ExpectExec("UPDATE accounts SET available_credit_limit").
+		WithArgs(reversal.Amount(), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectExec("UPDATE transactions SET balance = \\$1").
+		WithArgs(domain.MustParseMoney("0"), int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectQuery("INSERT INTO transactions").
+		WithArgs(int64(1), domain.Estorno, domain.MustParseMoney("50"), domain.MustParseMoney("40"), reversal.ReversesTransactionID(), (*int64)(nil), (*int64)(nil), sql.NullString{}, eventDate).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(8))
+	s.expectJournalEntry(8)
+	s.mock.ExpectExec("WITH credit AS").
+		WithArgs(int64(1), int64(8)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectCommit()
+
+	ctx := context.Background()
+	// Act
+	id, err := s.repo.CreateReversal(ctx, reversal)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(8), id)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_CreateReversal_WhenFullyReversed_ShouldReturnErrTransactionAlreadyReversed() {
+	// Arrange
+	eventDate := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	original := domain.NewTransaction(1, domain.CompraAVista, domain.MustParseMoney("-50"))
+	original.SetID(7)
+	reversal := domain.NewReversal(original, domain.MustParseMoney("50"), eventDate)
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT id FROM accounts").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	s.mock.ExpectQuery("SELECT id, account_id, operation_type_id").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "balance", "reverses_transaction_id", "invoice_id", "transfer_id", "explanation", "event_date", "created_at", "reversed", "disputed"}).
+			AddRow(7, 1, 1, "-50.00", "0.00", nil, nil, nil, nil, eventDate, eventDate, "50.00", "0"))
+	s.mock.ExpectRollback()
+
+	ctx := context.Background()
+	// Act
+	id, err := s.repo.CreateReversal(ctx, reversal)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, ErrTransactionAlreadyReversed)
+	assert.Equal(s.T(), int64(0), id)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_CreateReversal_WhenAmountExceedsRemaining_ShouldReturnErrReversalExceedsAmount() {
+	// Arrange
+	eventDate := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	original := domain.NewTransaction(1, domain.CompraAVista, domain.MustParseMoney("-50"))
+	original.SetID(7)
+	reversal := domain.NewReversal(original, domain.MustParseMoney("30"), eventDate)
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT id FROM accounts").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	s.mock.ExpectQuery("SELECT id, account_id, operation_type_id").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "balance", "reverses_transaction_id", "invoice_id", "transfer_id", "explanation", "event_date", "created_at", "reversed", "disputed"}).
+			AddRow(7, 1, 1, "-50.00", "-20.00", nil, nil, nil, nil, eventDate, eventDate, "30.00", "0"))
+	s.mock.ExpectRollback()
+
+	ctx := context.Background()
+	// Act
+	id, err := s.repo.CreateReversal(ctx, reversal)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, ErrReversalExceedsAmount)
+	assert.EqualError(s.T(), err, "reversal exceeds the amount not yet reversed or disputed: 30.00 requested, 20.00 left")
+	assert.Equal(s.T(), int64(0), id)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_CreateReversal_WhenWholeAmountIsDisputed_ShouldReturnErrTransactionDisputed() {
+	// Arrange
+	eventDate := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	original := domain.NewTransaction(1, domain.CompraAVista, domain.MustParseMoney("-50"))
+	original.SetID(7)
+	reversal := domain.NewReversal(original, domain.MustParseMoney("50"), eventDate)
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT id FROM accounts").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	s.mock.ExpectQuery("SELECT id, account_id, operation_type_id(.|\\n)+FROM disputes d(.|\\n)+FOR UPDATE").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "balance", "reverses_transaction_id", "invoice_id", "transfer_id", "explanation", "event_date", "created_at", "reversed", "disputed"}).
+			AddRow(7, 1, 1, "-50.00", "-50.00", nil, nil, nil, nil, eventDate, eventDate, "0", "50.00"))
+	s.mock.ExpectRollback()
+
+	ctx := context.Background()
+	// Act
+	id, err := s.repo.CreateReversal(ctx, reversal)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, ErrTransactionDisputed)
+	assert.Equal(s.T(), int64(0), id)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_CreateReversal_WhenPartiallyDisputed_ShouldReturnErrReversalExceedsAmount() {
+	// Arrange
+	eventDate := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	original := domain.NewTransaction(1, domain.CompraAVista, domain.MustParseMoney("-50"))
+	original.SetID(7)
+	reversal := domain.NewReversal(original, domain.MustParseMoney("50"), eventDate)
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT id FROM accounts").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	s.mock.ExpectQuery("SELECT id, account_id, operation_type_id").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "balance", "reverses_transaction_id", "invoice_id", "transfer_id", "explanation", "event_date", "created_at", "reversed", "disputed"}).
+			AddRow(7, 1, 1, "-50.00", "-50.00", nil, nil, nil, nil, eventDate, eventDate, "0", "20.00"))
+	s.mock.ExpectRollback()
+
+	ctx := context.Background()
+	// Act
+	id, err := s.repo.CreateReversal(ctx, reversal)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, ErrReversalExceedsAmount)
+	assert.EqualError(s.T(), err, "reversal exceeds the amount not yet reversed or disputed: 50.00 requested, 30.00 left")
+	assert.Equal(s.T(), int64(0), id)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_CreateCharge_WhenValidInput_ShouldLowerLimitAndStoreCharge() {
+	// Arrange
+	day := time.Date(2025, 2, 11, 0, 0, 0, 0, time.UTC)
+	invoice := domain.Invoice{ID: 3, AccountID: 1, DueDate: time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)}
+	charge := domain.NewLateFee(invoice, domain.MustParseMoney("100"), domain.MustParseMoney("10"), day)
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT id FROM accounts WHERE id = \\$1 FOR UPDATE").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	s.mock.ExpectExec("UPDATE accounts SET available_credit_limit = CASE").
+		WithArgs(charge.Amount(), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectQuery("INSERT INTO transactions").
+		WithArgs(int64(1), domain.MultaPorAtraso, domain.MustParseMoney("-10"), domain.MustParseMoney("-10"), charge.ReversesTransactionID(), charge.InvoiceID(), charge.TransferID(), sql.NullString{String: charge.Explanation(), Valid: true}, day).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+	s.expectJournalEntry(9)
+	s.mock.ExpectCommit()
+
+	ctx := context.Background()
+	// Act
+	id, err := s.repo.CreateCharge(ctx, charge)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(9), id)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_CreateCharge_WhenAlreadyCharged_ShouldReturnErrAlreadyExists() {
+	// Arrange
+	day := time.Date(2025, 2, 11, 0, 0, 0, 0, time.UTC)
+	invoice := domain.Invoice{ID: 3, AccountID: 1, DueDate: time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)}
+	charge := domain.NewInterestCharge(invoice, domain.MustParseMoney("100"), day, domain.InterestRate(1200))
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT id FROM accounts WHERE id = \\$1 FOR UPDATE").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	s.mock.ExpectExec("UPDATE accounts SET available_credit_limit = CASE").
+		WithArgs(charge.Amount(), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectQuery("INSERT INTO transactions").
+		WillReturnError(&pq.Error{Code: pgUniqueViolation, Message: "duplicate key value violates unique constraint"})
+	s.mock.ExpectRollback()
+
+	ctx := context.Background()
+	// Act
+	id, err := s.repo.CreateCharge(ctx, charge)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, domain.ErrAlreadyExists)
+	assert.Equal(s.T(), int64(0), id)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_CreateTransfer_WhenValidInput_ShouldLockAccountsInOrderAndStoreBothLegs() {
+	// Arrange
+	createdAt := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	transfer, _ := domain.NewTransfer(2, 1, domain.MustParseMoney("30"), createdAt)
+	transferID := int64(5)
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT id FROM accounts WHERE id = \\$1 FOR UPDATE").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	s.mock.ExpectQuery("SELECT id FROM accounts WHERE id = \\$1 FOR UPDATE").
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	s.mock.ExpectQuery("INSERT INTO transfers").
+		WithArgs(int64(2), int64(1), domain.MustParseMoney("30"), createdAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(transferID))
+	s.mock.ExpectQuery("SELECT status, available_credit_limit").
+		WithArgs(domain.MustParseMoney("-30"), int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "has_credit_limit"}).AddRow("ACTIVE", true))
+	s.mock.ExpectExec("UPDATE accounts SET available_credit_limit").
+		WithArgs(domain.MustParseMoney("-30"), int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectQuery("INSERT INTO transactions").
+		WithArgs(int64(2), domain.TransferenciaEnviada, domain.MustParseMoney("-30"), domain.MustParseMoney("-30"), (*int64)(nil), (*int64)(nil), &transferID, sql.NullString{}, createdAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(11))
+	s.expectJournalEntry(11)
+	s.mock.ExpectQuery("SELECT status, available_credit_limit").
+		WithArgs(domain.MustParseMoney("30"), int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "has_credit_limit"}).AddRow("ACTIVE", true))
+	s.mock.ExpectExec("UPDATE accounts SET available_credit_limit").
+		WithArgs(domain.MustParseMoney("30"), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectQuery("INSERT INTO transactions").
+		WithArgs(int64(1), domain.TransferenciaRecebida, domain.MustParseMoney("30"), domain.MustParseMoney("30"), (*int64)(nil), (*int64)(nil), &transferID, sql.NullString{}, createdAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(12))
+	s.expectJournalEntry(12)
+	s.mock.ExpectExec("WITH credit AS").
+		WithArgs(int64(1), int64(12)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectCommit()
+
+	ctx := context.Background()
+	// Act
+	created, err := s.repo.CreateTransfer(ctx, transfer)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), transferID, created.ID)
+	assert.Equal(s.T(), int64(11), created.DebitTransactionID)
+	assert.Equal(s.T(), int64(12), created.CreditTransactionID)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_CreateTransfer_WhenInsufficientCreditLimit_ShouldRollback() {
+	// Arrange
+	transfer, _ := domain.NewTransfer(1, 2, domain.MustParseMoney("30"), time.Now())
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT id FROM accounts WHERE id = \\$1 FOR UPDATE").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	s.mock.ExpectQuery("SELECT id FROM accounts WHERE id = \\$1 FOR UPDATE").
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	s.mock.ExpectQuery("INSERT INTO transfers").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+	s.mock.ExpectQuery("SELECT status, available_credit_limit").
+		WithArgs(domain.MustParseMoney("-30"), int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "has_credit_limit"}).AddRow("ACTIVE", false))
+	s.mock.ExpectRollback()
+
+	ctx := context.Background()
+	// Act
+	created, err := s.repo.CreateTransfer(ctx, transfer)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, ErrInsufficientCreditLimit)
+	assert.Nil(s.T(), created)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionRepository_CreateTransfer_WhenAccountDoesNotExist_ShouldReturnErrAccountNotFound() {
+	// Arrange
+	transfer, _ := domain.NewTransfer(1, 2, domain.MustParseMoney("30"), time.Now())
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery("SELECT id FROM accounts WHERE id = \\$1 FOR UPDATE").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	s.mock.ExpectQuery("SELECT id FROM accounts WHERE id = \\$1 FOR UPDATE").
+		WithArgs(int64(2)).
+		WillReturnError(sql.ErrNoRows)
+	s.mock.ExpectRollback()
+
+	ctx := context.Background()
+	// Act
+	created, err := s.repo.CreateTransfer(ctx, transfer)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, ErrAccountNotFound)
+	assert.Nil(s.T(), created)
+	assert.NoError(s.T(), s.mock.ExpectationsWereMet())
+}
+
+// expectJournalEntry expects the journal entry posted along with the transaction.
+func (s *TransactionRepositoryTestSuite) expectJournalEntry(transactionID int64) {
+	s.mock.ExpectQuery("INSERT INTO journal_entries").
+		WithArgs(transactionID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(transactionID))
+	s.mock.ExpectExec("INSERT INTO postings").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+}
@@ -0,0 +1,69 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VieiraVitor/transaction-flow/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ServiceName = "transaction-flow"
+
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// Tracer creates the spans of the application. It delegates to the provider installed by
+// InitTracing, so it can be used before tracing is initialized.
+var Tracer = otel.Tracer("github.com/VieiraVitor/transaction-flow")
+
+// InitTracing installs the global tracer provider and the W3C trace context propagator. Spans
+// are sent to the exporter chosen in cfg; with ExporterNone trace ids are still generated and
+// propagated, so logs stay correlated, but spans are dropped. The returned function flushes
+// the pending spans and must be called on shutdown.
+func InitTracing(ctx context.Context, cfg *config.Config) (func(context.Context) error, error) {
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(ServiceName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing resource: %w", err)
+	}
+
+	options := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+	switch cfg.TracingExporter {
+	case ExporterNone, "":
+	case ExporterStdout:
+		exporter, err := stdouttrace.New()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stdout exporter: %w", err)
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	case ExporterOTLP:
+		exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q, expected one of none, stdout or otlp", cfg.TracingExporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// StartSpan starts a child span of the one in ctx, if any.
+func StartSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return Tracer.Start(ctx, name)
+}
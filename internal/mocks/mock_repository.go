@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/VieiraVitor/transaction-flow/internal/domain"
 	gomock "github.com/golang/mock/gomock"
@@ -65,6 +66,63 @@ func (mr *MockAccountRepositoryMockRecorder) GetAccount(ctx, accountID interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockAccountRepository)(nil).GetAccount), ctx, accountID)
 }
 
+// ListStatusChanges mocks base method.
+func (m *MockAccountRepository) ListStatusChanges(ctx context.Context, accountID int64) ([]domain.AccountStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStatusChanges", ctx, accountID)
+	ret0, _ := ret[0].([]domain.AccountStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStatusChanges indicates an expected call of ListStatusChanges.
+func (mr *MockAccountRepositoryMockRecorder) ListStatusChanges(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStatusChanges", reflect.TypeOf((*MockAccountRepository)(nil).ListStatusChanges), ctx, accountID)
+}
+
+// UpdateBillingCycle mocks base method.
+func (m *MockAccountRepository) UpdateBillingCycle(ctx context.Context, accountID int64, billingCycle domain.BillingCycle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBillingCycle", ctx, accountID, billingCycle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBillingCycle indicates an expected call of UpdateBillingCycle.
+func (mr *MockAccountRepositoryMockRecorder) UpdateBillingCycle(ctx, accountID, billingCycle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBillingCycle", reflect.TypeOf((*MockAccountRepository)(nil).UpdateBillingCycle), ctx, accountID, billingCycle)
+}
+
+// UpdateCreditLimit mocks base method.
+func (m *MockAccountRepository) UpdateCreditLimit(ctx context.Context, accountID int64, creditLimit domain.Money) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCreditLimit", ctx, accountID, creditLimit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCreditLimit indicates an expected call of UpdateCreditLimit.
+func (mr *MockAccountRepositoryMockRecorder) UpdateCreditLimit(ctx, accountID, creditLimit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCreditLimit", reflect.TypeOf((*MockAccountRepository)(nil).UpdateCreditLimit), ctx, accountID, creditLimit)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockAccountRepository) UpdateStatus(ctx context.Context, change domain.AccountStatusChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockAccountRepositoryMockRecorder) UpdateStatus(ctx, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockAccountRepository)(nil).UpdateStatus), ctx, change)
+}
+
 // MockTransactionRepository is a mock of TransactionRepository interface.
 type MockTransactionRepository struct {
 	ctrl     *gomock.Controller
@@ -88,6 +146,51 @@ func (m *MockTransactionRepository) EXPECT() *MockTransactionRepositoryMockRecor
 	return m.recorder
 }
 
+// CreateCharge mocks base method.
+func (m *MockTransactionRepository) CreateCharge(ctx context.Context, charge domain.Transaction) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCharge", ctx, charge)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCharge indicates an expected call of CreateCharge.
+func (mr *MockTransactionRepositoryMockRecorder) CreateCharge(ctx, charge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCharge", reflect.TypeOf((*MockTransactionRepository)(nil).CreateCharge), ctx, charge)
+}
+
+// CreateInstallmentPurchase mocks base method.
+func (m *MockTransactionRepository) CreateInstallmentPurchase(ctx context.Context, purchase domain.Transaction, installments []domain.Installment) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInstallmentPurchase", ctx, purchase, installments)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInstallmentPurchase indicates an expected call of CreateInstallmentPurchase.
+func (mr *MockTransactionRepositoryMockRecorder) CreateInstallmentPurchase(ctx, purchase, installments interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInstallmentPurchase", reflect.TypeOf((*MockTransactionRepository)(nil).CreateInstallmentPurchase), ctx, purchase, installments)
+}
+
+// CreateReversal mocks base method.
+func (m *MockTransactionRepository) CreateReversal(ctx context.Context, reversal domain.Transaction) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReversal", ctx, reversal)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReversal indicates an expected call of CreateReversal.
+func (mr *MockTransactionRepositoryMockRecorder) CreateReversal(ctx, reversal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReversal", reflect.TypeOf((*MockTransactionRepository)(nil).CreateReversal), ctx, reversal)
+}
+
 // CreateTransaction mocks base method.
 func (m *MockTransactionRepository) CreateTransaction(ctx context.Context, transaction domain.Transaction) (int64, error) {
 	m.ctrl.T.Helper()
@@ -102,3 +205,640 @@ func (mr *MockTransactionRepositoryMockRecorder) CreateTransaction(ctx, transact
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransaction", reflect.TypeOf((*MockTransactionRepository)(nil).CreateTransaction), ctx, transaction)
 }
+
+// CreateTransfer mocks base method.
+func (m *MockTransactionRepository) CreateTransfer(ctx context.Context, transfer domain.Transfer) (*domain.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransfer", ctx, transfer)
+	ret0, _ := ret[0].(*domain.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransfer indicates an expected call of CreateTransfer.
+func (mr *MockTransactionRepositoryMockRecorder) CreateTransfer(ctx, transfer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockTransactionRepository)(nil).CreateTransfer), ctx, transfer)
+}
+
+// GetAccountBalance mocks base method.
+func (m *MockTransactionRepository) GetAccountBalance(ctx context.Context, accountID int64, asOf *time.Time) (*domain.AccountBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountBalance", ctx, accountID, asOf)
+	ret0, _ := ret[0].(*domain.AccountBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountBalance indicates an expected call of GetAccountBalance.
+func (mr *MockTransactionRepositoryMockRecorder) GetAccountBalance(ctx, accountID, asOf interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountBalance", reflect.TypeOf((*MockTransactionRepository)(nil).GetAccountBalance), ctx, accountID, asOf)
+}
+
+// GetTransaction mocks base method.
+func (m *MockTransactionRepository) GetTransaction(ctx context.Context, transactionID int64) (*domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransaction", ctx, transactionID)
+	ret0, _ := ret[0].(*domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransaction indicates an expected call of GetTransaction.
+func (mr *MockTransactionRepositoryMockRecorder) GetTransaction(ctx, transactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransaction", reflect.TypeOf((*MockTransactionRepository)(nil).GetTransaction), ctx, transactionID)
+}
+
+// ListInstallments mocks base method.
+func (m *MockTransactionRepository) ListInstallments(ctx context.Context, transactionID int64) ([]domain.Installment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInstallments", ctx, transactionID)
+	ret0, _ := ret[0].([]domain.Installment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInstallments indicates an expected call of ListInstallments.
+func (mr *MockTransactionRepositoryMockRecorder) ListInstallments(ctx, transactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstallments", reflect.TypeOf((*MockTransactionRepository)(nil).ListInstallments), ctx, transactionID)
+}
+
+// ListTransactions mocks base method.
+func (m *MockTransactionRepository) ListTransactions(ctx context.Context, filter domain.TransactionFilter) ([]domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransactions", ctx, filter)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransactions indicates an expected call of ListTransactions.
+func (mr *MockTransactionRepositoryMockRecorder) ListTransactions(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactions", reflect.TypeOf((*MockTransactionRepository)(nil).ListTransactions), ctx, filter)
+}
+
+// MockInvoiceRepository is a mock of InvoiceRepository interface.
+type MockInvoiceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvoiceRepositoryMockRecorder
+}
+
+// MockInvoiceRepositoryMockRecorder is the mock recorder for MockInvoiceRepository.
+type MockInvoiceRepositoryMockRecorder struct {
+	mock *MockInvoiceRepository
+}
+
+// NewMockInvoiceRepository creates a new mock instance.
+func NewMockInvoiceRepository(ctrl *gomock.Controller) *MockInvoiceRepository {
+	mock := &MockInvoiceRepository{ctrl: ctrl}
+	mock.recorder = &MockInvoiceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvoiceRepository) EXPECT() *MockInvoiceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateInvoice mocks base method.
+func (m *MockInvoiceRepository) CreateInvoice(ctx context.Context, invoice domain.Invoice) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvoice", ctx, invoice)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInvoice indicates an expected call of CreateInvoice.
+func (mr *MockInvoiceRepositoryMockRecorder) CreateInvoice(ctx, invoice interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvoice", reflect.TypeOf((*MockInvoiceRepository)(nil).CreateInvoice), ctx, invoice)
+}
+
+// GetInvoice mocks base method.
+func (m *MockInvoiceRepository) GetInvoice(ctx context.Context, invoiceID int64) (*domain.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoice", ctx, invoiceID)
+	ret0, _ := ret[0].(*domain.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoice indicates an expected call of GetInvoice.
+func (mr *MockInvoiceRepositoryMockRecorder) GetInvoice(ctx, invoiceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoice", reflect.TypeOf((*MockInvoiceRepository)(nil).GetInvoice), ctx, invoiceID)
+}
+
+// ListBillableItems mocks base method.
+func (m *MockInvoiceRepository) ListBillableItems(ctx context.Context, accountID int64, periodStart, closingDate, installmentsDueUntil time.Time) ([]domain.InvoiceItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBillableItems", ctx, accountID, periodStart, closingDate, installmentsDueUntil)
+	ret0, _ := ret[0].([]domain.InvoiceItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBillableItems indicates an expected call of ListBillableItems.
+func (mr *MockInvoiceRepositoryMockRecorder) ListBillableItems(ctx, accountID, periodStart, closingDate, installmentsDueUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBillableItems", reflect.TypeOf((*MockInvoiceRepository)(nil).ListBillableItems), ctx, accountID, periodStart, closingDate, installmentsDueUntil)
+}
+
+// ListBillingStates mocks base method.
+func (m *MockInvoiceRepository) ListBillingStates(ctx context.Context) ([]domain.BillingState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBillingStates", ctx)
+	ret0, _ := ret[0].([]domain.BillingState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBillingStates indicates an expected call of ListBillingStates.
+func (mr *MockInvoiceRepositoryMockRecorder) ListBillingStates(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBillingStates", reflect.TypeOf((*MockInvoiceRepository)(nil).ListBillingStates), ctx)
+}
+
+// ListInvoices mocks base method.
+func (m *MockInvoiceRepository) ListInvoices(ctx context.Context, accountID int64) ([]domain.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInvoices", ctx, accountID)
+	ret0, _ := ret[0].([]domain.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInvoices indicates an expected call of ListInvoices.
+func (mr *MockInvoiceRepositoryMockRecorder) ListInvoices(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvoices", reflect.TypeOf((*MockInvoiceRepository)(nil).ListInvoices), ctx, accountID)
+}
+
+// ListOverdueInvoices mocks base method.
+func (m *MockInvoiceRepository) ListOverdueInvoices(ctx context.Context, day time.Time) ([]domain.OverdueInvoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOverdueInvoices", ctx, day)
+	ret0, _ := ret[0].([]domain.OverdueInvoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOverdueInvoices indicates an expected call of ListOverdueInvoices.
+func (mr *MockInvoiceRepositoryMockRecorder) ListOverdueInvoices(ctx, day interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOverdueInvoices", reflect.TypeOf((*MockInvoiceRepository)(nil).ListOverdueInvoices), ctx, day)
+}
+
+// SumPayments mocks base method.
+func (m *MockInvoiceRepository) SumPayments(ctx context.Context, accountID int64, from, to time.Time) (domain.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumPayments", ctx, accountID, from, to)
+	ret0, _ := ret[0].(domain.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumPayments indicates an expected call of SumPayments.
+func (mr *MockInvoiceRepositoryMockRecorder) SumPayments(ctx, accountID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumPayments", reflect.TypeOf((*MockInvoiceRepository)(nil).SumPayments), ctx, accountID, from, to)
+}
+
+// MockAuthorizationRepository is a mock of AuthorizationRepository interface.
+type MockAuthorizationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorizationRepositoryMockRecorder
+}
+
+// MockAuthorizationRepositoryMockRecorder is the mock recorder for MockAuthorizationRepository.
+type MockAuthorizationRepositoryMockRecorder struct {
+	mock *MockAuthorizationRepository
+}
+
+// NewMockAuthorizationRepository creates a new mock instance.
+func NewMockAuthorizationRepository(ctrl *gomock.Controller) *MockAuthorizationRepository {
+	mock := &MockAuthorizationRepository{ctrl: ctrl}
+	mock.recorder = &MockAuthorizationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorizationRepository) EXPECT() *MockAuthorizationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CaptureAuthorization mocks base method.
+func (m *MockAuthorizationRepository) CaptureAuthorization(ctx context.Context, authorization domain.Authorization, purchase domain.Transaction) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CaptureAuthorization", ctx, authorization, purchase)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CaptureAuthorization indicates an expected call of CaptureAuthorization.
+func (mr *MockAuthorizationRepositoryMockRecorder) CaptureAuthorization(ctx, authorization, purchase interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CaptureAuthorization", reflect.TypeOf((*MockAuthorizationRepository)(nil).CaptureAuthorization), ctx, authorization, purchase)
+}
+
+// CreateAuthorization mocks base method.
+func (m *MockAuthorizationRepository) CreateAuthorization(ctx context.Context, authorization domain.Authorization) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuthorization", ctx, authorization)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuthorization indicates an expected call of CreateAuthorization.
+func (mr *MockAuthorizationRepositoryMockRecorder) CreateAuthorization(ctx, authorization interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuthorization", reflect.TypeOf((*MockAuthorizationRepository)(nil).CreateAuthorization), ctx, authorization)
+}
+
+// GetAuthorization mocks base method.
+func (m *MockAuthorizationRepository) GetAuthorization(ctx context.Context, authorizationID int64) (*domain.Authorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorization", ctx, authorizationID)
+	ret0, _ := ret[0].(*domain.Authorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthorization indicates an expected call of GetAuthorization.
+func (mr *MockAuthorizationRepositoryMockRecorder) GetAuthorization(ctx, authorizationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorization", reflect.TypeOf((*MockAuthorizationRepository)(nil).GetAuthorization), ctx, authorizationID)
+}
+
+// ListExpiredAuthorizations mocks base method.
+func (m *MockAuthorizationRepository) ListExpiredAuthorizations(ctx context.Context, now time.Time) ([]domain.Authorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredAuthorizations", ctx, now)
+	ret0, _ := ret[0].([]domain.Authorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredAuthorizations indicates an expected call of ListExpiredAuthorizations.
+func (mr *MockAuthorizationRepositoryMockRecorder) ListExpiredAuthorizations(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredAuthorizations", reflect.TypeOf((*MockAuthorizationRepository)(nil).ListExpiredAuthorizations), ctx, now)
+}
+
+// ReleaseAuthorization mocks base method.
+func (m *MockAuthorizationRepository) ReleaseAuthorization(ctx context.Context, authorization domain.Authorization) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseAuthorization", ctx, authorization)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseAuthorization indicates an expected call of ReleaseAuthorization.
+func (mr *MockAuthorizationRepositoryMockRecorder) ReleaseAuthorization(ctx, authorization interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseAuthorization", reflect.TypeOf((*MockAuthorizationRepository)(nil).ReleaseAuthorization), ctx, authorization)
+}
+
+// MockDisputeRepository is a mock of DisputeRepository interface.
+type MockDisputeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDisputeRepositoryMockRecorder
+}
+
+// MockDisputeRepositoryMockRecorder is the mock recorder for MockDisputeRepository.
+type MockDisputeRepositoryMockRecorder struct {
+	mock *MockDisputeRepository
+}
+
+// NewMockDisputeRepository creates a new mock instance.
+func NewMockDisputeRepository(ctrl *gomock.Controller) *MockDisputeRepository {
+	mock := &MockDisputeRepository{ctrl: ctrl}
+	mock.recorder = &MockDisputeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDisputeRepository) EXPECT() *MockDisputeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetDispute mocks base method.
+func (m *MockDisputeRepository) GetDispute(ctx context.Context, disputeID int64) (*domain.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDispute", ctx, disputeID)
+	ret0, _ := ret[0].(*domain.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDispute indicates an expected call of GetDispute.
+func (mr *MockDisputeRepositoryMockRecorder) GetDispute(ctx, disputeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDispute", reflect.TypeOf((*MockDisputeRepository)(nil).GetDispute), ctx, disputeID)
+}
+
+// ListDisputes mocks base method.
+func (m *MockDisputeRepository) ListDisputes(ctx context.Context, transactionID int64) ([]domain.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDisputes", ctx, transactionID)
+	ret0, _ := ret[0].([]domain.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDisputes indicates an expected call of ListDisputes.
+func (mr *MockDisputeRepositoryMockRecorder) ListDisputes(ctx, transactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDisputes", reflect.TypeOf((*MockDisputeRepository)(nil).ListDisputes), ctx, transactionID)
+}
+
+// OpenDispute mocks base method.
+func (m *MockDisputeRepository) OpenDispute(ctx context.Context, dispute domain.Dispute, credit domain.Transaction) (*domain.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenDispute", ctx, dispute, credit)
+	ret0, _ := ret[0].(*domain.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenDispute indicates an expected call of OpenDispute.
+func (mr *MockDisputeRepositoryMockRecorder) OpenDispute(ctx, dispute, credit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenDispute", reflect.TypeOf((*MockDisputeRepository)(nil).OpenDispute), ctx, dispute, credit)
+}
+
+// ResolveDispute mocks base method.
+func (m *MockDisputeRepository) ResolveDispute(ctx context.Context, dispute domain.Dispute, reversal *domain.Transaction) (*domain.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveDispute", ctx, dispute, reversal)
+	ret0, _ := ret[0].(*domain.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveDispute indicates an expected call of ResolveDispute.
+func (mr *MockDisputeRepositoryMockRecorder) ResolveDispute(ctx, dispute, reversal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveDispute", reflect.TypeOf((*MockDisputeRepository)(nil).ResolveDispute), ctx, dispute, reversal)
+}
+
+// MockLedgerRepository is a mock of LedgerRepository interface.
+type MockLedgerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLedgerRepositoryMockRecorder
+}
+
+// MockLedgerRepositoryMockRecorder is the mock recorder for MockLedgerRepository.
+type MockLedgerRepositoryMockRecorder struct {
+	mock *MockLedgerRepository
+}
+
+// NewMockLedgerRepository creates a new mock instance.
+func NewMockLedgerRepository(ctrl *gomock.Controller) *MockLedgerRepository {
+	mock := &MockLedgerRepository{ctrl: ctrl}
+	mock.recorder = &MockLedgerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLedgerRepository) EXPECT() *MockLedgerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetTrialBalance mocks base method.
+func (m *MockLedgerRepository) GetTrialBalance(ctx context.Context, asOf *time.Time) ([]domain.TrialBalanceLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrialBalance", ctx, asOf)
+	ret0, _ := ret[0].([]domain.TrialBalanceLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrialBalance indicates an expected call of GetTrialBalance.
+func (mr *MockLedgerRepositoryMockRecorder) GetTrialBalance(ctx, asOf interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrialBalance", reflect.TypeOf((*MockLedgerRepository)(nil).GetTrialBalance), ctx, asOf)
+}
+
+// MockHealthRepository is a mock of HealthRepository interface.
+type MockHealthRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthRepositoryMockRecorder
+}
+
+// MockHealthRepositoryMockRecorder is the mock recorder for MockHealthRepository.
+type MockHealthRepositoryMockRecorder struct {
+	mock *MockHealthRepository
+}
+
+// NewMockHealthRepository creates a new mock instance.
+func NewMockHealthRepository(ctrl *gomock.Controller) *MockHealthRepository {
+	mock := &MockHealthRepository{ctrl: ctrl}
+	mock.recorder = &MockHealthRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthRepository) EXPECT() *MockHealthRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetMigrationVersion mocks base method.
+func (m *MockHealthRepository) GetMigrationVersion(ctx context.Context) (uint, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMigrationVersion", ctx)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMigrationVersion indicates an expected call of GetMigrationVersion.
+func (mr *MockHealthRepositoryMockRecorder) GetMigrationVersion(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMigrationVersion", reflect.TypeOf((*MockHealthRepository)(nil).GetMigrationVersion), ctx)
+}
+
+// Ping mocks base method.
+func (m *MockHealthRepository) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockHealthRepositoryMockRecorder) Ping(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockHealthRepository)(nil).Ping), ctx)
+}
+
+// MockIdempotencyRepository is a mock of IdempotencyRepository interface.
+type MockIdempotencyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyRepositoryMockRecorder
+}
+
+// MockIdempotencyRepositoryMockRecorder is the mock recorder for MockIdempotencyRepository.
+type MockIdempotencyRepositoryMockRecorder struct {
+	mock *MockIdempotencyRepository
+}
+
+// NewMockIdempotencyRepository creates a new mock instance.
+func NewMockIdempotencyRepository(ctrl *gomock.Controller) *MockIdempotencyRepository {
+	mock := &MockIdempotencyRepository{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyRepository) EXPECT() *MockIdempotencyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteExpiredKeys mocks base method.
+func (m *MockIdempotencyRepository) DeleteExpiredKeys(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredKeys", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredKeys indicates an expected call of DeleteExpiredKeys.
+func (mr *MockIdempotencyRepositoryMockRecorder) DeleteExpiredKeys(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredKeys", reflect.TypeOf((*MockIdempotencyRepository)(nil).DeleteExpiredKeys), ctx)
+}
+
+// ReleaseKey mocks base method.
+func (m *MockIdempotencyRepository) ReleaseKey(ctx context.Context, scope, key, claimToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseKey", ctx, scope, key, claimToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseKey indicates an expected call of ReleaseKey.
+func (mr *MockIdempotencyRepositoryMockRecorder) ReleaseKey(ctx, scope, key, claimToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseKey", reflect.TypeOf((*MockIdempotencyRepository)(nil).ReleaseKey), ctx, scope, key, claimToken)
+}
+
+// ReserveKey mocks base method.
+func (m *MockIdempotencyRepository) ReserveKey(ctx context.Context, scope, key, requestHash, claimToken string, ttl, lease time.Duration) (*domain.IdempotentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveKey", ctx, scope, key, requestHash, claimToken, ttl, lease)
+	ret0, _ := ret[0].(*domain.IdempotentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveKey indicates an expected call of ReserveKey.
+func (mr *MockIdempotencyRepositoryMockRecorder) ReserveKey(ctx, scope, key, requestHash, claimToken, ttl, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveKey", reflect.TypeOf((*MockIdempotencyRepository)(nil).ReserveKey), ctx, scope, key, requestHash, claimToken, ttl, lease)
+}
+
+// SaveResponse mocks base method.
+func (m *MockIdempotencyRepository) SaveResponse(ctx context.Context, scope, key, claimToken string, response domain.IdempotentResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveResponse", ctx, scope, key, claimToken, response)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveResponse indicates an expected call of SaveResponse.
+func (mr *MockIdempotencyRepositoryMockRecorder) SaveResponse(ctx, scope, key, claimToken, response interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveResponse", reflect.TypeOf((*MockIdempotencyRepository)(nil).SaveResponse), ctx, scope, key, claimToken, response)
+}
+
+// MockOperationTypeRepository is a mock of OperationTypeRepository interface.
+type MockOperationTypeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOperationTypeRepositoryMockRecorder
+}
+
+// MockOperationTypeRepositoryMockRecorder is the mock recorder for MockOperationTypeRepository.
+type MockOperationTypeRepositoryMockRecorder struct {
+	mock *MockOperationTypeRepository
+}
+
+// NewMockOperationTypeRepository creates a new mock instance.
+func NewMockOperationTypeRepository(ctrl *gomock.Controller) *MockOperationTypeRepository {
+	mock := &MockOperationTypeRepository{ctrl: ctrl}
+	mock.recorder = &MockOperationTypeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperationTypeRepository) EXPECT() *MockOperationTypeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateOperationType mocks base method.
+func (m *MockOperationTypeRepository) CreateOperationType(ctx context.Context, operationType *domain.OperationTypeDefinition) (domain.OperationType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOperationType", ctx, operationType)
+	ret0, _ := ret[0].(domain.OperationType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOperationType indicates an expected call of CreateOperationType.
+func (mr *MockOperationTypeRepositoryMockRecorder) CreateOperationType(ctx, operationType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOperationType", reflect.TypeOf((*MockOperationTypeRepository)(nil).CreateOperationType), ctx, operationType)
+}
+
+// GetOperationType mocks base method.
+func (m *MockOperationTypeRepository) GetOperationType(ctx context.Context, id domain.OperationType) (*domain.OperationTypeDefinition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOperationType", ctx, id)
+	ret0, _ := ret[0].(*domain.OperationTypeDefinition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOperationType indicates an expected call of GetOperationType.
+func (mr *MockOperationTypeRepositoryMockRecorder) GetOperationType(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOperationType", reflect.TypeOf((*MockOperationTypeRepository)(nil).GetOperationType), ctx, id)
+}
+
+// ListOperationTypes mocks base method.
+func (m *MockOperationTypeRepository) ListOperationTypes(ctx context.Context) ([]domain.OperationTypeDefinition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOperationTypes", ctx)
+	ret0, _ := ret[0].([]domain.OperationTypeDefinition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOperationTypes indicates an expected call of ListOperationTypes.
+func (mr *MockOperationTypeRepositoryMockRecorder) ListOperationTypes(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOperationTypes", reflect.TypeOf((*MockOperationTypeRepository)(nil).ListOperationTypes), ctx)
+}
+
+// UpdateOperationType mocks base method.
+func (m *MockOperationTypeRepository) UpdateOperationType(ctx context.Context, operationType *domain.OperationTypeDefinition) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOperationType", ctx, operationType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOperationType indicates an expected call of UpdateOperationType.
+func (mr *MockOperationTypeRepositoryMockRecorder) UpdateOperationType(ctx, operationType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOperationType", reflect.TypeOf((*MockOperationTypeRepository)(nil).UpdateOperationType), ctx, operationType)
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/VieiraVitor/transaction-flow/internal/domain"
 	gomock "github.com/golang/mock/gomock"
@@ -36,18 +37,18 @@ func (m *MockAccountUseCase) EXPECT() *MockAccountUseCaseMockRecorder {
 }
 
 // CreateAccount mocks base method.
-func (m *MockAccountUseCase) CreateAccount(ctx context.Context, documentNumber string) (int64, error) {
+func (m *MockAccountUseCase) CreateAccount(ctx context.Context, documentNumber string, availableCreditLimit domain.Money) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateAccount", ctx, documentNumber)
+	ret := m.ctrl.Call(m, "CreateAccount", ctx, documentNumber, availableCreditLimit)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateAccount indicates an expected call of CreateAccount.
-func (mr *MockAccountUseCaseMockRecorder) CreateAccount(ctx, documentNumber interface{}) *gomock.Call {
+func (mr *MockAccountUseCaseMockRecorder) CreateAccount(ctx, documentNumber, availableCreditLimit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockAccountUseCase)(nil).CreateAccount), ctx, documentNumber)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockAccountUseCase)(nil).CreateAccount), ctx, documentNumber, availableCreditLimit)
 }
 
 // GetAccount mocks base method.
@@ -65,6 +66,63 @@ func (mr *MockAccountUseCaseMockRecorder) GetAccount(ctx, accountID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockAccountUseCase)(nil).GetAccount), ctx, accountID)
 }
 
+// ListStatusChanges mocks base method.
+func (m *MockAccountUseCase) ListStatusChanges(ctx context.Context, accountID int64) ([]domain.AccountStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStatusChanges", ctx, accountID)
+	ret0, _ := ret[0].([]domain.AccountStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStatusChanges indicates an expected call of ListStatusChanges.
+func (mr *MockAccountUseCaseMockRecorder) ListStatusChanges(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStatusChanges", reflect.TypeOf((*MockAccountUseCase)(nil).ListStatusChanges), ctx, accountID)
+}
+
+// UpdateBillingCycle mocks base method.
+func (m *MockAccountUseCase) UpdateBillingCycle(ctx context.Context, accountID int64, closingDay, dueDay int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBillingCycle", ctx, accountID, closingDay, dueDay)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBillingCycle indicates an expected call of UpdateBillingCycle.
+func (mr *MockAccountUseCaseMockRecorder) UpdateBillingCycle(ctx, accountID, closingDay, dueDay interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBillingCycle", reflect.TypeOf((*MockAccountUseCase)(nil).UpdateBillingCycle), ctx, accountID, closingDay, dueDay)
+}
+
+// UpdateCreditLimit mocks base method.
+func (m *MockAccountUseCase) UpdateCreditLimit(ctx context.Context, accountID int64, creditLimit domain.Money) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCreditLimit", ctx, accountID, creditLimit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCreditLimit indicates an expected call of UpdateCreditLimit.
+func (mr *MockAccountUseCaseMockRecorder) UpdateCreditLimit(ctx, accountID, creditLimit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCreditLimit", reflect.TypeOf((*MockAccountUseCase)(nil).UpdateCreditLimit), ctx, accountID, creditLimit)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockAccountUseCase) UpdateStatus(ctx context.Context, accountID int64, status domain.AccountStatus, reason, actor string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, accountID, status, reason, actor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockAccountUseCaseMockRecorder) UpdateStatus(ctx, accountID, status, reason, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockAccountUseCase)(nil).UpdateStatus), ctx, accountID, status, reason, actor)
+}
+
 // MockTransactionUseCase is a mock of TransactionUseCase interface.
 type MockTransactionUseCase struct {
 	ctrl     *gomock.Controller
@@ -88,8 +146,23 @@ func (m *MockTransactionUseCase) EXPECT() *MockTransactionUseCaseMockRecorder {
 	return m.recorder
 }
 
+// CreateInstallmentPurchase mocks base method.
+func (m *MockTransactionUseCase) CreateInstallmentPurchase(ctx context.Context, accountID int64, amount domain.Money, installments int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInstallmentPurchase", ctx, accountID, amount, installments)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInstallmentPurchase indicates an expected call of CreateInstallmentPurchase.
+func (mr *MockTransactionUseCaseMockRecorder) CreateInstallmentPurchase(ctx, accountID, amount, installments interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInstallmentPurchase", reflect.TypeOf((*MockTransactionUseCase)(nil).CreateInstallmentPurchase), ctx, accountID, amount, installments)
+}
+
 // CreateTransaction mocks base method.
-func (m *MockTransactionUseCase) CreateTransaction(ctx context.Context, accountID int64, operationTypeID int, amount float64) (int64, error) {
+func (m *MockTransactionUseCase) CreateTransaction(ctx context.Context, accountID int64, operationTypeID int, amount domain.Money) (int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateTransaction", ctx, accountID, operationTypeID, amount)
 	ret0, _ := ret[0].(int64)
@@ -102,3 +175,618 @@ func (mr *MockTransactionUseCaseMockRecorder) CreateTransaction(ctx, accountID,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransaction", reflect.TypeOf((*MockTransactionUseCase)(nil).CreateTransaction), ctx, accountID, operationTypeID, amount)
 }
+
+// CreateTransfer mocks base method.
+func (m *MockTransactionUseCase) CreateTransfer(ctx context.Context, fromAccountID, toAccountID int64, amount domain.Money) (*domain.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransfer", ctx, fromAccountID, toAccountID, amount)
+	ret0, _ := ret[0].(*domain.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransfer indicates an expected call of CreateTransfer.
+func (mr *MockTransactionUseCaseMockRecorder) CreateTransfer(ctx, fromAccountID, toAccountID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockTransactionUseCase)(nil).CreateTransfer), ctx, fromAccountID, toAccountID, amount)
+}
+
+// GetAccountBalance mocks base method.
+func (m *MockTransactionUseCase) GetAccountBalance(ctx context.Context, accountID int64, asOf *time.Time) (*domain.AccountBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountBalance", ctx, accountID, asOf)
+	ret0, _ := ret[0].(*domain.AccountBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountBalance indicates an expected call of GetAccountBalance.
+func (mr *MockTransactionUseCaseMockRecorder) GetAccountBalance(ctx, accountID, asOf interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountBalance", reflect.TypeOf((*MockTransactionUseCase)(nil).GetAccountBalance), ctx, accountID, asOf)
+}
+
+// GetTransaction mocks base method.
+func (m *MockTransactionUseCase) GetTransaction(ctx context.Context, transactionID int64) (*domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransaction", ctx, transactionID)
+	ret0, _ := ret[0].(*domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransaction indicates an expected call of GetTransaction.
+func (mr *MockTransactionUseCaseMockRecorder) GetTransaction(ctx, transactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransaction", reflect.TypeOf((*MockTransactionUseCase)(nil).GetTransaction), ctx, transactionID)
+}
+
+// ListInstallments mocks base method.
+func (m *MockTransactionUseCase) ListInstallments(ctx context.Context, transactionID int64) ([]domain.Installment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInstallments", ctx, transactionID)
+	ret0, _ := ret[0].([]domain.Installment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInstallments indicates an expected call of ListInstallments.
+func (mr *MockTransactionUseCaseMockRecorder) ListInstallments(ctx, transactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstallments", reflect.TypeOf((*MockTransactionUseCase)(nil).ListInstallments), ctx, transactionID)
+}
+
+// ListTransactions mocks base method.
+func (m *MockTransactionUseCase) ListTransactions(ctx context.Context, filter domain.TransactionFilter) ([]domain.Transaction, *domain.TransactionCursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransactions", ctx, filter)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(*domain.TransactionCursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListTransactions indicates an expected call of ListTransactions.
+func (mr *MockTransactionUseCaseMockRecorder) ListTransactions(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactions", reflect.TypeOf((*MockTransactionUseCase)(nil).ListTransactions), ctx, filter)
+}
+
+// ReverseTransaction mocks base method.
+func (m *MockTransactionUseCase) ReverseTransaction(ctx context.Context, transactionID int64, amount *domain.Money) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReverseTransaction", ctx, transactionID, amount)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReverseTransaction indicates an expected call of ReverseTransaction.
+func (mr *MockTransactionUseCaseMockRecorder) ReverseTransaction(ctx, transactionID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReverseTransaction", reflect.TypeOf((*MockTransactionUseCase)(nil).ReverseTransaction), ctx, transactionID, amount)
+}
+
+// MockInvoiceUseCase is a mock of InvoiceUseCase interface.
+type MockInvoiceUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvoiceUseCaseMockRecorder
+}
+
+// MockInvoiceUseCaseMockRecorder is the mock recorder for MockInvoiceUseCase.
+type MockInvoiceUseCaseMockRecorder struct {
+	mock *MockInvoiceUseCase
+}
+
+// NewMockInvoiceUseCase creates a new mock instance.
+func NewMockInvoiceUseCase(ctrl *gomock.Controller) *MockInvoiceUseCase {
+	mock := &MockInvoiceUseCase{ctrl: ctrl}
+	mock.recorder = &MockInvoiceUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvoiceUseCase) EXPECT() *MockInvoiceUseCaseMockRecorder {
+	return m.recorder
+}
+
+// CloseInvoices mocks base method.
+func (m *MockInvoiceUseCase) CloseInvoices(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseInvoices", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseInvoices indicates an expected call of CloseInvoices.
+func (mr *MockInvoiceUseCaseMockRecorder) CloseInvoices(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseInvoices", reflect.TypeOf((*MockInvoiceUseCase)(nil).CloseInvoices), ctx, now)
+}
+
+// GetInvoice mocks base method.
+func (m *MockInvoiceUseCase) GetInvoice(ctx context.Context, invoiceID int64) (*domain.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoice", ctx, invoiceID)
+	ret0, _ := ret[0].(*domain.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoice indicates an expected call of GetInvoice.
+func (mr *MockInvoiceUseCaseMockRecorder) GetInvoice(ctx, invoiceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoice", reflect.TypeOf((*MockInvoiceUseCase)(nil).GetInvoice), ctx, invoiceID)
+}
+
+// ListInvoices mocks base method.
+func (m *MockInvoiceUseCase) ListInvoices(ctx context.Context, accountID int64) ([]domain.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInvoices", ctx, accountID)
+	ret0, _ := ret[0].([]domain.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInvoices indicates an expected call of ListInvoices.
+func (mr *MockInvoiceUseCaseMockRecorder) ListInvoices(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvoices", reflect.TypeOf((*MockInvoiceUseCase)(nil).ListInvoices), ctx, accountID)
+}
+
+// MockHealthUseCase is a mock of HealthUseCase interface.
+type MockHealthUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthUseCaseMockRecorder
+}
+
+// MockHealthUseCaseMockRecorder is the mock recorder for MockHealthUseCase.
+type MockHealthUseCaseMockRecorder struct {
+	mock *MockHealthUseCase
+}
+
+// NewMockHealthUseCase creates a new mock instance.
+func NewMockHealthUseCase(ctrl *gomock.Controller) *MockHealthUseCase {
+	mock := &MockHealthUseCase{ctrl: ctrl}
+	mock.recorder = &MockHealthUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthUseCase) EXPECT() *MockHealthUseCaseMockRecorder {
+	return m.recorder
+}
+
+// BeginShutdown mocks base method.
+func (m *MockHealthUseCase) BeginShutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "BeginShutdown")
+}
+
+// BeginShutdown indicates an expected call of BeginShutdown.
+func (mr *MockHealthUseCaseMockRecorder) BeginShutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginShutdown", reflect.TypeOf((*MockHealthUseCase)(nil).BeginShutdown))
+}
+
+// CheckReadiness mocks base method.
+func (m *MockHealthUseCase) CheckReadiness(ctx context.Context) domain.Readiness {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckReadiness", ctx)
+	ret0, _ := ret[0].(domain.Readiness)
+	return ret0
+}
+
+// CheckReadiness indicates an expected call of CheckReadiness.
+func (mr *MockHealthUseCaseMockRecorder) CheckReadiness(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckReadiness", reflect.TypeOf((*MockHealthUseCase)(nil).CheckReadiness), ctx)
+}
+
+// MockLedgerUseCase is a mock of LedgerUseCase interface.
+type MockLedgerUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockLedgerUseCaseMockRecorder
+}
+
+// MockLedgerUseCaseMockRecorder is the mock recorder for MockLedgerUseCase.
+type MockLedgerUseCaseMockRecorder struct {
+	mock *MockLedgerUseCase
+}
+
+// NewMockLedgerUseCase creates a new mock instance.
+func NewMockLedgerUseCase(ctrl *gomock.Controller) *MockLedgerUseCase {
+	mock := &MockLedgerUseCase{ctrl: ctrl}
+	mock.recorder = &MockLedgerUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLedgerUseCase) EXPECT() *MockLedgerUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetTrialBalance mocks base method.
+func (m *MockLedgerUseCase) GetTrialBalance(ctx context.Context, asOf *time.Time) (*domain.TrialBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrialBalance", ctx, asOf)
+	ret0, _ := ret[0].(*domain.TrialBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrialBalance indicates an expected call of GetTrialBalance.
+func (mr *MockLedgerUseCaseMockRecorder) GetTrialBalance(ctx, asOf interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrialBalance", reflect.TypeOf((*MockLedgerUseCase)(nil).GetTrialBalance), ctx, asOf)
+}
+
+// MockAuthorizationUseCase is a mock of AuthorizationUseCase interface.
+type MockAuthorizationUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorizationUseCaseMockRecorder
+}
+
+// MockAuthorizationUseCaseMockRecorder is the mock recorder for MockAuthorizationUseCase.
+type MockAuthorizationUseCaseMockRecorder struct {
+	mock *MockAuthorizationUseCase
+}
+
+// NewMockAuthorizationUseCase creates a new mock instance.
+func NewMockAuthorizationUseCase(ctrl *gomock.Controller) *MockAuthorizationUseCase {
+	mock := &MockAuthorizationUseCase{ctrl: ctrl}
+	mock.recorder = &MockAuthorizationUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorizationUseCase) EXPECT() *MockAuthorizationUseCaseMockRecorder {
+	return m.recorder
+}
+
+// CaptureAuthorization mocks base method.
+func (m *MockAuthorizationUseCase) CaptureAuthorization(ctx context.Context, authorizationID int64, amount *domain.Money) (*domain.Authorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CaptureAuthorization", ctx, authorizationID, amount)
+	ret0, _ := ret[0].(*domain.Authorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CaptureAuthorization indicates an expected call of CaptureAuthorization.
+func (mr *MockAuthorizationUseCaseMockRecorder) CaptureAuthorization(ctx, authorizationID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CaptureAuthorization", reflect.TypeOf((*MockAuthorizationUseCase)(nil).CaptureAuthorization), ctx, authorizationID, amount)
+}
+
+// CreateAuthorization mocks base method.
+func (m *MockAuthorizationUseCase) CreateAuthorization(ctx context.Context, accountID int64, amount domain.Money) (*domain.Authorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuthorization", ctx, accountID, amount)
+	ret0, _ := ret[0].(*domain.Authorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuthorization indicates an expected call of CreateAuthorization.
+func (mr *MockAuthorizationUseCaseMockRecorder) CreateAuthorization(ctx, accountID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuthorization", reflect.TypeOf((*MockAuthorizationUseCase)(nil).CreateAuthorization), ctx, accountID, amount)
+}
+
+// ExpireAuthorizations mocks base method.
+func (m *MockAuthorizationUseCase) ExpireAuthorizations(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireAuthorizations", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireAuthorizations indicates an expected call of ExpireAuthorizations.
+func (mr *MockAuthorizationUseCaseMockRecorder) ExpireAuthorizations(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireAuthorizations", reflect.TypeOf((*MockAuthorizationUseCase)(nil).ExpireAuthorizations), ctx, now)
+}
+
+// GetAuthorization mocks base method.
+func (m *MockAuthorizationUseCase) GetAuthorization(ctx context.Context, authorizationID int64) (*domain.Authorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorization", ctx, authorizationID)
+	ret0, _ := ret[0].(*domain.Authorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthorization indicates an expected call of GetAuthorization.
+func (mr *MockAuthorizationUseCaseMockRecorder) GetAuthorization(ctx, authorizationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorization", reflect.TypeOf((*MockAuthorizationUseCase)(nil).GetAuthorization), ctx, authorizationID)
+}
+
+// VoidAuthorization mocks base method.
+func (m *MockAuthorizationUseCase) VoidAuthorization(ctx context.Context, authorizationID int64) (*domain.Authorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoidAuthorization", ctx, authorizationID)
+	ret0, _ := ret[0].(*domain.Authorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VoidAuthorization indicates an expected call of VoidAuthorization.
+func (mr *MockAuthorizationUseCaseMockRecorder) VoidAuthorization(ctx, authorizationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoidAuthorization", reflect.TypeOf((*MockAuthorizationUseCase)(nil).VoidAuthorization), ctx, authorizationID)
+}
+
+// MockDisputeUseCase is a mock of DisputeUseCase interface.
+type MockDisputeUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockDisputeUseCaseMockRecorder
+}
+
+// MockDisputeUseCaseMockRecorder is the mock recorder for MockDisputeUseCase.
+type MockDisputeUseCaseMockRecorder struct {
+	mock *MockDisputeUseCase
+}
+
+// NewMockDisputeUseCase creates a new mock instance.
+func NewMockDisputeUseCase(ctrl *gomock.Controller) *MockDisputeUseCase {
+	mock := &MockDisputeUseCase{ctrl: ctrl}
+	mock.recorder = &MockDisputeUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDisputeUseCase) EXPECT() *MockDisputeUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetDispute mocks base method.
+func (m *MockDisputeUseCase) GetDispute(ctx context.Context, disputeID int64) (*domain.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDispute", ctx, disputeID)
+	ret0, _ := ret[0].(*domain.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDispute indicates an expected call of GetDispute.
+func (mr *MockDisputeUseCaseMockRecorder) GetDispute(ctx, disputeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDispute", reflect.TypeOf((*MockDisputeUseCase)(nil).GetDispute), ctx, disputeID)
+}
+
+// ListDisputes mocks base method.
+func (m *MockDisputeUseCase) ListDisputes(ctx context.Context, transactionID int64) ([]domain.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDisputes", ctx, transactionID)
+	ret0, _ := ret[0].([]domain.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDisputes indicates an expected call of ListDisputes.
+func (mr *MockDisputeUseCaseMockRecorder) ListDisputes(ctx, transactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDisputes", reflect.TypeOf((*MockDisputeUseCase)(nil).ListDisputes), ctx, transactionID)
+}
+
+// OpenDispute mocks base method.
+func (m *MockDisputeUseCase) OpenDispute(ctx context.Context, transactionID int64, amount *domain.Money, reason string) (*domain.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenDispute", ctx, transactionID, amount, reason)
+	ret0, _ := ret[0].(*domain.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenDispute indicates an expected call of OpenDispute.
+func (mr *MockDisputeUseCaseMockRecorder) OpenDispute(ctx, transactionID, amount, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenDispute", reflect.TypeOf((*MockDisputeUseCase)(nil).OpenDispute), ctx, transactionID, amount, reason)
+}
+
+// ResolveDispute mocks base method.
+func (m *MockDisputeUseCase) ResolveDispute(ctx context.Context, disputeID int64, outcome domain.DisputeStatus) (*domain.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveDispute", ctx, disputeID, outcome)
+	ret0, _ := ret[0].(*domain.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveDispute indicates an expected call of ResolveDispute.
+func (mr *MockDisputeUseCaseMockRecorder) ResolveDispute(ctx, disputeID, outcome interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveDispute", reflect.TypeOf((*MockDisputeUseCase)(nil).ResolveDispute), ctx, disputeID, outcome)
+}
+
+// MockChargeUseCase is a mock of ChargeUseCase interface.
+type MockChargeUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockChargeUseCaseMockRecorder
+}
+
+// MockChargeUseCaseMockRecorder is the mock recorder for MockChargeUseCase.
+type MockChargeUseCaseMockRecorder struct {
+	mock *MockChargeUseCase
+}
+
+// NewMockChargeUseCase creates a new mock instance.
+func NewMockChargeUseCase(ctrl *gomock.Controller) *MockChargeUseCase {
+	mock := &MockChargeUseCase{ctrl: ctrl}
+	mock.recorder = &MockChargeUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChargeUseCase) EXPECT() *MockChargeUseCaseMockRecorder {
+	return m.recorder
+}
+
+// AccrueCharges mocks base method.
+func (m *MockChargeUseCase) AccrueCharges(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccrueCharges", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccrueCharges indicates an expected call of AccrueCharges.
+func (mr *MockChargeUseCaseMockRecorder) AccrueCharges(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccrueCharges", reflect.TypeOf((*MockChargeUseCase)(nil).AccrueCharges), ctx, now)
+}
+
+// MockIdempotencyUseCase is a mock of IdempotencyUseCase interface.
+type MockIdempotencyUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyUseCaseMockRecorder
+}
+
+// MockIdempotencyUseCaseMockRecorder is the mock recorder for MockIdempotencyUseCase.
+type MockIdempotencyUseCaseMockRecorder struct {
+	mock *MockIdempotencyUseCase
+}
+
+// NewMockIdempotencyUseCase creates a new mock instance.
+func NewMockIdempotencyUseCase(ctrl *gomock.Controller) *MockIdempotencyUseCase {
+	mock := &MockIdempotencyUseCase{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyUseCase) EXPECT() *MockIdempotencyUseCaseMockRecorder {
+	return m.recorder
+}
+
+// AbortRequest mocks base method.
+func (m *MockIdempotencyUseCase) AbortRequest(ctx context.Context, scope, key, claimToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortRequest", ctx, scope, key, claimToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AbortRequest indicates an expected call of AbortRequest.
+func (mr *MockIdempotencyUseCaseMockRecorder) AbortRequest(ctx, scope, key, claimToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortRequest", reflect.TypeOf((*MockIdempotencyUseCase)(nil).AbortRequest), ctx, scope, key, claimToken)
+}
+
+// BeginRequest mocks base method.
+func (m *MockIdempotencyUseCase) BeginRequest(ctx context.Context, scope, key string, requestBody []byte) (*domain.IdempotentResponse, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginRequest", ctx, scope, key, requestBody)
+	ret0, _ := ret[0].(*domain.IdempotentResponse)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BeginRequest indicates an expected call of BeginRequest.
+func (mr *MockIdempotencyUseCaseMockRecorder) BeginRequest(ctx, scope, key, requestBody interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginRequest", reflect.TypeOf((*MockIdempotencyUseCase)(nil).BeginRequest), ctx, scope, key, requestBody)
+}
+
+// CompleteRequest mocks base method.
+func (m *MockIdempotencyUseCase) CompleteRequest(ctx context.Context, scope, key, claimToken string, response domain.IdempotentResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteRequest", ctx, scope, key, claimToken, response)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteRequest indicates an expected call of CompleteRequest.
+func (mr *MockIdempotencyUseCaseMockRecorder) CompleteRequest(ctx, scope, key, claimToken, response interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteRequest", reflect.TypeOf((*MockIdempotencyUseCase)(nil).CompleteRequest), ctx, scope, key, claimToken, response)
+}
+
+// PurgeExpiredKeys mocks base method.
+func (m *MockIdempotencyUseCase) PurgeExpiredKeys(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpiredKeys", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpiredKeys indicates an expected call of PurgeExpiredKeys.
+func (mr *MockIdempotencyUseCaseMockRecorder) PurgeExpiredKeys(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpiredKeys", reflect.TypeOf((*MockIdempotencyUseCase)(nil).PurgeExpiredKeys), ctx)
+}
+
+// MockOperationTypeUseCase is a mock of OperationTypeUseCase interface.
+type MockOperationTypeUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockOperationTypeUseCaseMockRecorder
+}
+
+// MockOperationTypeUseCaseMockRecorder is the mock recorder for MockOperationTypeUseCase.
+type MockOperationTypeUseCaseMockRecorder struct {
+	mock *MockOperationTypeUseCase
+}
+
+// NewMockOperationTypeUseCase creates a new mock instance.
+func NewMockOperationTypeUseCase(ctrl *gomock.Controller) *MockOperationTypeUseCase {
+	mock := &MockOperationTypeUseCase{ctrl: ctrl}
+	mock.recorder = &MockOperationTypeUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperationTypeUseCase) EXPECT() *MockOperationTypeUseCaseMockRecorder {
+	return m.recorder
+}
+
+// CreateOperationType mocks base method.
+func (m *MockOperationTypeUseCase) CreateOperationType(ctx context.Context, description string, direction domain.OperationDirection) (domain.OperationType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOperationType", ctx, description, direction)
+	ret0, _ := ret[0].(domain.OperationType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOperationType indicates an expected call of CreateOperationType.
+func (mr *MockOperationTypeUseCaseMockRecorder) CreateOperationType(ctx, description, direction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOperationType", reflect.TypeOf((*MockOperationTypeUseCase)(nil).CreateOperationType), ctx, description, direction)
+}
+
+// ListOperationTypes mocks base method.
+func (m *MockOperationTypeUseCase) ListOperationTypes(ctx context.Context) ([]domain.OperationTypeDefinition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOperationTypes", ctx)
+	ret0, _ := ret[0].([]domain.OperationTypeDefinition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOperationTypes indicates an expected call of ListOperationTypes.
+func (mr *MockOperationTypeUseCaseMockRecorder) ListOperationTypes(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOperationTypes", reflect.TypeOf((*MockOperationTypeUseCase)(nil).ListOperationTypes), ctx)
+}
+
+// UpdateOperationType mocks base method.
+func (m *MockOperationTypeUseCase) UpdateOperationType(ctx context.Context, id domain.OperationType, description *string, active *bool) (*domain.OperationTypeDefinition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOperationType", ctx, id, description, active)
+	ret0, _ := ret[0].(*domain.OperationTypeDefinition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOperationType indicates an expected call of UpdateOperationType.
+func (mr *MockOperationTypeUseCaseMockRecorder) UpdateOperationType(ctx, id, description, active interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOperationType", reflect.TypeOf((*MockOperationTypeUseCase)(nil).UpdateOperationType), ctx, id, description, active)
+}
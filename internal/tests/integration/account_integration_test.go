@@ -17,7 +17,7 @@ func TestCreateAccount_WhenValidInput_ShouldReturn201(t *testing.T) {
 	// Arrange
 	setup := testutils.SetupTest(t)
 	defer testutils.CleanupTest(t, setup)
-	accountRequest := dto.CreateAccountRequest{DocumentNumber: "01101101001"}
+	accountRequest := dto.CreateAccountRequest{DocumentNumber: "01101101024"}
 	w, req := testutils.CreateRequest(t, http.MethodPost, "/accounts", accountRequest)
 
 	// Act
@@ -78,12 +78,12 @@ func TestCreateAccount_WhenInvalidInput_ShouldReturn400(t *testing.T) {
 	assert.Contains(t, errorResponse.Description, "malformed request")
 }
 
-func TestCreateAccount_WhenDuplicateDocumentNumber_ShouldReturn500(t *testing.T) {
+func TestCreateAccount_WhenDuplicateDocumentNumber_ShouldReturn409(t *testing.T) {
 	// Arrange
 	setup := testutils.SetupTest(t)
 	defer testutils.CleanupTest(t, setup)
 
-	w, req := testutils.CreateRequest(t, http.MethodPost, "/accounts", dto.CreateAccountRequest{DocumentNumber: "7777777"})
+	w, req := testutils.CreateRequest(t, http.MethodPost, "/accounts", dto.CreateAccountRequest{DocumentNumber: "77777777009"})
 	setup.Router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusCreated, w.Code)
@@ -96,17 +96,57 @@ func TestCreateAccount_WhenDuplicateDocumentNumber_ShouldReturn500(t *testing.T)
 	setup.AccountIDs = append(setup.AccountIDs, accountResponse.ID)
 
 	// Act - Try to create a second account with the same document_number
-	w, req = testutils.CreateRequest(t, http.MethodPost, "/accounts", dto.CreateAccountRequest{DocumentNumber: "7777777"})
+	w, req = testutils.CreateRequest(t, http.MethodPost, "/accounts", dto.CreateAccountRequest{DocumentNumber: "77777777009"})
 	setup.Router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var conflictResponse dto.AccountAlreadyExistsResponse
+	err = json.Unmarshal(w.Body.Bytes(), &conflictResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, conflictResponse.StatusCode)
+	assert.Equal(t, "account already exists", conflictResponse.Error)
+	assert.Equal(t, "an account with document number 77777777009 already exists", conflictResponse.Description)
+	assert.Equal(t, accountResponse.ID, conflictResponse.AccountID)
+}
+
+func TestCreateAccount_WhenFormattedDocumentNumberAlreadyExists_ShouldReturn409(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "11.222.333/0001-81"})
+
+	w, req := testutils.CreateRequest(t, http.MethodPost, "/accounts", dto.CreateAccountRequest{DocumentNumber: "11222333000181"})
+
+	// Act
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var conflictResponse dto.AccountAlreadyExistsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &conflictResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, accountID, conflictResponse.AccountID)
+}
+
+func TestCreateAccount_WhenDocumentNumberIsInvalid_ShouldReturn422(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	w, req := testutils.CreateRequest(t, http.MethodPost, "/accounts", dto.CreateAccountRequest{DocumentNumber: "123"})
+
+	// Act
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
 	var errorResponse response.ErrorResponse
-	err = json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
 	assert.NoError(t, err)
-	assert.Equal(t, http.StatusInternalServerError, errorResponse.StatusCode)
-	assert.Equal(t, "could not create account", errorResponse.Error)
-	assert.Equal(t, "failed to create account: pq: duplicate key value violates unique constraint \"accounts_document_number_key\"", errorResponse.Description)
+	assert.Equal(t, "validation failed", errorResponse.Error)
+	assert.Equal(t, "invalid document number: must have 11 digits (CPF) or 14 digits (CNPJ), got 3", errorResponse.Description)
 }
 
 func TestGetAccount_WhenAccountExists_ShouldReturn200(t *testing.T) {
@@ -114,7 +154,7 @@ func TestGetAccount_WhenAccountExists_ShouldReturn200(t *testing.T) {
 	setup := testutils.SetupTest(t)
 	defer testutils.CleanupTest(t, setup)
 
-	w, req := testutils.CreateRequest(t, http.MethodPost, "/accounts", dto.CreateAccountRequest{DocumentNumber: "123456789"})
+	w, req := testutils.CreateRequest(t, http.MethodPost, "/accounts", dto.CreateAccountRequest{DocumentNumber: "12345678909"})
 	setup.Router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusCreated, w.Code)
@@ -136,7 +176,8 @@ func TestGetAccount_WhenAccountExists_ShouldReturn200(t *testing.T) {
 	err = json.Unmarshal(w.Body.Bytes(), &getResponse)
 	assert.NoError(t, err)
 	assert.Equal(t, response.ID, getResponse.AccountID)
-	assert.Equal(t, "123456789", getResponse.DocumentNumber)
+	assert.Equal(t, "12345678909", getResponse.DocumentNumber)
+	assert.Equal(t, "CPF", getResponse.DocumentType)
 }
 
 func TestGetAccount_WhenAccountDoesNotExist_ShouldReturn404(t *testing.T) {
@@ -0,0 +1,93 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/api/response"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/tests/integration/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateAccountStatus_WhenBlocked_ShouldRejectDebitsAndAcceptPayments(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+	updateAccountStatus(t, setup, accountID, "BLOCKED", http.StatusNoContent)
+
+	// Act
+	wDebit, reqDebit := testutils.CreateRequest(t, http.MethodPost, "/transactions", dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: int(domain.CompraAVista), Amount: domain.MustParseMoney("10")})
+	setup.Router.ServeHTTP(wDebit, reqDebit)
+	wPayment, reqPayment := testutils.CreateRequest(t, http.MethodPost, "/transactions", dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: int(domain.Pagamento), Amount: domain.MustParseMoney("10")})
+	setup.Router.ServeHTTP(wPayment, reqPayment)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, wDebit.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(wDebit.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "account blocked", errorResponse.Error)
+	assert.Equal(t, http.StatusCreated, wPayment.Code)
+	assertTransactionCount(setup, t, accountID, 1)
+}
+
+func TestUpdateAccountStatus_WhenClosed_ShouldRejectEverythingAndStayClosed(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+	updateAccountStatus(t, setup, accountID, "CLOSED", http.StatusNoContent)
+
+	// Act
+	w, req := testutils.CreateRequest(t, http.MethodPost, "/transactions", dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: int(domain.Pagamento), Amount: domain.MustParseMoney("10")})
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "account closed", errorResponse.Error)
+	assertTransactionCount(setup, t, accountID, 0)
+	updateAccountStatus(t, setup, accountID, "ACTIVE", http.StatusUnprocessableEntity)
+}
+
+func TestListAccountStatusChanges_ShouldReturnTransitionsInOrder(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+	updateAccountStatus(t, setup, accountID, "BLOCKED", http.StatusNoContent)
+	updateAccountStatus(t, setup, accountID, "ACTIVE", http.StatusNoContent)
+	w, req := testutils.CreateRequest(t, http.MethodGet, fmt.Sprintf("/accounts/%d/status-history", accountID), nil)
+
+	// Act
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var history dto.ListAccountStatusChangesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &history)
+	assert.NoError(t, err)
+	assert.Len(t, history.Changes, 2)
+	assert.Equal(t, "ACTIVE", history.Changes[0].FromStatus)
+	assert.Equal(t, "BLOCKED", history.Changes[0].ToStatus)
+	assert.Equal(t, "BLOCKED", history.Changes[1].FromStatus)
+	assert.Equal(t, "ACTIVE", history.Changes[1].ToStatus)
+	assert.Equal(t, "integration test", history.Changes[1].Reason)
+	assert.Equal(t, "tester", history.Changes[1].Actor)
+}
+
+func updateAccountStatus(t *testing.T, setup *testutils.TestContext, accountID int64, status string, expectedStatus int) {
+	w, req := testutils.CreateRequest(t, http.MethodPatch, fmt.Sprintf("/accounts/%d/status", accountID), dto.UpdateAccountStatusRequest{Status: status, Reason: "integration test", Actor: "tester"})
+	setup.Router.ServeHTTP(w, req)
+	assert.Equal(t, expectedStatus, w.Code)
+}
@@ -0,0 +1,157 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/api/response"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/tests/integration/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAuthorization_ShouldHoldCreditLimitWithoutPostingTransaction(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("200")})
+
+	// Act
+	authorization := createAuthorization(t, setup, accountID, "150")
+
+	// Assert
+	assert.Equal(t, "PENDING", authorization.Status)
+	assertAvailableCreditLimit(setup, t, accountID, "50")
+	assertTransactionCount(setup, t, accountID, 0)
+}
+
+func TestUpdateCreditLimit_WhenCreditIsInUse_ShouldKeepDebitsAndHoldsUsed(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+	testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 1, Amount: domain.MustParseMoney("30")})
+	createAuthorization(t, setup, accountID, "20")
+
+	w, req := testutils.CreateRequest(t, http.MethodPatch, fmt.Sprintf("/accounts/%d/credit-limit", accountID), map[string]float64{"credit_limit": 250})
+
+	// Act
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assertAvailableCreditLimit(setup, t, accountID, "200")
+}
+
+func TestCreateAuthorization_WhenAmountExceedsCreditLimit_ShouldReturn422(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("200")})
+	createAuthorization(t, setup, accountID, "150")
+	w, req := testutils.CreateRequest(t, http.MethodPost, "/authorizations", dto.CreateAuthorizationRequest{AccountID: accountID, Amount: domain.MustParseMoney("60")})
+
+	// Act
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "insufficient credit limit", errorResponse.Error)
+	assertAvailableCreditLimit(setup, t, accountID, "50")
+}
+
+func TestCaptureAuthorization_WhenPartial_ShouldPostPurchaseAndReleaseTheRest(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("200")})
+	authorization := createAuthorization(t, setup, accountID, "150")
+	amount := domain.MustParseMoney("120.50")
+	w, req := testutils.CreateRequest(t, http.MethodPost, fmt.Sprintf("/authorizations/%d/capture", authorization.ID), dto.CaptureAuthorizationRequest{Amount: &amount})
+
+	// Act
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var captured dto.AuthorizationResponse
+	err := json.Unmarshal(w.Body.Bytes(), &captured)
+	assert.NoError(t, err)
+	assert.Equal(t, "CAPTURED", captured.Status)
+	assert.Equal(t, amount, captured.CapturedAmount)
+	assertAvailableCreditLimit(setup, t, accountID, "79.50")
+
+	var (
+		operationTypeID domain.OperationType
+		purchaseAmount  domain.Money
+	)
+	err = setup.DB.QueryRow("SELECT operation_type_id, amount FROM transactions WHERE id = $1", *captured.TransactionID).Scan(&operationTypeID, &purchaseAmount)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.CompraAVista, operationTypeID)
+	assert.Equal(t, domain.MustParseMoney("-120.50"), purchaseAmount)
+
+	w, req = testutils.CreateRequest(t, http.MethodPost, fmt.Sprintf("/authorizations/%d/void", authorization.ID), nil)
+	setup.Router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestVoidAuthorization_ShouldReleaseHold(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("200")})
+	authorization := createAuthorization(t, setup, accountID, "150")
+	w, req := testutils.CreateRequest(t, http.MethodPost, fmt.Sprintf("/authorizations/%d/void", authorization.ID), nil)
+
+	// Act
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assertAvailableCreditLimit(setup, t, accountID, "200")
+	assertTransactionCount(setup, t, accountID, 0)
+}
+
+func TestExpireAuthorizations_ShouldReleaseStaleHolds(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("200")})
+	authorization := createAuthorization(t, setup, accountID, "150")
+
+	// Act
+	_, err := setup.AuthorizationUseCase.ExpireAuthorizations(context.Background(), authorization.ExpiresAt.Add(time.Second))
+
+	// Assert
+	assert.NoError(t, err)
+	assertAvailableCreditLimit(setup, t, accountID, "200")
+	var status string
+	err = setup.DB.QueryRow("SELECT status FROM authorizations WHERE id = $1", authorization.ID).Scan(&status)
+	assert.NoError(t, err)
+	assert.Equal(t, "EXPIRED", status)
+}
+
+func createAuthorization(t *testing.T, setup *testutils.TestContext, accountID int64, amount string) dto.AuthorizationResponse {
+	w, req := testutils.CreateRequest(t, http.MethodPost, "/authorizations", dto.CreateAuthorizationRequest{AccountID: accountID, Amount: domain.MustParseMoney(amount)})
+	setup.Router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var authorization dto.AuthorizationResponse
+	err := json.Unmarshal(w.Body.Bytes(), &authorization)
+	assert.NoError(t, err)
+	return authorization
+}
@@ -0,0 +1,153 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/tests/integration/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccrueCharges_WhenInvoiceIsOverdue_ShouldPostLateFeeAndInterestOnce(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("1000")})
+	testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: int(domain.CompraAVista), Amount: domain.MustParseMoney("100")})
+
+	cycle := domain.BillingCycle{ClosingDay: domain.DefaultClosingDay, DueDay: domain.DefaultDueDay}
+	closingDate := cycle.NextClosingDate(time.Now())
+	_, err := setup.InvoiceUseCase.CloseInvoices(context.Background(), closingDate)
+	assert.NoError(t, err)
+	dueDate := cycle.DueDate(closingDate)
+
+	// Act
+	_, err = setup.ChargeUseCase.AccrueCharges(context.Background(), dueDate.AddDate(0, 0, 2))
+
+	// Assert
+	assert.NoError(t, err)
+	charges := listCharges(t, setup, accountID)
+	assert.Len(t, charges, 3)
+	assert.Equal(t, map[int]int{int(domain.JurosRotativos): 2, int(domain.MultaPorAtraso): 1}, countByOperationType(charges))
+	for _, charge := range charges {
+		assert.NotNil(t, charge.InvoiceID)
+		assert.NotEmpty(t, charge.Explanation)
+		if charge.OperationTypeID == int(domain.MultaPorAtraso) {
+			assert.Equal(t, domain.MustParseMoney("-10"), charge.Amount)
+		} else {
+			assert.Equal(t, domain.MustParseMoney("-0.40"), charge.Amount)
+		}
+	}
+
+	// Act - accruing the same days again
+	_, err = setup.ChargeUseCase.AccrueCharges(context.Background(), dueDate.AddDate(0, 0, 2))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, listCharges(t, setup, accountID), 3)
+}
+
+func TestAccrueCharges_WhenChargesExceedCreditLimit_ShouldLeaveAvailableLimitNegative(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+	testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: int(domain.CompraAVista), Amount: domain.MustParseMoney("100")})
+
+	cycle := domain.BillingCycle{ClosingDay: domain.DefaultClosingDay, DueDay: domain.DefaultDueDay}
+	closingDate := cycle.NextClosingDate(time.Now())
+	_, err := setup.InvoiceUseCase.CloseInvoices(context.Background(), closingDate)
+	assert.NoError(t, err)
+	dueDate := cycle.DueDate(closingDate)
+
+	// Act
+	_, err = setup.ChargeUseCase.AccrueCharges(context.Background(), dueDate.AddDate(0, 0, 1))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, listCharges(t, setup, accountID), 2)
+	assertAvailableCreditLimit(setup, t, accountID, "-10.40")
+}
+
+func TestAccrueCharges_WhenRunSeveralDaysLate_ShouldBillCatchUpChargesOnNextInvoice(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("1000")})
+	testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: int(domain.CompraAVista), Amount: domain.MustParseMoney("100")})
+
+	cycle := domain.BillingCycle{ClosingDay: domain.DefaultClosingDay, DueDay: domain.DefaultDueDay}
+	closingDate := cycle.NextClosingDate(time.Now())
+	nextClosingDate := closingDate.AddDate(0, 1, 0)
+	_, err := setup.InvoiceUseCase.CloseInvoices(context.Background(), nextClosingDate)
+	assert.NoError(t, err)
+
+	// Act - the accrual only runs days after the next invoice closed
+	_, err = setup.ChargeUseCase.AccrueCharges(context.Background(), nextClosingDate.AddDate(0, 0, 3))
+	assert.NoError(t, err)
+	_, err = setup.InvoiceUseCase.CloseInvoices(context.Background(), nextClosingDate.AddDate(0, 1, 0))
+
+	// Assert
+	assert.NoError(t, err)
+	charges := listCharges(t, setup, accountID)
+	assert.NotEmpty(t, charges)
+
+	w, req := testutils.CreateRequest(t, http.MethodGet, fmt.Sprintf("/accounts/%d/invoices", accountID), nil)
+	setup.Router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var listResponse dto.ListInvoicesResponse
+	err = json.Unmarshal(w.Body.Bytes(), &listResponse)
+	assert.NoError(t, err)
+	assert.Len(t, listResponse.Invoices, 3)
+
+	billed := map[int64]int{}
+	for _, summary := range listResponse.Invoices {
+		w, req = testutils.CreateRequest(t, http.MethodGet, fmt.Sprintf("/invoices/%d", summary.ID), nil)
+		setup.Router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var invoice dto.InvoiceResponse
+		err = json.Unmarshal(w.Body.Bytes(), &invoice)
+		assert.NoError(t, err)
+		for _, item := range invoice.Items {
+			billed[item.TransactionID]++
+		}
+	}
+	for _, charge := range charges {
+		assert.Equal(t, 1, billed[charge.ID], "charge %d dated %s", charge.ID, charge.EventDate)
+	}
+}
+
+func listCharges(t *testing.T, setup *testutils.TestContext, accountID int64) []dto.TransactionResponse {
+	w, req := testutils.CreateRequest(t, http.MethodGet, fmt.Sprintf("/accounts/%d/transactions?limit=100", accountID), nil)
+	setup.Router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dto.ListTransactionsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	charges := []dto.TransactionResponse{}
+	for _, transaction := range response.Transactions {
+		if domain.OperationType(transaction.OperationTypeID).IsCharge() {
+			charges = append(charges, transaction)
+		}
+	}
+	return charges
+}
+
+func countByOperationType(transactions []dto.TransactionResponse) map[int]int {
+	counts := map[int]int{}
+	for _, transaction := range transactions {
+		counts[transaction.OperationTypeID]++
+	}
+	return counts
+}
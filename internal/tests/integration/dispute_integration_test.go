@@ -0,0 +1,210 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/api/response"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/tests/integration/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenDispute_ShouldPostProvisionalCreditAndDischargePurchase(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+	purchaseID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 1, Amount: domain.MustParseMoney("60")})
+
+	// Act
+	dispute := openDispute(t, setup, purchaseID, "purchase not recognized")
+
+	// Assert
+	assert.Equal(t, "PROVISIONAL_CREDIT", dispute.Status)
+	assert.Equal(t, domain.MustParseMoney("60"), dispute.Amount)
+	assert.Len(t, dispute.Events, 2)
+	assertAvailableCreditLimit(setup, t, accountID, "100")
+	assertTransactionBalance(setup, t, purchaseID, "0")
+	assertTransactionBalance(setup, t, *dispute.ProvisionalCreditTransactionID, "0")
+
+	// Act - disputing it again while the dispute is not lost
+	w, req := testutils.CreateRequest(t, http.MethodPost, fmt.Sprintf("/transactions/%d/disputes", purchaseID), dto.OpenDisputeRequest{Reason: "again"})
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestResolveDispute_WhenLost_ShouldReverseProvisionalCredit(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+	purchaseID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 1, Amount: domain.MustParseMoney("60")})
+	dispute := openDispute(t, setup, purchaseID, "purchase not recognized")
+
+	// Act
+	resolved := resolveDispute(t, setup, dispute.ID, "LOST", http.StatusOK)
+
+	// Assert
+	assert.Equal(t, "LOST", resolved.Status)
+	assert.NotNil(t, resolved.ResolvedAt)
+	assertAvailableCreditLimit(setup, t, accountID, "40")
+	assertTransactionBalance(setup, t, *resolved.ReversalTransactionID, "-60")
+
+	var (
+		operationTypeID       domain.OperationType
+		reversesTransactionID int64
+	)
+	err := setup.DB.QueryRow("SELECT operation_type_id, reverses_transaction_id FROM transactions WHERE id = $1", *resolved.ReversalTransactionID).Scan(&operationTypeID, &reversesTransactionID)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.EstornoCreditoProvisorio, operationTypeID)
+	assert.Equal(t, *dispute.ProvisionalCreditTransactionID, reversesTransactionID)
+
+	var disputesBalance domain.Money
+	err = setup.DB.QueryRow("SELECT COALESCE(SUM(amount), 0) FROM postings WHERE ledger_account = 'DISPUTES' AND journal_entry_id IN (SELECT id FROM journal_entries WHERE transaction_id IN ($1, $2))",
+		*dispute.ProvisionalCreditTransactionID, *resolved.ReversalTransactionID).Scan(&disputesBalance)
+	assert.NoError(t, err)
+	assert.True(t, disputesBalance.IsZero())
+
+	w, req := testutils.CreateRequest(t, http.MethodGet, fmt.Sprintf("/transactions/%d/disputes", purchaseID), nil)
+	setup.Router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var listResponse dto.ListDisputesResponse
+	err = json.Unmarshal(w.Body.Bytes(), &listResponse)
+	assert.NoError(t, err)
+	assert.Len(t, listResponse.Disputes, 1)
+	events := listResponse.Disputes[0].Events
+	assert.Len(t, events, 3)
+	assert.Equal(t, []string{"OPENED", "PROVISIONAL_CREDIT", "LOST"}, []string{events[0].ToStatus, events[1].ToStatus, events[2].ToStatus})
+	assert.Equal(t, resolved.ReversalTransactionID, events[2].TransactionID)
+}
+
+func TestResolveDispute_WhenLostAfterCreditWasSpent_ShouldLeaveAvailableLimitNegative(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+	purchaseID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 1, Amount: domain.MustParseMoney("60")})
+	dispute := openDispute(t, setup, purchaseID, "purchase not recognized")
+	testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 1, Amount: domain.MustParseMoney("100")})
+
+	// Act
+	resolveDispute(t, setup, dispute.ID, "LOST", http.StatusOK)
+
+	// Assert
+	assertAvailableCreditLimit(setup, t, accountID, "-60")
+}
+
+func TestResolveDispute_WhenWon_ShouldKeepCreditAndResolveOnlyOnce(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+	purchaseID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 1, Amount: domain.MustParseMoney("60")})
+	dispute := openDispute(t, setup, purchaseID, "purchase not recognized")
+
+	// Act
+	resolved := resolveDispute(t, setup, dispute.ID, "WON", http.StatusOK)
+
+	// Assert
+	assert.Equal(t, "WON", resolved.Status)
+	assert.Nil(t, resolved.ReversalTransactionID)
+	assertAvailableCreditLimit(setup, t, accountID, "100")
+	assertTransactionCount(setup, t, accountID, 2)
+
+	// Act - resolving it again
+	resolveDispute(t, setup, dispute.ID, "LOST", http.StatusConflict)
+
+	// Assert
+	assertAvailableCreditLimit(setup, t, accountID, "100")
+}
+
+func TestReverseTransaction_WhenTransactionIsDisputed_ShouldReturn409(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+	purchaseID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 1, Amount: domain.MustParseMoney("60")})
+	dispute := openDispute(t, setup, purchaseID, "purchase not recognized")
+	resolveDispute(t, setup, dispute.ID, "WON", http.StatusOK)
+
+	w, req := testutils.CreateRequest(t, http.MethodPost, fmt.Sprintf("/transactions/%d/reversal", purchaseID), dto.ReverseTransactionRequest{})
+
+	// Act
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "transaction disputed", errorResponse.Error)
+	assertAvailableCreditLimit(setup, t, accountID, "100")
+}
+
+func TestOpenDispute_WhenTransactionWasPartiallyReversed_ShouldDisputeOnlyWhatIsLeft(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+	purchaseID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 1, Amount: domain.MustParseMoney("60")})
+	reversed := domain.MustParseMoney("40")
+	reverseTransaction(t, setup, purchaseID, &reversed)
+
+	w, req := testutils.CreateRequest(t, http.MethodPost, fmt.Sprintf("/transactions/%d/disputes", purchaseID), dto.OpenDisputeRequest{Reason: "purchase not recognized"})
+
+	// Act
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "dispute exceeds amount", errorResponse.Error)
+	assertAvailableCreditLimit(setup, t, accountID, "80")
+
+	// Act - disputing what the reversal left
+	left := domain.MustParseMoney("20")
+	w, req = testutils.CreateRequest(t, http.MethodPost, fmt.Sprintf("/transactions/%d/disputes", purchaseID), dto.OpenDisputeRequest{Amount: &left, Reason: "purchase not recognized"})
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assertAvailableCreditLimit(setup, t, accountID, "100")
+}
+
+func openDispute(t *testing.T, setup *testutils.TestContext, transactionID int64, reason string) dto.DisputeResponse {
+	w, req := testutils.CreateRequest(t, http.MethodPost, fmt.Sprintf("/transactions/%d/disputes", transactionID), dto.OpenDisputeRequest{Reason: reason})
+	setup.Router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var dispute dto.DisputeResponse
+	err := json.Unmarshal(w.Body.Bytes(), &dispute)
+	assert.NoError(t, err)
+	return dispute
+}
+
+func resolveDispute(t *testing.T, setup *testutils.TestContext, disputeID int64, outcome string, expectedStatus int) dto.DisputeResponse {
+	w, req := testutils.CreateRequest(t, http.MethodPost, fmt.Sprintf("/disputes/%d/resolve", disputeID), dto.ResolveDisputeRequest{Outcome: outcome})
+	setup.Router.ServeHTTP(w, req)
+	assert.Equal(t, expectedStatus, w.Code)
+
+	var dispute dto.DisputeResponse
+	if expectedStatus == http.StatusOK {
+		err := json.Unmarshal(w.Body.Bytes(), &dispute)
+		assert.NoError(t, err)
+	}
+	return dispute
+}
@@ -0,0 +1,30 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/tests/integration/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadiness_WhenDatabaseIsMigrated_ShouldReturn200(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	// Act
+	w, req := testutils.CreateRequest(t, http.MethodGet, "/readyz", nil)
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp dto.ReadinessResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "UP", resp.Status)
+	assert.Equal(t, dto.HealthCheckResponse{Status: "UP"}, resp.Checks["database"])
+	assert.Equal(t, dto.HealthCheckResponse{Status: "UP"}, resp.Checks["migrations"])
+}
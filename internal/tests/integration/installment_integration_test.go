@@ -0,0 +1,71 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/tests/integration/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstallmentPurchase_WhenCreated_ShouldStoreScheduleAndDebitFullAmount(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("1000")})
+
+	// Act
+	purchaseID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{
+		AccountID:       accountID,
+		OperationTypeID: int(domain.CompraParcelada),
+		Amount:          domain.MustParseMoney("100"),
+		Installments:    3,
+	})
+
+	// Assert
+	assertAvailableCreditLimit(setup, t, accountID, "900")
+	assertTransactionBalance(setup, t, purchaseID, "-100")
+
+	w, req := testutils.CreateRequest(t, http.MethodGet, fmt.Sprintf("/transactions/%d/installments", purchaseID), nil)
+	setup.Router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.ListInstallmentsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Installments, 3)
+	assert.Equal(t, domain.MustParseMoney("33.34"), resp.Installments[0].Amount)
+	assert.Equal(t, domain.MustParseMoney("33.33"), resp.Installments[2].Amount)
+	for _, installment := range resp.Installments {
+		dueDate, err := time.Parse(time.DateOnly, installment.DueDate)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.DefaultDueDay, dueDate.Day())
+	}
+}
+
+func TestInstallmentPurchase_WhenFullAmountExceedsCreditLimit_ShouldReturn422(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+	w, req := testutils.CreateRequest(t, http.MethodPost, "/transactions", dto.CreateTransactionRequest{
+		AccountID:       accountID,
+		OperationTypeID: int(domain.CompraParcelada),
+		Amount:          domain.MustParseMoney("120"),
+		Installments:    12,
+	})
+
+	// Act
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assertAvailableCreditLimit(setup, t, accountID, "100")
+}
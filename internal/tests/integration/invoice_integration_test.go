@@ -0,0 +1,155 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/tests/integration/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloseInvoices_WhenCycleEnds_ShouldBillTransactionsAndFirstInstallment(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("1000")})
+	testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{
+		AccountID:       accountID,
+		OperationTypeID: int(domain.CompraParcelada),
+		Amount:          domain.MustParseMoney("100"),
+		Installments:    2,
+	})
+	testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: int(domain.Pagamento), Amount: domain.MustParseMoney("20")})
+
+	cycle := domain.BillingCycle{ClosingDay: domain.DefaultClosingDay, DueDay: domain.DefaultDueDay}
+	closingDate := cycle.NextClosingDate(time.Now())
+
+	// Act
+	_, err := setup.InvoiceUseCase.CloseInvoices(context.Background(), closingDate)
+
+	// Assert
+	assert.NoError(t, err)
+
+	w, req := testutils.CreateRequest(t, http.MethodGet, fmt.Sprintf("/accounts/%d/invoices", accountID), nil)
+	setup.Router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var listResponse dto.ListInvoicesResponse
+	err = json.Unmarshal(w.Body.Bytes(), &listResponse)
+	assert.NoError(t, err)
+	assert.Len(t, listResponse.Invoices, 1)
+
+	w, req = testutils.CreateRequest(t, http.MethodGet, fmt.Sprintf("/invoices/%d", listResponse.Invoices[0].ID), nil)
+	setup.Router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var invoice dto.InvoiceResponse
+	err = json.Unmarshal(w.Body.Bytes(), &invoice)
+	assert.NoError(t, err)
+	assert.Equal(t, closingDate.Format(time.DateOnly), invoice.ClosingDate)
+	assert.Equal(t, cycle.DueDate(closingDate).Format(time.DateOnly), invoice.DueDate)
+	assert.Equal(t, domain.MustParseMoney("50"), invoice.TotalDebits)
+	assert.Equal(t, domain.MustParseMoney("20"), invoice.TotalCredits)
+	assert.Equal(t, domain.MustParseMoney("30"), invoice.TotalDue)
+	assert.Equal(t, domain.MustParseMoney("10"), invoice.MinimumPayment)
+	assert.Len(t, invoice.Items, 2)
+
+	// Act - closing the same cycle again
+	_, err = setup.InvoiceUseCase.CloseInvoices(context.Background(), closingDate)
+
+	// Assert
+	assert.NoError(t, err)
+	w, req = testutils.CreateRequest(t, http.MethodGet, fmt.Sprintf("/accounts/%d/invoices", accountID), nil)
+	setup.Router.ServeHTTP(w, req)
+	err = json.Unmarshal(w.Body.Bytes(), &listResponse)
+	assert.NoError(t, err)
+	assert.Len(t, listResponse.Invoices, 1)
+}
+
+func TestUpdateBillingCycle_WhenDaysAreValid_ShouldReturnThemOnAccount(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("1000")})
+	closingDay, dueDay := 20, 5
+	w, req := testutils.CreateRequest(t, http.MethodPatch, fmt.Sprintf("/accounts/%d/billing-cycle", accountID), dto.UpdateBillingCycleRequest{ClosingDay: &closingDay, DueDay: &dueDay})
+
+	// Act
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w, req = testutils.CreateRequest(t, http.MethodGet, fmt.Sprintf("/accounts/%d", accountID), nil)
+	setup.Router.ServeHTTP(w, req)
+	var account dto.GetAccountResponse
+	err := json.Unmarshal(w.Body.Bytes(), &account)
+	assert.NoError(t, err)
+	assert.Equal(t, 20, account.ClosingDay)
+	assert.Equal(t, 5, account.DueDay)
+}
+
+func TestCloseInvoices_WhenBillingCycleChangesMidSchedule_ShouldBillEachInstallmentOnce(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("1000")})
+	purchaseID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{
+		AccountID:       accountID,
+		OperationTypeID: int(domain.CompraParcelada),
+		Amount:          domain.MustParseMoney("90"),
+		Installments:    3,
+	})
+
+	oldCycle := domain.BillingCycle{ClosingDay: domain.DefaultClosingDay, DueDay: domain.DefaultDueDay}
+	firstClosingDate := oldCycle.NextClosingDate(time.Now())
+	_, err := setup.InvoiceUseCase.CloseInvoices(context.Background(), firstClosingDate)
+	assert.NoError(t, err)
+
+	newCycle := domain.BillingCycle{ClosingDay: 20, DueDay: 5}
+	w, req := testutils.CreateRequest(t, http.MethodPatch, fmt.Sprintf("/accounts/%d/billing-cycle", accountID), dto.UpdateBillingCycleRequest{ClosingDay: &newCycle.ClosingDay, DueDay: &newCycle.DueDay})
+	setup.Router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	secondClosingDate := newCycle.NextClosingDate(firstClosingDate)
+	thirdClosingDate := secondClosingDate.AddDate(0, 1, 0)
+
+	// Act
+	_, err = setup.InvoiceUseCase.CloseInvoices(context.Background(), thirdClosingDate)
+
+	// Assert
+	assert.NoError(t, err)
+
+	w, req = testutils.CreateRequest(t, http.MethodGet, fmt.Sprintf("/transactions/%d/installments", purchaseID), nil)
+	setup.Router.ServeHTTP(w, req)
+	var installments dto.ListInstallmentsResponse
+	err = json.Unmarshal(w.Body.Bytes(), &installments)
+	assert.NoError(t, err)
+	assert.Equal(t, oldCycle.DueDate(firstClosingDate).Format(time.DateOnly), installments.Installments[0].DueDate)
+	assert.Equal(t, newCycle.DueDate(secondClosingDate).Format(time.DateOnly), installments.Installments[1].DueDate)
+	assert.Equal(t, newCycle.DueDate(thirdClosingDate).Format(time.DateOnly), installments.Installments[2].DueDate)
+
+	w, req = testutils.CreateRequest(t, http.MethodGet, fmt.Sprintf("/accounts/%d/invoices", accountID), nil)
+	setup.Router.ServeHTTP(w, req)
+	var listResponse dto.ListInvoicesResponse
+	err = json.Unmarshal(w.Body.Bytes(), &listResponse)
+	assert.NoError(t, err)
+	assert.Len(t, listResponse.Invoices, 3)
+
+	for _, summary := range listResponse.Invoices {
+		w, req = testutils.CreateRequest(t, http.MethodGet, fmt.Sprintf("/invoices/%d", summary.ID), nil)
+		setup.Router.ServeHTTP(w, req)
+		var invoice dto.InvoiceResponse
+		err = json.Unmarshal(w.Body.Bytes(), &invoice)
+		assert.NoError(t, err)
+		assert.Len(t, invoice.Items, 1, invoice.ClosingDate)
+		assert.Equal(t, domain.MustParseMoney("30"), invoice.TotalDebits, invoice.ClosingDate)
+	}
+}
@@ -0,0 +1,81 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/tests/integration/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedger_WhenTransactionsAreCreated_ShouldPostBalancedJournalEntries(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("1000")})
+	otherAccountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "77777777009", AvailableCreditLimit: domain.MustParseMoney("1000")})
+
+	// Act
+	testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: int(domain.CompraAVista), Amount: domain.MustParseMoney("100")})
+	testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: int(domain.Pagamento), Amount: domain.MustParseMoney("40")})
+	w, req := testutils.CreateRequest(t, http.MethodPost, "/transfers", dto.CreateTransferRequest{FromAccountID: accountID, ToAccountID: otherAccountID, Amount: domain.MustParseMoney("15")})
+	setup.Router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	// Assert
+	var entries, unbalanced int
+	err := setup.DB.QueryRow(`
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE total <> 0)
+		FROM (
+			SELECT j.id, SUM(p.amount) AS total
+			FROM journal_entries j
+			JOIN transactions t ON t.id = j.transaction_id
+			JOIN postings p ON p.journal_entry_id = j.id
+			WHERE t.account_id IN ($1, $2)
+			GROUP BY j.id
+		) e`, accountID, otherAccountID).Scan(&entries, &unbalanced)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, entries)
+	assert.Equal(t, 0, unbalanced)
+
+	var receivables domain.Money
+	err = setup.DB.QueryRow("SELECT SUM(amount) FROM postings WHERE ledger_account = 'RECEIVABLES' AND account_id = $1", accountID).Scan(&receivables)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.MustParseMoney("75"), receivables)
+
+	w, req = testutils.CreateRequest(t, http.MethodGet, "/ledger/trial-balance", nil)
+	setup.Router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var trialBalance dto.TrialBalanceResponse
+	err = json.Unmarshal(w.Body.Bytes(), &trialBalance)
+	assert.NoError(t, err)
+	assert.True(t, trialBalance.Balanced)
+	assert.Equal(t, trialBalance.TotalDebits, trialBalance.TotalCredits)
+}
+
+func TestLedger_WhenJournalEntryDoesNotBalance_ShouldRejectCommit(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("1000")})
+	transactionID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: int(domain.CompraAVista), Amount: domain.MustParseMoney("100")})
+
+	tx, err := setup.DB.Begin()
+	assert.NoError(t, err)
+	defer tx.Rollback()
+
+	// Act
+	_, err = tx.Exec(`
+		INSERT INTO postings (journal_entry_id, ledger_account, amount)
+		SELECT id, 'CASH', 1 FROM journal_entries WHERE transaction_id = $1`, transactionID)
+	assert.NoError(t, err)
+	err = tx.Commit()
+
+	// Assert
+	assert.ErrorContains(t, err, "does not balance")
+}
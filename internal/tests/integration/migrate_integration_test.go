@@ -0,0 +1,41 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/VieiraVitor/transaction-flow/internal/infra/database"
+	"github.com/VieiraVitor/transaction-flow/internal/tests/integration/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrator_WhenDatabaseIsMigrated_ShouldReportEveryMigrationApplied(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	migrator, err := database.NewMigrator(context.Background(), setup.DB, time.Second)
+	assert.NoError(t, err)
+	defer migrator.Close()
+
+	latest, err := database.LatestSchemaVersion()
+	assert.NoError(t, err)
+
+	// Act
+	err = migrator.Up()
+
+	// Assert
+	assert.NoError(t, err)
+	version, dirty, err := migrator.Version()
+	assert.NoError(t, err)
+	assert.Equal(t, latest, version)
+	assert.False(t, dirty)
+
+	statuses, err := migrator.Status()
+	assert.NoError(t, err)
+	assert.Len(t, statuses, int(latest))
+	for _, status := range statuses {
+		assert.True(t, status.Applied, "migration %d should be applied", status.Version)
+	}
+}
@@ -0,0 +1,102 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/api/response"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/tests/integration/testutils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListOperationTypes_ShouldReturnSeededTypes(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	w, req := testutils.CreateRequest(t, http.MethodGet, "/operation-types", nil)
+
+	// Act
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var listResponse dto.ListOperationTypesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &listResponse)
+	assert.NoError(t, err)
+
+	directions := map[int]string{}
+	for _, operationType := range listResponse.OperationTypes {
+		directions[operationType.ID] = operationType.Direction
+	}
+	assert.Equal(t, "DEBIT", directions[1])
+	assert.Equal(t, "DEBIT", directions[2])
+	assert.Equal(t, "DEBIT", directions[3])
+	assert.Equal(t, "CREDIT", directions[4])
+}
+
+func TestCreateTransaction_WhenNewCreditOperationType_ShouldStorePositiveAmount(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	operationTypeID := testutils.CreateOperationType(t, setup, dto.CreateOperationTypeRequest{Description: "CASHBACK " + uuid.NewString()[:8], Direction: domain.DirectionCredit})
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+
+	// Act
+	transactionID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: operationTypeID, Amount: domain.MustParseMoney("-30")})
+
+	// Assert
+	w, req := testutils.CreateRequest(t, http.MethodGet, fmt.Sprintf("/transactions/%d", transactionID), nil)
+	setup.Router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var transactionResponse dto.GetTransactionResponse
+	err := json.Unmarshal(w.Body.Bytes(), &transactionResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.MustParseMoney("30"), transactionResponse.Amount)
+}
+
+func TestCreateTransaction_WhenOperationTypeDeactivated_ShouldReturn422(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	operationTypeID := testutils.CreateOperationType(t, setup, dto.CreateOperationTypeRequest{Description: "TARIFA " + uuid.NewString()[:8], Direction: domain.DirectionDebit})
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+
+	active := false
+	w, req := testutils.CreateRequest(t, http.MethodPatch, fmt.Sprintf("/operation-types/%d", operationTypeID), dto.UpdateOperationTypeRequest{Active: &active})
+	setup.Router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w, req = testutils.CreateRequest(t, http.MethodPost, "/transactions", dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: operationTypeID, Amount: domain.MustParseMoney("10")})
+
+	// Act
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "invalid operation type", errorResponse.Error)
+}
+
+func TestCreateOperationType_WhenDescriptionExists_ShouldReturn409(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	w, req := testutils.CreateRequest(t, http.MethodPost, "/operation-types", dto.CreateOperationTypeRequest{Description: "SAQUE", Direction: domain.DirectionDebit})
+
+	// Act
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
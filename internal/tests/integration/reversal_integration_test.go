@@ -0,0 +1,129 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/api/response"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/tests/integration/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReverseTransaction_WhenPurchaseReversed_ShouldRestoreCreditLimit(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+	purchaseID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 1, Amount: domain.MustParseMoney("60")})
+
+	// Act
+	reversalID := reverseTransaction(t, setup, purchaseID, nil)
+
+	// Assert
+	assertAvailableCreditLimit(setup, t, accountID, "100")
+	assertTransactionBalance(setup, t, purchaseID, "0")
+	assertTransactionBalance(setup, t, reversalID, "0")
+
+	w, req := testutils.CreateRequest(t, http.MethodGet, fmt.Sprintf("/transactions/%d", reversalID), nil)
+	setup.Router.ServeHTTP(w, req)
+	var reversalResponse dto.GetTransactionResponse
+	err := json.Unmarshal(w.Body.Bytes(), &reversalResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, int(domain.Estorno), reversalResponse.OperationTypeID)
+	assert.Equal(t, domain.MustParseMoney("60"), reversalResponse.Amount)
+	assert.Equal(t, &purchaseID, reversalResponse.ReversesTransactionID)
+
+	// Act - reversing it again
+	w, req = testutils.CreateRequest(t, http.MethodPost, fmt.Sprintf("/transactions/%d/reversal", purchaseID), nil)
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestReverseTransaction_WhenPartialReversalsExceedAmount_ShouldReturn422(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+	purchaseID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 1, Amount: domain.MustParseMoney("60")})
+	firstAmount := domain.MustParseMoney("40")
+	reverseTransaction(t, setup, purchaseID, &firstAmount)
+
+	secondAmount := domain.MustParseMoney("20.01")
+	w, req := testutils.CreateRequest(t, http.MethodPost, fmt.Sprintf("/transactions/%d/reversal", purchaseID), dto.ReverseTransactionRequest{Amount: &secondAmount})
+
+	// Act
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "reversal exceeds amount", errorResponse.Error)
+	assertAvailableCreditLimit(setup, t, accountID, "80")
+	assertTransactionBalance(setup, t, purchaseID, "-20")
+}
+
+func TestReverseTransaction_WhenPaidPurchaseReversed_ShouldDischargeOtherDebits(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("1000")})
+	purchaseID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 1, Amount: domain.MustParseMoney("50")})
+	testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 4, Amount: domain.MustParseMoney("50")})
+	withdrawID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 3, Amount: domain.MustParseMoney("30")})
+
+	// Act
+	reversalID := reverseTransaction(t, setup, purchaseID, nil)
+
+	// Assert
+	assertTransactionBalance(setup, t, purchaseID, "0")
+	assertTransactionBalance(setup, t, withdrawID, "0")
+	assertTransactionBalance(setup, t, reversalID, "20")
+}
+
+func TestReverseTransaction_WhenPaymentReversed_ShouldReopenDebt(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+	purchaseID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 1, Amount: domain.MustParseMoney("30")})
+	paymentID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 4, Amount: domain.MustParseMoney("50")})
+
+	// Act
+	reversalID := reverseTransaction(t, setup, paymentID, nil)
+
+	// Assert
+	assertAvailableCreditLimit(setup, t, accountID, "70")
+	assertTransactionBalance(setup, t, purchaseID, "0")
+	assertTransactionBalance(setup, t, paymentID, "0")
+	assertTransactionBalance(setup, t, reversalID, "-30")
+
+	// Act - a reversal cannot be reversed
+	w, req := testutils.CreateRequest(t, http.MethodPost, fmt.Sprintf("/transactions/%d/reversal", reversalID), nil)
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func reverseTransaction(t *testing.T, setup *testutils.TestContext, transactionID int64, amount *domain.Money) int64 {
+	w, req := testutils.CreateRequest(t, http.MethodPost, fmt.Sprintf("/transactions/%d/reversal", transactionID), dto.ReverseTransactionRequest{Amount: amount})
+	setup.Router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var reversalResponse dto.CreateTransactionResponse
+	err := json.Unmarshal(w.Body.Bytes(), &reversalResponse)
+	assert.NoError(t, err)
+	return reversalResponse.ID
+}
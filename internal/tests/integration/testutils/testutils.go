@@ -8,9 +8,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
 	"github.com/VieiraVitor/transaction-flow/internal/api/handler"
+	"github.com/VieiraVitor/transaction-flow/internal/api/middleware"
 	"github.com/VieiraVitor/transaction-flow/internal/application/usecase"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/database"
 	"github.com/VieiraVitor/transaction-flow/internal/infra/logger"
 	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
 	"github.com/go-chi/chi/v5"
@@ -27,9 +32,15 @@ const (
 )
 
 type TestContext struct {
-	DB         *sql.DB
-	Router     *chi.Mux
-	AccountIDs []int64
+	DB                   *sql.DB
+	Router               *chi.Mux
+	InvoiceUseCase       usecase.InvoiceUseCase
+	ChargeUseCase        usecase.ChargeUseCase
+	AuthorizationUseCase usecase.AuthorizationUseCase
+	IdempotencyUseCase   usecase.IdempotencyUseCase
+	AccountIDs           []int64
+	IdempotencyKeys      []string
+	OperationTypeIDs     []int64
 }
 
 func SetupTest(t *testing.T) *TestContext {
@@ -47,25 +58,82 @@ func SetupTest(t *testing.T) *TestContext {
 	accountUseCase := usecase.NewAccountUseCase(accountRepo)
 	accountHandler := handler.NewAccountHandler(accountUseCase)
 	transactionRepo := repository.NewTransactionRepository(db)
-	transactionUseCase := usecase.NewTransactionUseCase(transactionRepo)
+	operationTypeRepo := repository.NewOperationTypeRepository(db)
+	operationTypeCatalog := usecase.NewOperationTypeCatalog(operationTypeRepo, time.Minute)
+	operationTypeUseCase := usecase.NewOperationTypeUseCase(operationTypeRepo, operationTypeCatalog)
+	operationTypeHandler := handler.NewOperationTypeHandler(operationTypeUseCase)
+	transactionUseCase := usecase.NewTransactionUseCase(transactionRepo, accountRepo, operationTypeCatalog)
 	transactionHandler := handler.NewTransactionHandler(transactionUseCase)
+	idempotencyRepo := repository.NewIdempotencyRepository(db)
+	idempotencyUseCase := usecase.NewIdempotencyUseCase(idempotencyRepo, time.Hour, time.Minute)
+	idempotency := middleware.NewIdempotencyMiddleware(idempotencyUseCase)
+	invoiceRepo := repository.NewInvoiceRepository(db)
+	invoiceUseCase := usecase.NewInvoiceUseCase(invoiceRepo, accountRepo)
+	invoiceHandler := handler.NewInvoiceHandler(invoiceUseCase)
+	transferHandler := handler.NewTransferHandler(transactionUseCase)
+	ledgerHandler := handler.NewLedgerHandler(usecase.NewLedgerUseCase(repository.NewLedgerRepository(db)))
+	chargeUseCase := usecase.NewChargeUseCase(invoiceRepo, transactionRepo, domain.InterestRate(1200), domain.MustParseMoney("10"))
+	authorizationUseCase := usecase.NewAuthorizationUseCase(repository.NewAuthorizationRepository(db), accountRepo, time.Hour)
+	authorizationHandler := handler.NewAuthorizationHandler(authorizationUseCase)
+	disputeHandler := handler.NewDisputeHandler(usecase.NewDisputeUseCase(repository.NewDisputeRepository(db), transactionRepo, accountRepo))
+	schemaVersion, err := database.LatestSchemaVersion()
+	assert.NoError(t, err)
+	healthHandler := handler.NewHealthHandler(usecase.NewHealthUseCase(repository.NewHealthRepository(db), schemaVersion, time.Second))
 
 	router := chi.NewRouter()
 	assert.NotNil(t, router, "router should not be nil")
-	router.Post("/accounts", accountHandler.CreateAccount)
+	router.Get("/healthz", healthHandler.Liveness)
+	router.Get("/readyz", healthHandler.Readiness)
+	router.With(idempotency).Post("/accounts", accountHandler.CreateAccount)
 	router.Get("/accounts/{id}", accountHandler.GetAccount)
-	router.Post("/transactions", transactionHandler.CreateTransaction)
-
-	return &TestContext{DB: db, Router: router, AccountIDs: []int64{}}
+	router.Patch("/accounts/{id}/credit-limit", accountHandler.UpdateCreditLimit)
+	router.Patch("/accounts/{id}/billing-cycle", accountHandler.UpdateBillingCycle)
+	router.Patch("/accounts/{id}/status", accountHandler.UpdateStatus)
+	router.Get("/accounts/{id}/status-history", accountHandler.ListStatusChanges)
+	router.Get("/accounts/{id}/transactions", transactionHandler.ListTransactions)
+	router.Get("/accounts/{id}/balance", transactionHandler.GetAccountBalance)
+	router.Get("/accounts/{id}/invoices", invoiceHandler.ListInvoices)
+	router.Get("/invoices/{id}", invoiceHandler.GetInvoice)
+	router.With(idempotency).Post("/transactions", transactionHandler.CreateTransaction)
+	router.Get("/transactions/{id}", transactionHandler.GetTransaction)
+	router.Get("/transactions/{id}/installments", transactionHandler.ListInstallments)
+	router.With(idempotency).Post("/transactions/{id}/reversal", transactionHandler.ReverseTransaction)
+	router.With(idempotency).Post("/transactions/{id}/disputes", disputeHandler.OpenDispute)
+	router.Get("/transactions/{id}/disputes", disputeHandler.ListDisputes)
+	router.Get("/disputes/{id}", disputeHandler.GetDispute)
+	router.With(idempotency).Post("/disputes/{id}/resolve", disputeHandler.ResolveDispute)
+	router.With(idempotency).Post("/transfers", transferHandler.CreateTransfer)
+	router.With(idempotency).Post("/authorizations", authorizationHandler.CreateAuthorization)
+	router.Get("/authorizations/{id}", authorizationHandler.GetAuthorization)
+	router.With(idempotency).Post("/authorizations/{id}/capture", authorizationHandler.CaptureAuthorization)
+	router.Post("/authorizations/{id}/void", authorizationHandler.VoidAuthorization)
+	router.Get("/ledger/trial-balance", ledgerHandler.GetTrialBalance)
+	router.Get("/operation-types", operationTypeHandler.ListOperationTypes)
+	router.Post("/operation-types", operationTypeHandler.CreateOperationType)
+	router.Patch("/operation-types/{id}", operationTypeHandler.UpdateOperationType)
+
+	return &TestContext{DB: db, Router: router, InvoiceUseCase: invoiceUseCase, ChargeUseCase: chargeUseCase, AuthorizationUseCase: authorizationUseCase, IdempotencyUseCase: idempotencyUseCase, AccountIDs: []int64{}, IdempotencyKeys: []string{}, OperationTypeIDs: []int64{}}
 }
 
 func CleanupTest(t *testing.T, setup *TestContext) {
-	_, err := setup.DB.Exec("DELETE FROM transactions WHERE account_id = ANY($1)", pq.Array(setup.AccountIDs))
+	_, err := setup.DB.Exec("DELETE FROM disputes WHERE account_id = ANY($1)", pq.Array(setup.AccountIDs))
+	assert.NoError(t, err, "failed to clean up disputes")
+
+	_, err = setup.DB.Exec("DELETE FROM authorizations WHERE account_id = ANY($1)", pq.Array(setup.AccountIDs))
+	assert.NoError(t, err, "failed to clean up authorizations")
+
+	_, err = setup.DB.Exec("DELETE FROM transactions WHERE account_id = ANY($1)", pq.Array(setup.AccountIDs))
 	assert.NoError(t, err, "failed to clean up transactions")
 
 	_, err = setup.DB.Exec("DELETE FROM accounts WHERE id = ANY($1)", pq.Array(setup.AccountIDs))
 	assert.NoError(t, err, "failed to clean up accounts")
 
+	_, err = setup.DB.Exec("DELETE FROM operation_types WHERE id = ANY($1)", pq.Array(setup.OperationTypeIDs))
+	assert.NoError(t, err, "failed to clean up operation types")
+
+	_, err = setup.DB.Exec("DELETE FROM idempotency_keys WHERE key = ANY($1)", pq.Array(setup.IdempotencyKeys))
+	assert.NoError(t, err, "failed to clean up idempotency keys")
+
 	setup.DB.Close()
 }
 
@@ -84,3 +152,47 @@ func CreateRequest(t *testing.T, method, url string, body interface{}) (*httptes
 	w := httptest.NewRecorder()
 	return w, req
 }
+
+// CreateIdempotentRequest is like CreateRequest but sends an Idempotency-Key header, which is
+// removed from the database on cleanup.
+func CreateIdempotentRequest(t *testing.T, setup *TestContext, method, url, key string, body interface{}) (*httptest.ResponseRecorder, *http.Request) {
+	w, req := CreateRequest(t, method, url, body)
+	req.Header.Set(middleware.IdempotencyKeyHeader, key)
+	setup.IdempotencyKeys = append(setup.IdempotencyKeys, key)
+	return w, req
+}
+
+func CreateAccount(t *testing.T, setup *TestContext, body dto.CreateAccountRequest) int64 {
+	w, req := CreateRequest(t, http.MethodPost, "/accounts", body)
+	setup.Router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var accountResponse dto.CreateAccountResponse
+	err := json.Unmarshal(w.Body.Bytes(), &accountResponse)
+	assert.NoError(t, err)
+	setup.AccountIDs = append(setup.AccountIDs, accountResponse.ID)
+	return accountResponse.ID
+}
+
+func CreateTransaction(t *testing.T, setup *TestContext, body dto.CreateTransactionRequest) int64 {
+	w, req := CreateRequest(t, http.MethodPost, "/transactions", body)
+	setup.Router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var transactionResponse dto.CreateTransactionResponse
+	err := json.Unmarshal(w.Body.Bytes(), &transactionResponse)
+	assert.NoError(t, err)
+	return transactionResponse.ID
+}
+
+func CreateOperationType(t *testing.T, setup *TestContext, body dto.CreateOperationTypeRequest) int {
+	w, req := CreateRequest(t, http.MethodPost, "/operation-types", body)
+	setup.Router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var operationTypeResponse dto.CreateOperationTypeResponse
+	err := json.Unmarshal(w.Body.Bytes(), &operationTypeResponse)
+	assert.NoError(t, err)
+	setup.OperationTypeIDs = append(setup.OperationTypeIDs, int64(operationTypeResponse.ID))
+	return operationTypeResponse.ID
+}
@@ -1,13 +1,22 @@
 package integration
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
 	"github.com/VieiraVitor/transaction-flow/internal/api/response"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/infra/repository"
 	"github.com/VieiraVitor/transaction-flow/internal/tests/integration/testutils"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -16,7 +25,7 @@ func TestCreateTransaction_WhenValidInput_ShouldReturn201(t *testing.T) {
 	setup := testutils.SetupTest(t)
 	defer testutils.CleanupTest(t, setup)
 
-	w, req := testutils.CreateRequest(t, http.MethodPost, "/accounts", dto.CreateAccountRequest{DocumentNumber: "01101101001"})
+	w, req := testutils.CreateRequest(t, http.MethodPost, "/accounts", dto.CreateAccountRequest{DocumentNumber: "01101101024"})
 	setup.Router.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusCreated, w.Code)
 	var accountResponse dto.CreateAccountResponse
@@ -27,7 +36,7 @@ func TestCreateTransaction_WhenValidInput_ShouldReturn201(t *testing.T) {
 	body := dto.CreateTransactionRequest{
 		AccountID:       accountResponse.ID,
 		OperationTypeID: 4,
-		Amount:          100,
+		Amount:          domain.MustParseMoney("100"),
 	}
 
 	w, req = testutils.CreateRequest(t, http.MethodPost, "/transactions", body)
@@ -52,7 +61,7 @@ func TestCreateTransaction_WhenCreateMoreThanOneTransactionSuccessfully_ShouldRe
 	defer testutils.CleanupTest(t, setup)
 
 	// Create new account
-	w, req := testutils.CreateRequest(t, http.MethodPost, "/accounts", dto.CreateAccountRequest{DocumentNumber: "01101101001"})
+	w, req := testutils.CreateRequest(t, http.MethodPost, "/accounts", dto.CreateAccountRequest{DocumentNumber: "01101101024"})
 	setup.Router.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusCreated, w.Code)
 	var response dto.CreateAccountResponse
@@ -64,7 +73,7 @@ func TestCreateTransaction_WhenCreateMoreThanOneTransactionSuccessfully_ShouldRe
 	body := dto.CreateTransactionRequest{
 		AccountID:       response.ID,
 		OperationTypeID: 4,
-		Amount:          100,
+		Amount:          domain.MustParseMoney("100"),
 	}
 
 	w, req = testutils.CreateRequest(t, http.MethodPost, "/transactions", body)
@@ -81,7 +90,7 @@ func TestCreateTransaction_WhenCreateMoreThanOneTransactionSuccessfully_ShouldRe
 	body2 := dto.CreateTransactionRequest{
 		AccountID:       response.ID,
 		OperationTypeID: 4,
-		Amount:          100,
+		Amount:          domain.MustParseMoney("100"),
 	}
 
 	w, req = testutils.CreateRequest(t, http.MethodPost, "/transactions", body2)
@@ -126,7 +135,7 @@ func TestCreateTransaction_WhenValidationFailed_ShouldReturn422(t *testing.T) {
 	body := dto.CreateTransactionRequest{
 		AccountID:       1,
 		OperationTypeID: 0,
-		Amount:          100,
+		Amount:          domain.MustParseMoney("100"),
 	}
 
 	w, req := testutils.CreateRequest(t, http.MethodPost, "/transactions", body)
@@ -144,11 +153,36 @@ func TestCreateTransaction_WhenValidationFailed_ShouldReturn422(t *testing.T) {
 	assert.Equal(t, "operationTypeID is mandatory", errorResponse.Description)
 }
 
+func TestCreateTransaction_WhenAccountDoesNotExist_ShouldReturn422(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	body := dto.CreateTransactionRequest{
+		AccountID:       -1,
+		OperationTypeID: 1,
+		Amount:          domain.MustParseMoney("100"),
+	}
+
+	w, req := testutils.CreateRequest(t, http.MethodPost, "/transactions", body)
+
+	// Act
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "account not found", errorResponse.Error)
+	assert.Equal(t, "account of the transaction does not exist: -1", errorResponse.Description)
+}
+
 func TestCreateTransaction_WhenCreateTransactionFails_ShouldReturn500(t *testing.T) {
 	// Arrange
 	setup := testutils.SetupTest(t)
 
-	w, req := testutils.CreateRequest(t, http.MethodPost, "/accounts", dto.CreateAccountRequest{DocumentNumber: "01101101001"})
+	w, req := testutils.CreateRequest(t, http.MethodPost, "/accounts", dto.CreateAccountRequest{DocumentNumber: "01101101024"})
 	setup.Router.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusCreated, w.Code)
 	var accountResponse dto.CreateAccountResponse
@@ -159,7 +193,7 @@ func TestCreateTransaction_WhenCreateTransactionFails_ShouldReturn500(t *testing
 	body := dto.CreateTransactionRequest{
 		AccountID:       1,
 		OperationTypeID: 1,
-		Amount:          100,
+		Amount:          domain.MustParseMoney("100"),
 	}
 
 	testutils.CleanupTest(t, setup) // Close the connection before trying to create the transaction
@@ -175,7 +209,542 @@ func TestCreateTransaction_WhenCreateTransactionFails_ShouldReturn500(t *testing
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusInternalServerError, errorResponse.StatusCode)
 	assert.Equal(t, "could not create transaction", errorResponse.Error)
-	assert.Equal(t, "failed to create transaction: sql: database is closed", errorResponse.Description)
+	assert.Equal(t, "failed to list operation types: sql: database is closed", errorResponse.Description)
+}
+
+func TestCreateTransaction_WhenPurchaseWithinCreditLimit_ShouldDecrementLimit(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+
+	body := dto.CreateTransactionRequest{
+		AccountID:       accountID,
+		OperationTypeID: 1,
+		Amount:          domain.MustParseMoney("60"),
+	}
+	w, req := testutils.CreateRequest(t, http.MethodPost, "/transactions", body)
+
+	// Act
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assertAvailableCreditLimit(setup, t, accountID, "40")
+
+	// Act - payment restores the limit
+	body = dto.CreateTransactionRequest{
+		AccountID:       accountID,
+		OperationTypeID: 4,
+		Amount:          domain.MustParseMoney("60"),
+	}
+	w, req = testutils.CreateRequest(t, http.MethodPost, "/transactions", body)
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assertAvailableCreditLimit(setup, t, accountID, "100")
+}
+
+func TestCreateTransaction_WhenPurchaseExceedsCreditLimit_ShouldReturn422(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("50")})
+
+	body := dto.CreateTransactionRequest{
+		AccountID:       accountID,
+		OperationTypeID: 3,
+		Amount:          domain.MustParseMoney("50.01"),
+	}
+	w, req := testutils.CreateRequest(t, http.MethodPost, "/transactions", body)
+
+	// Act
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "insufficient credit limit", errorResponse.Error)
+	assertAvailableCreditLimit(setup, t, accountID, "50")
+}
+
+func TestCreateTransaction_WhenConcurrentPurchasesExceedCreditLimit_ShouldAcceptOnlyOne(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+
+	body := dto.CreateTransactionRequest{
+		AccountID:       accountID,
+		OperationTypeID: 1,
+		Amount:          domain.MustParseMoney("80"),
+	}
+
+	// Act
+	statusCodes := make([]int, 2)
+	var wg sync.WaitGroup
+	for i := range statusCodes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			w, req := testutils.CreateRequest(t, http.MethodPost, "/transactions", body)
+			setup.Router.ServeHTTP(w, req)
+			statusCodes[i] = w.Code
+		}(i)
+	}
+	wg.Wait()
+
+	// Assert
+	assert.ElementsMatch(t, []int{http.StatusCreated, http.StatusUnprocessableEntity}, statusCodes)
+	assertAvailableCreditLimit(setup, t, accountID, "20")
+}
+
+func TestCreateTransaction_WhenRetriedWithSameIdempotencyKey_ShouldReplayResponse(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+	key := uuid.NewString()
+	body := dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 1, Amount: domain.MustParseMoney("30")}
+
+	w, req := testutils.CreateIdempotentRequest(t, setup, http.MethodPost, "/transactions", key, body)
+	setup.Router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	// Act
+	retry, req := testutils.CreateIdempotentRequest(t, setup, http.MethodPost, "/transactions", key, body)
+	setup.Router.ServeHTTP(retry, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, w.Body.String(), retry.Body.String())
+	assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+	assertTransactionCount(setup, t, accountID, 1)
+	assertAvailableCreditLimit(setup, t, accountID, "70")
+}
+
+func TestCreateTransaction_WhenIdempotencyKeyReusedWithDifferentPayload_ShouldReturn422(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+	key := uuid.NewString()
+
+	w, req := testutils.CreateIdempotentRequest(t, setup, http.MethodPost, "/transactions", key,
+		dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 1, Amount: domain.MustParseMoney("30")})
+	setup.Router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	// Act
+	w, req = testutils.CreateIdempotentRequest(t, setup, http.MethodPost, "/transactions", key,
+		dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 1, Amount: domain.MustParseMoney("40")})
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "idempotency key reused", errorResponse.Error)
+	assertTransactionCount(setup, t, accountID, 1)
+}
+
+func TestCreateTransaction_WhenIdempotencyReservationIsStale_ShouldTakeItOver(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+	key := uuid.NewString()
+	body := dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 1, Amount: domain.MustParseMoney("10")}
+
+	// A request that reserved the key and died before answering.
+	_, err := setup.DB.Exec(`
+		INSERT INTO idempotency_keys (scope, key, request_hash, expires_at, locked_until)
+		VALUES ('POST /transactions', $1, $2, NOW() + INTERVAL '1 hour', NOW() - INTERVAL '1 second')`,
+		key, strings.Repeat("0", 64))
+	assert.NoError(t, err)
+
+	// Act
+	w, req := testutils.CreateIdempotentRequest(t, setup, http.MethodPost, "/transactions", key, body)
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assertTransactionCount(setup, t, accountID, 1)
+}
+
+func TestIdempotencyKey_WhenLeaseRunsOutAndKeyIsTakenOver_ShouldKeepNewOwnersClaim(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	ctx := context.Background()
+	scope, key, body := "POST /transactions", uuid.NewString(), []byte(`{"amount": 10}`)
+	setup.IdempotencyKeys = append(setup.IdempotencyKeys, key)
+
+	_, firstToken, err := setup.IdempotencyUseCase.BeginRequest(ctx, scope, key, body)
+	assert.NoError(t, err)
+
+	// The first request is still running when its lease runs out and a retry takes over.
+	_, err = setup.DB.Exec("UPDATE idempotency_keys SET locked_until = NOW() - INTERVAL '1 second' WHERE scope = $1 AND key = $2", scope, key)
+	assert.NoError(t, err)
+	recorded, secondToken, err := setup.IdempotencyUseCase.BeginRequest(ctx, scope, key, body)
+	assert.NoError(t, err)
+	assert.Nil(t, recorded)
+
+	// Act
+	errorErr := setup.IdempotencyUseCase.CompleteRequest(ctx, scope, key, firstToken, domain.IdempotentResponse{StatusCode: http.StatusInternalServerError})
+	saveErr := setup.IdempotencyUseCase.CompleteRequest(ctx, scope, key, firstToken, domain.IdempotentResponse{StatusCode: http.StatusCreated, Body: []byte(`{"id":1}`)})
+
+	// Assert
+	assert.NoError(t, errorErr)
+	assert.ErrorIs(t, saveErr, repository.ErrIdempotencyClaimLost)
+
+	_, _, err = setup.IdempotencyUseCase.BeginRequest(ctx, scope, key, body)
+	assert.ErrorIs(t, err, repository.ErrIdempotencyKeyInProgress)
+
+	err = setup.IdempotencyUseCase.CompleteRequest(ctx, scope, key, secondToken, domain.IdempotentResponse{StatusCode: http.StatusCreated, Body: []byte(`{"id":2}`)})
+	assert.NoError(t, err)
+	recorded, _, err = setup.IdempotencyUseCase.BeginRequest(ctx, scope, key, body)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`{"id":2}`), recorded.Body)
+}
+
+func TestCreateTransaction_WhenConcurrentRequestsShareIdempotencyKey_ShouldExecuteOnlyOnce(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+	key := uuid.NewString()
+	body := dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 1, Amount: domain.MustParseMoney("10")}
+
+	requests := make([]*http.Request, 5)
+	for i := range requests {
+		_, requests[i] = testutils.CreateIdempotentRequest(t, setup, http.MethodPost, "/transactions", key, body)
+	}
+
+	// Act
+	statusCodes := make([]int, len(requests))
+	var wg sync.WaitGroup
+	for i := range requests {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			setup.Router.ServeHTTP(w, requests[i])
+			statusCodes[i] = w.Code
+		}(i)
+	}
+	wg.Wait()
+
+	// Assert
+	for _, statusCode := range statusCodes {
+		assert.Contains(t, []int{http.StatusCreated, http.StatusConflict}, statusCode)
+	}
+	assertTransactionCount(setup, t, accountID, 1)
+	assertAvailableCreditLimit(setup, t, accountID, "90")
+}
+
+func assertTransactionCount(setup *testutils.TestContext, t *testing.T, accountID int64, expected int) {
+	var count int
+	err := setup.DB.QueryRow("SELECT COUNT(*) FROM transactions WHERE account_id = $1", accountID).Scan(&count)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, count)
+}
+
+func TestUpdateCreditLimit_WhenAccountExists_ShouldReturn204(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+
+	w, req := testutils.CreateRequest(t, http.MethodPatch, fmt.Sprintf("/accounts/%d/credit-limit", accountID), map[string]float64{"credit_limit": 250})
+
+	// Act
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assertAvailableCreditLimit(setup, t, accountID, "250")
+}
+
+func TestCreateTransaction_WhenPayment_ShouldDischargeDebitsOldestFirst(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("1000")})
+
+	purchaseID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 1, Amount: domain.MustParseMoney("50")})
+	withdrawID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 3, Amount: domain.MustParseMoney("23.5")})
+	secondPurchaseID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 1, Amount: domain.MustParseMoney("18.7")})
+
+	// Act
+	paymentID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 4, Amount: domain.MustParseMoney("60")})
+
+	// Assert
+	assertTransactionBalance(setup, t, purchaseID, "0")
+	assertTransactionBalance(setup, t, withdrawID, "-13.5")
+	assertTransactionBalance(setup, t, secondPurchaseID, "-18.7")
+	assertTransactionBalance(setup, t, paymentID, "0")
+
+	// Act - a second payment settles the remaining debits and keeps the surplus
+	secondPaymentID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 4, Amount: domain.MustParseMoney("100")})
+
+	// Assert
+	assertTransactionBalance(setup, t, withdrawID, "0")
+	assertTransactionBalance(setup, t, secondPurchaseID, "0")
+	assertTransactionBalance(setup, t, secondPaymentID, "67.8")
+}
+
+func TestCreateTransaction_WhenPaymentWithoutOpenDebits_ShouldKeepFullBalance(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024"})
+
+	// Act
+	paymentID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 4, Amount: domain.MustParseMoney("45.9")})
+
+	// Assert
+	assertTransactionBalance(setup, t, paymentID, "45.9")
+
+	// Act - debits created after the payment are not discharged by it
+	purchaseID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 1, Amount: domain.MustParseMoney("10")})
+
+	// Assert
+	assertTransactionBalance(setup, t, purchaseID, "-10")
+	assertTransactionBalance(setup, t, paymentID, "45.9")
+}
+
+func TestCreateTransaction_WhenPaymentExceedsOpenDebits_ShouldKeepSurplusAsCreditBalance(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+	testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 1, Amount: domain.MustParseMoney("30")})
+
+	// Act
+	paymentID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 4, Amount: domain.MustParseMoney("100")})
+
+	// Assert
+	assertAvailableCreditLimit(setup, t, accountID, "100")
+	assertCreditBalance(setup, t, accountID, "70")
+	assertTransactionBalance(setup, t, paymentID, "70")
+
+	// Act - the next debits are taken from the credit balance first
+	testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 1, Amount: domain.MustParseMoney("50")})
+
+	// Assert
+	assertAvailableCreditLimit(setup, t, accountID, "100")
+	assertCreditBalance(setup, t, accountID, "20")
+
+	// Act - reversing the payment spends the rest of the credit balance and then the limit
+	reverseTransaction(t, setup, paymentID, nil)
+
+	// Assert
+	assertAvailableCreditLimit(setup, t, accountID, "20")
+	assertCreditBalance(setup, t, accountID, "0")
+}
+
+func TestGetAccountBalance_WhenTransactionsExist_ShouldReturnTotals(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("1000")})
+
+	oldPurchaseID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 1, Amount: domain.MustParseMoney("100")})
+	testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 3, Amount: domain.MustParseMoney("20.5")})
+	testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 4, Amount: domain.MustParseMoney("50")})
+
+	_, err := setup.DB.Exec("UPDATE transactions SET event_date = '2025-01-10T12:00:00' WHERE id = $1", oldPurchaseID)
+	assert.NoError(t, err)
+
+	// Act
+	w, req := testutils.CreateRequest(t, http.MethodGet, fmt.Sprintf("/accounts/%d/balance", accountID), nil)
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var balance dto.AccountBalanceResponse
+	err = json.Unmarshal(w.Body.Bytes(), &balance)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.MustParseMoney("-70.5"), balance.Balance)
+	assert.Equal(t, domain.MustParseMoney("120.5"), balance.TotalDebits)
+	assert.Equal(t, domain.MustParseMoney("50"), balance.TotalCredits)
+	assert.NotNil(t, balance.LastTransactionAt)
+
+	// Act - only the backdated purchase happened up to as_of
+	w, req = testutils.CreateRequest(t, http.MethodGet, fmt.Sprintf("/accounts/%d/balance?as_of=2025-01-31T00:00:00Z", accountID), nil)
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	err = json.Unmarshal(w.Body.Bytes(), &balance)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.MustParseMoney("-100"), balance.Balance)
+	assert.Equal(t, domain.MustParseMoney("100"), balance.TotalDebits)
+	assert.True(t, balance.TotalCredits.IsZero())
+	assert.Equal(t, "2025-01-10T12:00:00Z", balance.LastTransactionAt.UTC().Format(time.RFC3339))
+}
+
+func TestGetAccountBalance_WhenAccountDoesNotExist_ShouldReturn404(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	w, req := testutils.CreateRequest(t, http.MethodGet, "/accounts/9999999/balance", nil)
+
+	// Act
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func assertTransactionBalance(setup *testutils.TestContext, t *testing.T, transactionID int64, expected string) {
+	var balance domain.Money
+	err := setup.DB.QueryRow("SELECT balance FROM transactions WHERE id = $1", transactionID).Scan(&balance)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.MustParseMoney(expected), balance)
+}
+
+func TestListTransactions_WhenPaginating_ShouldReturnEveryTransactionOnce(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("1000")})
+
+	var createdIDs []int64
+	for i := 0; i < 5; i++ {
+		createdIDs = append(createdIDs, testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 1, Amount: domain.MustParseMoney("10")}))
+	}
+	createdIDs = append(createdIDs, testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 4, Amount: domain.MustParseMoney("10")}))
+
+	// Act
+	var listedIDs []int64
+	url := fmt.Sprintf("/accounts/%d/transactions?limit=2", accountID)
+	for pages := 0; url != ""; pages++ {
+		assert.Less(t, pages, 3)
+
+		w, req := testutils.CreateRequest(t, http.MethodGet, url, nil)
+		setup.Router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var page dto.ListTransactionsResponse
+		err := json.Unmarshal(w.Body.Bytes(), &page)
+		assert.NoError(t, err)
+		for _, transaction := range page.Transactions {
+			listedIDs = append(listedIDs, transaction.ID)
+		}
+
+		url = ""
+		if page.NextCursor != "" {
+			url = fmt.Sprintf("/accounts/%d/transactions?limit=2&cursor=%s", accountID, page.NextCursor)
+		}
+	}
+
+	// Assert
+	assert.Equal(t, []int64{createdIDs[5], createdIDs[4], createdIDs[3], createdIDs[2], createdIDs[1], createdIDs[0]}, listedIDs)
+}
+
+func TestListTransactions_WhenFilteringByOperationType_ShouldReturnOnlyMatching(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("1000")})
+	testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 1, Amount: domain.MustParseMoney("10")})
+	paymentID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 4, Amount: domain.MustParseMoney("10")})
+
+	w, req := testutils.CreateRequest(t, http.MethodGet, fmt.Sprintf("/accounts/%d/transactions?operation_type_id=4", accountID), nil)
+
+	// Act
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var page dto.ListTransactionsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &page)
+	assert.NoError(t, err)
+	assert.Len(t, page.Transactions, 1)
+	assert.Equal(t, paymentID, page.Transactions[0].ID)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestGetTransaction_WhenTransactionExists_ShouldReturn200(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	accountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("1000")})
+	transactionID := testutils.CreateTransaction(t, setup, dto.CreateTransactionRequest{AccountID: accountID, OperationTypeID: 3, Amount: domain.MustParseMoney("42.5")})
+
+	w, req := testutils.CreateRequest(t, http.MethodGet, fmt.Sprintf("/transactions/%d", transactionID), nil)
+
+	// Act
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var transactionResponse dto.GetTransactionResponse
+	err := json.Unmarshal(w.Body.Bytes(), &transactionResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, transactionID, transactionResponse.ID)
+	assert.Equal(t, accountID, transactionResponse.AccountID)
+	assert.Equal(t, 3, transactionResponse.OperationTypeID)
+	assert.Equal(t, "SAQUE", transactionResponse.OperationTypeDescription)
+	assert.Equal(t, domain.MustParseMoney("-42.5"), transactionResponse.Amount)
+	assert.False(t, transactionResponse.EventDate.IsZero())
+	assert.False(t, transactionResponse.CreatedAt.IsZero())
+}
+
+func TestGetTransaction_WhenTransactionDoesNotExist_ShouldReturn404(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	w, req := testutils.CreateRequest(t, http.MethodGet, "/transactions/9999999", nil)
+
+	// Act
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "transaction not found", errorResponse.Error)
+}
+
+func assertAvailableCreditLimit(setup *testutils.TestContext, t *testing.T, accountID int64, expected string) {
+	var availableCreditLimit domain.Money
+	err := setup.DB.QueryRow("SELECT available_credit_limit FROM accounts WHERE id = $1", accountID).Scan(&availableCreditLimit)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.MustParseMoney(expected), availableCreditLimit)
+}
+
+func assertCreditBalance(setup *testutils.TestContext, t *testing.T, accountID int64, expected string) {
+	var creditBalance domain.Money
+	err := setup.DB.QueryRow("SELECT credit_balance FROM accounts WHERE id = $1", accountID).Scan(&creditBalance)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.MustParseMoney(expected), creditBalance)
 }
 
 func assertCreateTransaction(setup *testutils.TestContext,
@@ -186,7 +755,7 @@ func assertCreateTransaction(setup *testutils.TestContext,
 		transactionID   int64
 		accountID       int64
 		operationTypeID int
-		amount          float64
+		amount          domain.Money
 	)
 	err := setup.DB.QueryRow(
 		"SELECT id, account_id, operation_type_id, amount FROM transactions WHERE id = $1",
@@ -0,0 +1,110 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/VieiraVitor/transaction-flow/internal/api/dto"
+	"github.com/VieiraVitor/transaction-flow/internal/api/response"
+	"github.com/VieiraVitor/transaction-flow/internal/domain"
+	"github.com/VieiraVitor/transaction-flow/internal/tests/integration/testutils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTransfer_WhenSenderHasCreditLimit_ShouldDebitAndCreditBothAccounts(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	fromAccountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+	toAccountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "77777777009", AvailableCreditLimit: domain.MustParseMoney("100")})
+	w, req := testutils.CreateRequest(t, http.MethodPost, "/transfers", dto.CreateTransferRequest{FromAccountID: fromAccountID, ToAccountID: toAccountID, Amount: domain.MustParseMoney("30")})
+
+	// Act
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var transfer dto.TransferResponse
+	err := json.Unmarshal(w.Body.Bytes(), &transfer)
+	assert.NoError(t, err)
+	assertAvailableCreditLimit(setup, t, fromAccountID, "70")
+	assertAvailableCreditLimit(setup, t, toAccountID, "100")
+	assertCreditBalance(setup, t, toAccountID, "30")
+
+	for _, leg := range []struct {
+		transactionID   int64
+		accountID       int64
+		operationTypeID domain.OperationType
+		amount          string
+	}{
+		{transfer.DebitTransactionID, fromAccountID, domain.TransferenciaEnviada, "-30"},
+		{transfer.CreditTransactionID, toAccountID, domain.TransferenciaRecebida, "30"},
+	} {
+		var (
+			accountID       int64
+			operationTypeID domain.OperationType
+			amount          domain.Money
+			transferID      int64
+		)
+		err := setup.DB.QueryRow("SELECT account_id, operation_type_id, amount, transfer_id FROM transactions WHERE id = $1", leg.transactionID).
+			Scan(&accountID, &operationTypeID, &amount, &transferID)
+		assert.NoError(t, err)
+		assert.Equal(t, leg.accountID, accountID)
+		assert.Equal(t, leg.operationTypeID, operationTypeID)
+		assert.Equal(t, domain.MustParseMoney(leg.amount), amount)
+		assert.Equal(t, transfer.ID, transferID)
+	}
+}
+
+func TestCreateTransfer_WhenInsufficientCreditLimit_ShouldNotRecordEitherLeg(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	fromAccountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+	toAccountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "77777777009", AvailableCreditLimit: domain.MustParseMoney("100")})
+	w, req := testutils.CreateRequest(t, http.MethodPost, "/transfers", dto.CreateTransferRequest{FromAccountID: fromAccountID, ToAccountID: toAccountID, Amount: domain.MustParseMoney("150")})
+
+	// Act
+	setup.Router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var errorResponse response.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, "insufficient credit limit", errorResponse.Error)
+	assertTransactionCount(setup, t, fromAccountID, 0)
+	assertTransactionCount(setup, t, toAccountID, 0)
+	assertAvailableCreditLimit(setup, t, fromAccountID, "100")
+	assertAvailableCreditLimit(setup, t, toAccountID, "100")
+}
+
+func TestCreateTransfer_WhenRetriedWithSameIdempotencyKey_ShouldTransferOnlyOnce(t *testing.T) {
+	// Arrange
+	setup := testutils.SetupTest(t)
+	defer testutils.CleanupTest(t, setup)
+
+	fromAccountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "01101101024", AvailableCreditLimit: domain.MustParseMoney("100")})
+	toAccountID := testutils.CreateAccount(t, setup, dto.CreateAccountRequest{DocumentNumber: "77777777009", AvailableCreditLimit: domain.MustParseMoney("100")})
+	key := uuid.NewString()
+	body := dto.CreateTransferRequest{FromAccountID: fromAccountID, ToAccountID: toAccountID, Amount: domain.MustParseMoney("30")}
+
+	w, req := testutils.CreateIdempotentRequest(t, setup, http.MethodPost, "/transfers", key, body)
+	setup.Router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	// Act
+	retry, req := testutils.CreateIdempotentRequest(t, setup, http.MethodPost, "/transfers", key, body)
+	setup.Router.ServeHTTP(retry, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, w.Body.String(), retry.Body.String())
+	assertTransactionCount(setup, t, fromAccountID, 1)
+	assertTransactionCount(setup, t, toAccountID, 1)
+	assertAvailableCreditLimit(setup, t, fromAccountID, "70")
+}
@@ -0,0 +1 @@
+ALTER TABLE accounts ADD COLUMN available_credit_limit NUMERIC(15, 2) NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+DROP INDEX idx_transactions_open_debits;
+
+ALTER TABLE transactions DROP COLUMN balance;
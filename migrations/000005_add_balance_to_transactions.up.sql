@@ -0,0 +1,7 @@
+ALTER TABLE transactions ADD COLUMN balance NUMERIC(15, 2);
+
+UPDATE transactions SET balance = amount;
+
+ALTER TABLE transactions ALTER COLUMN balance SET NOT NULL;
+
+CREATE INDEX idx_transactions_open_debits ON transactions (account_id, event_date, id) WHERE balance < 0;
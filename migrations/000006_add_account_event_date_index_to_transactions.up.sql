@@ -0,0 +1 @@
+CREATE INDEX idx_transactions_account_id_event_date ON transactions (account_id, event_date);
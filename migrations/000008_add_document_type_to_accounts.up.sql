@@ -0,0 +1,5 @@
+ALTER TABLE accounts ADD COLUMN document_type VARCHAR(4) CHECK (document_type IN ('CPF', 'CNPJ'));
+
+UPDATE accounts
+SET document_type = CASE length(document_number) WHEN 11 THEN 'CPF' WHEN 14 THEN 'CNPJ' END
+WHERE document_number ~ '^[0-9]+$';
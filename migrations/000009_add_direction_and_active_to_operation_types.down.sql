@@ -0,0 +1,2 @@
+ALTER TABLE operation_types DROP COLUMN active;
+ALTER TABLE operation_types DROP COLUMN direction;
@@ -0,0 +1,7 @@
+ALTER TABLE operation_types ADD COLUMN direction VARCHAR(6) NOT NULL DEFAULT 'DEBIT' CHECK (direction IN ('DEBIT', 'CREDIT'));
+ALTER TABLE operation_types ADD COLUMN active BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE operation_types ALTER COLUMN direction DROP DEFAULT;
+
+UPDATE operation_types SET direction = 'CREDIT' WHERE description = 'PAGAMENTO';
+
+SELECT setval('operation_types_id_seq', (SELECT MAX(id) FROM operation_types));
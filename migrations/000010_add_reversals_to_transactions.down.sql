@@ -0,0 +1,4 @@
+DROP INDEX idx_transactions_reverses_transaction_id;
+ALTER TABLE transactions DROP COLUMN reverses_transaction_id;
+
+DELETE FROM operation_types WHERE id IN (5, 6);
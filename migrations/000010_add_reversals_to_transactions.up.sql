@@ -0,0 +1,9 @@
+ALTER TABLE transactions ADD COLUMN reverses_transaction_id INT REFERENCES transactions(id);
+
+CREATE INDEX idx_transactions_reverses_transaction_id ON transactions (reverses_transaction_id) WHERE reverses_transaction_id IS NOT NULL;
+
+INSERT INTO operation_types (id, description, direction) VALUES
+(5, 'ESTORNO', 'CREDIT'),
+(6, 'ESTORNO DE PAGAMENTO', 'DEBIT');
+
+SELECT setval('operation_types_id_seq', (SELECT MAX(id) FROM operation_types));
@@ -0,0 +1 @@
+ALTER TABLE accounts ADD COLUMN due_day SMALLINT NOT NULL DEFAULT 10 CHECK (due_day BETWEEN 1 AND 28);
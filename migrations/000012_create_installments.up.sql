@@ -0,0 +1,11 @@
+CREATE TABLE installments (
+    id SERIAL PRIMARY KEY,
+    transaction_id INT NOT NULL,
+    number SMALLINT NOT NULL,
+    amount NUMERIC(15, 2) NOT NULL CHECK (amount > 0),
+    due_date DATE NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE CASCADE,
+    UNIQUE (transaction_id, number)
+);
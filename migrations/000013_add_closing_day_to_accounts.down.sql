@@ -0,0 +1,2 @@
+ALTER TABLE accounts DROP CONSTRAINT accounts_closing_day_due_day_check;
+ALTER TABLE accounts DROP COLUMN closing_day;
@@ -0,0 +1,2 @@
+ALTER TABLE accounts ADD COLUMN closing_day SMALLINT NOT NULL DEFAULT 3 CHECK (closing_day BETWEEN 1 AND 28);
+ALTER TABLE accounts ADD CONSTRAINT accounts_closing_day_due_day_check CHECK (closing_day <> due_day);
@@ -0,0 +1,2 @@
+DROP TABLE invoice_items;
+DROP TABLE invoices;
@@ -0,0 +1,29 @@
+CREATE TABLE invoices (
+    id SERIAL PRIMARY KEY,
+    account_id INT NOT NULL,
+    period_start TIMESTAMP NOT NULL,
+    closing_date DATE NOT NULL,
+    due_date DATE NOT NULL,
+    previous_balance NUMERIC(15, 2) NOT NULL,
+    total_debits NUMERIC(15, 2) NOT NULL,
+    total_credits NUMERIC(15, 2) NOT NULL,
+    balance NUMERIC(15, 2) NOT NULL,
+    minimum_payment NUMERIC(15, 2) NOT NULL,
+    closed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE,
+    UNIQUE (account_id, closing_date)
+);
+
+CREATE TABLE invoice_items (
+    id SERIAL PRIMARY KEY,
+    invoice_id INT NOT NULL,
+    transaction_id INT NOT NULL,
+    installment_number SMALLINT,
+    amount NUMERIC(15, 2) NOT NULL,
+
+    FOREIGN KEY (invoice_id) REFERENCES invoices(id) ON DELETE CASCADE,
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_invoice_items_invoice_id ON invoice_items (invoice_id);
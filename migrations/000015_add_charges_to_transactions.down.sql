@@ -0,0 +1,5 @@
+DROP INDEX idx_transactions_invoice_charges;
+ALTER TABLE transactions DROP COLUMN explanation;
+ALTER TABLE transactions DROP COLUMN invoice_id;
+
+DELETE FROM operation_types WHERE id IN (7, 8);
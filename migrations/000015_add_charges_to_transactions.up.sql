@@ -0,0 +1,10 @@
+ALTER TABLE transactions ADD COLUMN invoice_id INT REFERENCES invoices(id);
+ALTER TABLE transactions ADD COLUMN explanation VARCHAR(255);
+
+CREATE UNIQUE INDEX idx_transactions_invoice_charges ON transactions (invoice_id, operation_type_id, event_date) WHERE invoice_id IS NOT NULL;
+
+INSERT INTO operation_types (id, description, direction) VALUES
+(7, 'JUROS ROTATIVOS', 'DEBIT'),
+(8, 'MULTA POR ATRASO', 'DEBIT');
+
+SELECT setval('operation_types_id_seq', (SELECT MAX(id) FROM operation_types));
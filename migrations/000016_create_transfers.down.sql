@@ -0,0 +1,6 @@
+DROP INDEX idx_transactions_transfer_id;
+ALTER TABLE transactions DROP COLUMN transfer_id;
+
+DROP TABLE transfers;
+
+DELETE FROM operation_types WHERE id IN (9, 10);
@@ -0,0 +1,21 @@
+CREATE TABLE transfers (
+    id SERIAL PRIMARY KEY,
+    from_account_id INT NOT NULL,
+    to_account_id INT NOT NULL,
+    amount NUMERIC(15, 2) NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (from_account_id) REFERENCES accounts(id) ON DELETE CASCADE,
+    FOREIGN KEY (to_account_id) REFERENCES accounts(id) ON DELETE CASCADE,
+    CONSTRAINT transfers_distinct_accounts_check CHECK (from_account_id <> to_account_id)
+);
+
+ALTER TABLE transactions ADD COLUMN transfer_id INT REFERENCES transfers(id);
+
+CREATE INDEX idx_transactions_transfer_id ON transactions (transfer_id) WHERE transfer_id IS NOT NULL;
+
+INSERT INTO operation_types (id, description, direction) VALUES
+(9, 'TRANSFERENCIA ENVIADA', 'DEBIT'),
+(10, 'TRANSFERENCIA RECEBIDA', 'CREDIT');
+
+SELECT setval('operation_types_id_seq', (SELECT MAX(id) FROM operation_types));
@@ -0,0 +1,5 @@
+DROP TRIGGER postings_journal_entry_balance_check ON postings;
+DROP FUNCTION check_journal_entry_balance();
+
+DROP TABLE postings;
+DROP TABLE journal_entries;
@@ -0,0 +1,59 @@
+CREATE TABLE journal_entries (
+    id SERIAL PRIMARY KEY,
+    transaction_id INT NOT NULL UNIQUE,
+    entry_date TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE CASCADE
+);
+
+CREATE TABLE postings (
+    id SERIAL PRIMARY KEY,
+    journal_entry_id INT NOT NULL,
+    ledger_account VARCHAR(32) NOT NULL CHECK (ledger_account IN ('RECEIVABLES', 'CASH', 'FEE_INCOME', 'TRANSFER_CLEARING')),
+    account_id INT,
+    amount NUMERIC(15, 2) NOT NULL CHECK (amount <> 0),
+
+    FOREIGN KEY (journal_entry_id) REFERENCES journal_entries(id) ON DELETE CASCADE,
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_postings_journal_entry_id ON postings (journal_entry_id);
+CREATE INDEX idx_postings_ledger_account ON postings (ledger_account);
+
+-- Every journal entry must sum to zero. The check is deferred to the end of the database
+-- transaction so the postings of an entry can be inserted one at a time.
+CREATE FUNCTION check_journal_entry_balance() RETURNS TRIGGER AS $$
+BEGIN
+    IF (SELECT SUM(amount) FROM postings WHERE journal_entry_id = NEW.journal_entry_id) <> 0 THEN
+        RAISE EXCEPTION 'journal entry % does not balance', NEW.journal_entry_id
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'postings_journal_entry_balance_check';
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER postings_journal_entry_balance_check
+    AFTER INSERT OR UPDATE ON postings
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION check_journal_entry_balance();
+
+-- Post the transactions recorded before the ledger existed.
+INSERT INTO journal_entries (transaction_id, entry_date)
+SELECT id, event_date FROM transactions ORDER BY id;
+
+INSERT INTO postings (journal_entry_id, ledger_account, account_id, amount)
+SELECT j.id, 'RECEIVABLES', t.account_id, -t.amount
+FROM journal_entries j
+JOIN transactions t ON t.id = j.transaction_id
+UNION ALL
+SELECT j.id,
+    CASE
+        WHEN t.operation_type_id IN (7, 8) THEN 'FEE_INCOME'
+        WHEN t.operation_type_id IN (9, 10) THEN 'TRANSFER_CLEARING'
+        ELSE 'CASH'
+    END,
+    NULL,
+    t.amount
+FROM journal_entries j
+JOIN transactions t ON t.id = j.transaction_id;
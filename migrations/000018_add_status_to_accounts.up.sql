@@ -0,0 +1,15 @@
+ALTER TABLE accounts ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'ACTIVE' CHECK (status IN ('ACTIVE', 'BLOCKED', 'CLOSED'));
+
+CREATE TABLE account_status_changes (
+    id SERIAL PRIMARY KEY,
+    account_id INT NOT NULL,
+    from_status VARCHAR(16) NOT NULL,
+    to_status VARCHAR(16) NOT NULL,
+    reason TEXT NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_account_status_changes_account_id ON account_status_changes (account_id, changed_at);
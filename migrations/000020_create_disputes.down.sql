@@ -0,0 +1,17 @@
+-- Provisional credits already changed the available credit limit of their accounts and
+-- cannot be undone by dropping the tables, so the rollback refuses to run while any exist.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM disputes) OR EXISTS (SELECT 1 FROM transactions WHERE operation_type_id IN (11, 12)) THEN
+        RAISE EXCEPTION 'disputes were opened, migration 20 cannot be rolled back';
+    END IF;
+END $$;
+
+DROP TABLE dispute_events;
+DROP TABLE disputes;
+
+ALTER TABLE postings DROP CONSTRAINT postings_ledger_account_check;
+ALTER TABLE postings ADD CONSTRAINT postings_ledger_account_check
+    CHECK (ledger_account IN ('RECEIVABLES', 'CASH', 'FEE_INCOME', 'TRANSFER_CLEARING'));
+
+DELETE FROM operation_types WHERE id IN (11, 12);
@@ -0,0 +1,44 @@
+CREATE TABLE disputes (
+    id SERIAL PRIMARY KEY,
+    transaction_id INT NOT NULL,
+    account_id INT NOT NULL,
+    amount NUMERIC(15, 2) NOT NULL CHECK (amount > 0),
+    reason TEXT NOT NULL,
+    status VARCHAR(32) NOT NULL DEFAULT 'OPENED' CHECK (status IN ('OPENED', 'PROVISIONAL_CREDIT', 'WON', 'LOST')),
+    provisional_credit_transaction_id INT REFERENCES transactions(id),
+    reversal_transaction_id INT REFERENCES transactions(id),
+    opened_at TIMESTAMP NOT NULL,
+    resolved_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE CASCADE,
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE
+);
+
+-- A transaction can only be disputed again once its previous dispute was lost.
+CREATE UNIQUE INDEX disputes_transaction_id_active_key ON disputes (transaction_id) WHERE status <> 'LOST';
+
+CREATE TABLE dispute_events (
+    id SERIAL PRIMARY KEY,
+    dispute_id INT NOT NULL,
+    from_status VARCHAR(32),
+    to_status VARCHAR(32) NOT NULL,
+    transaction_id INT REFERENCES transactions(id),
+    note TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (dispute_id) REFERENCES disputes(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_dispute_events_dispute_id ON dispute_events (dispute_id, id);
+
+ALTER TABLE postings DROP CONSTRAINT postings_ledger_account_check;
+ALTER TABLE postings ADD CONSTRAINT postings_ledger_account_check
+    CHECK (ledger_account IN ('RECEIVABLES', 'CASH', 'FEE_INCOME', 'TRANSFER_CLEARING', 'DISPUTES'));
+
+INSERT INTO operation_types (id, description, direction) VALUES
+(11, 'CREDITO PROVISORIO', 'CREDIT'),
+(12, 'ESTORNO DE CREDITO PROVISORIO', 'DEBIT');
+
+SELECT setval('operation_types_id_seq', (SELECT MAX(id) FROM operation_types));
@@ -0,0 +1,3 @@
+ALTER TABLE idempotency_keys ADD COLUMN locked_until TIMESTAMP;
+
+UPDATE idempotency_keys SET locked_until = created_at WHERE status_code IS NULL;
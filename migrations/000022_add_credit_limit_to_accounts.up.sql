@@ -0,0 +1,16 @@
+ALTER TABLE accounts ADD COLUMN credit_limit NUMERIC(15, 2);
+
+-- The available limit moves by the amount of every transaction and of every pending hold,
+-- so the total limit is what it would be with none of them. Accounts whose data cannot
+-- be reconciled start at zero rather than with a negative limit.
+UPDATE accounts
+SET credit_limit = GREATEST(
+    available_credit_limit
+    + COALESCE((SELECT SUM(amount) FROM authorizations WHERE authorizations.account_id = accounts.id AND status = 'PENDING'), 0)
+    - COALESCE((SELECT SUM(amount) FROM transactions WHERE transactions.account_id = accounts.id), 0),
+    0
+);
+
+ALTER TABLE accounts ALTER COLUMN credit_limit SET NOT NULL;
+ALTER TABLE accounts ALTER COLUMN credit_limit SET DEFAULT 0;
+ALTER TABLE accounts ADD CONSTRAINT accounts_credit_limit_check CHECK (credit_limit >= 0);
@@ -0,0 +1 @@
+DROP INDEX idx_invoice_items_transaction_id;
@@ -0,0 +1 @@
+CREATE INDEX idx_invoice_items_transaction_id ON invoice_items (transaction_id, installment_number);
@@ -0,0 +1,3 @@
+-- Identifies the request holding a pending key, so that a request whose lease ran out
+-- cannot record or release the key once a retry took it over.
+ALTER TABLE idempotency_keys ADD COLUMN claim_token UUID;
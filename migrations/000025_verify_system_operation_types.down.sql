@@ -0,0 +1 @@
+-- Nothing to undo: the up migration only verifies the system operation types.
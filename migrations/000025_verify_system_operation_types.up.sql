@@ -0,0 +1,28 @@
+-- Operation types 5 to 12 are reserved for the types the application posts by itself.
+-- Databases migrated while an earlier revision of the migrations adopted whatever row
+-- already held those ids must be fixed by hand before going further.
+DO $$
+DECLARE
+    mismatched TEXT;
+BEGIN
+    SELECT string_agg(expected.id::TEXT, ', ' ORDER BY expected.id) INTO mismatched
+    FROM (VALUES
+        (5, 'ESTORNO', 'CREDIT'),
+        (6, 'ESTORNO DE PAGAMENTO', 'DEBIT'),
+        (7, 'JUROS ROTATIVOS', 'DEBIT'),
+        (8, 'MULTA POR ATRASO', 'DEBIT'),
+        (9, 'TRANSFERENCIA ENVIADA', 'DEBIT'),
+        (10, 'TRANSFERENCIA RECEBIDA', 'CREDIT'),
+        (11, 'CREDITO PROVISORIO', 'CREDIT'),
+        (12, 'ESTORNO DE CREDITO PROVISORIO', 'DEBIT')
+    ) AS expected (id, description, direction)
+    LEFT JOIN operation_types ot ON ot.id = expected.id
+    WHERE ot.id IS NULL
+       OR ot.description <> expected.description
+       OR ot.direction <> expected.direction
+       OR NOT ot.active;
+
+    IF mismatched IS NOT NULL THEN
+        RAISE EXCEPTION 'operation types % are not the expected system types', mismatched;
+    END IF;
+END $$;
@@ -0,0 +1,2 @@
+UPDATE accounts SET available_credit_limit = available_credit_limit + credit_balance;
+ALTER TABLE accounts DROP COLUMN credit_balance;
@@ -0,0 +1,8 @@
+-- What credits pay beyond the credit limit is kept apart from the available credit limit,
+-- which never goes over the credit limit, and is spent first by the next debits.
+ALTER TABLE accounts ADD COLUMN credit_balance NUMERIC(15, 2) NOT NULL DEFAULT 0 CHECK (credit_balance >= 0);
+
+UPDATE accounts
+SET credit_balance = available_credit_limit - credit_limit,
+    available_credit_limit = credit_limit
+WHERE available_credit_limit > credit_limit;
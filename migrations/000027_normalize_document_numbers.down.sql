@@ -0,0 +1 @@
+-- Nothing to undo: normalized document numbers are kept, as are the types derived from them.
@@ -0,0 +1,46 @@
+-- Accounts created before validation may hold formatted numbers such as 123.456.789-09,
+-- which the API now stores as digits only. When several accounts collapse to the same
+-- digits only one of them is normalized, preferring the one already stored as digits and
+-- then the oldest; the others keep their original number and no document_type, so they
+-- can be found with "WHERE document_type IS NULL" and merged by hand.
+WITH normalized AS (
+    SELECT id, document_number, regexp_replace(document_number, '[./ -]', '', 'g') AS digits
+    FROM accounts
+    WHERE document_number ~ '^[0-9./ -]+$'
+),
+ranked AS (
+    SELECT id, document_number, digits,
+        ROW_NUMBER() OVER (PARTITION BY digits ORDER BY document_number = digits DESC, id) AS ranking
+    FROM normalized
+    WHERE digits <> ''
+)
+UPDATE accounts
+SET document_number = ranked.digits
+FROM ranked
+WHERE accounts.id = ranked.id AND ranked.ranking = 1 AND ranked.document_number <> ranked.digits;
+
+-- Migration 8 derived document_type from the length of the number alone. It is derived
+-- again with the check digit rules of domain.NormalizeDocument, and left empty for
+-- documents that fail them.
+CREATE FUNCTION pg_temp.check_digit(digits TEXT, weights INT[]) RETURNS INT AS $$
+    SELECT CASE WHEN total % 11 < 2 THEN 0 ELSE 11 - total % 11 END
+    FROM (
+        SELECT SUM(substr(digits, n, 1)::INT * weights[n]) AS total
+        FROM generate_series(1, length(digits)) AS n
+    ) AS sums
+$$ LANGUAGE SQL IMMUTABLE;
+
+UPDATE accounts
+SET document_type = CASE
+    WHEN document_number !~ '^[0-9]+$' OR document_number ~ '^(.)\1*$' THEN NULL
+    WHEN length(document_number) = 11
+        AND substr(document_number, 10, 1)::INT = pg_temp.check_digit(left(document_number, 9), ARRAY[10, 9, 8, 7, 6, 5, 4, 3, 2])
+        AND substr(document_number, 11, 1)::INT = pg_temp.check_digit(left(document_number, 10), ARRAY[11, 10, 9, 8, 7, 6, 5, 4, 3, 2])
+        THEN 'CPF'
+    WHEN length(document_number) = 14
+        AND substr(document_number, 13, 1)::INT = pg_temp.check_digit(left(document_number, 12), ARRAY[5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2])
+        AND substr(document_number, 14, 1)::INT = pg_temp.check_digit(left(document_number, 13), ARRAY[6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2])
+        THEN 'CNPJ'
+END;
+
+DROP FUNCTION pg_temp.check_digit(TEXT, INT[]);